@@ -1,29 +1,66 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"log"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/skip"
+	"github.com/lwshen/vault-hub/internal/auditforward"
 	"github.com/lwshen/vault-hub/internal/config"
+	"github.com/lwshen/vault-hub/internal/dynamic"
+	"github.com/lwshen/vault-hub/internal/email"
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"github.com/lwshen/vault-hub/internal/rotation"
 	"github.com/lwshen/vault-hub/internal/version"
+	"github.com/lwshen/vault-hub/internal/webhook"
 	"github.com/lwshen/vault-hub/model"
 	"github.com/lwshen/vault-hub/route"
 	slogfiber "github.com/samber/slog-fiber"
 )
 
+// secretCleanupInterval is how often expired shares and wrapping tokens are deleted
+const secretCleanupInterval = 10 * time.Minute
+
+// expiryReminderInterval is how often vaults nearing their rotation deadline are looked up
+const expiryReminderInterval = time.Hour
+
 func main() {
 	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
 
 	logger.Info("Starting VaultHub Server", "version", version.Version, "commit", version.Commit)
 
-	err := model.Open(logger)
+	keyProvider, err := encryption.NewKeyProvider()
+	if err != nil {
+		logger.Error("Failed to configure key provider", "error", err)
+		os.Exit(1)
+	}
+	if err := encryption.LoadKeyProvider(context.Background(), keyProvider); err != nil {
+		logger.Error("Failed to load master key", "provider", config.EncryptionKeyProvider, "error", err)
+		os.Exit(1)
+	}
+	logger.Info("Master key loaded", "provider", config.EncryptionKeyProvider, "keyID", encryption.ActiveKeyID())
+
+	err = model.Open(logger)
 	if err != nil {
 		logger.Error("Failed to open database", "error", err)
 		os.Exit(1)
 	}
 
+	if len(os.Args) > 1 && os.Args[1] == "rotate-master-key" {
+		rotateMasterKey(logger, os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "verify-audit-log" {
+		verifyAuditLog(logger, os.Args[2:])
+		return
+	}
+
 	// Ensure demo user exists when demo mode is enabled
 	if config.DemoEnabled {
 		logger.Info("Demo mode enabled, ensuring demo user exists")
@@ -34,11 +71,187 @@ func main() {
 		logger.Info("Demo user verified successfully", "email", model.DemoUserEmail)
 	}
 
-	app := fiber.New()
+	if config.AuditForwardUrl != "" {
+		forwarder, err := auditforward.New(config.AuditForwardUrl, config.AuditForwardToken, logger)
+		if err != nil {
+			logger.Error("Failed to configure audit log forwarding", "error", err)
+			os.Exit(1)
+		}
+		go forwarder.Run(context.Background())
+	}
 
-	app.Use(slogfiber.New(logger))
+	go webhook.NewDispatcher(logger).Run(context.Background())
+	go rotation.NewRotator(logger).Run(context.Background())
+	go dynamic.NewReaper(logger).Run(context.Background())
+	go pruneExpiredSecrets(context.Background(), logger)
+	if config.EmailEnabled {
+		go sendExpiryReminders(context.Background(), logger)
+	}
+
+	// Behind trusted reverse proxies, rate limits apply to the client IP they forward
+	fiberConfig := fiber.Config{}
+	if len(config.TrustedProxies) > 0 {
+		fiberConfig.ProxyHeader = fiber.HeaderXForwardedFor
+		fiberConfig.EnableTrustedProxyCheck = true
+		fiberConfig.TrustedProxies = config.TrustedProxies
+		fiberConfig.EnableIPValidation = true
+	}
+	app := fiber.New(fiberConfig)
+
+	// Request logging reads the response body, which would block on streamed responses
+	app.Use(skip.New(slogfiber.New(logger), func(c *fiber.Ctx) bool {
+		return c.Path() == "/api/cli/events" || c.Path() == "/api/audit-logs/export"
+	}))
 
 	route.SetupRoutes(app)
 
 	log.Fatal(app.Listen(":" + config.AppPort))
 }
+
+// pruneExpiredSecrets deletes expired shares and wrapping tokens periodically until ctx is
+// done. They can no longer be redeemed; this also removes their ciphertext from the database.
+func pruneExpiredSecrets(ctx context.Context, logger *slog.Logger) {
+	ticker := time.NewTicker(secretCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if pruned, err := model.PruneExpiredShares(time.Now()); err != nil {
+				logger.Error("Failed to prune expired shares", "error", err)
+			} else if pruned > 0 {
+				logger.Info("Pruned expired shares", "shares", pruned)
+			}
+			if pruned, err := model.PruneWrappingTokens(time.Now()); err != nil {
+				logger.Error("Failed to prune wrapping tokens", "error", err)
+			} else if pruned > 0 {
+				logger.Info("Pruned wrapping tokens", "tokens", pruned)
+			}
+		}
+	}
+}
+
+// sendExpiryReminders emails the owners of vaults nearing their rotation deadline periodically
+// until ctx is done. Every deadline is reminded of once, by whichever server claims it first;
+// claims whose reminders could not be sent are released and retried on the next tick.
+func sendExpiryReminders(ctx context.Context, logger *slog.Logger) {
+	window := time.Duration(config.ExpiryReminderDays) * 24 * time.Hour
+	svc := email.NewService(email.NewSender(), "Vault Hub")
+	ticker := time.NewTicker(expiryReminderInterval)
+	defer ticker.Stop()
+
+	for {
+		vaults, err := model.ClaimExpiryReminders(window)
+		if err != nil {
+			logger.Error("Failed to claim expiry reminders", "error", err)
+		}
+		retry := false
+		for _, vault := range vaults {
+			if remindVaultExpiry(svc, &vault, logger) {
+				continue
+			}
+			retry = true
+			if err := model.ReleaseExpiryReminder(&vault); err != nil {
+				logger.Error("Failed to release expiry reminder", "vaultID", vault.ID, "error", err)
+			}
+		}
+		if len(vaults) == 0 || retry {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}
+}
+
+// remindVaultExpiry emails the recipients of a vault's expiry reminder and reports whether the
+// reminder went out, to at least one of them
+func remindVaultExpiry(svc *email.Service, vault *model.Vault, logger *slog.Logger) bool {
+	recipients, err := model.VaultNotificationRecipients(vault)
+	if err != nil {
+		logger.Error("Failed to find expiry reminder recipients", "vaultID", vault.ID, "error", err)
+		return false
+	}
+	if len(recipients) == 0 {
+		return true
+	}
+
+	sent := false
+	for _, user := range recipients {
+		name := ""
+		if user.Name != nil {
+			name = *user.Name
+		}
+		if err := svc.SendVaultExpiryReminder(user.Email, name, vault.Name, *vault.ExpiresAt); err != nil {
+			logger.Error("Failed to send expiry reminder", "vaultID", vault.ID, "email", user.Email, "error", err)
+			continue
+		}
+		sent = true
+	}
+	return sent
+}
+
+// rotateMasterKey re-wraps all vault data keys with the active master key of the key provider.
+// Keep the old key configured as a previous key on every server until it finishes: in
+// ENCRYPTION_KEY_PREVIOUS, or with the provider's own setting (ENCRYPTION_KEY_PREVIOUS_FILES,
+// PKCS11_PREVIOUS_KEY_LABELS or KMS_PREVIOUS_CIPHERTEXTS).
+func rotateMasterKey(logger *slog.Logger, args []string) {
+	flags := flag.NewFlagSet("rotate-master-key", flag.ExitOnError)
+	batchSize := flags.Int("batch-size", model.DefaultKeyRotationBatchSize, "number of vaults to re-wrap per batch")
+	_ = flags.Parse(args)
+
+	logger.Info("Rotating master key", "batchSize", *batchSize)
+
+	result, err := model.RotateMasterKey(*batchSize, logger)
+	if err != nil {
+		logger.Error("Master key rotation incomplete", "error", err, "processed", result.Processed, "failed", result.Failed)
+		os.Exit(1)
+	}
+
+	logger.Info("Master key rotation complete", "processed", result.Processed)
+}
+
+// verifyAuditLog verifies the audit log hash chains directly in the database and exits
+// with status 1 if any chain is broken
+func verifyAuditLog(logger *slog.Logger, args []string) {
+	flags := flag.NewFlagSet("verify-audit-log", flag.ExitOnError)
+	userID := flags.Uint("user-id", 0, "verify only the chain of this user")
+	_ = flags.Parse(args)
+
+	var reports []*model.AuditChainReport
+	if *userID != 0 {
+		report, err := model.VerifyAuditChain(*userID)
+		if err != nil {
+			logger.Error("Failed to verify audit log", "userID", *userID, "error", err)
+			os.Exit(1)
+		}
+		reports = append(reports, report)
+	} else {
+		var err error
+		reports, err = model.VerifyAllAuditChains()
+		if err != nil {
+			logger.Error("Failed to verify audit log", "error", err)
+			os.Exit(1)
+		}
+	}
+
+	broken := 0
+	for _, report := range reports {
+		if report.Valid() {
+			logger.Info("Audit chain verified", "userID", report.UserID, "entries", report.Verified, "lastHash", report.LastHash)
+			continue
+		}
+		broken++
+		logger.Error("Audit chain broken", "userID", report.UserID, "entryID", *report.BrokenID,
+			"reason", report.Reason, "verifiedEntries", report.Verified)
+	}
+
+	if broken > 0 {
+		logger.Error("Audit log verification failed", "brokenChains", broken, "chains", len(reports))
+		os.Exit(1)
+	}
+	logger.Info("Audit log verification complete", "chains", len(reports))
+}
@@ -0,0 +1,111 @@
+package e2e
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestAudit_VerifyChain tests that the audit log chain of a user verifies after some activity
+func TestAudit_VerifyChain(t *testing.T) {
+	server := StartTestServer(t)
+
+	RunCLI(t,
+		"get",
+		"--name", server.VaultName,
+		"--base-url", server.URL,
+		"--api-key", server.APIKey,
+	).MustSucceed(t)
+
+	req, _ := http.NewRequest("GET", server.URL+"/api/audit-logs/verify", nil)
+	req.Header.Set("Authorization", "Bearer "+server.JWTToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to verify audit logs: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	var verification struct {
+		Valid           bool   `json:"valid"`
+		VerifiedEntries int    `json:"verifiedEntries"`
+		LastHash        string `json:"lastHash"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&verification); err != nil {
+		t.Fatalf("Failed to decode verification: %v", err)
+	}
+	// Signup, API key and vault creation, and the read above
+	if !verification.Valid || verification.VerifiedEntries < 4 || len(verification.LastHash) != 64 {
+		t.Fatalf("Unexpected verification result: %+v", verification)
+	}
+}
+
+// TestAudit_Export tests exporting audit logs as JSON lines and CSV with the list filters
+func TestAudit_Export(t *testing.T) {
+	server := StartTestServer(t)
+
+	RunCLI(t,
+		"get",
+		"--name", server.VaultName,
+		"--base-url", server.URL,
+		"--api-key", server.APIKey,
+	).MustSucceed(t)
+
+	export := func(query string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest("GET", server.URL+"/api/audit-logs/export?"+query, nil)
+		req.Header.Set("Authorization", "Bearer "+server.JWTToken)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to export audit logs: %v", err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := export("format=jsonl&source=cli")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "application/x-ndjson" {
+		t.Fatalf("Expected JSON lines content type, got %q", contentType)
+	}
+	var records []map[string]any
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		var record map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+	// Only the CLI read matches the source filter
+	if len(records) != 1 || records[0]["action"] != "read_vault" || records[0]["vaultName"] != server.VaultName {
+		t.Fatalf("Unexpected JSON lines export: %v", records)
+	}
+
+	resp = export("format=csv")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if disposition := resp.Header.Get("Content-Disposition"); !strings.Contains(disposition, ".csv") {
+		t.Fatalf("Expected a CSV attachment, got %q", disposition)
+	}
+	rows, err := csv.NewReader(resp.Body).ReadAll()
+	if err != nil {
+		t.Fatalf("Invalid CSV export: %v", err)
+	}
+	// Header, then signup, API key and vault creation, and the read above, oldest first
+	if len(rows) < 5 || rows[0][0] != "id" || rows[len(rows)-1][4] != "read_vault" {
+		t.Fatalf("Unexpected CSV export: %v", rows)
+	}
+
+	if resp := export("format=xml"); resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected status 400 for an unknown format, got %d", resp.StatusCode)
+	}
+}
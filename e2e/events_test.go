@@ -0,0 +1,130 @@
+package e2e
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sseEvent is one event read from a Server-Sent Events stream
+type sseEvent struct {
+	ID   string
+	Type string
+	Data map[string]interface{}
+}
+
+// openEventStream connects to the vault event stream, optionally resuming after lastEventID
+func openEventStream(t *testing.T, server *TestServer, apiKey, lastEventID string) (*http.Response, <-chan sseEvent) {
+	t.Helper()
+
+	req, _ := http.NewRequest("GET", server.URL+"/api/cli/events", nil)
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open event stream: %v", err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+
+	events := make(chan sseEvent, 16)
+	go func() {
+		defer close(events)
+		var event sseEvent
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			line := scanner.Text()
+			switch {
+			case line == "":
+				if event.Data != nil {
+					events <- event
+				}
+				event = sseEvent{}
+			case strings.HasPrefix(line, "id: "):
+				event.ID = strings.TrimPrefix(line, "id: ")
+			case strings.HasPrefix(line, "event: "):
+				event.Type = strings.TrimPrefix(line, "event: ")
+			case strings.HasPrefix(line, "data: "):
+				_ = json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event.Data)
+			}
+		}
+	}()
+	return resp, events
+}
+
+// waitForVaultEvent returns the next event about the named vault
+func waitForVaultEvent(t *testing.T, events <-chan sseEvent, vaultName string) sseEvent {
+	t.Helper()
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				t.Fatal("Event stream closed before the expected event")
+			}
+			if event.Data["name"] == vaultName {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("Timed out waiting for an event about vault %s", vaultName)
+		}
+	}
+}
+
+// TestEvents_StreamsAndResumes tests that vault updates are streamed and replayed on reconnect
+func TestEvents_StreamsAndResumes(t *testing.T) {
+	server := StartTestServer(t)
+
+	resp, events := openEventStream(t, server, server.APIKey, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		t.Fatalf("Expected an event stream, got content type %s", resp.Header.Get("Content-Type"))
+	}
+
+	for _, value := range []string{"first-update", "second-update"} {
+		RunCLI(t,
+			"update",
+			"--name", server.VaultName,
+			"--value", value,
+			"--base-url", server.URL,
+			"--api-key", server.APIKey,
+		).MustSucceed(t)
+	}
+
+	first := waitForVaultEvent(t, events, server.VaultName)
+	if first.Type != "vault.updated" || first.Data["uniqueId"] != server.VaultID {
+		t.Fatalf("Unexpected event: %+v", first)
+	}
+	second := waitForVaultEvent(t, events, server.VaultName)
+	firstID, _ := strconv.Atoi(first.ID)
+	secondID, _ := strconv.Atoi(second.ID)
+	if secondID <= firstID {
+		t.Fatalf("Expected increasing event IDs, got %s then %s", first.ID, second.ID)
+	}
+	resp.Body.Close()
+
+	// A client reconnecting after the first event catches up on the second
+	_, resumed := openEventStream(t, server, server.APIKey, first.ID)
+	replayed := waitForVaultEvent(t, resumed, server.VaultName)
+	if replayed.ID != second.ID {
+		t.Fatalf("Expected event %s to be replayed, got %s", second.ID, replayed.ID)
+	}
+}
+
+// TestEvents_RequiresListPermission tests that keys without vault:list cannot subscribe
+func TestEvents_RequiresListPermission(t *testing.T) {
+	server := StartTestServer(t)
+	readOnlyKey := server.CreateAPIKey(t, "vault:read")
+
+	resp, _ := openEventStream(t, server, readOnlyKey, "")
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("Expected status 403, got %d", resp.StatusCode)
+	}
+}
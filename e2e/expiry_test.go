@@ -0,0 +1,56 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestVaultExpiry_ExpiringWithin tests that vaults due for rotation can be listed
+func TestVaultExpiry_ExpiringWithin(t *testing.T) {
+	server := StartTestServer(t)
+
+	var due struct {
+		UniqueID string `json:"uniqueId"`
+	}
+	body := map[string]any{"name": "db-password", "value": "hunter2", "expiresAt": time.Now().Add(48 * time.Hour)}
+	if status := doJSON(t, server, "POST", "/api/vaults", body, &due); status != http.StatusCreated {
+		t.Fatalf("Expected vault to be created, got %d", status)
+	}
+	body = map[string]any{"name": "api-token", "value": "abc", "rotationInterval": 90 * 24 * 3600}
+	var scheduled struct {
+		ExpiresAt        *time.Time `json:"expiresAt"`
+		RotationInterval int64      `json:"rotationInterval"`
+	}
+	if status := doJSON(t, server, "POST", "/api/vaults", body, &scheduled); status != http.StatusCreated {
+		t.Fatalf("Expected vault to be created, got %d", status)
+	}
+	if scheduled.ExpiresAt == nil || scheduled.RotationInterval != 90*24*3600 {
+		t.Fatalf("Expected a deadline from the rotation interval, got %+v", scheduled)
+	}
+
+	var list struct {
+		Vaults []struct {
+			UniqueID string `json:"uniqueId"`
+		} `json:"vaults"`
+		TotalCount int `json:"totalCount"`
+	}
+	if status := doJSON(t, server, "GET", "/api/vaults?expiring_within=7d", nil, &list); status != http.StatusOK {
+		t.Fatalf("Failed to list expiring vaults: status %d", status)
+	}
+	if list.TotalCount != 1 || list.Vaults[0].UniqueID != due.UniqueID {
+		t.Errorf("Expected only the vault due in two days, got %+v", list)
+	}
+
+	if status := doJSON(t, server, "GET", "/api/vaults?expiring_within=soon", nil, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid period, got %d", status)
+	}
+
+	// Clearing the deadline takes the vault off the list
+	if status := doJSON(t, server, "PUT", "/api/vaults/"+due.UniqueID, map[string]any{"clearExpiry": true}, nil); status != http.StatusOK {
+		t.Fatalf("Failed to clear expiry: status %d", status)
+	}
+	if status := doJSON(t, server, "GET", "/api/vaults?expiring_within=7d", nil, &list); status != http.StatusOK || list.TotalCount != 0 {
+		t.Errorf("Expected no expiring vaults, got %d: %+v", status, list)
+	}
+}
@@ -0,0 +1,177 @@
+package e2e
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jimlambrt/gldap"
+)
+
+// mockDirectory is a minimal LDAP server. The service account may search for users by mail,
+// and users bind with their own password.
+type mockDirectory struct {
+	server *gldap.Server
+	addr   string
+
+	mu          sync.Mutex
+	unavailable bool
+	bound       map[int]string
+	passwords   map[string]string
+	users       map[string]*gldap.Entry
+}
+
+const mockDirectoryServiceDN = "cn=vault-hub,dc=example,dc=com"
+
+func newMockDirectory(t *testing.T) *mockDirectory {
+	t.Helper()
+
+	d := &mockDirectory{
+		bound:     map[int]string{},
+		passwords: map[string]string{mockDirectoryServiceDN: "service-secret"},
+		users:     map[string]*gldap.Entry{},
+	}
+	var err error
+	if d.server, err = gldap.NewServer(); err != nil {
+		t.Fatalf("Failed to create LDAP server: %v", err)
+	}
+	mux, _ := gldap.NewMux()
+	mux.Bind(d.bind)
+	mux.Search(d.search)
+	d.server.Router(mux)
+
+	d.addr = "127.0.0.1:" + findAvailablePort(t)
+	go d.server.Run(d.addr)
+	t.Cleanup(func() { d.server.Stop() })
+	for start := time.Now(); !d.server.Ready(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("LDAP server did not start")
+		}
+	}
+	return d
+}
+
+// addUser adds a user that logs in with email and password
+func (d *mockDirectory) addUser(email, name, password string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	dn := "uid=" + strings.Split(email, "@")[0] + ",ou=people,dc=example,dc=com"
+	d.passwords[dn] = password
+	d.users[email] = gldap.NewEntry(dn, map[string][]string{"mail": {email}, "cn": {name}})
+}
+
+func (d *mockDirectory) bind(w *gldap.ResponseWriter, r *gldap.Request) {
+	resp := r.NewBindResponse(gldap.WithResponseCode(gldap.ResultInvalidCredentials))
+	defer w.Write(resp)
+
+	m, err := r.GetSimpleBindMessage()
+	if err != nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.bound, r.ConnectionID())
+	if password, ok := d.passwords[m.UserName]; ok && password == string(m.Password) {
+		d.bound[r.ConnectionID()] = m.UserName
+		resp.SetResultCode(gldap.ResultSuccess)
+	}
+}
+
+func (d *mockDirectory) search(w *gldap.ResponseWriter, r *gldap.Request) {
+	resp := r.NewSearchDoneResponse(gldap.WithResponseCode(gldap.ResultSuccess))
+	defer w.Write(resp)
+
+	m, err := r.GetSearchMessage()
+	if err != nil {
+		resp.SetResultCode(gldap.ResultProtocolError)
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.unavailable {
+		resp.SetResultCode(gldap.ResultUnavailable)
+		return
+	}
+	if d.bound[r.ConnectionID()] != mockDirectoryServiceDN {
+		resp.SetResultCode(gldap.ResultInsufficientAccessRights)
+		return
+	}
+	for email, entry := range d.users {
+		if m.Filter == "(mail="+email+")" {
+			result := r.NewSearchResponseEntry(entry.DN)
+			for _, attr := range entry.Attributes {
+				result.AddAttribute(attr.Name, attr.Values)
+			}
+			w.Write(result)
+		}
+	}
+}
+
+// TestLDAP_Login tests logging in with a directory password, provisioning the user on the
+// first login, and that local accounts keep their own password
+func TestLDAP_Login(t *testing.T) {
+	directory := newMockDirectory(t)
+	directory.addUser("carol@example.com", "Carol", "Directory1234!")
+	directory.addUser("mock@demo.com", "Impostor", "Directory1234!")
+
+	server := StartTestServer(t,
+		"LDAP_URL=ldap://"+directory.addr,
+		"LDAP_BIND_DN="+mockDirectoryServiceDN,
+		"LDAP_BIND_PASSWORD=service-secret",
+		"LDAP_BASE_DN=dc=example,dc=com",
+	)
+
+	login := func(email, password string) (int, string) {
+		t.Helper()
+		var resp struct {
+			Token string `json:"token"`
+		}
+		body := map[string]string{"email": email, "password": password}
+		status := doJSON(t, server, "POST", "/api/auth/login", body, &resp)
+		return status, resp.Token
+	}
+
+	// The first login creates the user from the directory entry
+	status, token := login("carol@example.com", "Directory1234!")
+	if status != http.StatusOK || token == "" {
+		t.Fatalf("Expected LDAP login to succeed, got %d", status)
+	}
+	session := *server
+	session.JWTToken = token
+	var me struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	}
+	if status := doJSON(t, &session, "GET", "/api/user", nil, &me); status != http.StatusOK {
+		t.Fatalf("Failed to get current user: status %d", status)
+	}
+	if me.Email != "carol@example.com" || me.Name != "Carol" {
+		t.Fatalf("Unexpected provisioned user: %+v", me)
+	}
+
+	// Later logins use the existing user
+	if status, _ := login("carol@example.com", "Directory1234!"); status != http.StatusOK {
+		t.Fatalf("Expected second LDAP login to succeed, got %d", status)
+	}
+	if status, _ := login("carol@example.com", "Wrong1234!"); status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a wrong directory password, got %d", status)
+	}
+
+	// A user with a local password is never checked against the directory
+	if status, _ := login("mock@demo.com", "Directory1234!"); status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a directory password of a local user, got %d", status)
+	}
+	if status, _ := login("mock@demo.com", "Test1234!"); status != http.StatusOK {
+		t.Fatalf("Expected local password login to succeed, got %d", status)
+	}
+
+	// Directory outages are not reported as wrong passwords
+	directory.mu.Lock()
+	directory.unavailable = true
+	directory.mu.Unlock()
+	if status, _ := login("carol@example.com", "Directory1234!"); status != http.StatusServiceUnavailable {
+		t.Fatalf("Expected 503 when the directory is down, got %d", status)
+	}
+}
@@ -0,0 +1,318 @@
+package e2e
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockIssuer is a minimal OpenID Connect provider that authenticates whoever is set as its
+// current identity. It requires PKCE with S256 on the authorization code flow.
+type mockIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu       sync.Mutex
+	identity map[string]any
+	codes    map[string]mockAuthorization
+	tokens   map[string]map[string]any
+}
+
+// mockAuthorization is an issued authorization code waiting to be redeemed
+type mockAuthorization struct {
+	challenge string
+	nonce     string
+	identity  map[string]any
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate issuer key: %v", err)
+	}
+	issuer := &mockIssuer{key: key, codes: map[string]mockAuthorization{}, tokens: map[string]map[string]any{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", issuer.discovery)
+	mux.HandleFunc("/authorize", issuer.authorize)
+	mux.HandleFunc("/token", issuer.token)
+	mux.HandleFunc("/userinfo", issuer.userinfo)
+	mux.HandleFunc("/jwks", issuer.jwks)
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+	return issuer
+}
+
+// setIdentity sets the claims of the user the next authorization authenticates
+func (m *mockIssuer) setIdentity(email string, groups ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.identity = map[string]any{
+		"sub":            email,
+		"email":          email,
+		"email_verified": true,
+		"name":           strings.Split(email, "@")[0],
+		"groups":         groups,
+	}
+}
+
+func (m *mockIssuer) discovery(w http.ResponseWriter, _ *http.Request) {
+	url := m.server.URL
+	json.NewEncoder(w).Encode(map[string]any{
+		"issuer":                                url,
+		"authorization_endpoint":                url + "/authorize",
+		"token_endpoint":                        url + "/token",
+		"userinfo_endpoint":                     url + "/userinfo",
+		"jwks_uri":                              url + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"code_challenge_methods_supported":      []string{"S256"},
+	})
+}
+
+func (m *mockIssuer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "" {
+		http.Error(w, "PKCE required", http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	code := generateRandomString(16)
+	m.codes[code] = mockAuthorization{challenge: query.Get("code_challenge"), nonce: query.Get("nonce"), identity: m.identity}
+	m.mu.Unlock()
+
+	redirect, _ := url.Parse(query.Get("redirect_uri"))
+	params := redirect.Query()
+	params.Set("code", code)
+	params.Set("state", query.Get("state"))
+	redirect.RawQuery = params.Encode()
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (m *mockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	authorization, ok := m.codes[r.FormValue("code")]
+	delete(m.codes, r.FormValue("code"))
+	m.mu.Unlock()
+
+	verifier := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+	if !ok || base64.RawURLEncoding.EncodeToString(verifier[:]) != authorization.challenge {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	clientID, _, _ := r.BasicAuth()
+	if clientID == "" {
+		clientID = r.FormValue("client_id")
+	}
+	claims := jwt.MapClaims{
+		"iss":   m.server.URL,
+		"aud":   clientID,
+		"iat":   time.Now().Unix(),
+		"exp":   time.Now().Add(time.Minute).Unix(),
+		"nonce": authorization.nonce,
+	}
+	for key, value := range authorization.identity {
+		claims[key] = value
+	}
+	signed, err := m.sign(claims)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	accessToken := generateRandomString(16)
+	m.mu.Lock()
+	m.tokens[accessToken] = authorization.identity
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   60,
+		"id_token":     signed,
+	})
+}
+
+// sign signs claims with the issuer's key, the way a CI system signs its workload tokens
+func (m *mockIssuer) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "mock"
+	return token.SignedString(m.key)
+}
+
+func (m *mockIssuer) userinfo(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	identity, ok := m.tokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	m.mu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{
+		"sub":            identity["sub"],
+		"email":          identity["email"],
+		"email_verified": identity["email_verified"],
+		"name":           identity["name"],
+	})
+}
+
+func (m *mockIssuer) jwks(w http.ResponseWriter, _ *http.Request) {
+	json.NewEncoder(w).Encode(map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "mock",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(m.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(m.key.E)).Bytes()),
+		}},
+	})
+}
+
+// oidcLogin logs in through a provider in a fresh browser and returns the redirect to the web
+// app, or the response that ended the flow early
+func oidcLogin(t *testing.T, server *TestServer, provider string) (*http.Response, url.Values) {
+	t.Helper()
+
+	jar, _ := cookiejar.New(nil)
+	browser := &http.Client{
+		Jar: jar,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	location := server.URL + "/api/auth/login/oidc/" + provider
+	for i := 0; i < 3; i++ {
+		resp, err := browser.Get(location)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", location, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusFound {
+			return resp, nil
+		}
+		next, err := resp.Location()
+		if err != nil {
+			t.Fatalf("Invalid redirect from %s: %v", location, err)
+		}
+		if next.Path == "/login" {
+			fragment, _ := url.ParseQuery(next.Fragment)
+			return resp, fragment
+		}
+		location = next.String()
+	}
+	t.Fatalf("OIDC login did not redirect to the web app")
+	return nil, nil
+}
+
+// TestOIDC_ProvidersAndGroupRoles tests logging in with a named provider, syncing organization
+// roles from groups and blocking email domains that are not allowed
+func TestOIDC_ProvidersAndGroupRoles(t *testing.T) {
+	issuer := newMockIssuer(t)
+	server := StartTestServer(t,
+		"OIDC_PROVIDERS=mock",
+		"OIDC_MOCK_ISSUER="+issuer.server.URL,
+		"OIDC_MOCK_CLIENT_ID=vault-hub",
+		"OIDC_MOCK_CLIENT_SECRET=secret",
+		"OIDC_MOCK_DISPLAY_NAME=Mock SSO",
+		"OIDC_MOCK_GROUP_ROLES=platform-admins=1:admin,developers=1:member",
+		"OIDC_MOCK_ALLOWED_DOMAINS=example.com",
+	)
+
+	var cfg struct {
+		OidcEnabled   bool `json:"oidcEnabled"`
+		OidcProviders []struct {
+			Name        string `json:"name"`
+			DisplayName string `json:"displayName"`
+			LoginURL    string `json:"loginUrl"`
+		} `json:"oidcProviders"`
+	}
+	if status := doJSON(t, server, "GET", "/api/config", nil, &cfg); status != http.StatusOK {
+		t.Fatalf("Failed to get config: status %d", status)
+	}
+	if !cfg.OidcEnabled || len(cfg.OidcProviders) != 1 || cfg.OidcProviders[0].DisplayName != "Mock SSO" || cfg.OidcProviders[0].LoginURL != "/api/auth/login/oidc/mock" {
+		t.Fatalf("Unexpected OIDC config: %+v", cfg)
+	}
+
+	// The group role mappings refer to the first organization
+	var org struct {
+		ID int `json:"id"`
+	}
+	if status := doJSON(t, server, "POST", "/api/organizations", map[string]string{"name": "Platform"}, &org); status != http.StatusCreated && status != http.StatusOK {
+		t.Fatalf("Failed to create organization: status %d", status)
+	}
+	if org.ID != 1 {
+		t.Fatalf("Expected organization ID 1, got %d", org.ID)
+	}
+
+	orgRole := func(token string) string {
+		t.Helper()
+		session := *server
+		session.JWTToken = token
+		var orgs struct {
+			Organizations []struct {
+				ID   int    `json:"id"`
+				Role string `json:"role"`
+			} `json:"organizations"`
+		}
+		if status := doJSON(t, &session, "GET", "/api/organizations", nil, &orgs); status != http.StatusOK {
+			t.Fatalf("Failed to list organizations: status %d", status)
+		}
+		for _, o := range orgs.Organizations {
+			if o.ID == org.ID {
+				return o.Role
+			}
+		}
+		return ""
+	}
+
+	// The highest role of the user's groups is granted
+	issuer.setIdentity("alice@example.com", "developers", "platform-admins")
+	_, fragment := oidcLogin(t, server, "mock")
+	if fragment.Get("token") == "" || fragment.Get("refreshToken") == "" {
+		t.Fatalf("OIDC login returned no tokens: %v", fragment)
+	}
+	if role := orgRole(fragment.Get("token")); role != "admin" {
+		t.Fatalf("Expected admin role from groups, got %q", role)
+	}
+
+	// Leaving the groups removes the membership on the next login
+	issuer.setIdentity("alice@example.com")
+	_, fragment = oidcLogin(t, server, "mock")
+	if role := orgRole(fragment.Get("token")); role != "" {
+		t.Fatalf("Expected membership to be removed, got role %q", role)
+	}
+
+	// Other email domains are blocked
+	issuer.setIdentity("mallory@example.net", "platform-admins")
+	resp, fragment := oidcLogin(t, server, "mock")
+	if resp.StatusCode != http.StatusForbidden || fragment.Get("token") != "" {
+		t.Fatalf("Expected 403 for a blocked domain, got %d", resp.StatusCode)
+	}
+
+	// Unknown providers are rejected before redirecting
+	resp, _ = oidcLogin(t, server, "unknown")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown provider, got %d", resp.StatusCode)
+	}
+}
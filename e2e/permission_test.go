@@ -0,0 +1,58 @@
+package e2e
+
+import (
+	"testing"
+)
+
+// TestPermission_ReadOnlyKeyCannotUpdate tests that a key without vault:write cannot update vaults
+func TestPermission_ReadOnlyKeyCannotUpdate(t *testing.T) {
+	server := StartTestServer(t)
+	readOnlyKey := server.CreateAPIKey(t, "vault:read", "vault:list")
+
+	result := RunCLI(t,
+		"update",
+		"--name", server.VaultName,
+		"--value", "overwritten",
+		"--base-url", server.URL,
+		"--api-key", readOnlyKey,
+	)
+
+	result.MustFail(t, 1)
+	if !result.ContainsStderr(t, "vault:write") {
+		t.Errorf("Expected permission error, got: %s", result.Stderr)
+	}
+
+	result = RunCLI(t,
+		"get",
+		"--name", server.VaultName,
+		"--base-url", server.URL,
+		"--api-key", readOnlyKey,
+	)
+
+	result.MustSucceed(t)
+	if !result.ContainsStdout(t, "initial-test-value") {
+		t.Errorf("Expected vault value in stdout, got: %s", result.Stdout)
+	}
+}
+
+// TestPermission_WriteOnlyKeyCannotList tests that a key without vault:list cannot list vaults
+func TestPermission_WriteOnlyKeyCannotList(t *testing.T) {
+	server := StartTestServer(t)
+	writeOnlyKey := server.CreateAPIKey(t, "vault:write")
+
+	result := RunCLI(t,
+		"list",
+		"--base-url", server.URL,
+		"--api-key", writeOnlyKey,
+	)
+	result.MustFail(t, 1)
+
+	result = RunCLI(t,
+		"update",
+		"--name", server.VaultName,
+		"--value", "updated-by-write-key",
+		"--base-url", server.URL,
+		"--api-key", writeOnlyKey,
+	)
+	result.MustSucceed(t)
+}
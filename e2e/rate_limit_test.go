@@ -0,0 +1,81 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+// TestRateLimit_AccountLockout tests that repeated wrong passwords lock the account
+func TestRateLimit_AccountLockout(t *testing.T) {
+	server := StartTestServer(t)
+
+	wrong := map[string]string{"email": "mock@demo.com", "password": "Wrong1234!"}
+	if status := doJSON(t, server, "POST", "/api/auth/login", wrong, nil); status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a wrong password, got %d", status)
+	}
+
+	// The second failure makes the next attempt wait
+	if status := doJSON(t, server, "POST", "/api/auth/login", wrong, nil); status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a wrong password, got %d", status)
+	}
+	data, _ := json.Marshal(wrong)
+	resp, err := http.Post(server.URL+"/api/auth/login", "application/json", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Failed to login: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || resp.Header.Get("Retry-After") == "" {
+		t.Fatalf("Expected 429 with Retry-After, got %d %q", resp.StatusCode, resp.Header.Get("Retry-After"))
+	}
+
+	// Even the right password waits, and unknown accounts are throttled alike
+	right := map[string]string{"email": "mock@demo.com", "password": "Test1234!"}
+	if status := doJSON(t, server, "POST", "/api/auth/login", right, nil); status != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 for a delayed account, got %d", status)
+	}
+	unknown := map[string]string{"email": "nobody@demo.com", "password": "Wrong1234!"}
+	for i := 0; i < 2; i++ {
+		if status := doJSON(t, server, "POST", "/api/auth/login", unknown, nil); status != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for an unknown account, got %d", status)
+		}
+	}
+	if status := doJSON(t, server, "POST", "/api/auth/login", unknown, nil); status != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 for a delayed unknown account, got %d", status)
+	}
+
+	var logs struct {
+		AuditLogs []struct {
+			Action string `json:"action"`
+		} `json:"auditLogs"`
+	}
+	if status := doJSON(t, server, "GET", "/api/audit-logs?pageSize=100&pageIndex=1", nil, &logs); status != http.StatusOK {
+		t.Fatalf("Failed to get audit logs: status %d", status)
+	}
+	failed := 0
+	for _, log := range logs.AuditLogs {
+		if log.Action == "login_failed" {
+			failed++
+		}
+	}
+	if failed != 2 {
+		t.Fatalf("Expected 2 login_failed audit logs, got %d", failed)
+	}
+}
+
+// TestRateLimit_InvalidAPIKeys tests that a client presenting invalid API keys is locked out
+func TestRateLimit_InvalidAPIKeys(t *testing.T) {
+	server := StartTestServer(t)
+
+	client := *server
+	client.JWTToken = "vhub_invalid"
+	for i := 0; i < 10; i++ {
+		if status := doJSON(t, &client, "GET", "/api/cli/vaults", nil, nil); status != http.StatusUnauthorized {
+			t.Fatalf("Expected 401 for an invalid API key, got %d", status)
+		}
+	}
+	if status := doJSON(t, &client, "GET", "/api/cli/vaults", nil, nil); status != http.StatusTooManyRequests {
+		t.Fatalf("Expected 429 after repeated invalid API keys, got %d", status)
+	}
+}
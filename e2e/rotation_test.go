@@ -0,0 +1,106 @@
+package e2e
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestRotation_Policies tests that vaults are rotated with their rotation policy and that
+// failed rotations are reported
+func TestRotation_Policies(t *testing.T) {
+	server := StartTestServer(t)
+	base := "/api/vaults/" + server.VaultID
+
+	var policy struct {
+		Strategy   string  `json:"strategy"`
+		Interval   int64   `json:"interval"`
+		Length     int     `json:"length"`
+		LastStatus string  `json:"lastStatus"`
+		LastError  string  `json:"lastError"`
+		Secret     *string `json:"secret"`
+	}
+	body := map[string]any{"strategy": "random", "interval": 86400, "length": 40, "charset": "abcdef0123456789"}
+	if status := doJSON(t, server, "PUT", base+"/rotation", body, &policy); status != http.StatusOK {
+		t.Fatalf("Expected rotation policy to be set, got %d", status)
+	}
+	if policy.Strategy != "random" || policy.Interval != 86400 || policy.Length != 40 {
+		t.Fatalf("Unexpected rotation policy: %+v", policy)
+	}
+
+	var vault struct {
+		Value            string `json:"value"`
+		RotationInterval int64  `json:"rotationInterval"`
+		RotationPolicy   *struct {
+			LastStatus string `json:"lastStatus"`
+		} `json:"rotationPolicy"`
+	}
+	if status := doJSON(t, server, "POST", base+"/rotation/rotate", nil, &vault); status != http.StatusOK {
+		t.Fatalf("Expected vault to be rotated, got %d", status)
+	}
+	if len(vault.Value) != 40 || vault.Value == "initial-test-value" {
+		t.Errorf("Expected a generated value, got %q", vault.Value)
+	}
+	if vault.RotationInterval != 86400 || vault.RotationPolicy == nil || vault.RotationPolicy.LastStatus != "succeeded" {
+		t.Errorf("Expected the rotation status on the vault, got %+v", vault)
+	}
+
+	var versions struct {
+		Versions []struct {
+			Version int `json:"version"`
+		} `json:"versions"`
+	}
+	if status := doJSON(t, server, "GET", base+"/versions", nil, &versions); status != http.StatusOK || len(versions.Versions) < 2 {
+		t.Errorf("Expected the rotated value in the version history, got %d: %+v", status, versions)
+	}
+
+	// A failing rotation endpoint is reported and retried
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer endpoint.Close()
+	body = map[string]any{"strategy": "http", "interval": 86400, "url": endpoint.URL}
+	if status := doJSON(t, server, "PUT", base+"/rotation", body, &policy); status != http.StatusOK {
+		t.Fatalf("Expected rotation policy to be replaced, got %d", status)
+	}
+	if policy.Secret == nil || *policy.Secret == "" {
+		t.Error("Expected a signing secret for the rotation endpoint")
+	}
+	if status := doJSON(t, server, "POST", base+"/rotation/rotate", nil, nil); status != http.StatusBadGateway {
+		t.Errorf("Expected 502 for a failing rotation endpoint, got %d", status)
+	}
+	policy.Secret = nil
+	if status := doJSON(t, server, "GET", base+"/rotation", nil, &policy); status != http.StatusOK {
+		t.Fatalf("Failed to get rotation policy: status %d", status)
+	}
+	if policy.LastStatus != "retrying" || policy.LastError == "" || policy.Secret != nil {
+		t.Errorf("Expected the failure to be recorded, got %+v", policy)
+	}
+
+	if status := doJSON(t, server, "PUT", base+"/rotation", map[string]any{"strategy": "random", "interval": 60}, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a too short interval, got %d", status)
+	}
+
+	var logs struct {
+		AuditLogs []struct {
+			Action string `json:"action"`
+		} `json:"auditLogs"`
+	}
+	if status := doJSON(t, server, "GET", "/api/audit-logs?pageSize=100&pageIndex=1", nil, &logs); status != http.StatusOK {
+		t.Fatalf("Failed to get audit logs: status %d", status)
+	}
+	actions := map[string]int{}
+	for _, log := range logs.AuditLogs {
+		actions[log.Action]++
+	}
+	if actions["set_rotation_policy"] != 2 || actions["rotate_vault"] != 1 || actions["rotate_vault_failed"] != 1 {
+		t.Errorf("Expected rotation audit entries, got %v", actions)
+	}
+
+	if status := doJSON(t, server, "DELETE", base+"/rotation", nil, nil); status != http.StatusNoContent {
+		t.Errorf("Expected rotation policy to be removed, got %d", status)
+	}
+	if status := doJSON(t, server, "GET", base+"/rotation", nil, nil); status != http.StatusNotFound {
+		t.Errorf("Expected 404 after removing the policy, got %d", status)
+	}
+}
@@ -0,0 +1,71 @@
+package e2e
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestRun_InjectsVaultVariables tests that variables from several vaults reach the command
+func TestRun_InjectsVaultVariables(t *testing.T) {
+	server := StartTestServer(t)
+	appVault := "app-env-" + generateRandomString(8)
+	dbVault := "db-env-" + generateRandomString(8)
+	server.CreateVault(t, appVault, "GREETING=hello\nSHARED=from-app\n")
+	dbVaultID := server.CreateVault(t, dbVault, "DB_URL='postgres://db/app'\nSHARED=from-db\n")
+
+	result := RunCLI(t,
+		"run",
+		"--name", appVault,
+		"--id", dbVaultID,
+		"--prefix", "VH_",
+		"--base-url", server.URL,
+		"--api-key", server.APIKey,
+		"--",
+		"sh", "-c", `echo "$VH_GREETING|$VH_DB_URL|$VH_SHARED"`,
+	)
+
+	result.MustSucceed(t)
+	if strings.TrimSpace(result.Stdout) != "hello|postgres://db/app|from-db" {
+		t.Errorf("Expected injected variables in stdout, got: %s", result.Stdout)
+	}
+	if !result.ContainsStderr(t, "VH_SHARED") {
+		t.Errorf("Expected a warning about the replaced variable, got: %s", result.Stderr)
+	}
+}
+
+// TestRun_ExitCodePassthrough tests that the exit code of the command is returned
+func TestRun_ExitCodePassthrough(t *testing.T) {
+	server := StartTestServer(t)
+	vaultName := "exit-env-" + generateRandomString(8)
+	server.CreateVault(t, vaultName, "CODE=7")
+
+	result := RunCLI(t,
+		"run",
+		"--name", vaultName,
+		"--base-url", server.URL,
+		"--api-key", server.APIKey,
+		"--",
+		"sh", "-c", `exit "$CODE"`,
+	)
+
+	result.MustFail(t, 7)
+}
+
+// TestRun_InvalidDotenv tests that a vault that is not in dotenv format is rejected
+func TestRun_InvalidDotenv(t *testing.T) {
+	server := StartTestServer(t)
+
+	result := RunCLI(t,
+		"run",
+		"--name", server.VaultName,
+		"--base-url", server.URL,
+		"--api-key", server.APIKey,
+		"--",
+		"true",
+	)
+
+	result.MustFail(t, 1)
+	if !result.ContainsStderr(t, "dotenv") {
+		t.Errorf("Expected dotenv format error, got: %s", result.Stderr)
+	}
+}
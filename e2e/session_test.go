@@ -0,0 +1,107 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+)
+
+// tokenResponse is the response of the refresh endpoint
+type tokenResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refreshToken"`
+}
+
+// demoLogin logs in as the demo user with a password and returns a client for the new session
+func demoLogin(t *testing.T, server *TestServer) (*TestServer, loginResponse) {
+	t.Helper()
+
+	var login loginResponse
+	status := doJSON(t, server, "POST", "/api/auth/login", map[string]string{
+		"email":    "mock@demo.com",
+		"password": "Test1234!",
+	}, &login)
+	if status != http.StatusOK || login.Token == "" || login.RefreshToken == "" {
+		t.Fatalf("Failed to login: status %d, %+v", status, login)
+	}
+	session := *server
+	session.JWTToken = login.Token
+	return &session, login
+}
+
+// TestSession_RefreshAndRevoke tests rotating refresh tokens, revoking a session from another
+// device and logging out everywhere on password change
+func TestSession_RefreshAndRevoke(t *testing.T) {
+	server := StartTestServer(t)
+
+	laptop, laptopLogin := demoLogin(t, server)
+	phone, phoneLogin := demoLogin(t, server)
+
+	// A refresh token is exchanged for new tokens once
+	var refreshed tokenResponse
+	status := doJSON(t, server, "POST", "/api/auth/refresh", map[string]string{"refreshToken": laptopLogin.RefreshToken}, &refreshed)
+	if status != http.StatusOK || refreshed.Token == "" || refreshed.RefreshToken == laptopLogin.RefreshToken {
+		t.Fatalf("Failed to refresh: status %d, %+v", status, refreshed)
+	}
+	laptop.JWTToken = refreshed.Token
+	if status := doJSON(t, laptop, "GET", "/api/user", nil, nil); status != http.StatusOK {
+		t.Fatalf("Refreshed access token rejected: status %d", status)
+	}
+
+	// Each device sees its own session marked as current; the laptop revokes the phone's
+	var sessions struct {
+		Sessions []struct {
+			ID      string `json:"id"`
+			Current bool   `json:"current"`
+		} `json:"sessions"`
+	}
+	if status := doJSON(t, phone, "GET", "/api/sessions", nil, &sessions); status != http.StatusOK {
+		t.Fatalf("Failed to list sessions: status %d", status)
+	}
+	var phoneSessionID string
+	for _, session := range sessions.Sessions {
+		if session.Current {
+			phoneSessionID = session.ID
+		}
+	}
+	// The setup login of the test server is the third session
+	if len(sessions.Sessions) != 3 || phoneSessionID == "" {
+		t.Fatalf("Unexpected sessions: %+v", sessions.Sessions)
+	}
+	if status := doJSON(t, laptop, "DELETE", "/api/sessions/"+phoneSessionID, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("Failed to revoke session: status %d", status)
+	}
+	if status := doJSON(t, phone, "GET", "/api/user", nil, nil); status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a revoked session, got %d", status)
+	}
+	status = doJSON(t, server, "POST", "/api/auth/refresh", map[string]string{"refreshToken": phoneLogin.RefreshToken}, nil)
+	if status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 when refreshing a revoked session, got %d", status)
+	}
+
+	// Changing the password logs out every other session
+	other, _ := demoLogin(t, server)
+	status = doJSON(t, laptop, "POST", "/api/auth/password/change", map[string]string{
+		"currentPassword": "Test1234!",
+		"newPassword":     "Changed1234!",
+	}, nil)
+	if status != http.StatusOK {
+		t.Fatalf("Failed to change password: status %d", status)
+	}
+	if status := doJSON(t, other, "GET", "/api/user", nil, nil); status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 after password change, got %d", status)
+	}
+	if status := doJSON(t, server, "GET", "/api/user", nil, nil); status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for the setup session after password change, got %d", status)
+	}
+	if status := doJSON(t, laptop, "GET", "/api/user", nil, nil); status != http.StatusOK {
+		t.Fatalf("Session that changed the password was logged out: status %d", status)
+	}
+
+	// Logging out revokes the access token
+	if status := doJSON(t, laptop, "GET", "/api/auth/logout", nil, nil); status != http.StatusOK {
+		t.Fatalf("Failed to logout: status %d", status)
+	}
+	if status := doJSON(t, laptop, "GET", "/api/user", nil, nil); status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 after logout, got %d", status)
+	}
+}
@@ -36,7 +36,8 @@ type CLIResult struct {
 }
 
 // StartTestServer starts a test server with SQLite in-memory database
-func StartTestServer(t *testing.T) *TestServer {
+// Extra environment variables, such as identity provider settings, are passed to the server.
+func StartTestServer(t *testing.T, env ...string) *TestServer {
 	t.Helper()
 
 	// Get project root directory (parent of e2e/)
@@ -84,7 +85,10 @@ func StartTestServer(t *testing.T) *TestServer {
 		"JWT_SECRET="+jwtSecret,
 		"ENCRYPTION_KEY="+encryptionKey,
 		"DEMO_ENABLED=true", // Enable demo mode to auto-create demo user
+		"WEBAUTHN_RP_ID=localhost",
+		"WEBAUTHN_RP_ORIGINS=http://localhost:"+port,
 	)
+	cmd.Env = append(cmd.Env, env...)
 
 	// Capture server output
 	stdoutPipe, err := cmd.StdoutPipe()
@@ -188,10 +192,26 @@ func (s *TestServer) setupTestUserAndAPIKey(t *testing.T) {
 	}
 	s.JWTToken = token // Save JWT token for later use
 
-	// Create API key using the token
-	apiKeyReq := map[string]interface{}{
+	s.APIKey = s.createAPIKey(t, map[string]interface{}{
 		"name": "e2e-test-key",
-	}
+	})
+}
+
+// CreateAPIKey creates an additional API key for the demo user with the given permissions
+func (s *TestServer) CreateAPIKey(t *testing.T, permissions ...string) string {
+	t.Helper()
+
+	return s.createAPIKey(t, map[string]interface{}{
+		"name":        "e2e-scoped-key-" + generateRandomString(8),
+		"permissions": permissions,
+	})
+}
+
+// createAPIKey creates an API key via API using JWT authentication and returns the plain key
+func (s *TestServer) createAPIKey(t *testing.T, apiKeyReq map[string]interface{}) string {
+	t.Helper()
+
+	token := s.JWTToken
 	apiKeyBody, _ := json.Marshal(apiKeyReq)
 
 	req, _ := http.NewRequest("POST", s.URL+"/api/api-keys", bytes.NewBuffer(apiKeyBody))
@@ -227,7 +247,7 @@ func (s *TestServer) setupTestUserAndAPIKey(t *testing.T) {
 		s.Stop()
 		t.Fatalf("No key or plainKey in API key response: %v", apiKeyResp)
 	}
-	s.APIKey = apiKey
+	return apiKey
 }
 
 // setupTestVault creates a test vault via API using JWT authentication
@@ -236,13 +256,18 @@ func (s *TestServer) setupTestVault(t *testing.T) {
 
 	vaultName := "test-vault-" + generateRandomString(8)
 	s.VaultName = vaultName
+	s.VaultID = s.CreateVault(t, vaultName, "initial-test-value")
+}
+
+// CreateVault creates a vault via API using JWT authentication and returns its unique ID
+func (s *TestServer) CreateVault(t *testing.T, name, value string) string {
+	t.Helper()
 
-	// Create vault via API using JWT token (not API key)
 	// POST /api/vaults requires JWT authentication
 	vaultReq := map[string]interface{}{
 		"uniqueId": uuid.New().String(),
-		"name":     vaultName,
-		"value":    "initial-test-value",
+		"name":     name,
+		"value":    value,
 	}
 	vaultBody, _ := json.Marshal(vaultReq)
 
@@ -274,7 +299,7 @@ func (s *TestServer) setupTestVault(t *testing.T) {
 		s.Stop()
 		t.Fatalf("No uniqueId in vault response")
 	}
-	s.VaultID = vaultID
+	return vaultID
 }
 
 // Stop stops the test server
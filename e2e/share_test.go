@@ -0,0 +1,137 @@
+package e2e
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// viewShare fetches a share without authentication, the way the recipient's browser does
+func viewShare(t *testing.T, server *TestServer, id string, out any) int {
+	t.Helper()
+
+	resp, err := http.Get(server.URL + "/api/shares/" + id)
+	if err != nil {
+		t.Fatalf("Failed to get share: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode share: %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// openShare decrypts a shared secret with the key from the share URL's fragment
+func openShare(t *testing.T, shareURL, ciphertext string) string {
+	t.Helper()
+
+	u, err := url.Parse(shareURL)
+	if err != nil {
+		t.Fatalf("Invalid share URL %q: %v", shareURL, err)
+	}
+	key, err := base64.RawURLEncoding.DecodeString(u.Fragment)
+	if err != nil {
+		t.Fatalf("Invalid share key: %v", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		t.Fatalf("Invalid ciphertext: %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("Failed to create cipher: %v", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatalf("Failed to create GCM: %v", err)
+	}
+	id := u.Path[strings.LastIndex(u.Path, "/")+1:]
+	plaintext, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], []byte(id))
+	if err != nil {
+		t.Fatalf("Failed to decrypt share: %v", err)
+	}
+	return string(plaintext)
+}
+
+// TestShare_OneTimeLink tests that a shared vault value can be viewed once through its link
+func TestShare_OneTimeLink(t *testing.T) {
+	server := StartTestServer(t)
+
+	var share struct {
+		ID       string `json:"id"`
+		URL      string `json:"url"`
+		MaxViews int    `json:"maxViews"`
+	}
+	status := doJSON(t, server, "POST", "/api/shares", map[string]any{"vaultUniqueId": server.VaultID}, &share)
+	if status != http.StatusCreated {
+		t.Fatalf("Expected share to be created, got %d", status)
+	}
+	if share.MaxViews != 1 || !strings.Contains(share.URL, "/share/"+share.ID+"#") {
+		t.Fatalf("Unexpected share: %+v", share)
+	}
+
+	var secret struct {
+		Ciphertext     string `json:"ciphertext"`
+		RemainingViews int    `json:"remainingViews"`
+	}
+	if status := viewShare(t, server, share.ID, &secret); status != http.StatusOK {
+		t.Fatalf("Expected share to be viewable, got %d", status)
+	}
+	if secret.RemainingViews != 0 {
+		t.Errorf("Expected no remaining views, got %d", secret.RemainingViews)
+	}
+	if value := openShare(t, share.URL, secret.Ciphertext); value != "initial-test-value" {
+		t.Errorf("Expected vault value, got %q", value)
+	}
+
+	// The link is burnt after its only view
+	if status := viewShare(t, server, share.ID, nil); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for a used share, got %d", status)
+	}
+
+	// Ad-hoc secrets can be viewed as often as allowed
+	status = doJSON(t, server, "POST", "/api/shares", map[string]any{"value": "contractor-password", "maxViews": 2, "expiresIn": 600}, &share)
+	if status != http.StatusCreated {
+		t.Fatalf("Expected ad-hoc share to be created, got %d", status)
+	}
+	for i := 0; i < 2; i++ {
+		if status := viewShare(t, server, share.ID, &secret); status != http.StatusOK {
+			t.Fatalf("Expected view %d to succeed, got %d", i+1, status)
+		}
+		if value := openShare(t, share.URL, secret.Ciphertext); value != "contractor-password" {
+			t.Errorf("Expected shared secret, got %q", value)
+		}
+	}
+	if status := viewShare(t, server, share.ID, nil); status != http.StatusNotFound {
+		t.Errorf("Expected 404 after the last view, got %d", status)
+	}
+
+	if status := doJSON(t, server, "POST", "/api/shares", map[string]any{"value": "x", "vaultUniqueId": server.VaultID}, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a share of both a vault and a value, got %d", status)
+	}
+
+	var logs struct {
+		AuditLogs []struct {
+			Action string `json:"action"`
+		} `json:"auditLogs"`
+	}
+	if status := doJSON(t, server, "GET", "/api/audit-logs?pageSize=100&pageIndex=1", nil, &logs); status != http.StatusOK {
+		t.Fatalf("Failed to get audit logs: status %d", status)
+	}
+	actions := map[string]int{}
+	for _, log := range logs.AuditLogs {
+		actions[log.Action]++
+	}
+	if actions["create_share"] != 2 || actions["view_share"] != 3 {
+		t.Errorf("Expected 2 create_share and 3 view_share entries, got %v", actions)
+	}
+}
@@ -0,0 +1,141 @@
+package e2e
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/lwshen/vault-hub/internal/auth"
+)
+
+// loginResponse is the response of the password and two-factor login endpoints
+type loginResponse struct {
+	Token             string `json:"token"`
+	RefreshToken      string `json:"refreshToken"`
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	ChallengeToken    string `json:"challengeToken"`
+}
+
+// totpCode returns the code of a secret for a time step
+func totpCode(t *testing.T, secret string, counter int64) string {
+	t.Helper()
+	code, err := auth.TOTPCode(secret, counter)
+	if err != nil {
+		t.Fatalf("Failed to compute TOTP code: %v", err)
+	}
+	return code
+}
+
+// passwordLogin logs in as the demo user and expects a two-factor challenge
+func passwordLogin(t *testing.T, server *TestServer) string {
+	t.Helper()
+
+	var login loginResponse
+	status := doJSON(t, server, "POST", "/api/auth/login", map[string]string{
+		"email":    "mock@demo.com",
+		"password": "Test1234!",
+	}, &login)
+	if status != http.StatusOK || !login.TwoFactorRequired || login.Token != "" || login.ChallengeToken == "" {
+		t.Fatalf("Expected a two-factor challenge, got status %d: %+v", status, login)
+	}
+	return login.ChallengeToken
+}
+
+// TestTwoFactor_TOTPLogin tests enrolling an authenticator app and completing password logins
+// with TOTP and recovery codes
+func TestTwoFactor_TOTPLogin(t *testing.T) {
+	server := StartTestServer(t)
+
+	var setup struct {
+		Secret          string `json:"secret"`
+		ProvisioningURI string `json:"provisioningUri"`
+	}
+	if status := doJSON(t, server, "POST", "/api/auth/2fa/totp/setup", nil, &setup); status != http.StatusOK || setup.Secret == "" {
+		t.Fatalf("Failed to start TOTP setup: status %d, %+v", status, setup)
+	}
+
+	step := auth.TOTPCounter(time.Now())
+	var enabled struct {
+		RecoveryCodes []string `json:"recoveryCodes"`
+	}
+	status := doJSON(t, server, "POST", "/api/auth/2fa/totp/enable", map[string]string{"code": totpCode(t, setup.Secret, step)}, &enabled)
+	if status != http.StatusOK || len(enabled.RecoveryCodes) == 0 {
+		t.Fatalf("Failed to enable TOTP: status %d, %+v", status, enabled)
+	}
+
+	// A wrong code does not complete the login
+	challenge := passwordLogin(t, server)
+	wrong := map[string]string{"challengeToken": challenge, "code": totpCode(t, setup.Secret, step-10)}
+	if status := doJSON(t, server, "POST", "/api/auth/login/2fa", wrong, nil); status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a wrong code, got %d", status)
+	}
+
+	var login loginResponse
+	status = doJSON(t, server, "POST", "/api/auth/login/2fa", map[string]string{
+		"challengeToken": challenge,
+		"code":           totpCode(t, setup.Secret, step+1),
+	}, &login)
+	if status != http.StatusOK || login.Token == "" {
+		t.Fatalf("Failed to complete login with TOTP: status %d, %+v", status, login)
+	}
+
+	// The new token works, and the challenge cannot be used again
+	session := *server
+	session.JWTToken = login.Token
+	var twoFactor struct {
+		Enabled                bool `json:"enabled"`
+		RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+	}
+	if status := doJSON(t, &session, "GET", "/api/auth/2fa", nil, &twoFactor); status != http.StatusOK || !twoFactor.Enabled {
+		t.Fatalf("Unexpected two-factor status: status %d, %+v", status, twoFactor)
+	}
+	reused := map[string]string{"challengeToken": challenge, "recoveryCode": enabled.RecoveryCodes[0]}
+	if status := doJSON(t, server, "POST", "/api/auth/login/2fa", reused, nil); status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a used challenge, got %d", status)
+	}
+
+	// Recovery codes complete a login once
+	challenge = passwordLogin(t, server)
+	status = doJSON(t, server, "POST", "/api/auth/login/2fa", map[string]string{
+		"challengeToken": challenge,
+		"recoveryCode":   enabled.RecoveryCodes[0],
+	}, &login)
+	if status != http.StatusOK || login.Token == "" {
+		t.Fatalf("Failed to complete login with a recovery code: status %d, %+v", status, login)
+	}
+	challenge = passwordLogin(t, server)
+	reused = map[string]string{"challengeToken": challenge, "recoveryCode": enabled.RecoveryCodes[0]}
+	if status := doJSON(t, server, "POST", "/api/auth/login/2fa", reused, nil); status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a used recovery code, got %d", status)
+	}
+
+	var logs struct {
+		AuditLogs []struct {
+			Action string `json:"action"`
+		} `json:"auditLogs"`
+	}
+	if status := doJSON(t, server, "GET", "/api/audit-logs?pageSize=100&pageIndex=1", nil, &logs); status != http.StatusOK {
+		t.Fatalf("Failed to get audit logs: status %d", status)
+	}
+	actions := map[string]bool{}
+	for _, log := range logs.AuditLogs {
+		actions[log.Action] = true
+	}
+	if !actions["enable_two_factor"] || !actions["use_recovery_code"] {
+		t.Fatalf("Missing two-factor audit actions in %v", actions)
+	}
+
+	// Disabling needs the password and a second factor
+	disable := map[string]string{"password": "Test1234!", "recoveryCode": enabled.RecoveryCodes[1]}
+	if status := doJSON(t, server, "POST", "/api/auth/2fa/totp/disable", disable, nil); status != http.StatusOK {
+		t.Fatalf("Failed to disable TOTP: status %d", status)
+	}
+	var plain loginResponse
+	status = doJSON(t, server, "POST", "/api/auth/login", map[string]string{
+		"email":    "mock@demo.com",
+		"password": "Test1234!",
+	}, &plain)
+	if status != http.StatusOK || plain.TwoFactorRequired || plain.Token == "" {
+		t.Fatalf("Expected a token without two-factor authentication, got status %d: %+v", status, plain)
+	}
+}
@@ -0,0 +1,89 @@
+package e2e
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestVaultKV_Keys tests kv vaults end to end: creating one from data, reading and setting
+// single keys through the API and the CLI, and partial updates from the web API
+func TestVaultKV_Keys(t *testing.T) {
+	server := StartTestServer(t)
+
+	var vault struct {
+		UniqueID string            `json:"uniqueId"`
+		Type     string            `json:"type"`
+		Value    string            `json:"value"`
+		Data     map[string]string `json:"data"`
+	}
+	body := map[string]any{"name": "app", "data": map[string]string{"DATABASE_URL": "postgres://db/app", "LOG_LEVEL": "info"}}
+	if status := doJSON(t, server, "POST", "/api/vaults", body, &vault); status != http.StatusCreated {
+		t.Fatalf("Expected kv vault to be created, got %d", status)
+	}
+	if vault.Type != "kv" || vault.Data["DATABASE_URL"] != "postgres://db/app" {
+		t.Errorf("Expected a kv vault with its data, got %+v", vault)
+	}
+	body = map[string]any{"name": "app-invalid", "type": "kv", "value": "DATABASE_URL=postgres://db/app"}
+	if status := doJSON(t, server, "POST", "/api/vaults", body, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a kv value that is not a JSON object, got %d", status)
+	}
+
+	// Reading a single key with the API key
+	getKey := func(path string) (int, map[string]string) {
+		req, _ := http.NewRequest("GET", server.URL+path, nil)
+		req.Header.Set("Authorization", "Bearer "+server.APIKey)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to get key: %v", err)
+		}
+		defer resp.Body.Close()
+		var keyValue map[string]string
+		_ = json.NewDecoder(resp.Body).Decode(&keyValue)
+		return resp.StatusCode, keyValue
+	}
+	if status, keyValue := getKey("/api/cli/vault/name/app/keys/DATABASE_URL"); status != http.StatusOK || keyValue["value"] != "postgres://db/app" {
+		t.Errorf("Expected the key value, got %d: %v", status, keyValue)
+	}
+	if status, _ := getKey("/api/cli/vault/" + vault.UniqueID + "/keys/MISSING"); status != http.StatusNotFound {
+		t.Errorf("Expected 404 for a missing key, got %d", status)
+	}
+	if status, _ := getKey("/api/cli/vault/" + server.VaultID + "/keys/DATABASE_URL"); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a key of a static vault, got %d", status)
+	}
+
+	// Changing one key from the web keeps the others
+	update := map[string]any{"setKeys": map[string]string{"LOG_LEVEL": "debug"}}
+	if status := doJSON(t, server, "PUT", "/api/vaults/"+vault.UniqueID, update, &vault); status != http.StatusOK {
+		t.Fatalf("Expected the key update to succeed, got %d", status)
+	}
+	if vault.Data["LOG_LEVEL"] != "debug" || vault.Data["DATABASE_URL"] != "postgres://db/app" {
+		t.Errorf("Expected only LOG_LEVEL to change, got %v", vault.Data)
+	}
+
+	// The CLI sets and gets single keys with client-side encryption
+	result := RunCLI(t, "update", "--name", "app", "--key", "DATABASE_URL", "--value", "postgres://db/app2",
+		"--base-url", server.URL, "--api-key", server.APIKey)
+	result.MustSucceed(t)
+
+	result = RunCLI(t, "get", "--name", "app", "--key", "DATABASE_URL", "--base-url", server.URL, "--api-key", server.APIKey)
+	result.MustSucceed(t)
+	if strings.TrimSpace(result.Stdout) != "postgres://db/app2" {
+		t.Errorf("Expected the CLI to print the key value, got %q", result.Stdout)
+	}
+
+	if status := doJSON(t, server, "GET", "/api/vaults/"+vault.UniqueID, nil, &vault); status != http.StatusOK {
+		t.Fatalf("Failed to get vault: status %d", status)
+	}
+	if vault.Data["DATABASE_URL"] != "postgres://db/app2" || vault.Data["LOG_LEVEL"] != "debug" {
+		t.Errorf("Expected the CLI to change only DATABASE_URL, got %v", vault.Data)
+	}
+
+	// The whole vault is still readable by released CLIs, as a JSON object
+	result = RunCLI(t, "get", "--name", "app", "--base-url", server.URL, "--api-key", server.APIKey)
+	result.MustSucceed(t)
+	if !result.ContainsStdout(t, `"LOG_LEVEL":"debug"`) {
+		t.Errorf("Expected the CLI to print the vault as JSON, got %q", result.Stdout)
+	}
+}
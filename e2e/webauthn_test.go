@@ -0,0 +1,165 @@
+package e2e
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/descope/virtualwebauthn"
+)
+
+// webAuthnBegin is the response of the begin endpoints of the passkey ceremonies
+type webAuthnBegin struct {
+	SessionToken string         `json:"sessionToken"`
+	Options      map[string]any `json:"options"`
+}
+
+// rawJSON re-encodes a decoded JSON value for the software authenticator
+func rawJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatalf("Failed to encode JSON: %v", err)
+	}
+	return string(data)
+}
+
+// TestWebAuthn_PasskeyLogin tests registering a passkey with a software authenticator and
+// logging in with it
+func TestWebAuthn_PasskeyLogin(t *testing.T) {
+	server := StartTestServer(t)
+
+	var cfg struct {
+		WebauthnEnabled bool `json:"webauthnEnabled"`
+	}
+	if status := doJSON(t, server, "GET", "/api/config", nil, &cfg); status != http.StatusOK || !cfg.WebauthnEnabled {
+		t.Fatalf("Expected WebAuthn to be enabled: status %d, %+v", status, cfg)
+	}
+
+	rp := virtualwebauthn.RelyingParty{ID: "localhost", Name: "Vault Hub", Origin: server.URL}
+	authenticator := virtualwebauthn.NewAuthenticator()
+	credential := virtualwebauthn.NewCredential(virtualwebauthn.KeyTypeEC2)
+
+	var begin webAuthnBegin
+	if status := doJSON(t, server, "POST", "/api/auth/webauthn/register/begin", nil, &begin); status != http.StatusOK || begin.SessionToken == "" {
+		t.Fatalf("Failed to begin registration: status %d, %+v", status, begin)
+	}
+	attestationOptions, err := virtualwebauthn.ParseAttestationOptions(rawJSON(t, begin.Options))
+	if err != nil {
+		t.Fatalf("Failed to parse attestation options: %v", err)
+	}
+	attestation := virtualwebauthn.CreateAttestationResponse(rp, authenticator, credential, *attestationOptions)
+
+	var registered struct {
+		ID        int64  `json:"id"`
+		Name      string `json:"name"`
+		SignCount int64  `json:"signCount"`
+	}
+	status := doJSON(t, server, "POST", "/api/auth/webauthn/register/finish", map[string]any{
+		"sessionToken": begin.SessionToken,
+		"name":         "Test key",
+		"credential":   json.RawMessage(attestation),
+	}, &registered)
+	if status != http.StatusCreated || registered.Name != "Test key" {
+		t.Fatalf("Failed to finish registration: status %d, %+v", status, registered)
+	}
+
+	// The registration session is single use
+	status = doJSON(t, server, "POST", "/api/auth/webauthn/register/finish", map[string]any{
+		"sessionToken": begin.SessionToken,
+		"credential":   json.RawMessage(attestation),
+	}, nil)
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a used registration session, got %d", status)
+	}
+
+	// The authenticator returns the user handle, which identifies the account at login
+	authenticator.Options.UserHandle = []byte(attestationOptions.UserID)
+	authenticator.AddCredential(credential)
+
+	anonymous := *server
+	anonymous.JWTToken = ""
+	if status := doJSON(t, &anonymous, "POST", "/api/auth/webauthn/login/begin", nil, &begin); status != http.StatusOK {
+		t.Fatalf("Failed to begin login: status %d", status)
+	}
+	assertionOptions, err := virtualwebauthn.ParseAssertionOptions(rawJSON(t, begin.Options))
+	if err != nil {
+		t.Fatalf("Failed to parse assertion options: %v", err)
+	}
+	credential.Counter++
+	assertion := virtualwebauthn.CreateAssertionResponse(rp, authenticator, credential, *assertionOptions)
+
+	var login loginResponse
+	status = doJSON(t, &anonymous, "POST", "/api/auth/webauthn/login/finish", map[string]any{
+		"sessionToken": begin.SessionToken,
+		"credential":   json.RawMessage(assertion),
+	}, &login)
+	if status != http.StatusOK || login.Token == "" {
+		t.Fatalf("Failed to log in with the passkey: status %d, %+v", status, login)
+	}
+
+	// Replaying the assertion is rejected
+	status = doJSON(t, &anonymous, "POST", "/api/auth/webauthn/login/finish", map[string]any{
+		"sessionToken": begin.SessionToken,
+		"credential":   json.RawMessage(assertion),
+	}, nil)
+	if status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a replayed assertion, got %d", status)
+	}
+
+	// The issued token works like a password login token
+	session := *server
+	session.JWTToken = login.Token
+	var credentials struct {
+		Credentials []struct {
+			ID         int64   `json:"id"`
+			SignCount  int64   `json:"signCount"`
+			LastUsedAt *string `json:"lastUsedAt"`
+		} `json:"credentials"`
+	}
+	if status := doJSON(t, &session, "GET", "/api/auth/webauthn/credentials", nil, &credentials); status != http.StatusOK {
+		t.Fatalf("Failed to list passkeys: status %d", status)
+	}
+	if len(credentials.Credentials) != 1 || credentials.Credentials[0].SignCount != 1 || credentials.Credentials[0].LastUsedAt == nil {
+		t.Fatalf("Unexpected passkeys: %+v", credentials.Credentials)
+	}
+
+	var logs struct {
+		AuditLogs []struct {
+			Action string `json:"action"`
+		} `json:"auditLogs"`
+	}
+	if status := doJSON(t, server, "GET", "/api/audit-logs?pageSize=100&pageIndex=1", nil, &logs); status != http.StatusOK {
+		t.Fatalf("Failed to get audit logs: status %d", status)
+	}
+	actions := map[string]bool{}
+	for _, log := range logs.AuditLogs {
+		actions[log.Action] = true
+	}
+	if !actions["register_credential"] || !actions["webauthn_login"] {
+		t.Fatalf("Missing passkey audit actions in %v", actions)
+	}
+
+	// A deleted passkey can no longer log in
+	path := fmt.Sprintf("/api/auth/webauthn/credentials/%d", registered.ID)
+	if status := doJSON(t, &session, "DELETE", path, nil, nil); status != http.StatusNoContent {
+		t.Fatalf("Failed to delete passkey: status %d", status)
+	}
+	if status := doJSON(t, &anonymous, "POST", "/api/auth/webauthn/login/begin", nil, &begin); status != http.StatusOK {
+		t.Fatalf("Failed to begin login: status %d", status)
+	}
+	assertionOptions, err = virtualwebauthn.ParseAssertionOptions(rawJSON(t, begin.Options))
+	if err != nil {
+		t.Fatalf("Failed to parse assertion options: %v", err)
+	}
+	credential.Counter++
+	assertion = virtualwebauthn.CreateAssertionResponse(rp, authenticator, credential, *assertionOptions)
+	status = doJSON(t, &anonymous, "POST", "/api/auth/webauthn/login/finish", map[string]any{
+		"sessionToken": begin.SessionToken,
+		"credential":   json.RawMessage(assertion),
+	}, nil)
+	if status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a deleted passkey, got %d", status)
+	}
+}
@@ -0,0 +1,183 @@
+package e2e
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// webhookRequest is a request received by the test webhook endpoint
+type webhookRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// doJSON sends a JWT-authenticated JSON request and decodes the response into out
+func doJSON(t *testing.T, server *TestServer, method, path string, body, out any) int {
+	t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, _ := json.Marshal(body)
+		reader = bytes.NewReader(data)
+	}
+	req, _ := http.NewRequest(method, server.URL+path, reader)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+server.JWTToken)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("%s %s failed: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode %s %s response: %v", method, path, err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// verifyWebhookSignature checks a signature header the way a receiver would
+func verifyWebhookSignature(secret, header string, body []byte) error {
+	var timestamp, signature string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			signature = value
+		}
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "."))
+	mac.Write(body)
+	if !hmac.Equal([]byte(hex.EncodeToString(mac.Sum(nil))), []byte(signature)) {
+		return fmt.Errorf("signature mismatch in %q", header)
+	}
+	return nil
+}
+
+// TestWebhook_SignedDeliveries tests that subscribed events are delivered signed, logged
+// and that test events can be sent on demand
+func TestWebhook_SignedDeliveries(t *testing.T) {
+	server := StartTestServer(t)
+
+	received := make(chan webhookRequest, 10)
+	endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- webhookRequest{header: r.Header.Clone(), body: body}
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer endpoint.Close()
+
+	var created struct {
+		Webhook struct {
+			ID int64 `json:"id"`
+		} `json:"webhook"`
+		Secret string `json:"secret"`
+	}
+	status := doJSON(t, server, "POST", "/api/webhooks", map[string]any{
+		"name":   "e2e",
+		"url":    endpoint.URL,
+		"events": []string{"vault.read"},
+	}, &created)
+	if status != http.StatusCreated || created.Secret == "" {
+		t.Fatalf("Failed to create webhook: status %d, %+v", status, created)
+	}
+
+	RunCLI(t,
+		"get",
+		"--name", server.VaultName,
+		"--base-url", server.URL,
+		"--api-key", server.APIKey,
+	).MustSucceed(t)
+
+	var delivery webhookRequest
+	select {
+	case delivery = <-received:
+	case <-time.After(15 * time.Second):
+		t.Fatal("Timed out waiting for the webhook delivery")
+	}
+	if event := delivery.header.Get("X-VaultHub-Event"); event != "vault.read" {
+		t.Fatalf("Expected a vault.read event, got %q", event)
+	}
+	if err := verifyWebhookSignature(created.Secret, delivery.header.Get("X-VaultHub-Signature"), delivery.body); err != nil {
+		t.Fatal(err)
+	}
+	var payload struct {
+		ID     string `json:"id"`
+		Source string `json:"source"`
+		Vault  struct {
+			Name string `json:"name"`
+		} `json:"vault"`
+		APIKey *struct {
+			ID int64 `json:"id"`
+		} `json:"apiKey"`
+	}
+	if err := json.Unmarshal(delivery.body, &payload); err != nil {
+		t.Fatalf("Invalid payload %s: %v", delivery.body, err)
+	}
+	if payload.ID != delivery.header.Get("X-VaultHub-Delivery") || payload.Source != "cli" ||
+		payload.Vault.Name != server.VaultName || payload.APIKey == nil {
+		t.Fatalf("Unexpected payload %s", delivery.body)
+	}
+
+	// The result is recorded right after the endpoint responds
+	path := fmt.Sprintf("/api/webhooks/%d/deliveries", created.Webhook.ID)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var log struct {
+			Deliveries []struct {
+				ID             string `json:"id"`
+				Status         string `json:"status"`
+				ResponseStatus int    `json:"responseStatus"`
+			} `json:"deliveries"`
+			TotalCount int `json:"totalCount"`
+		}
+		if status := doJSON(t, server, "GET", path, nil, &log); status != http.StatusOK {
+			t.Fatalf("Failed to get deliveries: status %d", status)
+		}
+		if log.TotalCount == 1 && log.Deliveries[0].Status == "succeeded" {
+			if log.Deliveries[0].ID != payload.ID || log.Deliveries[0].ResponseStatus != http.StatusOK {
+				t.Fatalf("Unexpected delivery log entry %+v", log.Deliveries[0])
+			}
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Delivery was not recorded as succeeded: %+v", log)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	var test struct {
+		Event  string `json:"event"`
+		Status string `json:"status"`
+	}
+	path = fmt.Sprintf("/api/webhooks/%d/test", created.Webhook.ID)
+	if status := doJSON(t, server, "POST", path, nil, &test); status != http.StatusOK {
+		t.Fatalf("Failed to send test event: status %d", status)
+	}
+	if test.Event != "webhook.test" || test.Status != "succeeded" {
+		t.Fatalf("Unexpected test delivery %+v", test)
+	}
+	select {
+	case delivery = <-received:
+		if err := verifyWebhookSignature(created.Secret, delivery.header.Get("X-VaultHub-Signature"), delivery.body); err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Test event was not received")
+	}
+}
@@ -0,0 +1,135 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// workloadToken mints a token the way a CI system does for one of its jobs
+func workloadToken(t *testing.T, issuer *mockIssuer, audience, subject string) string {
+	t.Helper()
+
+	token, err := issuer.sign(jwt.MapClaims{
+		"iss":              issuer.server.URL,
+		"aud":              audience,
+		"sub":              subject,
+		"repository_owner": "my-org",
+		"iat":              time.Now().Unix(),
+		"exp":              time.Now().Add(5 * time.Minute).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to sign workload token: %v", err)
+	}
+	return token
+}
+
+// exchangeToken posts a workload token to the token exchange endpoint
+func exchangeToken(t *testing.T, server *TestServer, token string, out any) int {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"token": token})
+	resp, err := http.Post(server.URL+"/api/auth/token-exchange", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Token exchange failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode token exchange response: %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// TestWorkloadIdentity_TokenExchange tests that a CI job exchanges its OIDC token for a
+// short-lived API key scoped by the workload identity it matches
+func TestWorkloadIdentity_TokenExchange(t *testing.T) {
+	issuer := newMockIssuer(t)
+	server := StartTestServer(t, "WORKLOAD_ISSUERS="+issuer.server.URL)
+
+	var identity struct {
+		ID int64 `json:"id"`
+	}
+	status := doJSON(t, server, "POST", "/api/workload-identities", map[string]any{
+		"name":           "deploy",
+		"issuer":         issuer.server.URL,
+		"audience":       "vault-hub",
+		"subject":        "repo:my-org/app:*",
+		"claims":         map[string]string{"repository_owner": "my-org"},
+		"vaultUniqueIds": []string{server.VaultID},
+		"permissions":    []string{"vault:read"},
+	}, &identity)
+	if status != http.StatusCreated {
+		t.Fatalf("Expected workload identity to be created, got %d", status)
+	}
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	token := workloadToken(t, issuer, "vault-hub", "repo:my-org/app:ref:refs/heads/main")
+	if err := os.WriteFile(tokenFile, []byte(token+"\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write token file: %v", err)
+	}
+
+	result := RunCLI(t,
+		"get",
+		"--name", server.VaultName,
+		"--base-url", server.URL,
+		"--oidc-token-file", tokenFile,
+	)
+	result.MustSucceed(t)
+	if !result.ContainsStdout(t, "initial-test-value") {
+		t.Errorf("Expected vault value in stdout, got: %s", result.Stdout)
+	}
+
+	// The exchanged key only has the identity's permissions
+	result = RunCLI(t,
+		"update",
+		"--name", server.VaultName,
+		"--value", "overwritten",
+		"--base-url", server.URL,
+		"--oidc-token-file", tokenFile,
+	)
+	result.MustFail(t, 1)
+
+	// Tokens for another workload or audience match no identity
+	if status := exchangeToken(t, server, workloadToken(t, issuer, "vault-hub", "repo:my-org/other:ref:refs/heads/main"), nil); status != http.StatusForbidden {
+		t.Errorf("Expected 403 for another subject, got %d", status)
+	}
+	if status := exchangeToken(t, server, workloadToken(t, issuer, "other", "repo:my-org/app:ref:refs/heads/main"), nil); status != http.StatusForbidden {
+		t.Errorf("Expected 403 for another audience, got %d", status)
+	}
+	if status := exchangeToken(t, server, "not-a-token", nil); status != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an invalid token, got %d", status)
+	}
+
+	var exchanged struct {
+		APIKey     string `json:"apiKey"`
+		IdentityID int64  `json:"identityId"`
+	}
+	if status := exchangeToken(t, server, token, &exchanged); status != http.StatusOK {
+		t.Fatalf("Expected token exchange to succeed, got %d", status)
+	}
+	if exchanged.IdentityID != identity.ID {
+		t.Errorf("Expected identity %d, got %d", identity.ID, exchanged.IdentityID)
+	}
+
+	// Deleting the identity revokes the keys exchanged with it
+	if status := doJSON(t, server, "DELETE", fmt.Sprintf("/api/workload-identities/%d", identity.ID), nil, nil); status != http.StatusNoContent {
+		t.Fatalf("Expected workload identity to be deleted, got %d", status)
+	}
+	result = RunCLI(t,
+		"get",
+		"--name", server.VaultName,
+		"--base-url", server.URL,
+		"--api-key", exchanged.APIKey,
+	)
+	result.MustFail(t, 1)
+}
@@ -0,0 +1,96 @@
+package e2e
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// unwrap redeems a wrapping token without an API key, the way a freshly booted machine does
+func unwrap(t *testing.T, server *TestServer, token string, out any) int {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]string{"token": token})
+	resp, err := http.Post(server.URL+"/api/cli/unwrap", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Unwrap failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if out != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			t.Fatalf("Failed to decode unwrap response: %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+// TestWrap_SingleUseToken tests that a wrapped vault value is handed out exactly once
+func TestWrap_SingleUseToken(t *testing.T) {
+	server := StartTestServer(t)
+
+	getWrapped := func(wrapTTL string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest("GET", server.URL+"/api/cli/vault/"+server.VaultID+"?wrap_ttl="+url.QueryEscape(wrapTTL), nil)
+		req.Header.Set("Authorization", "Bearer "+server.APIKey)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("Failed to get vault: %v", err)
+		}
+		t.Cleanup(func() { resp.Body.Close() })
+		return resp
+	}
+
+	resp := getWrapped("5m")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", resp.StatusCode)
+	}
+	var wrapped map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&wrapped); err != nil {
+		t.Fatalf("Failed to decode wrap response: %v", err)
+	}
+	token, _ := wrapped["wrapToken"].(string)
+	if !strings.HasPrefix(token, "vhwrap_") {
+		t.Fatalf("Expected a wrapping token, got %v", wrapped)
+	}
+	if _, ok := wrapped["value"]; ok {
+		t.Fatal("Expected the value not to be returned when wrapping")
+	}
+
+	var vault struct {
+		UniqueID string `json:"uniqueId"`
+		Value    string `json:"value"`
+	}
+	if status := unwrap(t, server, token, &vault); status != http.StatusOK {
+		t.Fatalf("Expected unwrap to succeed, got %d", status)
+	}
+	if vault.UniqueID != server.VaultID || vault.Value != "initial-test-value" {
+		t.Errorf("Unexpected unwrapped vault: %+v", vault)
+	}
+	if status := unwrap(t, server, token, nil); status != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a used token, got %d", status)
+	}
+
+	if resp := getWrapped("48h"); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for a too long wrap_ttl, got %d", resp.StatusCode)
+	}
+
+	var logs struct {
+		AuditLogs []struct {
+			Action string `json:"action"`
+		} `json:"auditLogs"`
+	}
+	if status := doJSON(t, server, "GET", "/api/audit-logs?pageSize=100&pageIndex=1", nil, &logs); status != http.StatusOK {
+		t.Fatalf("Failed to get audit logs: status %d", status)
+	}
+	actions := map[string]int{}
+	for _, log := range logs.AuditLogs {
+		actions[log.Action]++
+	}
+	if actions["wrap_vault"] != 1 || actions["unwrap_vault"] != 1 || actions["read_vault"] != 0 {
+		t.Errorf("Expected one wrap_vault and one unwrap_vault entry, got %v", actions)
+	}
+}
@@ -1,11 +1,13 @@
 package handler
 
 import (
+	"errors"
 	"log/slog"
 	"net/url"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/lwshen/vault-hub/internal/auth"
+	"github.com/lwshen/vault-hub/internal/config"
 	"github.com/lwshen/vault-hub/model"
 )
 
@@ -14,98 +16,114 @@ type LoginResponse struct {
 	Token string `json:"token"`
 }
 
+// LoginOidc redirects to the identity provider named in the path, or the default provider
 func LoginOidc(c *fiber.Ctx) error {
-	baseUrl := c.BaseURL()
-	url, err := auth.AuthCodeURL(c, baseUrl)
+	provider, err := auth.GetOIDCProvider(c.Params("provider"))
 	if err != nil {
-		slog.Error("Failed to get OIDC URL", "error", err)
+		return SendError(c, fiber.StatusNotFound, err.Error())
+	}
+	url, err := provider.AuthCodeURL(c, c.BaseURL())
+	if err != nil {
+		slog.Error("Failed to get OIDC URL", "error", err, "provider", provider.Name)
 		return c.SendStatus(fiber.StatusInternalServerError)
 	}
-	slog.Debug("Login with OIDC", "url", url)
+	slog.Debug("Login with OIDC", "provider", provider.Name, "url", url)
 	return c.Redirect(url)
 }
 
 func LoginOidcCallback(c *fiber.Ctx) error {
-	code := c.Query("code")
-	state := c.Query("state")
-	slog.Debug("Login with OIDC callback", "uri", c.Request().URI(), "code", code, "state", state)
-
-	err := auth.VerifyState(c, state)
+	provider, err := auth.GetOIDCProvider(c.Params("provider", config.DefaultOidcProvider))
 	if err != nil {
-		return c.SendStatus(fiber.StatusBadRequest)
+		return SendError(c, fiber.StatusNotFound, err.Error())
 	}
 
-	token, err := auth.Verify(c.Context(), code)
-	if err != nil {
-		return c.SendStatus(fiber.StatusInternalServerError)
-	}
-	slog.Debug("Login with OIDC callback", "token", token)
+	code := c.Query("code")
+	state := c.Query("state")
+	slog.Debug("Login with OIDC callback", "provider", provider.Name, "uri", c.Request().URI(), "code", code, "state", state)
 
-	userInfo, err := auth.UserInfo(c.Context(), token)
+	identity, err := provider.Exchange(c, c.BaseURL(), code, state)
 	if err != nil {
-		return c.SendStatus(fiber.StatusInternalServerError)
-	}
-	slog.Debug("Login with OIDC callback", "userInfo", userInfo)
-
-	// Extract claims from userInfo
-	var claims map[string]interface{}
-	if err := userInfo.Claims(&claims); err != nil {
-		slog.Error("Failed to extract OIDC claims", "error", err)
-		return c.SendStatus(fiber.StatusInternalServerError)
-	}
-
-	// Extract email from claims
-	email, ok := claims["email"].(string)
-	if !ok || email == "" {
-		slog.Error("OIDC userInfo missing email claim", "claims", claims)
+		if errors.Is(err, auth.ErrOIDCEmailNotAllowed) {
+			slog.Warn("OIDC login blocked", "provider", provider.Name, "error", err)
+			return SendError(c, fiber.StatusForbidden, err.Error())
+		}
+		slog.Error("Failed to complete OIDC login", "provider", provider.Name, "error", err)
 		return c.SendStatus(fiber.StatusBadRequest)
 	}
+	slog.Debug("Login with OIDC callback", "provider", provider.Name, "identity", identity)
 
 	// Look up user by email
 	user := model.User{
-		Email: email,
+		Email: identity.Email,
 	}
 	if err := user.GetByEmail(); err != nil {
 		// User doesn't exist, create new user from OIDC data
-		name := ""
-		if nameClaim, ok := claims["name"].(string); ok {
-			name = nameClaim
-		}
-
 		createParams := model.CreateUserParams{
-			Email:    email,
+			Email:    identity.Email,
 			Password: nil, // OIDC users don't need passwords
-			Name:     name,
+			Name:     identity.Name,
 		}
 
 		newUser, createErr := createParams.Create()
 		if createErr != nil {
-			slog.Error("Failed to create user from OIDC", "error", createErr, "email", email)
+			slog.Error("Failed to create user from OIDC", "error", createErr, "email", identity.Email)
 			return c.SendStatus(fiber.StatusInternalServerError)
 		}
 		user = *newUser
-		slog.Info("User created from OIDC", "email", email, "name", name)
+		slog.Info("User created from OIDC", "email", identity.Email, "name", identity.Name, "provider", provider.Name)
 	}
 
-	// Generate JWT token for the user
-	jwtToken, err := user.GenerateToken()
+	clientIP, userAgent := getClientInfo(c)
+	syncOidcMemberships(provider, &user, identity.Groups, clientIP, userAgent)
+
+	// Start a session for the user
+	_, tokens, err := model.CreateSession(user.ID, clientIP, userAgent)
 	if err != nil {
-		slog.Error("Failed to generate token for OIDC user", "error", err, "userID", user.ID)
+		slog.Error("Failed to create session for OIDC user", "error", err, "userID", user.ID)
 		return c.SendStatus(fiber.StatusInternalServerError)
 	}
 
 	// Record successful login audit log
-	clientIP, userAgent := getClientInfo(c)
 	if err := model.LogUserAction(model.ActionLoginUser, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
 		slog.Error("Failed to create audit log for OIDC login", "error", err, "userID", user.ID)
 	}
 
 	// Redirect back to frontend with token in URL fragment (hash) for security
 	// URL fragments are never sent to the server, preventing token leakage in logs, Referer headers, and browser history
-	redirectUrl := "/login#token=" + url.QueryEscape(jwtToken) + "&source=oidc"
+	redirectUrl := "/login#token=" + url.QueryEscape(tokens.AccessToken) +
+		"&refreshToken=" + url.QueryEscape(tokens.RefreshToken) + "&source=oidc"
 	return c.Redirect(redirectUrl)
 }
 
+// syncOidcMemberships grants the organization roles the provider maps the user's groups to,
+// keeping the highest role per organization, and audits the membership changes. A failed sync
+// does not block the login.
+func syncOidcMemberships(provider *auth.OIDCProvider, user *model.User, groups []string, clientIP, userAgent string) {
+	if len(provider.GroupRoles) == 0 {
+		return
+	}
+
+	roles := make(map[uint]model.OrganizationRole)
+	for organizationID, names := range provider.MappedRoles(groups) {
+		for _, name := range names {
+			role := model.OrganizationRole(name)
+			if current, ok := roles[organizationID]; !ok || !current.AtLeast(role) {
+				roles[organizationID] = role
+			}
+		}
+	}
+
+	changes, err := model.SyncProviderMemberships(user.ID, provider.Name, roles)
+	if err != nil {
+		slog.Error("Failed to sync OIDC group memberships", "error", err, "userID", user.ID, "provider", provider.Name)
+	}
+	for _, change := range changes {
+		if err := model.LogOrganizationAction(change.OrganizationID, change.Action, user.ID, change.Role, model.SourceWeb, clientIP, userAgent); err != nil {
+			slog.Error("Failed to create audit log for OIDC group membership", "error", err, "userID", user.ID)
+		}
+	}
+}
+
 // getClientInfo extracts IP address and User-Agent from the request
 func getClientInfo(c *fiber.Ctx) (string, string) {
 	// Get IP address (check for forwarded headers first)
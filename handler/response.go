@@ -1,6 +1,12 @@
 package handler
 
-import "github.com/gofiber/fiber/v2"
+import (
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 // ErrorResponse represents a standardized error response
 type ErrorResponse struct {
@@ -17,3 +23,9 @@ func SendError(c *fiber.Ctx, code int, message string) error {
 		},
 	})
 }
+
+// SendTooManyRequests sends a 429 error response telling the client how long to wait
+func SendTooManyRequests(c *fiber.Ctx, retryAfter time.Duration, message string) error {
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	return SendError(c, fiber.StatusTooManyRequests, message)
+}
@@ -0,0 +1,148 @@
+// Package auditforward ships new audit log entries to an external syslog server or
+// HTTP collector.
+package auditforward
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/lwshen/vault-hub/model"
+)
+
+const (
+	// pollInterval is how often the audit log is checked for new entries
+	pollInterval = 2 * time.Second
+	// maxBackoff caps the wait between attempts while the sink is unreachable
+	maxBackoff = 5 * time.Minute
+	// batchSize is the number of entries loaded and sent at a time
+	batchSize = 100
+)
+
+// Sink delivers audit log records to an external system
+type Sink interface {
+	// Send delivers the records in order and returns how many were delivered before
+	// any error
+	Send(ctx context.Context, records []model.AuditLogRecord) (int, error)
+	Close() error
+}
+
+// NewSink returns the sink for a syslog+tcp://, syslog+udp:// or http(s):// URL.
+// The token, if any, is sent to HTTP collectors as a bearer token.
+func NewSink(rawURL, token string) (Sink, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid audit forward URL: %w", err)
+	}
+	if u.Host == "" {
+		return nil, fmt.Errorf("audit forward URL has no host")
+	}
+
+	switch u.Scheme {
+	case "syslog+tcp":
+		return newSyslogSink("tcp", u.Host), nil
+	case "syslog+udp":
+		return newSyslogSink("udp", u.Host), nil
+	case "http", "https":
+		return newHTTPSink(u.String(), token), nil
+	default:
+		return nil, fmt.Errorf("unsupported audit forward scheme %q", u.Scheme)
+	}
+}
+
+// Forwarder sends every new audit log entry to a sink. The position in the audit log is
+// stored in the database, so entries written while the sink is unreachable, or while the
+// server is down, are delivered later in order. Delivery is at least once: an entry may be
+// sent again if the server stops between sending it and saving the position.
+type Forwarder struct {
+	sink   Sink
+	name   string
+	logger *slog.Logger
+}
+
+// New returns a forwarder for the given URL. Its position is tracked per destination,
+// so pointing it somewhere new starts from the current end of the audit log.
+func New(rawURL, token string, logger *slog.Logger) (*Forwarder, error) {
+	sink, err := NewSink(rawURL, token)
+	if err != nil {
+		return nil, err
+	}
+
+	u, _ := url.Parse(rawURL)
+	return &Forwarder{
+		sink:   sink,
+		name:   u.Scheme + "://" + u.Host + u.Path,
+		logger: logger,
+	}, nil
+}
+
+// Run forwards entries until ctx is done, backing off while the sink fails
+func (f *Forwarder) Run(ctx context.Context) {
+	defer f.sink.Close()
+
+	f.logger.Info("Forwarding audit logs", "destination", f.name)
+	failures := 0
+	for {
+		delay := pollInterval
+		sent, err := f.forward(ctx)
+		switch {
+		case err != nil:
+			failures++
+			delay = backoffDelay(failures)
+			f.logger.Error("Failed to forward audit logs", "destination", f.name, "error", err, "retryIn", delay)
+		case sent == batchSize:
+			// More entries are likely waiting
+			failures = 0
+			delay = 0
+		default:
+			failures = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// forward sends the next batch of entries and advances the stored position past the
+// delivered ones. It returns the number of entries delivered.
+func (f *Forwarder) forward(ctx context.Context) (int, error) {
+	lastID, err := model.GetAuditForwardCursor(f.name)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load position: %w", err)
+	}
+
+	logs, err := model.GetAuditLogsAfter(lastID, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to load audit logs: %w", err)
+	}
+	if len(logs) == 0 {
+		return 0, nil
+	}
+
+	records := make([]model.AuditLogRecord, len(logs))
+	for i := range logs {
+		records[i] = logs[i].Record()
+	}
+
+	sent, sendErr := f.sink.Send(ctx, records)
+	if sent > 0 {
+		if err := model.SaveAuditForwardCursor(f.name, records[sent-1].ID); err != nil {
+			return sent, fmt.Errorf("failed to save position: %w", err)
+		}
+	}
+	return sent, sendErr
+}
+
+// backoffDelay doubles the poll interval for every consecutive failure, up to maxBackoff
+func backoffDelay(failures int) time.Duration {
+	delay := pollInterval
+	for i := 1; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
@@ -0,0 +1,287 @@
+package auditforward
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lwshen/vault-hub/internal/config"
+	"github.com/lwshen/vault-hub/model"
+)
+
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "auditforward")
+	if err != nil {
+		slog.Error("Failed to create test directory", "error", err)
+		os.Exit(1)
+	}
+	config.DatabaseType = config.DatabaseTypeSQLite
+	config.DatabaseUrl = filepath.Join(dir, "data.db")
+	if err := model.Open(slog.Default()); err != nil {
+		slog.Error("Failed to open test database", "error", err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+func testRecord() model.AuditLogRecord {
+	orgID := uint(3)
+	return model.AuditLogRecord{
+		ID:             42,
+		CreatedAt:      time.Date(2025, 1, 2, 3, 4, 5, 6000000, time.UTC),
+		UserID:         7,
+		Action:         string(model.ActionReadVault),
+		Source:         string(model.SourceCLI),
+		VaultUniqueID:  "vault-1",
+		OrganizationID: &orgID,
+		IPAddress:      "203.0.113.9",
+		UserAgent:      `agent "quoted"]`,
+	}
+}
+
+func TestNewSink(t *testing.T) {
+	tests := []struct {
+		url       string
+		wantError bool
+	}{
+		{url: "syslog+tcp://localhost:6514"},
+		{url: "syslog+udp://localhost:514"},
+		{url: "https://collector.example.com/ingest"},
+		{url: "ftp://example.com", wantError: true},
+		{url: "syslog+tcp://", wantError: true},
+		{url: "://bad", wantError: true},
+	}
+	for _, tt := range tests {
+		_, err := NewSink(tt.url, "")
+		if (err != nil) != tt.wantError {
+			t.Errorf("NewSink(%q) error = %v, wantError %v", tt.url, err, tt.wantError)
+		}
+	}
+}
+
+func TestSyslogFormat(t *testing.T) {
+	sink := newSyslogSink("udp", "localhost:514")
+	sink.hostname, sink.procID = "host", "123"
+
+	message, err := sink.format(testRecord())
+	if err != nil {
+		t.Fatalf("format() error = %v", err)
+	}
+
+	wantPrefix := `<85>1 2025-01-02T03:04:05.006Z host vault-hub 123 read_vault [audit@32473 id="42" userId="7" source="cli" vaultUniqueId="vault-1" organizationId="3" ipAddress="203.0.113.9"] {`
+	if !strings.HasPrefix(message, wantPrefix) {
+		t.Fatalf("format() = %q, want prefix %q", message, wantPrefix)
+	}
+
+	var body model.AuditLogRecord
+	if err := json.Unmarshal([]byte(message[len(wantPrefix)-1:]), &body); err != nil {
+		t.Fatalf("message body is not JSON: %v", err)
+	}
+	if body.UserAgent != testRecord().UserAgent {
+		t.Errorf("body userAgent = %q, want %q", body.UserAgent, testRecord().UserAgent)
+	}
+
+	if got := sdParam("ua", `a"b\c]`); got != `ua="a\"b\\c\]"` {
+		t.Errorf("sdParam() = %q", got)
+	}
+	if got := headerField("", 32); got != "-" {
+		t.Errorf("headerField(\"\") = %q, want -", got)
+	}
+}
+
+func TestSyslogTCPOctetCounting(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer listener.Close()
+
+	received := make(chan []string, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		var messages []string
+		reader := bufio.NewReader(conn)
+		for len(messages) < 2 {
+			prefix, err := reader.ReadString(' ')
+			if err != nil {
+				break
+			}
+			length, _ := strconv.Atoi(strings.TrimSpace(prefix))
+			buf := make([]byte, length)
+			if _, err := io.ReadFull(reader, buf); err != nil {
+				break
+			}
+			messages = append(messages, string(buf))
+		}
+		received <- messages
+	}()
+
+	sink := newSyslogSink("tcp", listener.Addr().String())
+	defer sink.Close()
+
+	second := testRecord()
+	second.ID = 43
+	sent, err := sink.Send(context.Background(), []model.AuditLogRecord{testRecord(), second})
+	if err != nil || sent != 2 {
+		t.Fatalf("Send() = %d, %v; want 2, nil", sent, err)
+	}
+
+	select {
+	case messages := <-received:
+		if len(messages) != 2 {
+			t.Fatalf("received %d messages, want 2", len(messages))
+		}
+		if !strings.Contains(messages[1], `id="43"`) {
+			t.Errorf("second message = %q, want id 43", messages[1])
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for syslog messages")
+	}
+}
+
+func TestSyslogUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer conn.Close()
+
+	sink := newSyslogSink("udp", conn.LocalAddr().String())
+	defer sink.Close()
+	if sent, err := sink.Send(context.Background(), []model.AuditLogRecord{testRecord()}); err != nil || sent != 1 {
+		t.Fatalf("Send() = %d, %v; want 1, nil", sent, err)
+	}
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	buf := make([]byte, 4096)
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatalf("read datagram: %v", err)
+	}
+	if !strings.HasPrefix(string(buf[:n]), "<85>1 ") {
+		t.Errorf("datagram = %q, want an RFC 5424 message without framing", buf[:n])
+	}
+}
+
+// collector is an HTTP collector that fails until told otherwise
+type collector struct {
+	mu       sync.Mutex
+	failing  bool
+	auth     []string
+	received []model.AuditLogRecord
+}
+
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.auth = append(c.auth, r.Header.Get("Authorization"))
+	if c.failing {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var records []model.AuditLogRecord
+	if err := json.NewDecoder(r.Body).Decode(&records); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	c.received = append(c.received, records...)
+}
+
+func TestForwarderRetriesUntilDelivered(t *testing.T) {
+	sink := &collector{failing: true}
+	server := httptest.NewServer(sink)
+	defer server.Close()
+
+	forwarder, err := New(server.URL+"/"+uuid.NewString(), "collector-token", slog.Default())
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	// A new destination starts at the end of the log
+	if sent, err := forwarder.forward(context.Background()); err != nil || sent != 0 {
+		t.Fatalf("forward() on empty queue = %d, %v; want 0, nil", sent, err)
+	}
+
+	userParams := model.CreateUserParams{Email: uuid.NewString() + "@example.com", Name: "test"}
+	user, err := userParams.Create()
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	for _, action := range []model.ActionType{model.ActionLoginUser, model.ActionLogoutUser} {
+		if err := model.CreateAuditLog(model.CreateAuditLogParams{Action: action, UserID: user.ID, Source: model.SourceWeb}); err != nil {
+			t.Fatalf("create audit log: %v", err)
+		}
+	}
+
+	if _, err := forwarder.forward(context.Background()); err == nil {
+		t.Fatal("forward() should fail while the collector is down")
+	}
+
+	// The entries stay queued and are delivered in order once the collector recovers
+	sink.mu.Lock()
+	sink.failing = false
+	sink.mu.Unlock()
+	sent, err := forwarder.forward(context.Background())
+	if err != nil || sent != 2 {
+		t.Fatalf("forward() = %d, %v; want 2, nil", sent, err)
+	}
+	if sent, err := forwarder.forward(context.Background()); err != nil || sent != 0 {
+		t.Fatalf("forward() after delivery = %d, %v; want 0, nil", sent, err)
+	}
+
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+	if len(sink.received) != 2 {
+		t.Fatalf("collector received %d records, want 2", len(sink.received))
+	}
+	if sink.received[0].Action != string(model.ActionLoginUser) || sink.received[1].Action != string(model.ActionLogoutUser) {
+		t.Errorf("records out of order: %s, %s", sink.received[0].Action, sink.received[1].Action)
+	}
+	if sink.received[0].UserEmail != user.Email || sink.received[0].Hash == nil {
+		t.Errorf("record missing user email or hash: %+v", sink.received[0])
+	}
+	for _, auth := range sink.auth {
+		if auth != "Bearer collector-token" {
+			t.Errorf("Authorization = %q, want bearer token", auth)
+		}
+	}
+}
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{failures: 1, want: pollInterval},
+		{failures: 2, want: 2 * pollInterval},
+		{failures: 4, want: 8 * pollInterval},
+		{failures: 100, want: maxBackoff},
+	}
+	for _, tt := range tests {
+		if got := backoffDelay(tt.failures); got != tt.want {
+			t.Errorf("backoffDelay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,67 @@
+package auditforward
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/lwshen/vault-hub/model"
+)
+
+// httpTimeout bounds a single delivery to a collector
+const httpTimeout = 30 * time.Second
+
+// httpSink posts each batch of records to a collector as a JSON array. The batch is
+// accepted or rejected as a whole.
+type httpSink struct {
+	url    string
+	token  string
+	client *http.Client
+}
+
+func newHTTPSink(url, token string) *httpSink {
+	return &httpSink{
+		url:    url,
+		token:  token,
+		client: &http.Client{Timeout: httpTimeout},
+	}
+}
+
+func (s *httpSink) Send(ctx context.Context, records []model.AuditLogRecord) (int, error) {
+	body, err := json.Marshal(records)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vault-hub-audit-forwarder")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return 0, fmt.Errorf("collector responded with %s", resp.Status)
+	}
+	return len(records), nil
+}
+
+func (s *httpSink) Close() error {
+	s.client.CloseIdleConnections()
+	return nil
+}
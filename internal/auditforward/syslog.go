@@ -0,0 +1,157 @@
+package auditforward
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lwshen/vault-hub/model"
+)
+
+const (
+	// syslogPriority is facility authpriv (10) with severity notice (5)
+	syslogPriority = 10*8 + 5
+	syslogAppName  = "vault-hub"
+	// syslogSDID identifies the structured data element; 32473 is the enterprise
+	// number reserved for documentation
+	syslogSDID = "audit@32473"
+
+	syslogDialTimeout  = 10 * time.Second
+	syslogWriteTimeout = 10 * time.Second
+)
+
+// syslogSink sends one RFC 5424 message per record. Over TCP messages are framed with
+// octet counting (RFC 6587); over UDP each message is one datagram.
+type syslogSink struct {
+	network  string
+	address  string
+	hostname string
+	procID   string
+	conn     net.Conn
+}
+
+func newSyslogSink(network, address string) *syslogSink {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "-"
+	}
+	return &syslogSink{
+		network:  network,
+		address:  address,
+		hostname: hostname,
+		procID:   strconv.Itoa(os.Getpid()),
+	}
+}
+
+func (s *syslogSink) Send(ctx context.Context, records []model.AuditLogRecord) (int, error) {
+	for i, record := range records {
+		message, err := s.format(record)
+		if err != nil {
+			return i, err
+		}
+		if err := s.write(ctx, message); err != nil {
+			return i, err
+		}
+	}
+	return len(records), nil
+}
+
+// write sends a message, connecting first if needed. The connection is dropped on failure
+// so the next attempt reconnects.
+func (s *syslogSink) write(ctx context.Context, message string) error {
+	if s.conn == nil {
+		dialer := net.Dialer{Timeout: syslogDialTimeout}
+		conn, err := dialer.DialContext(ctx, s.network, s.address)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+
+	if s.network == "tcp" {
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(syslogWriteTimeout)); err != nil {
+		_ = s.Close()
+		return err
+	}
+	if _, err := s.conn.Write([]byte(message)); err != nil {
+		_ = s.Close()
+		return err
+	}
+	return nil
+}
+
+// format renders a record as an RFC 5424 message. The identifying fields are repeated as
+// structured data so they can be filtered on without parsing the JSON message.
+func (s *syslogSink) format(record model.AuditLogRecord) (string, error) {
+	body, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+
+	params := []string{
+		sdParam("id", strconv.FormatUint(uint64(record.ID), 10)),
+		sdParam("userId", strconv.FormatUint(uint64(record.UserID), 10)),
+		sdParam("source", record.Source),
+	}
+	if record.VaultUniqueID != "" {
+		params = append(params, sdParam("vaultUniqueId", record.VaultUniqueID))
+	}
+	if record.OrganizationID != nil {
+		params = append(params, sdParam("organizationId", strconv.FormatUint(uint64(*record.OrganizationID), 10)))
+	}
+	if record.IPAddress != "" {
+		params = append(params, sdParam("ipAddress", record.IPAddress))
+	}
+
+	return fmt.Sprintf("<%d>1 %s %s %s %s %s [%s %s] %s",
+		syslogPriority,
+		record.CreatedAt.UTC().Format(time.RFC3339Nano),
+		headerField(s.hostname, 255),
+		syslogAppName,
+		headerField(s.procID, 128),
+		headerField(record.Action, 32),
+		syslogSDID,
+		strings.Join(params, " "),
+		body,
+	), nil
+}
+
+func (s *syslogSink) Close() error {
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
+
+// sdParam renders a structured data parameter, escaping the characters RFC 5424 reserves
+func sdParam(name, value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+	return name + `="` + value + `"`
+}
+
+// headerField limits a header field to printable ASCII without spaces and to the maximum
+// length allowed by RFC 5424, using the nil value "-" when nothing is left
+func headerField(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 {
+			return -1
+		}
+		return r
+	}, value)
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -4,17 +4,29 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/lwshen/vault-hub/internal/config"
 )
 
-const expirationHour = 24
+// AccessTokenTTL is the lifetime of an access token; clients renew it with their refresh token
+const AccessTokenTTL = 15 * time.Minute
 
-func GenerateToken(userId uint) (string, error) {
+// GenerateToken mints an access token for a session. The session ID is carried in the "sid"
+// claim so the token stops working as soon as the session is revoked.
+func GenerateToken(userId uint, sessionID string) (string, time.Time, error) {
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL)
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		"sub": userId,
-		"exp": time.Now().Add(time.Hour * expirationHour).Unix(),
-		"iat": time.Now().Unix(),
-		"nbf": time.Now().Unix(),
+		"sid": sessionID,
+		"jti": uuid.NewString(),
+		"exp": expiresAt.Unix(),
+		"iat": now.Unix(),
+		"nbf": now.Unix(),
 	})
-	return token.SignedString([]byte(config.JwtSecret))
+	signed, err := token.SignedString([]byte(config.JwtSecret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	return signed, expiresAt, nil
 }
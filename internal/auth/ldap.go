@@ -0,0 +1,269 @@
+package auth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/lwshen/vault-hub/internal/config"
+)
+
+// ErrLDAPInvalidCredentials is returned when the user is not found in the directory, does not
+// match the group filter, or the password is wrong
+var ErrLDAPInvalidCredentials = errors.New("invalid LDAP credentials")
+
+// ldapTimeout bounds dialing and each request to the directory
+const ldapTimeout = 10 * time.Second
+
+// LDAPConfig configures an LDAPAuthenticator
+type LDAPConfig struct {
+	URL       string
+	StartTLS  bool
+	TLSConfig *tls.Config
+	// BindDN and BindPassword are the service account used to search for users. Both are
+	// empty for an anonymous search.
+	BindDN       string
+	BindPassword string
+	BaseDN       string
+	// UserFilter finds the user, with {username} replaced by the escaped login name.
+	// GroupFilter is an optional filter the user must match as well, such as a memberOf check.
+	UserFilter     string
+	GroupFilter    string
+	EmailAttribute string
+	NameAttribute  string
+	PoolSize       int
+}
+
+// LDAPIdentity is a user the directory authenticated
+type LDAPIdentity struct {
+	DN    string
+	Email string
+	Name  string
+}
+
+// LDAPAuthenticator verifies passwords against an LDAP directory or Active Directory. It finds
+// the user with a search bound as the service account, then binds as the user.
+type LDAPAuthenticator struct {
+	cfg  LDAPConfig
+	pool chan *ldap.Conn
+}
+
+var ldapAuthenticator *LDAPAuthenticator
+
+func init() {
+	slog.Info("LDAP", "enabled", config.LdapEnabled)
+	if config.LdapEnabled {
+		tlsConfig, err := ldapTLSConfig(config.LdapUrl, config.LdapCACertFile)
+		if err == nil {
+			err = SetupLDAP(LDAPConfig{
+				URL:            config.LdapUrl,
+				StartTLS:       config.LdapStartTLS,
+				TLSConfig:      tlsConfig,
+				BindDN:         config.LdapBindDn,
+				BindPassword:   config.LdapBindPassword,
+				BaseDN:         config.LdapBaseDn,
+				UserFilter:     config.LdapUserFilter,
+				GroupFilter:    config.LdapGroupFilter,
+				EmailAttribute: config.LdapEmailAttribute,
+				NameAttribute:  config.LdapNameAttribute,
+				PoolSize:       config.LdapPoolSize,
+			})
+		}
+		if err != nil {
+			slog.Error("Failed to setup LDAP", "error", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// ldapTLSConfig verifies the directory's certificate against the system roots, or only against
+// the CA in caCertFile when it is set
+func ldapTLSConfig(rawUrl, caCertFile string) (*tls.Config, error) {
+	u, err := url.Parse(rawUrl)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12}
+	if caCertFile != "" {
+		pem, err := os.ReadFile(caCertFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates in %s", caCertFile)
+		}
+	}
+	return tlsConfig, nil
+}
+
+// SetupLDAP configures the directory used for password logins and checks that the service
+// account can bind
+func SetupLDAP(cfg LDAPConfig) error {
+	a := NewLDAPAuthenticator(cfg)
+	conn, err := a.get()
+	if err != nil {
+		return err
+	}
+	a.put(conn)
+	ldapAuthenticator = a
+	return nil
+}
+
+// LDAP returns the configured directory, or nil when LDAP is disabled
+func LDAP() *LDAPAuthenticator {
+	return ldapAuthenticator
+}
+
+// NewLDAPAuthenticator returns an authenticator that keeps up to cfg.PoolSize idle connections
+func NewLDAPAuthenticator(cfg LDAPConfig) *LDAPAuthenticator {
+	return &LDAPAuthenticator{cfg: cfg, pool: make(chan *ldap.Conn, max(cfg.PoolSize, 1))}
+}
+
+// Authenticate finds the user named username and verifies the password with a bind as the user
+func (a *LDAPAuthenticator) Authenticate(username, password string) (*LDAPIdentity, error) {
+	// An empty password would be an unauthenticated bind, which many directories accept
+	if username == "" || password == "" {
+		return nil, ErrLDAPInvalidCredentials
+	}
+
+	conn, entry, err := a.findUser(username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := conn.Bind(entry.DN, password); err != nil {
+		if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+			// The connection is still usable once it is bound as the service account again
+			if err := a.bindServiceAccount(conn); err != nil {
+				conn.Close()
+			} else {
+				a.put(conn)
+			}
+			return nil, ErrLDAPInvalidCredentials
+		}
+		conn.Close()
+		return nil, err
+	}
+	if err := a.bindServiceAccount(conn); err != nil {
+		conn.Close()
+	} else {
+		a.put(conn)
+	}
+
+	identity := &LDAPIdentity{
+		DN:    entry.DN,
+		Email: entry.GetAttributeValue(a.cfg.EmailAttribute),
+		Name:  entry.GetAttributeValue(a.cfg.NameAttribute),
+	}
+	if identity.Email == "" {
+		identity.Email = username
+	}
+	return identity, nil
+}
+
+// findUser searches for the user on a pooled connection, retrying once on a fresh connection
+// when the pooled one was closed by the directory. The connection is returned for the user bind.
+func (a *LDAPAuthenticator) findUser(username string) (*ldap.Conn, *ldap.Entry, error) {
+	filter := strings.ReplaceAll(a.cfg.UserFilter, "{username}", ldap.EscapeFilter(username))
+	if a.cfg.GroupFilter != "" {
+		filter = "(&" + filter + a.cfg.GroupFilter + ")"
+	}
+	request := ldap.NewSearchRequest(
+		a.cfg.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases,
+		2, int(ldapTimeout.Seconds()), false,
+		filter, []string{a.cfg.EmailAttribute, a.cfg.NameAttribute}, nil,
+	)
+
+	for attempt := 0; ; attempt++ {
+		conn, err := a.get()
+		if err != nil {
+			return nil, nil, err
+		}
+		result, err := conn.Search(request)
+		if err != nil {
+			conn.Close()
+			if attempt == 0 && ldap.IsErrorWithCode(err, ldap.ErrorNetwork) {
+				continue
+			}
+			return nil, nil, err
+		}
+		switch len(result.Entries) {
+		case 1:
+			return conn, result.Entries[0], nil
+		case 0:
+			a.put(conn)
+			return nil, nil, ErrLDAPInvalidCredentials
+		default:
+			a.put(conn)
+			return nil, nil, fmt.Errorf("LDAP user filter matches several entries for %s", username)
+		}
+	}
+}
+
+// get returns an idle connection from the pool, or dials a new one bound as the service account
+func (a *LDAPAuthenticator) get() (*ldap.Conn, error) {
+	select {
+	case conn := <-a.pool:
+		if !conn.IsClosing() {
+			return conn, nil
+		}
+	default:
+	}
+
+	conn, err := ldap.DialURL(a.cfg.URL,
+		ldap.DialWithTLSConfig(a.cfg.TLSConfig),
+		ldap.DialWithDialer(&net.Dialer{Timeout: ldapTimeout}))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetTimeout(ldapTimeout)
+	if a.cfg.StartTLS {
+		if err := conn.StartTLS(a.cfg.TLSConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+	if err := a.bindServiceAccount(conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// put returns a connection bound as the service account to the pool, closing it when the
+// pool is full
+func (a *LDAPAuthenticator) put(conn *ldap.Conn) {
+	select {
+	case a.pool <- conn:
+	default:
+		conn.Close()
+	}
+}
+
+func (a *LDAPAuthenticator) bindServiceAccount(conn *ldap.Conn) error {
+	if a.cfg.BindDN == "" {
+		return conn.UnauthenticatedBind("")
+	}
+	return conn.Bind(a.cfg.BindDN, a.cfg.BindPassword)
+}
+
+// Close closes the idle connections
+func (a *LDAPAuthenticator) Close() {
+	for {
+		select {
+		case conn := <-a.pool:
+			conn.Close()
+		default:
+			return
+		}
+	}
+}
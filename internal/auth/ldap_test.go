@@ -0,0 +1,353 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	"github.com/go-ldap/ldap/v3"
+	"github.com/jimlambrt/gldap"
+)
+
+const (
+	testServiceDN       = "cn=service,dc=example,dc=org"
+	testServicePassword = "service-secret"
+	testGroupDN         = "cn=vault-users,ou=groups,dc=example,dc=org"
+)
+
+// testDirectory is an in-process LDAP server. Searches must be bound as the service account,
+// and with requireTLS binds are rejected on connections that did not start TLS.
+type testDirectory struct {
+	server     *gldap.Server
+	serverTLS  *tls.Config
+	requireTLS bool
+	// tlsConfig trusts the directory's certificate
+	tlsConfig *tls.Config
+
+	mu          sync.Mutex
+	entries     []*gldap.Entry
+	passwords   map[string]string
+	bound       map[int]string
+	tlsConns    map[int]bool
+	connections map[int]bool
+}
+
+// startTestDirectory starts a directory with alice in the vault-users group and bob outside
+// it. ldaps serves TLS on the port; otherwise clients may upgrade with StartTLS.
+func startTestDirectory(t *testing.T, ldaps, requireTLS bool) (*testDirectory, string) {
+	t.Helper()
+
+	d := &testDirectory{
+		requireTLS: requireTLS,
+		passwords: map[string]string{
+			testServiceDN:                           testServicePassword,
+			"uid=alice,ou=people,dc=example,dc=org": "alice-secret",
+			"uid=bob,ou=people,dc=example,dc=org":   "bob-secret",
+		},
+		entries: []*gldap.Entry{
+			gldap.NewEntry("uid=alice,ou=people,dc=example,dc=org", map[string][]string{
+				"objectClass": {"person"},
+				"mail":        {"alice@example.org"},
+				"cn":          {"Alice Liddell"},
+				"memberOf":    {testGroupDN},
+			}),
+			gldap.NewEntry("uid=bob,ou=people,dc=example,dc=org", map[string][]string{
+				"objectClass": {"person"},
+				"mail":        {"bob@example.org"},
+				"cn":          {"Bob"},
+			}),
+		},
+		bound:       map[int]string{},
+		tlsConns:    map[int]bool{},
+		connections: map[int]bool{},
+	}
+	d.serverTLS, d.tlsConfig = testTLSConfigs(t)
+
+	var err error
+	d.server, err = gldap.NewServer()
+	if err != nil {
+		t.Fatalf("gldap.NewServer() error = %v", err)
+	}
+	mux, _ := gldap.NewMux()
+	mux.Bind(d.bind)
+	mux.Search(d.search)
+	mux.ExtendedOperation(d.startTLS, gldap.ExtendedOperationStartTLS)
+	d.server.Router(mux)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to find a free port: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	var opts []gldap.Option
+	scheme := "ldap"
+	if ldaps {
+		opts = append(opts, gldap.WithTLSConfig(d.serverTLS))
+		scheme = "ldaps"
+	}
+	go d.server.Run(addr, opts...)
+	t.Cleanup(func() { d.server.Stop() })
+	for start := time.Now(); !d.server.Ready(); time.Sleep(10 * time.Millisecond) {
+		if time.Since(start) > 5*time.Second {
+			t.Fatal("LDAP server did not start")
+		}
+	}
+
+	return d, scheme + "://" + addr
+}
+
+func (d *testDirectory) bind(w *gldap.ResponseWriter, r *gldap.Request) {
+	resp := r.NewBindResponse(gldap.WithResponseCode(gldap.ResultInvalidCredentials))
+	defer w.Write(resp)
+
+	m, err := r.GetSimpleBindMessage()
+	if err != nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.connections[r.ConnectionID()] = true
+	delete(d.bound, r.ConnectionID())
+	if d.requireTLS && !d.tlsConns[r.ConnectionID()] {
+		resp.SetResultCode(gldap.ResultConfidentialityRequired)
+		return
+	}
+	if password, ok := d.passwords[m.UserName]; ok && password != "" && password == string(m.Password) {
+		d.bound[r.ConnectionID()] = m.UserName
+		resp.SetResultCode(gldap.ResultSuccess)
+	}
+}
+
+func (d *testDirectory) search(w *gldap.ResponseWriter, r *gldap.Request) {
+	resp := r.NewSearchDoneResponse(gldap.WithResponseCode(gldap.ResultSuccess))
+	defer w.Write(resp)
+
+	d.mu.Lock()
+	bound := d.bound[r.ConnectionID()]
+	d.mu.Unlock()
+	if bound != testServiceDN {
+		resp.SetResultCode(gldap.ResultInsufficientAccessRights)
+		return
+	}
+
+	m, err := r.GetSearchMessage()
+	if err != nil {
+		resp.SetResultCode(gldap.ResultProtocolError)
+		return
+	}
+	filter, err := ldap.CompileFilter(m.Filter)
+	if err != nil {
+		resp.SetResultCode(gldap.ResultProtocolError)
+		return
+	}
+	for _, entry := range d.entries {
+		if matchFilter(filter, entry) {
+			result := r.NewSearchResponseEntry(entry.DN)
+			for _, attr := range entry.Attributes {
+				result.AddAttribute(attr.Name, attr.Values)
+			}
+			w.Write(result)
+		}
+	}
+}
+
+func (d *testDirectory) startTLS(w *gldap.ResponseWriter, r *gldap.Request) {
+	resp := r.NewExtendedResponse(gldap.WithResponseCode(gldap.ResultSuccess))
+	resp.SetResponseName(gldap.ExtendedOperationStartTLS)
+	w.Write(resp)
+	if err := r.StartTLS(d.serverTLS); err == nil {
+		d.mu.Lock()
+		d.tlsConns[r.ConnectionID()] = true
+		d.mu.Unlock()
+	}
+}
+
+// connectionCount returns the number of connections that sent a bind
+func (d *testDirectory) connectionCount() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.connections)
+}
+
+// matchFilter evaluates the and, or, equality and presence filters the authenticator sends
+func matchFilter(filter *ber.Packet, entry *gldap.Entry) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !matchFilter(child, entry) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if matchFilter(child, entry) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterEqualityMatch:
+		attribute := filter.Children[0].Value.(string)
+		value := filter.Children[1].Value.(string)
+		for _, v := range entry.GetAttributeValues(attribute) {
+			if strings.EqualFold(v, value) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(entry.GetAttributeValues(filter.Data.String())) > 0
+	default:
+		return false
+	}
+}
+
+// testTLSConfigs returns a server certificate for 127.0.0.1 and a client trusting it
+func testTLSConfigs(t *testing.T) (*tls.Config, *tls.Config) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test directory"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IPAddresses:           []net.IP{net.ParseIP("127.0.0.1")},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("Failed to create certificate: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+
+	server := &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}}
+	client := &tls.Config{RootCAs: roots, ServerName: "127.0.0.1"}
+	return server, client
+}
+
+func testLDAPConfig(url string, tlsConfig *tls.Config) LDAPConfig {
+	return LDAPConfig{
+		URL:            url,
+		TLSConfig:      tlsConfig,
+		BindDN:         testServiceDN,
+		BindPassword:   testServicePassword,
+		BaseDN:         "dc=example,dc=org",
+		UserFilter:     "(&(objectClass=person)(mail={username}))",
+		GroupFilter:    "(memberOf=" + testGroupDN + ")",
+		EmailAttribute: "mail",
+		NameAttribute:  "cn",
+		PoolSize:       1,
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	directory, url := startTestDirectory(t, false, false)
+	a := NewLDAPAuthenticator(testLDAPConfig(url, directory.tlsConfig))
+	defer a.Close()
+
+	identity, err := a.Authenticate("alice@example.org", "alice-secret")
+	if err != nil {
+		t.Fatalf("Authenticate() error = %v", err)
+	}
+	if identity.DN != "uid=alice,ou=people,dc=example,dc=org" || identity.Email != "alice@example.org" || identity.Name != "Alice Liddell" {
+		t.Errorf("Authenticate() = %+v", identity)
+	}
+
+	tests := []struct {
+		name     string
+		username string
+		password string
+	}{
+		{"wrong password", "alice@example.org", "wrong"},
+		{"empty password", "alice@example.org", ""},
+		{"not in group", "bob@example.org", "bob-secret"},
+		{"unknown user", "carol@example.org", "carol-secret"},
+		{"filter injection", "*", "alice-secret"},
+		{"filter injection with a closing paren", "alice@example.org)(mail=*", "alice-secret"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := a.Authenticate(tt.username, tt.password); !errors.Is(err, ErrLDAPInvalidCredentials) {
+				t.Errorf("Authenticate() error = %v, want ErrLDAPInvalidCredentials", err)
+			}
+		})
+	}
+
+	// A failed user bind leaves the pooled connection usable, so every login shares one
+	if _, err := a.Authenticate("alice@example.org", "alice-secret"); err != nil {
+		t.Fatalf("Authenticate() after failures error = %v", err)
+	}
+	if count := directory.connectionCount(); count != 1 {
+		t.Errorf("directory saw %d connections, want 1 pooled connection", count)
+	}
+}
+
+func TestLDAPAuthenticateTLS(t *testing.T) {
+	t.Run("StartTLS", func(t *testing.T) {
+		directory, url := startTestDirectory(t, false, true)
+
+		// The directory rejects binds before TLS is started
+		plain := NewLDAPAuthenticator(testLDAPConfig(url, directory.tlsConfig))
+		if _, err := plain.Authenticate("alice@example.org", "alice-secret"); err == nil || errors.Is(err, ErrLDAPInvalidCredentials) {
+			t.Fatalf("Authenticate() without StartTLS error = %v, want a bind error", err)
+		}
+
+		cfg := testLDAPConfig(url, directory.tlsConfig)
+		cfg.StartTLS = true
+		a := NewLDAPAuthenticator(cfg)
+		defer a.Close()
+		if _, err := a.Authenticate("alice@example.org", "alice-secret"); err != nil {
+			t.Fatalf("Authenticate() with StartTLS error = %v", err)
+		}
+	})
+
+	t.Run("LDAPS", func(t *testing.T) {
+		directory, url := startTestDirectory(t, true, false)
+		a := NewLDAPAuthenticator(testLDAPConfig(url, directory.tlsConfig))
+		defer a.Close()
+		if _, err := a.Authenticate("alice@example.org", "alice-secret"); err != nil {
+			t.Fatalf("Authenticate() over LDAPS error = %v", err)
+		}
+
+		// The directory's certificate must be trusted
+		untrusted := NewLDAPAuthenticator(testLDAPConfig(url, &tls.Config{ServerName: "127.0.0.1"}))
+		if _, err := untrusted.Authenticate("alice@example.org", "alice-secret"); err == nil {
+			t.Fatal("Authenticate() with an untrusted certificate succeeded")
+		}
+	})
+}
+
+func TestLDAPTLSConfig(t *testing.T) {
+	cfg, err := ldapTLSConfig("ldaps://dc1.corp.example.com:636", "")
+	if err != nil {
+		t.Fatalf("ldapTLSConfig() error = %v", err)
+	}
+	if cfg.ServerName != "dc1.corp.example.com" || cfg.RootCAs != nil {
+		t.Errorf("ldapTLSConfig() = %+v, want system roots for the URL's host", cfg)
+	}
+	if _, err := ldapTLSConfig("ldaps://dc1.corp.example.com", "/nonexistent/ca.pem"); err == nil {
+		t.Error("ldapTLSConfig() with a missing CA file succeeded")
+	}
+}
@@ -3,8 +3,10 @@ package auth
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
@@ -16,15 +18,36 @@ import (
 )
 
 var (
-	provider     *oidc.Provider
-	verifier     *oidc.IDTokenVerifier
-	oauthConfig  *oauth2.Config
+	// ErrUnknownOIDCProvider is returned for a provider name that is not configured
+	ErrUnknownOIDCProvider = errors.New("unknown OIDC provider")
+	// ErrOIDCEmailNotAllowed is returned when the provider's email is unverified or outside the
+	// allowed domains
+	ErrOIDCEmailNotAllowed = errors.New("email address is not allowed to log in")
+)
+
+// OIDCProvider is a configured OpenID Connect identity provider
+type OIDCProvider struct {
+	config.OidcProvider
+	provider    *oidc.Provider
+	verifier    *oidc.IDTokenVerifier
+	oauthConfig oauth2.Config
+}
+
+// OIDCIdentity is the user an identity provider authenticated
+type OIDCIdentity struct {
+	Email  string
+	Name   string
+	Groups []string
+}
+
+var (
+	providers    []*OIDCProvider
 	sessionStore *session.Store
 )
 
 func init() {
 	enabled := config.OidcEnabled
-	slog.Info("OIDC", "enabled", enabled)
+	slog.Info("OIDC", "enabled", enabled, "providers", len(config.OidcProviders))
 	if enabled {
 		err := SetupOIDC()
 		if err != nil {
@@ -34,6 +57,7 @@ func init() {
 	}
 }
 
+// SetupOIDC discovers the configured identity providers
 func SetupOIDC() error {
 	sessionStore = session.New(session.Config{
 		KeyLookup:  "cookie:auth_session",
@@ -41,90 +65,231 @@ func SetupOIDC() error {
 	})
 
 	ctx := context.Background()
-	oidcProvider, err := oidc.NewProvider(ctx, config.OidcIssuer)
-	if err != nil {
-		return err
-	}
-	provider = oidcProvider
-	oauthConfig = &oauth2.Config{
-		ClientID:     config.OidcClientId,
-		ClientSecret: config.OidcClientSecret,
-		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
-		Endpoint:     oidcProvider.Endpoint(),
+	providers = nil
+	for _, cfg := range config.OidcProviders {
+		oidcProvider, err := oidc.NewProvider(ctx, cfg.Issuer)
+		if err != nil {
+			return fmt.Errorf("provider %s: %w", cfg.Name, err)
+		}
+		providers = append(providers, &OIDCProvider{
+			OidcProvider: cfg,
+			provider:     oidcProvider,
+			verifier:     oidcProvider.Verifier(&oidc.Config{ClientID: cfg.ClientId}),
+			oauthConfig: oauth2.Config{
+				ClientID:     cfg.ClientId,
+				ClientSecret: cfg.ClientSecret,
+				Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+				Endpoint:     oidcProvider.Endpoint(),
+			},
+		})
 	}
-	verifier = oidcProvider.Verifier(&oidc.Config{ClientID: oauthConfig.ClientID})
 	return nil
 }
 
-func AuthCodeURL(ctx *fiber.Ctx, baseUrl string) (string, error) {
+// OIDCProviders returns the configured providers in configuration order
+func OIDCProviders() []*OIDCProvider {
+	return providers
+}
+
+// GetOIDCProvider returns a provider by name. An empty name selects the default provider, or
+// the first one when only named providers are configured.
+func GetOIDCProvider(name string) (*OIDCProvider, error) {
+	if name == "" {
+		name = config.DefaultOidcProvider
+		if len(providers) > 0 && providers[0].Name != name {
+			return providers[0], nil
+		}
+	}
+	for _, p := range providers {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return nil, ErrUnknownOIDCProvider
+}
+
+// CallbackPath returns the path the provider redirects back to. The default provider keeps
+// the path it had before named providers, so registered redirect URIs stay valid.
+func (p *OIDCProvider) CallbackPath() string {
+	if p.Name == config.DefaultOidcProvider {
+		return "/api/auth/callback/oidc"
+	}
+	return "/api/auth/callback/oidc/" + p.Name
+}
+
+// LoginPath returns the path that starts a login with the provider
+func (p *OIDCProvider) LoginPath() string {
+	return "/api/auth/login/oidc/" + p.Name
+}
+
+// oauth returns the OAuth2 configuration for a request, with the redirect URL of its host
+func (p *OIDCProvider) oauth(baseUrl string) *oauth2.Config {
+	cfg := p.oauthConfig
+	cfg.RedirectURL = baseUrl + p.CallbackPath()
+	return &cfg
+}
+
+// AuthCodeURL starts an authorization code flow with PKCE. The state, nonce and code verifier
+// are kept in the session cookie until the callback.
+func (p *OIDCProvider) AuthCodeURL(ctx *fiber.Ctx, baseUrl string) (string, error) {
 	state := generateState()
-	err := storeInSession(ctx, "oauth", state)
+	nonce := generateState()
+	verifier := oauth2.GenerateVerifier()
+	err := storeInSession(ctx, map[string]string{
+		"oauth":          state,
+		"oauth_nonce":    nonce,
+		"oauth_verifier": verifier,
+		"oauth_provider": p.Name,
+	})
 	if err != nil {
 		return "", err
 	}
-	oauthConfig.RedirectURL = baseUrl + "/api/auth/callback/oidc"
-	return oauthConfig.AuthCodeURL(state), nil
+	return p.oauth(baseUrl).AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), nil
 }
 
-func Verify(ctx context.Context, code string) (*oauth2.Token, error) {
-	token, err := oauthConfig.Exchange(ctx, code)
+// Exchange completes the flow started by AuthCodeURL: it checks the state, redeems the code
+// with the code verifier, verifies the ID token and returns the authenticated identity
+func (p *OIDCProvider) Exchange(ctx *fiber.Ctx, baseUrl, code, state string) (*OIDCIdentity, error) {
+	values, err := takeFromSession(ctx, "oauth", "oauth_nonce", "oauth_verifier", "oauth_provider")
 	if err != nil {
 		return nil, err
 	}
+	if values["oauth"] != state || values["oauth_provider"] != p.Name {
+		return nil, errors.New("cannot verify state")
+	}
 
-	_, err = verifyToken(ctx, token)
+	oauthConfig := p.oauth(baseUrl)
+	token, err := oauthConfig.Exchange(ctx.Context(), code, oauth2.VerifierOption(values["oauth_verifier"]))
 	if err != nil {
 		return nil, err
 	}
 
-	return token, nil
-}
-
-func verifyToken(ctx context.Context, token *oauth2.Token) (*oidc.IDToken, error) {
 	rawIDToken, ok := token.Extra("id_token").(string)
 	if !ok {
 		return nil, errors.New("missing ID token")
 	}
-	return verifier.Verify(ctx, rawIDToken)
-}
+	idToken, err := p.verifier.Verify(ctx.Context(), rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+	if idToken.Nonce != values["oauth_nonce"] {
+		return nil, errors.New("cannot verify nonce")
+	}
 
-func VerifyState(ctx *fiber.Ctx, state string) error {
-	storedState, err := getFromSession(ctx, "oauth")
+	// Groups are usually in the ID token and the email in both; userinfo claims take precedence
+	claims := map[string]interface{}{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, err
+	}
+	userInfo, err := p.provider.UserInfo(ctx.Context(), oauthConfig.TokenSource(ctx.Context(), token))
 	if err != nil {
-		return err
+		return nil, err
 	}
-	if storedState != state {
-		return errors.New("cannot verify state")
+	var userInfoClaims map[string]interface{}
+	if err := userInfo.Claims(&userInfoClaims); err != nil {
+		return nil, err
 	}
-	return nil
+	for key, value := range userInfoClaims {
+		claims[key] = value
+	}
+
+	return p.identity(claims)
 }
 
-func UserInfo(ctx context.Context, token *oauth2.Token) (*oidc.UserInfo, error) {
-	tokenSource := oauthConfig.TokenSource(ctx, token)
-	return provider.UserInfo(ctx, tokenSource)
+// identity reads the user from claims and applies the email restrictions
+func (p *OIDCProvider) identity(claims map[string]interface{}) (*OIDCIdentity, error) {
+	email, _ := claims["email"].(string)
+	if email == "" {
+		return nil, errors.New("missing email claim")
+	}
+	// Accounts are linked by email, so an unverified address could take over another user
+	if verified, ok := claims["email_verified"].(bool); ok && !verified {
+		return nil, ErrOIDCEmailNotAllowed
+	}
+	if !p.EmailAllowed(email) {
+		return nil, ErrOIDCEmailNotAllowed
+	}
+
+	identity := &OIDCIdentity{Email: email}
+	identity.Name, _ = claims["name"].(string)
+	switch groups := claims[p.GroupsClaim].(type) {
+	case []interface{}:
+		for _, group := range groups {
+			if name, ok := group.(string); ok {
+				identity.Groups = append(identity.Groups, name)
+			}
+		}
+	case string:
+		identity.Groups = []string{groups}
+	}
+	return identity, nil
+}
+
+// EmailAllowed reports whether an email address is in one of the allowed domains. Every
+// address is allowed when no domains are configured.
+func (p *OIDCProvider) EmailAllowed(email string) bool {
+	if len(p.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, allowed := range p.AllowedDomains {
+		if domain == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// MappedRoles returns the role names the provider's group mappings grant for groups, keyed by
+// organization ID. When several groups map to the same organization, all their roles are
+// returned and the caller picks the highest.
+func (p *OIDCProvider) MappedRoles(groups []string) map[uint][]string {
+	member := make(map[string]bool, len(groups))
+	for _, group := range groups {
+		member[group] = true
+	}
+	roles := make(map[uint][]string)
+	for _, mapping := range p.GroupRoles {
+		if member[mapping.Group] {
+			roles[mapping.OrganizationId] = append(roles[mapping.OrganizationId], mapping.Role)
+		}
+	}
+	return roles
 }
 
 func generateState() string {
 	return uuid.New().String()
 }
 
-func storeInSession(ctx *fiber.Ctx, key string, value string) error {
+func storeInSession(ctx *fiber.Ctx, values map[string]string) error {
 	session, err := sessionStore.Get(ctx)
 	if err != nil {
 		return err
 	}
-	session.Set(key, value)
+	for key, value := range values {
+		session.Set(key, value)
+	}
 	return session.Save()
 }
 
-func getFromSession(ctx *fiber.Ctx, key string) (string, error) {
+// takeFromSession returns values from the session and removes them, so a flow cannot be replayed
+func takeFromSession(ctx *fiber.Ctx, keys ...string) (map[string]string, error) {
 	session, err := sessionStore.Get(ctx)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	value, ok := session.Get(key).(string)
-	if !ok {
-		return "", errors.New("session value for key " + key + " is not a string")
+	values := make(map[string]string, len(keys))
+	for _, key := range keys {
+		value, ok := session.Get(key).(string)
+		if !ok {
+			return nil, errors.New("session value for key " + key + " is not a string")
+		}
+		values[key] = value
+		session.Delete(key)
 	}
-	return value, nil
+	return values, session.Save()
 }
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/lwshen/vault-hub/internal/config"
+)
+
+var webAuthn *webauthn.WebAuthn
+
+func init() {
+	slog.Info("WebAuthn", "enabled", config.WebAuthnEnabled)
+	if config.WebAuthnEnabled {
+		if err := SetupWebAuthn(config.WebAuthnRPID, config.WebAuthnRPName, config.WebAuthnRPOrigins); err != nil {
+			slog.Error("Failed to setup WebAuthn", "error", err)
+			os.Exit(1)
+		}
+	}
+}
+
+// SetupWebAuthn configures the relying party used for passkey registration and login.
+// Credentials are created as discoverable, so users can log in without entering an email.
+func SetupWebAuthn(rpID, rpName string, origins []string) error {
+	w, err := webauthn.New(&webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: rpName,
+		RPOrigins:     origins,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			UserVerification:   protocol.VerificationPreferred,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	webAuthn = w
+	return nil
+}
+
+// WebAuthn returns the configured relying party, or nil when WebAuthn is disabled
+func WebAuthn() *webauthn.WebAuthn {
+	return webAuthn
+}
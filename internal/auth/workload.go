@@ -0,0 +1,93 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lwshen/vault-hub/internal/config"
+)
+
+// ErrWorkloadTokenInvalid is returned for tokens that are malformed, expired, badly signed or
+// from an issuer that is not allowed
+var ErrWorkloadTokenInvalid = errors.New("invalid workload identity token")
+
+// WorkloadClaims are the verified claims of a workload identity token
+type WorkloadClaims struct {
+	Issuer   string
+	Subject  string
+	Audience []string
+	Claims   map[string]interface{}
+}
+
+// workloadTimeout bounds requests for an issuer's discovery document and keys
+const workloadTimeout = 10 * time.Second
+
+var (
+	workloadVerifiersMu sync.Mutex
+	workloadVerifiers   = map[string]*oidc.IDTokenVerifier{}
+)
+
+func init() {
+	slog.Info("Workload identity", "enabled", config.WorkloadIdentityEnabled, "issuers", len(config.WorkloadIssuers))
+}
+
+// VerifyWorkloadToken verifies a token from one of the allowed workload issuers against the
+// issuer's published keys. The audience is not checked here; each workload identity binds its own.
+func VerifyWorkloadToken(ctx context.Context, rawToken string) (*WorkloadClaims, error) {
+	// The issuer picks the keys, so it is read before the signature can be checked
+	unverified := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(rawToken, unverified); err != nil {
+		return nil, ErrWorkloadTokenInvalid
+	}
+	issuer, _ := unverified["iss"].(string)
+	if !slices.Contains(config.WorkloadIssuers, issuer) {
+		return nil, ErrWorkloadTokenInvalid
+	}
+
+	verifier, err := workloadVerifier(issuer)
+	if err != nil {
+		return nil, err
+	}
+	token, err := verifier.Verify(ctx, rawToken)
+	if err != nil {
+		slog.Debug("Workload token rejected", "issuer", issuer, "error", err)
+		return nil, ErrWorkloadTokenInvalid
+	}
+
+	claims := WorkloadClaims{
+		Issuer:   token.Issuer,
+		Subject:  token.Subject,
+		Audience: token.Audience,
+	}
+	if err := token.Claims(&claims.Claims); err != nil {
+		return nil, ErrWorkloadTokenInvalid
+	}
+	return &claims, nil
+}
+
+// workloadVerifier discovers an issuer on first use, so an issuer that is down only fails the
+// exchanges that need it. Failed discoveries are retried on the next exchange.
+func workloadVerifier(issuer string) (*oidc.IDTokenVerifier, error) {
+	workloadVerifiersMu.Lock()
+	defer workloadVerifiersMu.Unlock()
+
+	if verifier, ok := workloadVerifiers[issuer]; ok {
+		return verifier, nil
+	}
+	// The provider keeps the context for fetching rotated keys later, so it must outlive the request
+	client := oidc.ClientContext(context.Background(), &http.Client{Timeout: workloadTimeout})
+	provider, err := oidc.NewProvider(client, issuer)
+	if err != nil {
+		return nil, err
+	}
+	verifier := provider.Verifier(&oidc.Config{SkipClientIDCheck: true})
+	workloadVerifiers[issuer] = verifier
+	return verifier, nil
+}
@@ -13,10 +13,12 @@ import (
 )
 
 var (
-	APIKey  string
-	BaseURL string
-	Debug   bool
-	Client  *openapi.APIClient
+	APIKey           string
+	BaseURL          string
+	OIDCTokenFile    string
+	WorkloadIdentity string
+	Debug            bool
+	Client           *openapi.APIClient
 )
 
 // DebugLog prints debug messages to stderr when debug mode is enabled
@@ -115,8 +117,8 @@ func InitializeClient() {
 	DebugLog("Base URL: %s", BaseURL)
 	DebugLog("Debug mode: %v", Debug)
 
-	if APIKey == "" {
-		fmt.Fprintf(os.Stderr, "Error: API key is required. Set via --api-key flag or VAULT_HUB_API_KEY environment variable\n")
+	if APIKey == "" && OIDCTokenFile == "" {
+		fmt.Fprintf(os.Stderr, "Error: API key is required. Set via --api-key flag or VAULT_HUB_API_KEY environment variable, or log in with --oidc-token-file\n")
 		os.Exit(1)
 	}
 	if BaseURL == "" {
@@ -124,6 +126,17 @@ func InitializeClient() {
 		os.Exit(1)
 	}
 
+	// CI jobs exchange their workload's OIDC token for a short-lived API key
+	if APIKey == "" {
+		DebugLog("Exchanging OIDC token from %s", OIDCTokenFile)
+		key, err := exchangeWorkloadToken(BaseURL, OIDCTokenFile, WorkloadIdentity)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		APIKey = key
+	}
+
 	cfg := openapi.NewConfiguration()
 	cfg.Debug = Debug
 	cfg.Servers = openapi.ServerConfigurations{
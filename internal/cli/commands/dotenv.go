@@ -0,0 +1,162 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// envVar is a single variable parsed from a dotenv-formatted value
+type envVar struct {
+	Key   string
+	Value string
+}
+
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// isValidEnvKey reports whether key can be used as an environment variable name
+func isValidEnvKey(key string) bool {
+	return envKeyPattern.MatchString(key)
+}
+
+// parseVaultEnv parses the value of a vault into variables. Values of kv vaults, JSON objects
+// of strings, give one variable per key in key order; any other value is parsed as dotenv.
+func parseVaultEnv(content string) ([]envVar, error) {
+	var data map[string]string
+	if !strings.HasPrefix(strings.TrimSpace(content), "{") || json.Unmarshal([]byte(content), &data) != nil {
+		return parseDotenv(content)
+	}
+
+	vars := make([]envVar, 0, len(data))
+	for _, key := range slices.Sorted(maps.Keys(data)) {
+		if !isValidEnvKey(key) {
+			return nil, fmt.Errorf("invalid variable name %q", key)
+		}
+		vars = append(vars, envVar{Key: key, Value: data[key]})
+	}
+	return vars, nil
+}
+
+// parseDotenv parses a dotenv-formatted value into variables, in order of appearance.
+// It supports comments, blank lines, an optional "export " prefix, single-quoted literal
+// values, double-quoted values with escapes spanning multiple lines, and inline comments
+// after unquoted values. A key that appears twice keeps its last value.
+func parseDotenv(content string) ([]envVar, error) {
+	var vars []envVar
+	index := map[string]int{}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		lineNumber := i + 1
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, rawValue, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNumber)
+		}
+		key = strings.TrimSpace(key)
+		if !isValidEnvKey(key) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNumber, key)
+		}
+		rawValue = strings.TrimLeft(rawValue, " \t")
+
+		var value string
+		switch {
+		case strings.HasPrefix(rawValue, `"`):
+			// Double-quoted values may continue on the following lines
+			quoted := rawValue[1:]
+			for {
+				parsed, rest, closed := parseDoubleQuoted(quoted)
+				if closed {
+					if err := checkTrailing(rest); err != nil {
+						return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+					}
+					value = parsed
+					break
+				}
+				i++
+				if i >= len(lines) {
+					return nil, fmt.Errorf("line %d: unterminated double-quoted value", lineNumber)
+				}
+				quoted += "\n" + lines[i]
+			}
+		case strings.HasPrefix(rawValue, "'"):
+			end := strings.Index(rawValue[1:], "'")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated single-quoted value", lineNumber)
+			}
+			if err := checkTrailing(rawValue[end+2:]); err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			value = rawValue[1 : end+1]
+		default:
+			// An unquoted value ends at a comment preceded by whitespace
+			if idx := strings.Index(rawValue, " #"); idx >= 0 {
+				rawValue = rawValue[:idx]
+			}
+			if idx := strings.Index(rawValue, "\t#"); idx >= 0 {
+				rawValue = rawValue[:idx]
+			}
+			value = strings.TrimSpace(rawValue)
+		}
+
+		if existing, ok := index[key]; ok {
+			vars[existing].Value = value
+			continue
+		}
+		index[key] = len(vars)
+		vars = append(vars, envVar{Key: key, Value: value})
+	}
+
+	return vars, nil
+}
+
+// parseDoubleQuoted reads a double-quoted value up to its closing quote, expanding escapes.
+// It reports whether the closing quote was found and returns the text after it.
+func parseDoubleQuoted(s string) (string, string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; c {
+		case '"':
+			return b.String(), s[i+1:], true
+		case '\\':
+			if i+1 >= len(s) {
+				b.WriteByte(c)
+				continue
+			}
+			i++
+			switch s[i] {
+			case 'n':
+				b.WriteByte('\n')
+			case 'r':
+				b.WriteByte('\r')
+			case 't':
+				b.WriteByte('\t')
+			case '"', '\\', '$':
+				b.WriteByte(s[i])
+			default:
+				b.WriteByte('\\')
+				b.WriteByte(s[i])
+			}
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), "", false
+}
+
+// checkTrailing allows only whitespace or a comment after a quoted value
+func checkTrailing(rest string) error {
+	rest = strings.TrimSpace(rest)
+	if rest != "" && !strings.HasPrefix(rest, "#") {
+		return fmt.Errorf("unexpected characters after quoted value: %q", rest)
+	}
+	return nil
+}
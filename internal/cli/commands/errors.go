@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// apiErrorBody is implemented by errors returned from the API client that carry the response body
+type apiErrorBody interface {
+	error
+	Body() []byte
+}
+
+// describeAPIError adds the message sent by the server, such as the reason for a 403, to an API error
+func describeAPIError(err error) error {
+	var apiErr apiErrorBody
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+
+	var body struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(apiErr.Body(), &body) != nil || body.Error.Message == "" {
+		return err
+	}
+	return fmt.Errorf("%w: %s", err, body.Error.Message)
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"errors"
+	"testing"
+)
+
+type fakeAPIError struct {
+	body string
+}
+
+func (e fakeAPIError) Error() string { return "403 Forbidden" }
+func (e fakeAPIError) Body() []byte  { return []byte(e.body) }
+
+func TestDescribeAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name:     "server message is appended",
+			err:      fakeAPIError{body: `{"error":{"code":403,"message":"API key does not have the vault:write permission"}}`},
+			expected: "403 Forbidden: API key does not have the vault:write permission",
+		},
+		{
+			name:     "body without message",
+			err:      fakeAPIError{body: `not json`},
+			expected: "403 Forbidden",
+		},
+		{
+			name:     "plain error",
+			err:      errors.New("connection refused"),
+			expected: "connection refused",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := describeAPIError(tt.err).Error(); got != tt.expected {
+				t.Errorf("describeAPIError() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"time"
 
 	openapi "github.com/lwshen/vault-hub-go-client"
@@ -17,10 +18,11 @@ import (
 // NewGetCommand creates the get command
 func NewGetCommand(ctx *CommandContext) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "get --name/--id <vault-name-or-id> --output <file> --exec <command>",
+		Use:   "get --name/--id <vault-name-or-id> --key <key> --output <file> --exec <command>",
 		Short: "Get a specific vault by name or unique ID",
 		Long: `Get detailed information about a specific vault including its encrypted value.
 You can specify the vault by either its name or unique ID.
+For kv vaults, --key fetches the value of a single key instead of the whole vault.
 
 Client-side encryption is ENABLED BY DEFAULT for enhanced security.
 The vault value is encrypted with a per-vault key derived from your API key.
@@ -29,6 +31,7 @@ Use --no-client-encryption to disable this feature if needed.
 Examples:
   vault-hub get --name my-api-keys
   vault-hub get --id abc123-def456-ghi789
+  vault-hub get --name app --key DATABASE_URL
   vault-hub get --name my-api-keys --output ./secrets.txt
   vault-hub get --name my-api-keys --output .env --exec "source .env && echo 'Environment loaded'"
   vault-hub get --name my-api-keys --no-client-encryption`,
@@ -39,6 +42,7 @@ Examples:
 
 	cmd.Flags().StringP("name", "n", "", "Vault name")
 	cmd.Flags().StringP("id", "i", "", "Vault Unique ID")
+	cmd.Flags().StringP("key", "k", "", "Key of a kv vault to get")
 	cmd.Flags().StringP("output", "o", "", "Output to file instead of stdout")
 	cmd.Flags().StringP("exec", "e", "", "Command to execute if vault has been updated")
 	cmd.Flags().Bool("no-client-encryption", false, "Disable client-side encryption (less secure)")
@@ -53,8 +57,8 @@ func runGetCommand(cmd *cobra.Command, _ []string, ctx *CommandContext) {
 
 	// Parse command flags
 	params := parseGetCommandFlags(cmd, ctx)
-	ctx.DebugLog("Parameters - name: '%s', id: '%s', output: '%s', exec: '%s'",
-		params.name, params.id, params.outputFile, params.followUpCommand)
+	ctx.DebugLog("Parameters - name: '%s', id: '%s', key: '%s', output: '%s', exec: '%s'",
+		params.name, params.id, params.key, params.outputFile, params.followUpCommand)
 
 	// Validate required parameters
 	if err := validateGetParams(params); err != nil {
@@ -111,6 +115,7 @@ func runGetCommand(cmd *cobra.Command, _ []string, ctx *CommandContext) {
 type getCommandParams struct {
 	name               string
 	id                 string
+	key                string
 	outputFile         string
 	followUpCommand    string
 	noClientEncryption bool
@@ -122,6 +127,7 @@ func parseGetCommandFlags(cmd *cobra.Command, ctx *CommandContext) getCommandPar
 	return getCommandParams{
 		name:               ctx.MustGetStringFlag(cmd, "name"),
 		id:                 ctx.MustGetStringFlag(cmd, "id"),
+		key:                ctx.MustGetStringFlag(cmd, "key"),
 		outputFile:         ctx.MustGetStringFlag(cmd, "output"),
 		followUpCommand:    ctx.MustGetStringFlag(cmd, "exec"),
 		noClientEncryption: noClientEncryption,
@@ -136,9 +142,13 @@ func validateGetParams(params getCommandParams) error {
 	return nil
 }
 
-// fetchVault retrieves a vault from the API by name or ID
+// fetchVault retrieves a vault from the API by name or ID, or only one of its keys when a
+// key is set
 func fetchVault(params getCommandParams, ctx *CommandContext) (*openapi.Vault, error) {
 	apiCtx := context.Background()
+	if params.key != "" {
+		return fetchVaultKey(apiCtx, params, ctx)
+	}
 
 	// Enable client-side encryption by default (unless disabled)
 	enableClientEncryption := !params.noClientEncryption
@@ -162,7 +172,7 @@ func fetchVault(params getCommandParams, ctx *CommandContext) (*openapi.Vault, e
 		vault, _, err = ctx.GetClient().CliAPI.GetVaultByAPIKey(apiCtx, params.id).Execute()
 	}
 
-	return vault, err
+	return vault, describeAPIError(err)
 }
 
 // handleVaultOutput manages the output of vault data
@@ -275,7 +285,7 @@ func handleFileOutput(vault *openapi.Vault, outputFile, followUpCommand string,
 	}
 
 	if updateResult.HasUpdates {
-		err = os.WriteFile(outputFile, []byte(vault.Value), 0600)
+		err = writeFileAtomic(outputFile, []byte(vault.Value))
 		if err != nil {
 			debugLog("File write failed: %v", err)
 			fmt.Fprintf(os.Stderr, "Error writing to file: %v\n", err)
@@ -293,6 +303,35 @@ func handleFileOutput(vault *openapi.Vault, outputFile, followUpCommand string,
 	}
 }
 
+// writeFileAtomic writes data to a temporary file next to filePath and renames it into
+// place, so readers never see a partially written file. The file is created with 0600.
+func writeFileAtomic(filePath string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(filePath), "."+filepath.Base(filePath)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmp.Name()
+	// Remove the temporary file if anything fails before the rename
+	defer os.Remove(tmpPath)
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, filePath)
+}
+
 func executeFollowUpCommand(followUpCommand string, debugLog func(string, ...any)) {
 	debugLog("Executing follow-up command: %s", followUpCommand)
 	fmt.Printf("Executing follow-up command: %s\n", followUpCommand)
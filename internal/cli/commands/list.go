@@ -60,7 +60,7 @@ func fetchVaultsFromAPI(ctx *CommandContext) ([]openapi.VaultLite, error) {
 	apiCtx := context.Background()
 	ctx.DebugLog("Making API request to get vaults by API key")
 	vaults, _, err := ctx.GetClient().CliAPI.GetVaultsByAPIKey(apiCtx).Execute()
-	return vaults, err
+	return vaults, describeAPIError(err)
 }
 
 // printJSONOutput marshals and prints vaults in JSON format
@@ -0,0 +1,315 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lwshen/vault-hub/internal/cli/encryption"
+)
+
+// NewRunCommand creates the run command
+func NewRunCommand(ctx *CommandContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "run --name/--id <vault-name-or-id> [--name/--id ...] -- <command> [args...]",
+		Short: "Run a command with vaults injected as environment variables",
+		Long: `Fetch one or more vaults, parse their dotenv-formatted values and run a command
+with the variables added to its environment. Nothing is written to disk. The keys of
+kv vaults are injected as variables of the same name.
+
+Vaults are applied in the order given; a variable defined by a later vault replaces
+the same variable from an earlier one. Variables already set in the environment are
+kept unless --override is given. --prefix is prepended to every injected variable.
+
+Signals received by vault-hub are forwarded to the command, and vault-hub exits
+with the command's exit code.
+
+Examples:
+  vault-hub run --name app-env -- ./server
+  vault-hub run --name app-env --name db-env -- npm start
+  vault-hub run --name app-env --prefix APP_ --override -- ./server --port 8080`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			runRunCommand(cmd, args, ctx)
+		},
+	}
+
+	// --name and --id share one list so that vaults keep the order they were given in
+	vaults := &vaultRefs{}
+	cmd.Flags().VarP(&vaultRefFlag{refs: vaults, byName: true}, "name", "n", "Vault name (repeatable)")
+	cmd.Flags().VarP(&vaultRefFlag{refs: vaults}, "id", "i", "Vault Unique ID (repeatable)")
+	cmd.Flags().String("prefix", "", "Prefix added to every injected variable name")
+	cmd.Flags().Bool("override", false, "Replace variables that are already set in the environment")
+	cmd.Flags().Bool("no-client-encryption", false, "Disable client-side encryption (less secure)")
+	// Flags after the command belong to the command, even without "--"
+	cmd.Flags().SetInterspersed(false)
+
+	return cmd
+}
+
+// vaultRefs is the ordered list of vaults given with --name and --id
+type vaultRefs struct {
+	params []getCommandParams
+}
+
+// vaultRefFlag adds the vaults given with one of the flags to the shared list.
+// With withOutput set, each value has the form <vault>=<file>.
+type vaultRefFlag struct {
+	refs       *vaultRefs
+	byName     bool
+	withOutput bool
+	values     []string
+}
+
+func (f *vaultRefFlag) Set(value string) error {
+	params := getCommandParams{}
+	ref := value
+	if f.withOutput {
+		idx := strings.LastIndex(value, "=")
+		if idx <= 0 || idx == len(value)-1 {
+			return fmt.Errorf("expected <vault>=<file>, got %q", value)
+		}
+		ref, params.outputFile = value[:idx], value[idx+1:]
+	}
+	if ref == "" {
+		return fmt.Errorf("vault reference cannot be empty")
+	}
+
+	f.values = append(f.values, value)
+	if f.byName {
+		params.name = ref
+	} else {
+		params.id = ref
+	}
+	f.refs.params = append(f.refs.params, params)
+	return nil
+}
+
+func (f *vaultRefFlag) String() string { return strings.Join(f.values, ",") }
+
+func (f *vaultRefFlag) Type() string { return "string" }
+
+// runCommandParams holds the parsed command parameters
+type runCommandParams struct {
+	vaults             []getCommandParams
+	prefix             string
+	override           bool
+	noClientEncryption bool
+}
+
+// vaultEnv holds the variables parsed from one vault
+type vaultEnv struct {
+	source string
+	vars   []envVar
+}
+
+// runRunCommand fetches the vaults, builds the environment and runs the command
+func runRunCommand(cmd *cobra.Command, args []string, ctx *CommandContext) {
+	ctx.DebugLog("Executing run command")
+
+	params, err := parseRunCommandFlags(cmd)
+	if err == nil {
+		err = validateRunParams(params)
+	}
+	if err != nil {
+		ctx.DebugLog("Validation failed: %v", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	vaultEnvs := make([]vaultEnv, 0, len(params.vaults))
+	for _, vaultParams := range params.vaults {
+		env, err := fetchVaultEnv(vaultParams, ctx)
+		if err != nil {
+			ctx.DebugLog("Failed to load vault: %v", err)
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		vaultEnvs = append(vaultEnvs, env)
+	}
+
+	environment, warnings := buildChildEnv(os.Environ(), vaultEnvs, params.prefix, params.override)
+	for _, warning := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+
+	os.Exit(runChild(args, environment, ctx.DebugLog))
+}
+
+// parseRunCommandFlags extracts and returns command flags
+func parseRunCommandFlags(cmd *cobra.Command) (runCommandParams, error) {
+	nameFlag, ok := cmd.Flags().Lookup("name").Value.(*vaultRefFlag)
+	if !ok {
+		return runCommandParams{}, fmt.Errorf("failed to get name flag")
+	}
+	prefix, _ := cmd.Flags().GetString("prefix")
+	override, _ := cmd.Flags().GetBool("override")
+	noClientEncryption, _ := cmd.Flags().GetBool("no-client-encryption")
+
+	params := runCommandParams{
+		prefix:             prefix,
+		override:           override,
+		noClientEncryption: noClientEncryption,
+	}
+	for _, vault := range nameFlag.refs.params {
+		vault.noClientEncryption = noClientEncryption
+		params.vaults = append(params.vaults, vault)
+	}
+	return params, nil
+}
+
+// validateRunParams ensures at least one vault is given and the prefix is usable
+func validateRunParams(params runCommandParams) error {
+	if len(params.vaults) == 0 {
+		return fmt.Errorf("at least one --name or --id must be provided")
+	}
+	if params.prefix != "" && !isValidEnvKey(params.prefix) {
+		return fmt.Errorf("invalid prefix %q: must contain only letters, digits and underscores and not start with a digit", params.prefix)
+	}
+	return nil
+}
+
+// vaultSource returns the identifier a vault was requested with
+func vaultSource(params getCommandParams) string {
+	if params.name != "" {
+		return params.name
+	}
+	return params.id
+}
+
+// fetchVaultValue fetches a vault and returns its decrypted value
+func fetchVaultValue(params getCommandParams, ctx *CommandContext) (string, error) {
+	source := vaultSource(params)
+
+	vault, err := fetchVault(params, ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch vault %s: %w", source, err)
+	}
+
+	value := vault.Value
+	if !params.noClientEncryption {
+		// The salt is the vault identifier used in the request, as for get
+		value, err = encryption.DecryptForClient(vault.Value, ctx.GetAPIKey(), source)
+		if err != nil {
+			return "", fmt.Errorf("failed to decrypt vault %s: %w", source, err)
+		}
+	}
+	return value, nil
+}
+
+// fetchVaultEnv fetches a vault, decrypts its value and parses it as dotenv or kv
+func fetchVaultEnv(params getCommandParams, ctx *CommandContext) (vaultEnv, error) {
+	source := vaultSource(params)
+
+	value, err := fetchVaultValue(params, ctx)
+	if err != nil {
+		return vaultEnv{}, err
+	}
+
+	vars, err := parseVaultEnv(value)
+	if err != nil {
+		return vaultEnv{}, fmt.Errorf("vault %s is not in dotenv or kv format: %w", source, err)
+	}
+	ctx.DebugLog("Loaded %d variables from vault %s", len(vars), source)
+
+	return vaultEnv{source: source, vars: vars}, nil
+}
+
+// buildChildEnv merges the variables of the vaults into the base environment.
+// Later vaults replace variables of earlier ones; variables already in the base
+// environment are only replaced when override is set. The returned warnings name
+// the affected variables but never include values.
+func buildChildEnv(base []string, vaultEnvs []vaultEnv, prefix string, override bool) ([]string, []string) {
+	inBase := map[string]bool{}
+	for _, entry := range base {
+		if key, _, ok := strings.Cut(entry, "="); ok {
+			inBase[key] = true
+		}
+	}
+
+	var warnings []string
+	injected := map[string]string{}
+	sources := map[string]string{}
+	var order []string
+	for _, env := range vaultEnvs {
+		for _, v := range env.vars {
+			key := prefix + v.Key
+			if previous, ok := sources[key]; ok {
+				warnings = append(warnings, fmt.Sprintf("%s from vault %s replaces the value from vault %s", key, env.source, previous))
+			} else {
+				order = append(order, key)
+			}
+			injected[key] = v.Value
+			sources[key] = env.source
+		}
+	}
+
+	environment := make([]string, 0, len(base)+len(order))
+	for _, entry := range base {
+		key, _, _ := strings.Cut(entry, "=")
+		if _, ok := injected[key]; ok && override {
+			continue
+		}
+		environment = append(environment, entry)
+	}
+	for _, key := range order {
+		if inBase[key] && !override {
+			warnings = append(warnings, fmt.Sprintf("%s is already set in the environment and was kept (use --override to replace it)", key))
+			continue
+		}
+		environment = append(environment, key+"="+injected[key])
+	}
+
+	return environment, warnings
+}
+
+// runChild runs the command with the given environment, forwarding signals to it,
+// and returns the exit code to exit with
+func runChild(args []string, environment []string, debugLog func(string, ...any)) int {
+	child := exec.Command(args[0], args[1:]...)
+	child.Env = environment
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	// Start listening before the child starts so no signal is lost in between
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, forwardedSignals...)
+	defer signal.Stop(signals)
+
+	debugLog("Starting command: %s", args[0])
+	if err := child.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to start %s: %v\n", args[0], err)
+		return 127
+	}
+
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case sig := <-signals:
+				debugLog("Forwarding signal %v to command", sig)
+				_ = child.Process.Signal(sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	err := child.Wait()
+	close(done)
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	code := exitCode(child.ProcessState)
+	debugLog("Command exited with code %d", code)
+	return code
+}
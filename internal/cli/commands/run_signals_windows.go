@@ -0,0 +1,15 @@
+//go:build windows
+
+package commands
+
+import (
+	"os"
+)
+
+// forwardedSignals are passed on to the command started by run
+var forwardedSignals = []os.Signal{os.Interrupt}
+
+// exitCode returns the exit code of a finished command
+func exitCode(state *os.ProcessState) int {
+	return state.ExitCode()
+}
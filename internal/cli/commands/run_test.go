@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDotenv(t *testing.T) {
+	content := `# database settings
+DB_HOST=localhost
+export DB_PORT=5432
+DB_USER = admin # inline comment
+DB_PASS='p#ss word'
+GREETING="hello\n\"world\""
+MULTILINE="line one
+line two"
+EMPTY=
+DB_HOST=db.internal
+`
+	vars, err := parseDotenv(content)
+	if err != nil {
+		t.Fatalf("parseDotenv() error = %v", err)
+	}
+
+	expected := []envVar{
+		{Key: "DB_HOST", Value: "db.internal"},
+		{Key: "DB_PORT", Value: "5432"},
+		{Key: "DB_USER", Value: "admin"},
+		{Key: "DB_PASS", Value: "p#ss word"},
+		{Key: "GREETING", Value: "hello\n\"world\""},
+		{Key: "MULTILINE", Value: "line one\nline two"},
+		{Key: "EMPTY", Value: ""},
+	}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("parseDotenv() = %+v, want %+v", vars, expected)
+	}
+}
+
+func TestParseDotenvErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing equals", content: "JUST_A_KEY"},
+		{name: "invalid key", content: "1KEY=value"},
+		{name: "unterminated double quote", content: "KEY=\"value"},
+		{name: "unterminated single quote", content: "KEY='value"},
+		{name: "text after quote", content: "KEY=\"value\" extra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := parseDotenv(tt.content); err == nil {
+				t.Errorf("parseDotenv(%q) expected an error", tt.content)
+			}
+		})
+	}
+}
+
+func TestParseVaultEnv(t *testing.T) {
+	vars, err := parseVaultEnv(`{"DB_PORT":"5432","DB_HOST":"localhost"}`)
+	if err != nil {
+		t.Fatalf("parseVaultEnv() error = %v", err)
+	}
+	expected := []envVar{{Key: "DB_HOST", Value: "localhost"}, {Key: "DB_PORT", Value: "5432"}}
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("parseVaultEnv() = %+v, want %+v", vars, expected)
+	}
+
+	if vars, err := parseVaultEnv("DB_HOST=localhost"); err != nil || len(vars) != 1 {
+		t.Errorf("expected dotenv values to still be parsed, got %+v, %v", vars, err)
+	}
+	if _, err := parseVaultEnv(`{"app.mode":"prod"}`); err == nil {
+		t.Error("expected an error for a key that is not a variable name")
+	}
+}
+
+func TestBuildChildEnv(t *testing.T) {
+	base := []string{"PATH=/usr/bin", "APP_MODE=local"}
+	vaultEnvs := []vaultEnv{
+		{source: "app-env", vars: []envVar{{Key: "MODE", Value: "production"}, {Key: "TOKEN", Value: "first"}}},
+		{source: "extra-env", vars: []envVar{{Key: "TOKEN", Value: "second"}}},
+	}
+
+	environment, warnings := buildChildEnv(base, vaultEnvs, "APP_", false)
+	expected := []string{"PATH=/usr/bin", "APP_MODE=local", "APP_TOKEN=second"}
+	if !reflect.DeepEqual(environment, expected) {
+		t.Errorf("buildChildEnv() = %v, want %v", environment, expected)
+	}
+	if len(warnings) != 2 {
+		t.Errorf("buildChildEnv() warnings = %v, want 2 warnings", warnings)
+	}
+
+	environment, _ = buildChildEnv(base, vaultEnvs, "APP_", true)
+	expected = []string{"PATH=/usr/bin", "APP_MODE=production", "APP_TOKEN=second"}
+	if !reflect.DeepEqual(environment, expected) {
+		t.Errorf("buildChildEnv() with override = %v, want %v", environment, expected)
+	}
+}
+
+func TestRunCommandKeepsVaultOrder(t *testing.T) {
+	cmd := NewRunCommand(&CommandContext{})
+	if err := cmd.ParseFlags([]string{"--id", "abc", "--name", "app-env", "--id", "def"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+
+	params, err := parseRunCommandFlags(cmd)
+	if err != nil {
+		t.Fatalf("parseRunCommandFlags() error = %v", err)
+	}
+	expected := []getCommandParams{{id: "abc"}, {name: "app-env"}, {id: "def"}}
+	if !reflect.DeepEqual(params.vaults, expected) {
+		t.Errorf("parseRunCommandFlags() vaults = %+v, want %+v", params.vaults, expected)
+	}
+}
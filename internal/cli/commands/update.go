@@ -19,6 +19,7 @@ func NewUpdateCommand(ctx *CommandContext) *cobra.Command {
 		Use:   "update --name/--id <vault-name-or-id>",
 		Short: "Update a vault value by name or unique ID",
 		Long: `Update an existing vault's value by name or unique ID.
+For kv vaults, --key sets the value of a single key and keeps the other keys.
 
 Client-side encryption is ENABLED BY DEFAULT for enhanced security when updating values.
 The vault value is encrypted with a per-vault key derived from your API key.
@@ -28,6 +29,7 @@ Examples:
   vault-hub update --name my-api-keys --value "new-secret-value"
   vault-hub update --id abc123-def456-ghi789 --value "new-value"
   vault-hub update --name my-api-keys --value-file ./secret.txt
+  vault-hub update --name app --key DATABASE_URL --value "postgres://db/app"
   vault-hub update --id abc123 --value "plain-value" --no-client-encryption`,
 		Run: func(cmd *cobra.Command, args []string) {
 			runUpdateCommand(cmd, args, ctx)
@@ -36,6 +38,7 @@ Examples:
 
 	cmd.Flags().StringP("name", "n", "", "Vault name")
 	cmd.Flags().StringP("id", "i", "", "Vault Unique ID")
+	cmd.Flags().StringP("key", "k", "", "Key of a kv vault to set instead of the whole value")
 	cmd.Flags().StringP("value", "v", "", "New vault value")
 	cmd.Flags().String("value-file", "", "Read value from file (takes precedence over --value)")
 	cmd.Flags().StringP("output", "o", "text", "Output format: text|json")
@@ -48,6 +51,7 @@ Examples:
 type updateCommandParams struct {
 	name               string
 	id                 string
+	key                string
 	value              string
 	valueFile          string
 	output             string
@@ -87,6 +91,13 @@ func runUpdateCommand(cmd *cobra.Command, _ []string, ctx *CommandContext) {
 		ctx.DebugLog("Value read from file: %s", params.valueFile)
 	}
 
+	// A single key of a kv vault is set through its own endpoint
+	if params.key != "" {
+		updateVaultKey(params, ctx)
+		ctx.DebugLog("Update command completed successfully")
+		return
+	}
+
 	// Build update request
 	updateReq := buildUpdateRequest(params)
 
@@ -148,6 +159,7 @@ func parseUpdateCommandFlags(cmd *cobra.Command, ctx *CommandContext) updateComm
 	return updateCommandParams{
 		name:               ctx.MustGetStringFlag(cmd, "name"),
 		id:                 ctx.MustGetStringFlag(cmd, "id"),
+		key:                ctx.MustGetStringFlag(cmd, "key"),
 		value:              ctx.MustGetStringFlag(cmd, "value"),
 		valueFile:          ctx.MustGetStringFlag(cmd, "value-file"),
 		output:             output,
@@ -208,7 +220,51 @@ func updateVault(params updateCommandParams, updateReq openapi.UpdateVaultReques
 		vault, _, err = ctx.GetClient().CliAPI.UpdateVaultByAPIKey(apiCtx, params.id).UpdateVaultRequest(updateReq).Execute()
 	}
 
-	return vault, err
+	return vault, describeAPIError(err)
+}
+
+// updateVaultKey sets one key of a kv vault, encrypting the value unless client-side
+// encryption is disabled, and prints the result
+func updateVaultKey(params updateCommandParams, ctx *CommandContext) {
+	value := params.value
+	if !params.noClientEncryption {
+		// Determine the salt (vault identifier used for key derivation)
+		salt := params.name
+		if salt == "" {
+			salt = params.id
+		}
+
+		encryptedValue, err := encryption.EncryptForClient(params.value, ctx.GetAPIKey(), salt)
+		if err != nil {
+			ctx.DebugLog("Encryption failed: %v", err)
+			fmt.Fprintf(os.Stderr, "Error: Failed to encrypt vault value: %v\n", err)
+			os.Exit(1)
+		}
+		value = encryptedValue
+	}
+
+	if err := setVaultKey(context.Background(), params, value, ctx); err != nil {
+		ctx.DebugLog("API request failed: %v", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	ctx.DebugLog("API request successful, key updated")
+
+	if params.output == "json" {
+		output, err := json.MarshalIndent(map[string]string{"key": params.key, "value": params.value}, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error marshaling JSON: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(output))
+		return
+	}
+
+	vault := params.name
+	if vault == "" {
+		vault = params.id
+	}
+	fmt.Printf("✅ Key %s of vault %s updated successfully\n", params.key, vault)
 }
 
 // handleUpdateOutput manages the output of vault data
@@ -0,0 +1,370 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// NewWatchCommand creates the watch command
+func NewWatchCommand(ctx *CommandContext) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "watch --name/--id <vault-name-or-id>=<file> [--name/--id ...] --exec <command>",
+		Short: "Keep files in sync with vaults and run a command when they change",
+		Long: `Run as a long-lived agent that polls vaults for changes and keeps local files
+in sync with them. Files are replaced atomically (written to a temporary file and
+renamed), so readers never see a partially written file.
+
+When the server offers a change event stream, the agent subscribes to it and syncs
+as soon as a watched vault changes; polling continues as a fallback. Use --no-events
+to rely on polling alone.
+
+When one or more files change, the --exec command runs once after the changes have
+settled for --debounce. When the server cannot be reached, polling backs off
+exponentially up to --max-backoff.
+
+With --health-addr, a local HTTP server reports the state of the agent:
+  /livez    200 while the agent is running
+  /healthz  200 once every file is in sync and the last poll succeeded, 503 otherwise
+
+Examples:
+  vault-hub watch --name app-env=.env --exec "systemctl reload app"
+  vault-hub watch --name app-env=/run/secrets/app.env --id abc123=/run/secrets/db.env --interval 10s
+  vault-hub watch --name app-env=.env --health-addr 127.0.0.1:8081`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			runWatchCommand(cmd, args, ctx)
+		},
+	}
+
+	// --name and --id share one list so that vaults keep the order they were given in
+	vaults := &vaultRefs{}
+	cmd.Flags().VarP(&vaultRefFlag{refs: vaults, byName: true, withOutput: true}, "name", "n", "Vault name and file as <name>=<file> (repeatable)")
+	cmd.Flags().VarP(&vaultRefFlag{refs: vaults, withOutput: true}, "id", "i", "Vault Unique ID and file as <id>=<file> (repeatable)")
+	cmd.Flags().StringP("exec", "e", "", "Command to execute after files have been updated")
+	cmd.Flags().Duration("interval", 30*time.Second, "Time between polls")
+	cmd.Flags().Duration("debounce", 2*time.Second, "Time to wait for further changes before running the command")
+	cmd.Flags().Duration("max-backoff", 5*time.Minute, "Maximum time between polls while the server is failing")
+	cmd.Flags().String("health-addr", "", "Address to serve the health endpoints on, e.g. 127.0.0.1:8081")
+	cmd.Flags().Bool("no-events", false, "Only poll, without subscribing to change events")
+	cmd.Flags().Bool("no-client-encryption", false, "Disable client-side encryption (less secure)")
+
+	return cmd
+}
+
+// watchOptions holds the parsed command parameters
+type watchOptions struct {
+	targets         []getCommandParams
+	followUpCommand string
+	interval        time.Duration
+	debounce        time.Duration
+	maxBackoff      time.Duration
+	healthAddr      string
+	noEvents        bool
+}
+
+// runWatchCommand runs the watch loop until the process is interrupted
+func runWatchCommand(cmd *cobra.Command, _ []string, ctx *CommandContext) {
+	ctx.DebugLog("Executing watch command")
+
+	options, err := parseWatchCommandFlags(cmd, ctx)
+	if err == nil {
+		err = validateWatchOptions(options)
+	}
+	if err != nil {
+		ctx.DebugLog("Validation failed: %v", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	logger := log.New(os.Stdout, "", log.LstdFlags)
+	w := newWatcher(options,
+		func(params getCommandParams) (string, error) { return fetchVaultValue(params, ctx) },
+		func() { executeFollowUpCommand(options.followUpCommand, ctx.DebugLog) },
+		logger.Printf,
+	)
+
+	runCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if options.healthAddr != "" {
+		listener, err := net.Listen("tcp", options.healthAddr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to listen on %s: %v\n", options.healthAddr, err)
+			os.Exit(1)
+		}
+		server := &http.Server{Handler: w.healthHandler(), ReadHeaderTimeout: 5 * time.Second}
+		go func() {
+			if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Printf("Health server failed: %v", err)
+			}
+		}()
+		defer server.Close()
+		logger.Printf("Serving health endpoints on %s", listener.Addr())
+	}
+
+	if !options.noEvents {
+		notify := make(chan struct{}, 1)
+		w.notify = notify
+		subscriber := &vaultEventSubscriber{
+			baseURL:    ctx.GetClient().GetConfig().Servers[0].URL,
+			apiKey:     ctx.GetAPIKey(),
+			client:     &http.Client{},
+			targets:    options.targets,
+			notify:     notify,
+			logf:       logger.Printf,
+			maxBackoff: options.maxBackoff,
+		}
+		go subscriber.run(runCtx)
+	}
+
+	logger.Printf("Watching %d vault(s) every %s", len(options.targets), options.interval)
+	w.run(runCtx)
+	logger.Printf("Stopped watching")
+}
+
+// parseWatchCommandFlags extracts and returns command flags
+func parseWatchCommandFlags(cmd *cobra.Command, ctx *CommandContext) (watchOptions, error) {
+	nameFlag, ok := cmd.Flags().Lookup("name").Value.(*vaultRefFlag)
+	if !ok {
+		return watchOptions{}, fmt.Errorf("failed to get name flag")
+	}
+	interval, _ := cmd.Flags().GetDuration("interval")
+	debounce, _ := cmd.Flags().GetDuration("debounce")
+	maxBackoff, _ := cmd.Flags().GetDuration("max-backoff")
+	healthAddr, _ := cmd.Flags().GetString("health-addr")
+	noEvents, _ := cmd.Flags().GetBool("no-events")
+	noClientEncryption, _ := cmd.Flags().GetBool("no-client-encryption")
+
+	options := watchOptions{
+		followUpCommand: ctx.MustGetStringFlag(cmd, "exec"),
+		interval:        interval,
+		debounce:        debounce,
+		maxBackoff:      maxBackoff,
+		healthAddr:      healthAddr,
+		noEvents:        noEvents,
+	}
+	for _, target := range nameFlag.refs.params {
+		target.noClientEncryption = noClientEncryption
+		options.targets = append(options.targets, target)
+	}
+	return options, nil
+}
+
+// validateWatchOptions ensures at least one vault is given and the durations are usable
+func validateWatchOptions(options watchOptions) error {
+	if len(options.targets) == 0 {
+		return fmt.Errorf("at least one --name or --id must be provided")
+	}
+	files := map[string]bool{}
+	for _, target := range options.targets {
+		if files[target.outputFile] {
+			return fmt.Errorf("file %s is used by more than one vault", target.outputFile)
+		}
+		files[target.outputFile] = true
+	}
+	if options.interval <= 0 {
+		return fmt.Errorf("--interval must be positive")
+	}
+	if options.debounce < 0 {
+		return fmt.Errorf("--debounce cannot be negative")
+	}
+	if options.maxBackoff < options.interval {
+		return fmt.Errorf("--max-backoff must be at least --interval")
+	}
+	return nil
+}
+
+// watchTargetStatus is the sync state of one vault, as reported by the health endpoint
+type watchTargetStatus struct {
+	Vault      string     `json:"vault"`
+	File       string     `json:"file"`
+	LastSynced *time.Time `json:"lastSynced,omitempty"`
+	LastError  string     `json:"lastError,omitempty"`
+}
+
+// watchStatus is the state of the agent, as reported by the health endpoint
+type watchStatus struct {
+	Status              string              `json:"status"`
+	LastPoll            *time.Time          `json:"lastPoll,omitempty"`
+	ConsecutiveFailures int                 `json:"consecutiveFailures"`
+	Reloads             int                 `json:"reloads"`
+	Vaults              []watchTargetStatus `json:"vaults"`
+}
+
+// watcher polls vaults, writes changed values to their files and runs the reload
+// function once changes have settled
+type watcher struct {
+	options watchOptions
+	fetch   func(getCommandParams) (string, error)
+	reload  func()
+	logf    func(string, ...any)
+	// notify, if set, triggers a poll before the interval has passed
+	notify <-chan struct{}
+
+	mu       sync.Mutex
+	status   watchStatus
+	failures int
+}
+
+func newWatcher(options watchOptions, fetch func(getCommandParams) (string, error), reload func(), logf func(string, ...any)) *watcher {
+	w := &watcher{options: options, fetch: fetch, reload: reload, logf: logf}
+	w.status.Status = "starting"
+	for _, target := range options.targets {
+		w.status.Vaults = append(w.status.Vaults, watchTargetStatus{Vault: vaultSource(target), File: target.outputFile})
+	}
+	return w
+}
+
+// run polls until ctx is done. A reload still waiting for its debounce is dropped on exit.
+func (w *watcher) run(ctx context.Context) {
+	poll := time.After(0)
+	var debounce <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll:
+		case <-w.notify:
+			w.logf("Change event received")
+		case <-debounce:
+			debounce = nil
+			w.logf("Running reload command")
+			w.reload()
+			w.mu.Lock()
+			w.status.Reloads++
+			w.mu.Unlock()
+			continue
+		}
+
+		if w.poll() && w.options.followUpCommand != "" {
+			// Every change restarts the wait, so a burst of changes causes one reload
+			debounce = time.After(w.options.debounce)
+		}
+		poll = time.After(w.nextPollDelay())
+	}
+}
+
+// poll syncs every vault once and reports whether any file was changed
+func (w *watcher) poll() bool {
+	changed := false
+	failed := false
+
+	for i, target := range w.options.targets {
+		source := vaultSource(target)
+		updated, err := w.syncTarget(target)
+
+		w.mu.Lock()
+		if err != nil {
+			failed = true
+			w.status.Vaults[i].LastError = err.Error()
+			w.logf("Failed to sync vault %s: %v", source, err)
+		} else {
+			now := time.Now()
+			w.status.Vaults[i].LastSynced = &now
+			w.status.Vaults[i].LastError = ""
+			if updated {
+				changed = true
+				w.logf("Vault %s written to %s", source, target.outputFile)
+			}
+		}
+		w.mu.Unlock()
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := time.Now()
+	w.status.LastPoll = &now
+	if failed {
+		w.failures++
+	} else {
+		w.failures = 0
+	}
+	w.status.ConsecutiveFailures = w.failures
+	w.status.Status = w.currentStatus()
+	return changed
+}
+
+// syncTarget fetches a vault and replaces its file if the content differs
+func (w *watcher) syncTarget(target getCommandParams) (bool, error) {
+	value, err := w.fetch(target)
+	if err != nil {
+		return false, err
+	}
+
+	existing, err := os.ReadFile(target.outputFile)
+	if err == nil && string(existing) == value {
+		return false, nil
+	}
+	if err := writeFileAtomic(target.outputFile, []byte(value)); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", target.outputFile, err)
+	}
+	return true, nil
+}
+
+// currentStatus derives the overall status; the caller must hold w.mu
+func (w *watcher) currentStatus() string {
+	if w.failures > 0 {
+		return "degraded"
+	}
+	for _, target := range w.status.Vaults {
+		if target.LastSynced == nil {
+			return "starting"
+		}
+	}
+	return "ok"
+}
+
+// nextPollDelay returns the interval, doubled for every consecutive failed poll
+// up to the maximum backoff
+func (w *watcher) nextPollDelay() time.Duration {
+	w.mu.Lock()
+	failures := w.failures
+	w.mu.Unlock()
+	return backoffDelay(failures, w.options.interval, w.options.maxBackoff)
+}
+
+// backoffDelay returns interval * 2^failures, capped at maxBackoff
+func backoffDelay(failures int, interval, maxBackoff time.Duration) time.Duration {
+	delay := interval
+	for i := 0; i < failures && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// healthHandler serves /livez and /healthz
+func (w *watcher) healthHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /livez", func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+		_, _ = rw.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("GET /healthz", func(rw http.ResponseWriter, _ *http.Request) {
+		w.mu.Lock()
+		status := w.status
+		status.Vaults = append([]watchTargetStatus(nil), w.status.Vaults...)
+		w.mu.Unlock()
+
+		code := http.StatusOK
+		if status.Status != "ok" {
+			code = http.StatusServiceUnavailable
+		}
+		rw.Header().Set("Content-Type", "application/json")
+		rw.WriteHeader(code)
+		_ = json.NewEncoder(rw).Encode(status)
+	})
+	return mux
+}
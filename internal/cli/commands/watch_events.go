@@ -0,0 +1,125 @@
+package commands
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// errEventsUnsupported is returned when the server has no event stream
+var errEventsUnsupported = errors.New("server does not support change events")
+
+// vaultEventSubscriber follows the server's vault event stream and signals notify
+// whenever one of the watched vaults changes
+type vaultEventSubscriber struct {
+	baseURL    string
+	apiKey     string
+	client     *http.Client
+	targets    []getCommandParams
+	notify     chan<- struct{}
+	logf       func(string, ...any)
+	maxBackoff time.Duration
+
+	lastEventID string
+}
+
+// run keeps the stream connected until ctx is done, reconnecting with backoff and resuming
+// from the last event received. It returns early if the server has no event stream.
+func (s *vaultEventSubscriber) run(ctx context.Context) {
+	failures := 0
+	for {
+		err := s.stream(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if errors.Is(err, errEventsUnsupported) {
+			s.logf("Server does not support change events, relying on polling")
+			return
+		}
+		if err != nil {
+			failures++
+			s.logf("Change event stream failed: %v", err)
+		} else {
+			failures = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(backoffDelay(failures, time.Second, s.maxBackoff)):
+		}
+	}
+}
+
+// stream reads one connection of the event stream until it ends
+func (s *vaultEventSubscriber) stream(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(s.baseURL, "/")+"/api/cli/events", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Accept", "text/event-stream")
+	if s.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return errEventsUnsupported
+	case resp.StatusCode != http.StatusOK:
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	var id, data string
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			// A blank line ends an event
+			if data != "" {
+				s.lastEventID = id
+				s.handle(data)
+			}
+			data = ""
+		case strings.HasPrefix(line, "id:"):
+			id = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+		case strings.HasPrefix(line, "data:"):
+			data += strings.TrimSpace(strings.TrimPrefix(line, "data:"))
+		}
+	}
+	return scanner.Err()
+}
+
+// handle signals notify if the event is about a watched vault
+func (s *vaultEventSubscriber) handle(data string) {
+	var event struct {
+		UniqueID string `json:"uniqueId"`
+		Name     string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(data), &event); err != nil {
+		return
+	}
+
+	for _, target := range s.targets {
+		if (target.name != "" && target.name == event.Name) || (target.id != "" && target.id == event.UniqueID) {
+			// A poll that is already pending covers this change as well
+			select {
+			case s.notify <- struct{}{}:
+			default:
+			}
+			return
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestBackoffDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		expected time.Duration
+	}{
+		{name: "no failures", failures: 0, expected: 10 * time.Second},
+		{name: "one failure", failures: 1, expected: 20 * time.Second},
+		{name: "three failures", failures: 3, expected: 80 * time.Second},
+		{name: "capped", failures: 10, expected: 2 * time.Minute},
+		{name: "many failures", failures: 1000, expected: 2 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backoffDelay(tt.failures, 10*time.Second, 2*time.Minute); got != tt.expected {
+				t.Errorf("backoffDelay(%d) = %v, want %v", tt.failures, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, ".env")
+
+	if err := writeFileAtomic(path, []byte("FIRST=1")); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+	if err := writeFileAtomic(path, []byte("SECOND=2")); err != nil {
+		t.Fatalf("writeFileAtomic() error = %v", err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if string(content) != "SECOND=2" {
+		t.Errorf("file content = %q, want %q", content, "SECOND=2")
+	}
+	if runtime.GOOS != "windows" {
+		info, _ := os.Stat(path)
+		if info.Mode().Perm() != 0600 {
+			t.Errorf("file mode = %v, want 0600", info.Mode().Perm())
+		}
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 {
+		t.Errorf("expected only the target file in the directory, found %d entries", len(entries))
+	}
+}
+
+func TestWatchCommandParsesTargets(t *testing.T) {
+	ctx := &CommandContext{MustGetStringFlag: func(cmd *cobra.Command, name string) string {
+		value, _ := cmd.Flags().GetString(name)
+		return value
+	}}
+	cmd := NewWatchCommand(ctx)
+	if err := cmd.ParseFlags([]string{"--name", "app-env=.env", "--id", "abc=/run/secrets/db.env", "--exec", "reload"}); err != nil {
+		t.Fatalf("ParseFlags() error = %v", err)
+	}
+
+	options, err := parseWatchCommandFlags(cmd, ctx)
+	if err != nil {
+		t.Fatalf("parseWatchCommandFlags() error = %v", err)
+	}
+	expected := []getCommandParams{{name: "app-env", outputFile: ".env"}, {id: "abc", outputFile: "/run/secrets/db.env"}}
+	if !reflect.DeepEqual(options.targets, expected) {
+		t.Errorf("parseWatchCommandFlags() targets = %+v, want %+v", options.targets, expected)
+	}
+	if options.followUpCommand != "reload" {
+		t.Errorf("parseWatchCommandFlags() followUpCommand = %q, want %q", options.followUpCommand, "reload")
+	}
+
+	if err := NewWatchCommand(&CommandContext{}).ParseFlags([]string{"--name", "app-env"}); err == nil {
+		t.Error("expected an error for a vault without a file")
+	}
+}
+
+func TestWatcherDebouncesReload(t *testing.T) {
+	dir := t.TempDir()
+	options := watchOptions{
+		targets: []getCommandParams{
+			{name: "app", outputFile: filepath.Join(dir, "app.env")},
+			{name: "db", outputFile: filepath.Join(dir, "db.env")},
+		},
+		followUpCommand: "reload",
+		interval:        5 * time.Millisecond,
+		debounce:        30 * time.Millisecond,
+		maxBackoff:      time.Second,
+	}
+
+	var mu sync.Mutex
+	values := map[string]string{"app": "A=1", "db": "B=1"}
+	fetch := func(params getCommandParams) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return values[params.name], nil
+	}
+	reloads := make(chan struct{}, 10)
+	w := newWatcher(options, fetch, func() { reloads <- struct{}{} }, t.Logf)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.run(ctx)
+
+	waitForReload(t, reloads)
+	for file, expected := range map[string]string{"app.env": "A=1", "db.env": "B=1"} {
+		content, _ := os.ReadFile(filepath.Join(dir, file))
+		if string(content) != expected {
+			t.Errorf("%s content = %q, want %q", file, content, expected)
+		}
+	}
+
+	mu.Lock()
+	values["app"] = "A=2"
+	mu.Unlock()
+	waitForReload(t, reloads)
+	content, _ := os.ReadFile(filepath.Join(dir, "app.env"))
+	if string(content) != "A=2" {
+		t.Errorf("app.env content = %q, want %q", content, "A=2")
+	}
+
+	// Unchanged vaults do not trigger further reloads
+	select {
+	case <-reloads:
+		t.Error("unexpected reload without changes")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func waitForReload(t *testing.T, reloads <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-reloads:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for reload")
+	}
+}
+
+func TestWatcherBackoffAndHealth(t *testing.T) {
+	options := watchOptions{
+		targets:    []getCommandParams{{name: "app", outputFile: filepath.Join(t.TempDir(), ".env")}},
+		interval:   time.Second,
+		maxBackoff: time.Minute,
+	}
+	fetchErr := errors.New("server unavailable")
+	w := newWatcher(options, func(getCommandParams) (string, error) { return "A=1", fetchErr }, func() {}, t.Logf)
+	handler := w.healthHandler()
+
+	if code := healthCode(handler, "/healthz"); code != http.StatusServiceUnavailable {
+		t.Errorf("/healthz before the first poll = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if code := healthCode(handler, "/livez"); code != http.StatusOK {
+		t.Errorf("/livez = %d, want %d", code, http.StatusOK)
+	}
+
+	w.poll()
+	w.poll()
+	if delay := w.nextPollDelay(); delay != 4*time.Second {
+		t.Errorf("nextPollDelay() after two failures = %v, want %v", delay, 4*time.Second)
+	}
+	if code := healthCode(handler, "/healthz"); code != http.StatusServiceUnavailable {
+		t.Errorf("/healthz while failing = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+
+	fetchErr = nil
+	if !w.poll() {
+		t.Error("expected the first successful poll to write the file")
+	}
+	if delay := w.nextPollDelay(); delay != time.Second {
+		t.Errorf("nextPollDelay() after recovery = %v, want %v", delay, time.Second)
+	}
+	if code := healthCode(handler, "/healthz"); code != http.StatusOK {
+		t.Errorf("/healthz after recovery = %d, want %d", code, http.StatusOK)
+	}
+}
+
+func healthCode(handler http.Handler, path string) int {
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	return recorder.Code
+}
+
+func TestVaultEventSubscriber(t *testing.T) {
+	var lastEventIDs []string
+	var mu sync.Mutex
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/cli/events" || r.Header.Get("Authorization") != "Bearer vhub_test" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		mu.Unlock()
+
+		rw.Header().Set("Content-Type", "text/event-stream")
+		_, _ = rw.Write([]byte("retry: 5000\n\n" +
+			"id: 7\nevent: vault.updated\ndata: {\"uniqueId\":\"other\",\"name\":\"other-env\"}\n\n" +
+			": heartbeat\n\n" +
+			"id: 8\nevent: vault.updated\ndata: {\"uniqueId\":\"abc\",\"name\":\"app-env\"}\n\n"))
+	}))
+	defer server.Close()
+
+	notify := make(chan struct{}, 1)
+	subscriber := &vaultEventSubscriber{
+		baseURL:    server.URL,
+		apiKey:     "vhub_test",
+		client:     server.Client(),
+		targets:    []getCommandParams{{name: "app-env", outputFile: ".env"}},
+		notify:     notify,
+		logf:       t.Logf,
+		maxBackoff: time.Second,
+	}
+
+	if err := subscriber.stream(context.Background()); err != nil {
+		t.Fatalf("stream() error = %v", err)
+	}
+	select {
+	case <-notify:
+	default:
+		t.Fatal("expected a notification for the watched vault")
+	}
+	if subscriber.lastEventID != "8" {
+		t.Errorf("lastEventID = %q, want %q", subscriber.lastEventID, "8")
+	}
+
+	// Reconnects resume after the last event received
+	if err := subscriber.stream(context.Background()); err != nil {
+		t.Fatalf("stream() error = %v", err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if !reflect.DeepEqual(lastEventIDs, []string{"", "8"}) {
+		t.Errorf("Last-Event-ID headers = %v, want %v", lastEventIDs, []string{"", "8"})
+	}
+}
+
+func TestVaultEventSubscriberWithoutServerSupport(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	subscriber := &vaultEventSubscriber{
+		baseURL:    server.URL,
+		client:     server.Client(),
+		notify:     make(chan struct{}, 1),
+		logf:       t.Logf,
+		maxBackoff: time.Second,
+	}
+
+	done := make(chan struct{})
+	go func() {
+		subscriber.run(context.Background())
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the subscriber to stop when the server has no event stream")
+	}
+}
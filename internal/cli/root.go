@@ -18,16 +18,26 @@ func NewRootCommand() *cobra.Command {
 		Long: `VaultHub CLI is a command-line interface for managing your secure
 environment variables and API keys stored in VaultHub.
 
-This CLI allows you to list and retrieve vaults from your VaultHub instance.
+This CLI allows you to list and retrieve vaults from your VaultHub instance,
+run commands with vaults injected as environment variables, and keep files in
+sync with vaults as a long-running agent.
 
 Global flags can be set via environment variables:
-  --api-key     VAULT_HUB_API_KEY
-  --base-url    VAULT_HUB_BASE_URL  
-  --debug       DEBUG`,
+  --api-key            VAULT_HUB_API_KEY
+  --base-url           VAULT_HUB_BASE_URL  
+  --oidc-token-file    VAULT_HUB_OIDC_TOKEN_FILE
+  --workload-identity  VAULT_HUB_WORKLOAD_IDENTITY
+  --debug              DEBUG
+
+Instead of an API key, CI jobs can pass the OIDC token of their workload with
+--oidc-token-file. It is exchanged for a short-lived API key scoped by a workload
+identity configured in VaultHub.`,
 		PersistentPreRun: func(cmd *cobra.Command, args []string) {
 			// Update global variables with flag values or environment variables
 			APIKey = getFlagOrEnv(cmd, "api-key", "VAULT_HUB_API_KEY", "")
 			BaseURL = getFlagOrEnv(cmd, "base-url", "VAULT_HUB_BASE_URL", "")
+			OIDCTokenFile = getFlagOrEnv(cmd, "oidc-token-file", "VAULT_HUB_OIDC_TOKEN_FILE", "")
+			WorkloadIdentity = getFlagOrEnv(cmd, "workload-identity", "VAULT_HUB_WORKLOAD_IDENTITY", "")
 			Debug = getBoolFlagOrEnv(cmd, "debug", "DEBUG", false)
 
 			InitializeClient()
@@ -37,6 +47,8 @@ Global flags can be set via environment variables:
 	// Add global flags
 	rootCmd.PersistentFlags().StringVar(&APIKey, "api-key", "", "API key for authentication (env: VAULT_HUB_API_KEY)")
 	rootCmd.PersistentFlags().StringVar(&BaseURL, "base-url", "", "Base URL of VaultHub server (env: VAULT_HUB_BASE_URL)")
+	rootCmd.PersistentFlags().StringVar(&OIDCTokenFile, "oidc-token-file", "", "File with an OIDC token to exchange for a short-lived API key (env: VAULT_HUB_OIDC_TOKEN_FILE)")
+	rootCmd.PersistentFlags().StringVar(&WorkloadIdentity, "workload-identity", "", "Workload identity ID to use when the OIDC token matches several (env: VAULT_HUB_WORKLOAD_IDENTITY)")
 	rootCmd.PersistentFlags().BoolVar(&Debug, "debug", false, "Enable debug mode (env: DEBUG)")
 
 	// Create a context to pass dependencies to commands
@@ -51,6 +63,8 @@ Global flags can be set via environment variables:
 	rootCmd.AddCommand(commands.NewListCommand(ctx))
 	rootCmd.AddCommand(commands.NewGetCommand(ctx))
 	rootCmd.AddCommand(commands.NewUpdateCommand(ctx))
+	rootCmd.AddCommand(commands.NewRunCommand(ctx))
+	rootCmd.AddCommand(commands.NewWatchCommand(ctx))
 	rootCmd.AddCommand(commands.NewVersionCommand())
 
 	return rootCmd
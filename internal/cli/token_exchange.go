@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// tokenExchangeRequest is the body of POST /api/auth/token-exchange
+type tokenExchangeRequest struct {
+	Token      string `json:"token"`
+	IdentityID *int64 `json:"identityId,omitempty"`
+}
+
+// tokenExchangeResponse is the short-lived API key returned for a workload token
+type tokenExchangeResponse struct {
+	APIKey    string    `json:"apiKey"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// exchangeWorkloadToken reads the OIDC token a CI system or Kubernetes wrote to tokenFile and
+// exchanges it for a short-lived API key. identity selects the workload identity by ID when
+// the token matches several.
+func exchangeWorkloadToken(baseURL, tokenFile, identity string) (string, error) {
+	token, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read OIDC token: %w", err)
+	}
+	request := tokenExchangeRequest{Token: strings.TrimSpace(string(token))}
+	if identity != "" {
+		id, err := strconv.ParseInt(identity, 10, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid workload identity ID %q", identity)
+		}
+		request.IdentityID = &id
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Post(baseURL+"/api/auth/token-exchange", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("token exchange failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&apiErr) == nil && apiErr.Error.Message != "" {
+			return "", fmt.Errorf("token exchange failed: %s", apiErr.Error.Message)
+		}
+		return "", fmt.Errorf("token exchange failed with status %d", resp.StatusCode)
+	}
+
+	var exchanged tokenExchangeResponse
+	if err := json.NewDecoder(resp.Body).Decode(&exchanged); err != nil {
+		return "", fmt.Errorf("invalid token exchange response: %w", err)
+	}
+	DebugLog("Exchanged OIDC token for an API key valid until %s", exchanged.ExpiresAt.Format(time.RFC3339))
+	return exchanged.APIKey, nil
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"log/slog"
+	"net/url"
 	"os"
+	"strconv"
 	"strings"
 
 	_ "github.com/joho/godotenv/autoload"
@@ -28,9 +30,7 @@ var (
 	JwtSecret         string
 	EncryptionKey     string
 	OidcEnabled       bool
-	OidcClientId      string
-	OidcClientSecret  string
-	OidcIssuer        string
+	OidcProviders     []OidcProvider
 	DemoEnabled       bool
 	EmailEnabled      bool
 	EmailType         string
@@ -49,6 +49,107 @@ var (
 	ResendFromName    string
 )
 
+// Master key settings. EncryptionKeyPrevious holds retired master keys that are still accepted
+// for decryption while vault data keys are being re-wrapped with the active key; the
+// EncryptionKeyPreviousFiles, Pkcs11PreviousKeyLabels and KmsPreviousCiphertexts settings
+// point the file, pkcs11 and kms providers to retired keys of their own.
+var (
+	EncryptionKeyProvider      string
+	EncryptionKeyFile          string
+	EncryptionKeyPrevious      []string
+	EncryptionKeyPreviousFiles []string
+	Pkcs11Module               string
+	Pkcs11TokenLabel           string
+	Pkcs11Pin                  string
+	Pkcs11KeyLabel             string
+	Pkcs11PreviousKeyLabels    []string
+	KmsUrl                     string
+	KmsKeyId                   string
+	KmsToken                   string
+	KmsCiphertext              string
+	KmsPreviousCiphertexts     []string
+)
+
+// Audit log forwarding. AuditForwardUrl is syslog+tcp://host:port, syslog+udp://host:port
+// or the http(s) URL of a collector; AuditForwardToken is sent to collectors as a bearer token.
+var (
+	AuditForwardUrl   string
+	AuditForwardToken string
+)
+
+// WebAuthn (passkey) login is enabled by setting the relying party ID, usually the host name the
+// web interface is served from. WebAuthnRPOrigins lists the origins browsers may report.
+var (
+	WebAuthnEnabled   bool
+	WebAuthnRPID      string
+	WebAuthnRPName    string
+	WebAuthnRPOrigins []string
+)
+
+// LDAP / Active Directory password authentication. Users are searched below LdapBaseDn with
+// LdapUserFilter, where {username} is the login email, and must also match LdapGroupFilter
+// when it is set. Connections bound as the service account are pooled.
+var (
+	LdapEnabled        bool
+	LdapUrl            string
+	LdapStartTLS       bool
+	LdapCACertFile     string
+	LdapBindDn         string
+	LdapBindPassword   string
+	LdapBaseDn         string
+	LdapUserFilter     string
+	LdapGroupFilter    string
+	LdapEmailAttribute string
+	LdapNameAttribute  string
+	LdapPoolSize       int
+)
+
+// Workload identity token exchange. WorkloadIssuers lists the OIDC issuers (such as GitHub
+// Actions, GitLab or a Kubernetes cluster) whose tokens can be exchanged for short-lived API keys.
+var (
+	WorkloadIdentityEnabled bool
+	WorkloadIssuers         []string
+)
+
+// Vault rotation reminders are emailed ExpiryReminderDays days before a vault's rotation
+// deadline, once per deadline, when email is enabled.
+var ExpiryReminderDays int
+
+// Rate limiting of authentication attempts. RateLimitStore is memory (per process) or
+// database (shared by replicas). TrustedProxies lists the reverse proxies whose
+// X-Forwarded-For header is used as the client IP.
+var (
+	RateLimitStore string
+	TrustedProxies []string
+)
+
+// OidcProvider is an OpenID Connect identity provider users can log in with. The provider
+// named "default" is configured with the unprefixed OIDC_* variables; every other provider
+// listed in OIDC_PROVIDERS uses OIDC_<NAME>_* variables.
+type OidcProvider struct {
+	Name         string
+	DisplayName  string
+	Issuer       string
+	ClientId     string
+	ClientSecret string
+	// GroupsClaim is the ID token or userinfo claim listing the user's groups
+	GroupsClaim string
+	// GroupRoles grants organization roles to members of groups
+	GroupRoles []OidcGroupRole
+	// AllowedDomains restricts logins to email addresses in these domains when set
+	AllowedDomains []string
+}
+
+// OidcGroupRole grants Role in an organization to the members of an identity provider group
+type OidcGroupRole struct {
+	Group          string
+	OrganizationId uint
+	Role           string
+}
+
+// DefaultOidcProvider is the name of the provider configured with the unprefixed OIDC_* variables
+const DefaultOidcProvider = "default"
+
 type validation struct {
 	ok  bool
 	msg string
@@ -58,16 +159,58 @@ func init() {
 	AppPort = getEnv("APP_PORT", "3000")
 	JwtSecret = getEnv("JWT_SECRET", "")
 	EncryptionKey = getEnv("ENCRYPTION_KEY", "")
+	EncryptionKeyPrevious = splitList(getEnv("ENCRYPTION_KEY_PREVIOUS", ""))
+	// ENCRYPTION_KEY_PROVIDER selects where the master key comes from: env|file|pkcs11|kms
+	EncryptionKeyProvider = strings.ToLower(strings.TrimSpace(getEnv("ENCRYPTION_KEY_PROVIDER", "env")))
+	EncryptionKeyFile = getEnv("ENCRYPTION_KEY_FILE", "")
+	EncryptionKeyPreviousFiles = splitList(getEnv("ENCRYPTION_KEY_PREVIOUS_FILES", ""))
+	Pkcs11Module = getEnv("PKCS11_MODULE", "")
+	Pkcs11TokenLabel = getEnv("PKCS11_TOKEN_LABEL", "")
+	Pkcs11Pin = getEnv("PKCS11_PIN", "")
+	Pkcs11KeyLabel = getEnv("PKCS11_KEY_LABEL", "")
+	Pkcs11PreviousKeyLabels = splitList(getEnv("PKCS11_PREVIOUS_KEY_LABELS", ""))
+	KmsUrl = getEnv("KMS_URL", "")
+	KmsKeyId = getEnv("KMS_KEY_ID", "")
+	KmsToken = getEnv("KMS_TOKEN", "")
+	KmsCiphertext = getEnv("KMS_CIPHERTEXT", "")
+	KmsPreviousCiphertexts = splitList(getEnv("KMS_PREVIOUS_CIPHERTEXTS", ""))
 	DatabaseType = DatabaseTypeEnum(getEnv("DATABASE_TYPE", "sqlite"))
 	DatabaseUrl = getEnv("DATABASE_URL", "data.db")
 
-	OidcClientId = getEnv("OIDC_CLIENT_ID", "")
-	OidcClientSecret = getEnv("OIDC_CLIENT_SECRET", "")
-	OidcIssuer = getEnv("OIDC_ISSUER", "")
-	OidcEnabled = OidcClientId != "" || OidcClientSecret != "" || OidcIssuer != ""
+	OidcProviders = loadOidcProviders()
+	OidcEnabled = len(OidcProviders) > 0
+
+	WebAuthnRPID = strings.TrimSpace(getEnv("WEBAUTHN_RP_ID", ""))
+	WebAuthnRPName = getEnv("WEBAUTHN_RP_NAME", "Vault Hub")
+	WebAuthnRPOrigins = splitList(getEnv("WEBAUTHN_RP_ORIGINS", ""))
+	WebAuthnEnabled = WebAuthnRPID != ""
+
+	LdapUrl = strings.TrimSpace(getEnv("LDAP_URL", ""))
+	LdapEnabled = LdapUrl != ""
+	LdapStartTLS = getEnv("LDAP_START_TLS", "false") == "true"
+	LdapCACertFile = getEnv("LDAP_CA_CERT_FILE", "")
+	LdapBindDn = getEnv("LDAP_BIND_DN", "")
+	LdapBindPassword = getEnv("LDAP_BIND_PASSWORD", "")
+	LdapBaseDn = getEnv("LDAP_BASE_DN", "")
+	LdapUserFilter = getEnv("LDAP_USER_FILTER", "(mail={username})")
+	LdapGroupFilter = getEnv("LDAP_GROUP_FILTER", "")
+	LdapEmailAttribute = getEnv("LDAP_EMAIL_ATTRIBUTE", "mail")
+	LdapNameAttribute = getEnv("LDAP_NAME_ATTRIBUTE", "cn")
+	LdapPoolSize, _ = strconv.Atoi(getEnv("LDAP_POOL_SIZE", "5"))
+
+	WorkloadIssuers = splitList(getEnv("WORKLOAD_ISSUERS", ""))
+	WorkloadIdentityEnabled = len(WorkloadIssuers) > 0
 
 	DemoEnabled = getEnv("DEMO_ENABLED", "false") == "true"
 
+	ExpiryReminderDays, _ = strconv.Atoi(getEnv("EXPIRY_REMINDER_DAYS", "7"))
+
+	RateLimitStore = strings.ToLower(strings.TrimSpace(getEnv("RATE_LIMIT_STORE", "memory")))
+	TrustedProxies = splitList(getEnv("TRUSTED_PROXIES", ""))
+
+	AuditForwardUrl = strings.TrimSpace(getEnv("AUDIT_FORWARD_URL", ""))
+	AuditForwardToken = getEnv("AUDIT_FORWARD_TOKEN", "")
+
 	// SMTP
 	rawEmailType := strings.ToUpper(strings.TrimSpace(getEnv("EMAIL_TYPE", "")))
 	switch rawEmailType {
@@ -117,18 +260,74 @@ func init() {
 func printConfig() {
 	slog.Info("Config", "AppPort", AppPort)
 	slog.Info("Config", "JwtSecret", mask(JwtSecret))
-	slog.Info("Config", "EncryptionKey", mask(EncryptionKey))
+	slog.Info("Config", "EncryptionKeyProvider", EncryptionKeyProvider)
+	switch EncryptionKeyProvider {
+	case "env":
+		slog.Info("Config", "EncryptionKey", mask(EncryptionKey))
+	case "file":
+		slog.Info("Config", "EncryptionKeyFile", EncryptionKeyFile)
+		slog.Info("Config", "EncryptionKeyPreviousFiles", EncryptionKeyPreviousFiles)
+	case "pkcs11":
+		slog.Info("Config", "Pkcs11Module", Pkcs11Module)
+		slog.Info("Config", "Pkcs11TokenLabel", Pkcs11TokenLabel)
+		slog.Info("Config", "Pkcs11Pin", mask(Pkcs11Pin))
+		slog.Info("Config", "Pkcs11KeyLabel", Pkcs11KeyLabel)
+		slog.Info("Config", "Pkcs11PreviousKeyLabels", Pkcs11PreviousKeyLabels)
+	case "kms":
+		slog.Info("Config", "KmsUrl", KmsUrl)
+		slog.Info("Config", "KmsKeyId", KmsKeyId)
+		slog.Info("Config", "KmsToken", mask(KmsToken))
+		slog.Info("Config", "KmsPreviousCiphertexts", len(KmsPreviousCiphertexts))
+	}
+	for _, previous := range EncryptionKeyPrevious {
+		slog.Info("Config", "EncryptionKeyPrevious", mask(previous))
+	}
 	slog.Info("Config", "DatabaseType", DatabaseType)
 	slog.Info("Config", "DatabaseUrl", DatabaseUrl)
 	slog.Info("Config", "OidcEnabled", OidcEnabled)
-	if OidcEnabled {
-		slog.Info("Config", "OidcClientId", OidcClientId)
-		slog.Info("Config", "OidcClientSecret", mask(OidcClientSecret))
-		slog.Info("Config", "OidcIssuer", OidcIssuer)
+	for _, provider := range OidcProviders {
+		slog.Info("Config", "OidcProvider", provider.Name,
+			"Issuer", provider.Issuer,
+			"ClientId", provider.ClientId,
+			"ClientSecret", mask(provider.ClientSecret),
+			"GroupsClaim", provider.GroupsClaim,
+			"GroupRoles", len(provider.GroupRoles),
+			"AllowedDomains", provider.AllowedDomains)
+	}
+	slog.Info("Config", "WebAuthnEnabled", WebAuthnEnabled)
+	if WebAuthnEnabled {
+		slog.Info("Config", "WebAuthnRPID", WebAuthnRPID)
+		slog.Info("Config", "WebAuthnRPName", WebAuthnRPName)
+		slog.Info("Config", "WebAuthnRPOrigins", WebAuthnRPOrigins)
+	}
+	slog.Info("Config", "LdapEnabled", LdapEnabled)
+	if LdapEnabled {
+		slog.Info("Config", "LdapUrl", LdapUrl)
+		slog.Info("Config", "LdapStartTLS", LdapStartTLS)
+		slog.Info("Config", "LdapCACertFile", LdapCACertFile)
+		slog.Info("Config", "LdapBindDn", LdapBindDn)
+		slog.Info("Config", "LdapBindPassword", mask(LdapBindPassword))
+		slog.Info("Config", "LdapBaseDn", LdapBaseDn)
+		slog.Info("Config", "LdapUserFilter", LdapUserFilter)
+		slog.Info("Config", "LdapGroupFilter", LdapGroupFilter)
+		slog.Info("Config", "LdapPoolSize", LdapPoolSize)
+	}
+	slog.Info("Config", "WorkloadIdentityEnabled", WorkloadIdentityEnabled)
+	if WorkloadIdentityEnabled {
+		slog.Info("Config", "WorkloadIssuers", WorkloadIssuers)
 	}
 	slog.Info("Config", "DemoEnabled", DemoEnabled)
+	slog.Info("Config", "RateLimitStore", RateLimitStore)
+	if len(TrustedProxies) > 0 {
+		slog.Info("Config", "TrustedProxies", TrustedProxies)
+	}
+	if AuditForwardUrl != "" {
+		slog.Info("Config", "AuditForwardUrl", AuditForwardUrl)
+		slog.Info("Config", "AuditForwardToken", mask(AuditForwardToken))
+	}
 	slog.Info("Config", "EmailEnabled", EmailEnabled)
 	slog.Info("Config", "EmailType", EmailType)
+	slog.Info("Config", "ExpiryReminderDays", ExpiryReminderDays)
 	slog.Info("Config", "SmtpEnabled", SmtpEnabled)
 	if SmtpEnabled {
 		slog.Info("Config", "SmtpHost", SmtpHost)
@@ -150,10 +349,17 @@ func printConfig() {
 func checkConfig() {
 	validations := make([]validation, 0, 16)
 	validations = append(validations, baseValidations()...)
+	validations = append(validations, keyProviderValidations()...)
 	validations = append(validations, oidcValidations()...)
+	validations = append(validations, webAuthnValidations()...)
+	validations = append(validations, ldapValidations()...)
+	validations = append(validations, workloadIdentityValidations()...)
+	validations = append(validations, rateLimitValidations()...)
 	validations = append(validations, emailValidations()...)
+	validations = append(validations, expiryReminderValidations()...)
 	validations = append(validations, smtpValidations()...)
 	validations = append(validations, resendValidations()...)
+	validations = append(validations, auditForwardValidations()...)
 
 	if logValidationErrors(validations) {
 		slog.Error("Config is invalid, exiting")
@@ -164,18 +370,105 @@ func checkConfig() {
 func baseValidations() []validation {
 	return []validation{
 		{ok: JwtSecret != "", msg: "JwtSecret is not set"},
-		{ok: EncryptionKey != "", msg: "EncryptionKey is not set"},
+	}
+}
+
+func keyProviderValidations() []validation {
+	switch EncryptionKeyProvider {
+	case "env":
+		return []validation{
+			{ok: EncryptionKey != "", msg: "EncryptionKey is not set"},
+		}
+	case "file":
+		return []validation{
+			{ok: EncryptionKeyFile != "", msg: "Encryption key file is not set (ENCRYPTION_KEY_FILE)"},
+		}
+	case "pkcs11":
+		return []validation{
+			{ok: Pkcs11Module != "", msg: "PKCS#11 module is not set (PKCS11_MODULE)"},
+			{ok: Pkcs11TokenLabel != "", msg: "PKCS#11 token label is not set (PKCS11_TOKEN_LABEL)"},
+			{ok: Pkcs11Pin != "", msg: "PKCS#11 PIN is not set (PKCS11_PIN)"},
+			{ok: Pkcs11KeyLabel != "", msg: "PKCS#11 key label is not set (PKCS11_KEY_LABEL)"},
+		}
+	case "kms":
+		return []validation{
+			{ok: KmsUrl != "", msg: "KMS URL is not set (KMS_URL)"},
+			{ok: KmsCiphertext != "", msg: "KMS ciphertext is not set (KMS_CIPHERTEXT)"},
+		}
+	default:
+		return []validation{
+			{ok: false, msg: "Encryption key provider is invalid (ENCRYPTION_KEY_PROVIDER). Use env|file|pkcs11|kms"},
+		}
 	}
 }
 
 func oidcValidations() []validation {
-	if !OidcEnabled {
+	var validations []validation
+	for _, provider := range OidcProviders {
+		prefix := oidcEnvPrefix(provider.Name)
+		validations = append(validations,
+			validation{ok: isValidOidcProviderName(provider.Name), msg: "OIDC provider name " + provider.Name + " is invalid (OIDC_PROVIDERS). Use lowercase letters, digits and dashes"},
+			validation{ok: provider.ClientId != "", msg: "OIDC client ID is not set (" + prefix + "CLIENT_ID)"},
+			validation{ok: provider.ClientSecret != "", msg: "OIDC client secret is not set (" + prefix + "CLIENT_SECRET)"},
+			validation{ok: provider.Issuer != "", msg: "OIDC issuer is not set (" + prefix + "ISSUER)"},
+		)
+		for _, mapping := range provider.GroupRoles {
+			validations = append(validations, validation{
+				ok:  mapping.Group != "" && mapping.OrganizationId != 0 && isValidOrganizationRole(mapping.Role),
+				msg: "OIDC group role mapping is invalid (" + prefix + "GROUP_ROLES). Use group=organizationId:owner|admin|member|viewer",
+			})
+		}
+	}
+	return validations
+}
+
+func webAuthnValidations() []validation {
+	if !WebAuthnEnabled {
 		return nil
 	}
+	validOrigins := len(WebAuthnRPOrigins) > 0
+	for _, origin := range WebAuthnRPOrigins {
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			validOrigins = false
+		}
+	}
 	return []validation{
-		{ok: OidcClientId != "", msg: "OidcClientId is not set"},
-		{ok: OidcClientSecret != "", msg: "OidcClientSecret is not set"},
-		{ok: OidcIssuer != "", msg: "OidcIssuer is not set"},
+		{ok: validOrigins, msg: "WebAuthn origins are invalid (WEBAUTHN_RP_ORIGINS). Use a comma separated list of http(s) origins"},
+	}
+}
+
+func ldapValidations() []validation {
+	if !LdapEnabled {
+		return nil
+	}
+	u, err := url.Parse(LdapUrl)
+	validUrl := err == nil && (u.Scheme == "ldap" || u.Scheme == "ldaps") && u.Host != ""
+	return []validation{
+		{ok: validUrl, msg: "LDAP URL is invalid (LDAP_URL). Use ldap://host:port or ldaps://host:port"},
+		{ok: !LdapStartTLS || !validUrl || u.Scheme == "ldap", msg: "LDAP StartTLS needs an ldap:// URL (LDAP_START_TLS)"},
+		{ok: LdapBaseDn != "", msg: "LDAP base DN is not set (LDAP_BASE_DN)"},
+		{ok: strings.Contains(LdapUserFilter, "{username}"), msg: "LDAP user filter must contain {username} (LDAP_USER_FILTER)"},
+		{ok: LdapPoolSize > 0, msg: "LDAP pool size must be a positive number (LDAP_POOL_SIZE)"},
+	}
+}
+
+func workloadIdentityValidations() []validation {
+	validIssuers := true
+	for _, issuer := range WorkloadIssuers {
+		u, err := url.Parse(issuer)
+		if err != nil || u.Host == "" || (u.Scheme != "https" && u.Scheme != "http") {
+			validIssuers = false
+		}
+	}
+	return []validation{
+		{ok: validIssuers, msg: "Workload issuers are invalid (WORKLOAD_ISSUERS). Use a comma separated list of http(s) issuer URLs"},
+	}
+}
+
+func rateLimitValidations() []validation {
+	return []validation{
+		{ok: RateLimitStore == "memory" || RateLimitStore == "database", msg: "Rate limit store is invalid (RATE_LIMIT_STORE). Use memory or database"},
 	}
 }
 
@@ -213,6 +506,30 @@ func resendValidations() []validation {
 	}
 }
 
+func expiryReminderValidations() []validation {
+	return []validation{
+		{ok: ExpiryReminderDays > 0, msg: "Expiry reminder days must be a positive number (EXPIRY_REMINDER_DAYS)"},
+	}
+}
+
+func auditForwardValidations() []validation {
+	if AuditForwardUrl == "" {
+		return nil
+	}
+	u, err := url.Parse(AuditForwardUrl)
+	valid := err == nil && u.Host != ""
+	if valid {
+		switch u.Scheme {
+		case "syslog+tcp", "syslog+udp", "http", "https":
+		default:
+			valid = false
+		}
+	}
+	return []validation{
+		{ok: valid, msg: "Audit forward URL is invalid (AUDIT_FORWARD_URL). Use syslog+tcp://host:port, syslog+udp://host:port or an http(s) URL"},
+	}
+}
+
 func logValidationErrors(validations []validation) bool {
 	hasError := false
 	for _, v := range validations {
@@ -242,6 +559,90 @@ func isValidSmtpMode(mode string) bool {
 	}
 }
 
+// loadOidcProviders reads the default provider from the OIDC_* variables and the providers
+// named in OIDC_PROVIDERS from their OIDC_<NAME>_* variables
+func loadOidcProviders() []OidcProvider {
+	var providers []OidcProvider
+	if getEnv("OIDC_CLIENT_ID", "") != "" || getEnv("OIDC_CLIENT_SECRET", "") != "" || getEnv("OIDC_ISSUER", "") != "" {
+		providers = append(providers, loadOidcProvider(DefaultOidcProvider))
+	}
+	for _, name := range splitList(getEnv("OIDC_PROVIDERS", "")) {
+		name = strings.ToLower(name)
+		if name != DefaultOidcProvider {
+			providers = append(providers, loadOidcProvider(name))
+		}
+	}
+	return providers
+}
+
+// loadOidcProvider reads a provider's variables. The groups claim, group roles and allowed
+// domains fall back to the unprefixed variables, so they can be set once for all providers.
+func loadOidcProvider(name string) OidcProvider {
+	prefix := oidcEnvPrefix(name)
+	displayName := "OIDC"
+	if name != DefaultOidcProvider {
+		displayName = name
+	}
+	return OidcProvider{
+		Name:           name,
+		DisplayName:    getEnv(prefix+"DISPLAY_NAME", displayName),
+		Issuer:         strings.TrimSpace(getEnv(prefix+"ISSUER", "")),
+		ClientId:       getEnv(prefix+"CLIENT_ID", ""),
+		ClientSecret:   getEnv(prefix+"CLIENT_SECRET", ""),
+		GroupsClaim:    getEnv(prefix+"GROUPS_CLAIM", getEnv("OIDC_GROUPS_CLAIM", "groups")),
+		GroupRoles:     parseOidcGroupRoles(getEnv(prefix+"GROUP_ROLES", getEnv("OIDC_GROUP_ROLES", ""))),
+		AllowedDomains: splitList(strings.ToLower(getEnv(prefix+"ALLOWED_DOMAINS", getEnv("OIDC_ALLOWED_DOMAINS", "")))),
+	}
+}
+
+// oidcEnvPrefix returns the prefix of a provider's variables, e.g. OIDC_GITHUB_ for github
+func oidcEnvPrefix(name string) string {
+	if name == DefaultOidcProvider {
+		return "OIDC_"
+	}
+	return "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+}
+
+// parseOidcGroupRoles parses a comma separated list of group=organizationId:role mappings.
+// Malformed entries are kept with zero fields so that validation reports them.
+func parseOidcGroupRoles(value string) []OidcGroupRole {
+	var mappings []OidcGroupRole
+	for _, item := range splitList(value) {
+		var mapping OidcGroupRole
+		if i := strings.LastIndex(item, "="); i > 0 {
+			mapping.Group = strings.TrimSpace(item[:i])
+			orgId, role, _ := strings.Cut(item[i+1:], ":")
+			if id, err := strconv.ParseUint(strings.TrimSpace(orgId), 10, 0); err == nil {
+				mapping.OrganizationId = uint(id)
+			}
+			mapping.Role = strings.ToLower(strings.TrimSpace(role))
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings
+}
+
+func isValidOidcProviderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidOrganizationRole(role string) bool {
+	switch role {
+	case "owner", "admin", "member", "viewer":
+		return true
+	default:
+		return false
+	}
+}
+
 func getEnv(key, fallback string) string {
 	value, exists := os.LookupEnv(key)
 	if !exists {
@@ -250,6 +651,17 @@ func getEnv(key, fallback string) string {
 	return value
 }
 
+// splitList splits a comma separated value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func mask(value string) string {
 	if len(value) <= 4 {
 		return strings.Repeat("*", len(value))
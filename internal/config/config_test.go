@@ -126,3 +126,60 @@ func TestConfigValues(t *testing.T) {
 		}
 	})
 }
+
+func TestLoadOidcProviders(t *testing.T) {
+	t.Setenv("OIDC_ISSUER", "https://sso.example.com")
+	t.Setenv("OIDC_CLIENT_ID", "vault-hub")
+	t.Setenv("OIDC_CLIENT_SECRET", "secret")
+	t.Setenv("OIDC_ALLOWED_DOMAINS", "Example.com, example.org")
+	t.Setenv("OIDC_PROVIDERS", "github-dex")
+	t.Setenv("OIDC_GITHUB_DEX_ISSUER", "https://dex.example.com")
+	t.Setenv("OIDC_GITHUB_DEX_DISPLAY_NAME", "GitHub")
+	t.Setenv("OIDC_GITHUB_DEX_GROUPS_CLAIM", "teams")
+	t.Setenv("OIDC_GITHUB_DEX_GROUP_ROLES", "acme:platform=1:Admin, acme:dev=1:member,broken")
+
+	providers := loadOidcProviders()
+	if len(providers) != 2 {
+		t.Fatalf("loadOidcProviders() = %d providers, want 2", len(providers))
+	}
+
+	def := providers[0]
+	if def.Name != DefaultOidcProvider || def.DisplayName != "OIDC" || def.Issuer != "https://sso.example.com" || def.GroupsClaim != "groups" {
+		t.Errorf("default provider = %+v", def)
+	}
+
+	dex := providers[1]
+	if dex.Name != "github-dex" || dex.DisplayName != "GitHub" || dex.GroupsClaim != "teams" || dex.ClientId != "" {
+		t.Errorf("named provider = %+v", dex)
+	}
+	// Unset provider settings fall back to the unprefixed variables
+	if len(dex.AllowedDomains) != 2 || dex.AllowedDomains[0] != "example.com" {
+		t.Errorf("AllowedDomains = %v, want the lowercased OIDC_ALLOWED_DOMAINS", dex.AllowedDomains)
+	}
+	want := []OidcGroupRole{
+		{Group: "acme:platform", OrganizationId: 1, Role: "admin"},
+		{Group: "acme:dev", OrganizationId: 1, Role: "member"},
+		{},
+	}
+	if len(dex.GroupRoles) != len(want) {
+		t.Fatalf("GroupRoles = %+v, want %+v", dex.GroupRoles, want)
+	}
+	for i := range want {
+		if dex.GroupRoles[i] != want[i] {
+			t.Errorf("GroupRoles[%d] = %+v, want %+v", i, dex.GroupRoles[i], want[i])
+		}
+	}
+
+	OidcProviders = providers
+	defer func() { OidcProviders = nil }()
+	var failed []string
+	for _, v := range oidcValidations() {
+		if !v.ok {
+			failed = append(failed, v.msg)
+		}
+	}
+	// The named provider lacks a client ID and secret, and one mapping is malformed
+	if len(failed) != 3 {
+		t.Errorf("oidcValidations() failures = %v, want 3", failed)
+	}
+}
@@ -0,0 +1,225 @@
+// Package dynamic issues short-lived database users for database vaults and drops them
+// once their lease expires.
+package dynamic
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lwshen/vault-hub/internal/rotation"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+const (
+	// connectTimeout bounds opening the admin connection and running the statements for
+	// one user
+	connectTimeout = 15 * time.Second
+	// passwordLength is the length of issued passwords
+	passwordLength = 32
+	// usernameCharset and passwordCharset need no quoting in SQL statements
+	usernameCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+	passwordCharset = model.DefaultRotationCharset
+)
+
+// ErrConnect is returned when the database of a vault cannot be reached
+var ErrConnect = errors.New("failed to connect to database")
+
+// Default statements of each engine. MySQL has no expiring users; its users are only
+// dropped by the reaper.
+var (
+	defaultCreationStatements = map[model.DatabaseEngine][]string{
+		model.DatabaseEnginePostgres: {`CREATE ROLE "{{name}}" WITH LOGIN PASSWORD '{{password}}' VALID UNTIL '{{expiration}}'`},
+		model.DatabaseEngineMySQL:    {`CREATE USER '{{name}}'@'%' IDENTIFIED BY '{{password}}'`},
+	}
+	defaultRevocationStatements = map[model.DatabaseEngine][]string{
+		model.DatabaseEnginePostgres: {
+			`DO $$ BEGIN IF EXISTS (SELECT FROM pg_roles WHERE rolname = '{{name}}') THEN EXECUTE 'DROP OWNED BY "{{name}}"'; END IF; END $$`,
+			`DROP ROLE IF EXISTS "{{name}}"`,
+		},
+		model.DatabaseEngineMySQL: {`DROP USER IF EXISTS '{{name}}'@'%'`},
+	}
+)
+
+// Credentials are the database user issued on a read of a database vault. They are
+// returned as the vault's value.
+type Credentials struct {
+	LeaseID   string    `json:"leaseId"`
+	Username  string    `json:"username"`
+	Password  string    `json:"password"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Engine runs statements against the database of a vault
+type Engine interface {
+	Exec(ctx context.Context, statement string) error
+	Close() error
+}
+
+// sqlEngine is an Engine backed by a database/sql connection
+type sqlEngine struct {
+	db *sql.DB
+}
+
+func (e *sqlEngine) Exec(ctx context.Context, statement string) error {
+	_, err := e.db.ExecContext(ctx, statement)
+	return err
+}
+
+func (e *sqlEngine) Close() error {
+	return e.db.Close()
+}
+
+// Open connects to the database of a vault as its administrator. It is a variable so
+// tests can stand in for a database.
+var Open = func(config *model.DatabaseConfig) (Engine, error) {
+	var dialector gorm.Dialector
+	switch config.Engine {
+	case model.DatabaseEnginePostgres:
+		dialector = postgres.Open(config.URL)
+	case model.DatabaseEngineMySQL:
+		dialector = mysql.Open(config.URL)
+	default:
+		return nil, fmt.Errorf("unsupported database engine %q", config.Engine)
+	}
+
+	db, err := gorm.Open(dialector, &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConnect, err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrConnect, err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	return &sqlEngine{db: sqlDB}, nil
+}
+
+// Issue creates a database user for a read of the vault, whose value must be its decrypted
+// config. The lease is recorded first, so the reaper drops the user even if creating it
+// failed halfway.
+func Issue(ctx context.Context, vault *model.Vault, userID uint, apiKeyID *uint) (*Credentials, error) {
+	config, err := model.ParseDatabaseConfig(vault.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	engine, err := Open(config)
+	if err != nil {
+		return nil, err
+	}
+	defer engine.Close()
+
+	username, err := Username(vault.ID)
+	if err != nil {
+		return nil, err
+	}
+	password, err := rotation.Generate(passwordCharset, passwordLength)
+	if err != nil {
+		return nil, err
+	}
+
+	lease, err := model.CreateDatabaseLease(vault, config.Engine, username, config.LeaseTTL(), userID, apiKeyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record lease: %w", err)
+	}
+
+	for _, statement := range CreationStatements(config, username, password, lease.ExpiresAt) {
+		if err := engine.Exec(ctx, statement); err != nil {
+			// Leave nothing behind if possible; the reaper retries at expiry otherwise
+			if revokeErr := revoke(ctx, engine, config, username); revokeErr == nil {
+				_ = lease.RecordRevoked()
+			}
+			return nil, fmt.Errorf("failed to create database user: %w", err)
+		}
+	}
+
+	return &Credentials{
+		LeaseID:   lease.LeaseID,
+		Username:  username,
+		Password:  password,
+		ExpiresAt: lease.ExpiresAt,
+	}, nil
+}
+
+// Revoke drops the user of a lease using the current config of its vault
+func Revoke(ctx context.Context, lease *model.DatabaseLease) error {
+	config, err := lease.DatabaseConfig()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, connectTimeout)
+	defer cancel()
+
+	engine, err := Open(config)
+	if err != nil {
+		return err
+	}
+	defer engine.Close()
+
+	return revoke(ctx, engine, config, lease.Username)
+}
+
+// revoke runs the revocation statements for a user
+func revoke(ctx context.Context, engine Engine, config *model.DatabaseConfig, username string) error {
+	for _, statement := range RevocationStatements(config, username) {
+		if err := engine.Exec(ctx, statement); err != nil {
+			return fmt.Errorf("failed to drop database user: %w", err)
+		}
+	}
+	return nil
+}
+
+// Username returns a new username for a user of the vault. It fits MySQL's limit of 32
+// characters and names the vault, so leftover users can be traced back to it.
+func Username(vaultID uint) (string, error) {
+	suffix, err := rotation.Generate(usernameCharset, 12)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("vh_%08x_%s", vaultID, suffix), nil
+}
+
+// CreationStatements returns the statements creating a user, with their placeholders filled
+func CreationStatements(config *model.DatabaseConfig, username, password string, expiresAt time.Time) []string {
+	statements := config.CreationStatements
+	if len(statements) == 0 {
+		statements = defaultCreationStatements[config.Engine]
+	}
+	return render(statements, username, password, expiresAt)
+}
+
+// RevocationStatements returns the statements dropping a user, with their placeholders filled
+func RevocationStatements(config *model.DatabaseConfig, username string) []string {
+	statements := config.RevocationStatements
+	if len(statements) == 0 {
+		statements = defaultRevocationStatements[config.Engine]
+	}
+	return render(statements, username, "", time.Time{})
+}
+
+// render fills the placeholders of statements. Usernames and passwords are generated from
+// charsets that are safe inside quoted SQL identifiers and strings.
+func render(statements []string, username, password string, expiresAt time.Time) []string {
+	replacer := strings.NewReplacer(
+		"{{name}}", username,
+		"{{password}}", password,
+		"{{expiration}}", expiresAt.UTC().Format("2006-01-02 15:04:05Z07:00"),
+	)
+	rendered := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		rendered = append(rendered, replacer.Replace(statement))
+	}
+	return rendered
+}
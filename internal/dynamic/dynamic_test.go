@@ -0,0 +1,48 @@
+package dynamic
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lwshen/vault-hub/model"
+)
+
+func TestUsername(t *testing.T) {
+	username, err := Username(42)
+	if err != nil {
+		t.Fatalf("Username() error = %v", err)
+	}
+	if !strings.HasPrefix(username, "vh_0000002a_") || len(username) > 32 {
+		t.Errorf("Username() = %q, want the vault ID and at most 32 characters", username)
+	}
+	if other, _ := Username(42); other == username {
+		t.Error("Username() should return a new name every time")
+	}
+}
+
+func TestStatements(t *testing.T) {
+	expiresAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	postgres := &model.DatabaseConfig{Engine: model.DatabaseEnginePostgres}
+	created := CreationStatements(postgres, "vh_user", "pw", expiresAt)
+	if len(created) != 1 || created[0] != `CREATE ROLE "vh_user" WITH LOGIN PASSWORD 'pw' VALID UNTIL '2026-01-02 03:04:05Z'` {
+		t.Errorf("CreationStatements() = %q", created)
+	}
+	revoked := RevocationStatements(postgres, "vh_user")
+	if len(revoked) != 2 || revoked[1] != `DROP ROLE IF EXISTS "vh_user"` {
+		t.Errorf("RevocationStatements() = %q", revoked)
+	}
+
+	mysql := &model.DatabaseConfig{
+		Engine:             model.DatabaseEngineMySQL,
+		CreationStatements: []string{"CREATE USER '{{name}}'@'%' IDENTIFIED BY '{{password}}'", "GRANT SELECT ON app.* TO '{{name}}'@'%'"},
+	}
+	created = CreationStatements(mysql, "vh_user", "pw", expiresAt)
+	if len(created) != 2 || created[1] != "GRANT SELECT ON app.* TO 'vh_user'@'%'" {
+		t.Errorf("CreationStatements() with custom statements = %q", created)
+	}
+	if revoked := RevocationStatements(mysql, "vh_user"); len(revoked) != 1 || revoked[0] != "DROP USER IF EXISTS 'vh_user'@'%'" {
+		t.Errorf("RevocationStatements() = %q", revoked)
+	}
+}
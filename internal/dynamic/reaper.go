@@ -0,0 +1,83 @@
+package dynamic
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/lwshen/vault-hub/model"
+)
+
+const (
+	// reapInterval is how often expired leases are looked for
+	reapInterval = time.Minute
+	// reapBatchSize is the number of leases claimed at a time
+	reapBatchSize = 50
+)
+
+// Reaper drops the database users of expired leases. Several servers may run one against
+// the same database; each lease is claimed by one of them.
+type Reaper struct {
+	logger *slog.Logger
+}
+
+// NewReaper returns a reaper that logs to the given logger
+func NewReaper(logger *slog.Logger) *Reaper {
+	return &Reaper{logger: logger}
+}
+
+// Run revokes expired leases until ctx is done
+func (r *Reaper) Run(ctx context.Context) {
+	poll := time.NewTicker(reapInterval)
+	defer poll.Stop()
+
+	for {
+		r.reapExpired(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		}
+	}
+}
+
+// reapExpired revokes claimed batches until nothing is expired
+func (r *Reaper) reapExpired(ctx context.Context) {
+	for ctx.Err() == nil {
+		leases, err := model.ClaimExpiredDatabaseLeases(reapBatchSize)
+		if err != nil {
+			r.logger.Error("Failed to claim expired database leases", "error", err)
+			return
+		}
+
+		for i := range leases {
+			r.reap(ctx, &leases[i])
+		}
+
+		if len(leases) < reapBatchSize {
+			return
+		}
+	}
+}
+
+// reap drops the user of a lease and records the result
+func (r *Reaper) reap(ctx context.Context, lease *model.DatabaseLease) {
+	if err := Revoke(ctx, lease); err != nil {
+		if recordErr := lease.RecordRevokeFailure(err.Error()); recordErr != nil {
+			r.logger.Error("Failed to record database lease revocation", "error", recordErr, "leaseID", lease.LeaseID)
+		}
+		r.logger.Warn("Failed to revoke database lease, retrying", "leaseID", lease.LeaseID, "vaultID", lease.VaultID,
+			"attempt", lease.Attempts, "nextAttemptAt", lease.NextAttemptAt, "error", err)
+		return
+	}
+
+	if err := lease.RecordRevoked(); err != nil {
+		r.logger.Error("Failed to record database lease revocation", "error", err, "leaseID", lease.LeaseID)
+		return
+	}
+	if err := model.LogVaultAction(lease.VaultID, model.ActionRevokeDatabaseCredentials, lease.UserID, model.SourceSystem, lease.APIKeyID, "", ""); err != nil {
+		r.logger.Error("Failed to create audit log for database lease revocation", "error", err, "vaultID", lease.VaultID)
+	}
+	r.logger.Info("Revoked database lease", "leaseID", lease.LeaseID, "vaultID", lease.VaultID, "username", lease.Username)
+}
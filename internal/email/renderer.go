@@ -11,6 +11,10 @@ type TemplateData struct {
 	UserName  string
 	ActionURL string
 	TTL       string
+	VaultName string
+	ExpiresAt string
+	Expired   bool
+	Error     string
 }
 
 func renderTemplate(name string, data TemplateData) (string, error) {
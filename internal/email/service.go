@@ -2,6 +2,7 @@ package email
 
 import (
 	"fmt"
+	"time"
 )
 
 type Service struct {
@@ -55,3 +56,39 @@ func (s *Service) SendMagicLink(to, userName, actionURL, ttl string) error {
 	}
 	return s.sender.Send(to, data.Subject, body)
 }
+
+func (s *Service) SendVaultExpiryReminder(to, userName, vaultName string, expiresAt time.Time) error {
+	expired := !time.Now().Before(expiresAt)
+	subject := fmt.Sprintf("Vault %q is due for rotation", vaultName)
+	if expired {
+		subject = fmt.Sprintf("Vault %q is overdue for rotation", vaultName)
+	}
+	data := TemplateData{
+		Subject:   subject,
+		AppName:   s.appName,
+		UserName:  userName,
+		VaultName: vaultName,
+		ExpiresAt: expiresAt.UTC().Format("January 2, 2006 at 15:04 MST"),
+		Expired:   expired,
+	}
+	body, err := renderTemplate("vault_expiry_reminder.html.tmpl", data)
+	if err != nil {
+		return err
+	}
+	return s.sender.Send(to, data.Subject, body)
+}
+
+func (s *Service) SendVaultRotationFailed(to, userName, vaultName, reason string) error {
+	data := TemplateData{
+		Subject:   fmt.Sprintf("Rotation of vault %q failed", vaultName),
+		AppName:   s.appName,
+		UserName:  userName,
+		VaultName: vaultName,
+		Error:     reason,
+	}
+	body, err := renderTemplate("vault_rotation_failed.html.tmpl", data)
+	if err != nil {
+		return err
+	}
+	return s.sender.Send(to, data.Subject, body)
+}
@@ -1,83 +1,39 @@
 package encryption
 
-import (
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
-	"crypto/sha256"
-	"encoding/base64"
-	"fmt"
-	"io"
+import "strings"
 
-	"github.com/lwshen/vault-hub/internal/config"
-)
-
-// deriveKey derives a 32-byte key from the encryption key using SHA-256
-func deriveKey() []byte {
-	hash := sha256.Sum256([]byte(config.EncryptionKey))
-	return hash[:]
-}
-
-// Encrypt encrypts plaintext using AES-256-GCM and returns base64 encoded result
+// Encrypt encrypts plaintext using AES-256-GCM with the active master key.
+// The result is tagged with the key ID so it can still be decrypted after the master key is rotated.
 func Encrypt(plaintext string) (string, error) {
 	if plaintext == "" {
 		return "", nil
 	}
 
-	key := deriveKey()
-
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
-	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
-	}
-
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", fmt.Errorf("failed to generate nonce: %w", err)
-	}
-
-	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	return sealWithMasterKey(masterKeys()[0], []byte(plaintext))
 }
 
-// Decrypt decrypts base64 encoded ciphertext using AES-256-GCM
+// Decrypt decrypts ciphertext produced by Encrypt.
+// Untagged values from before key IDs existed are tried against every configured master key.
 func Decrypt(ciphertext string) (string, error) {
 	if ciphertext == "" {
 		return "", nil
 	}
 
-	key := deriveKey()
-
-	data, err := base64.StdEncoding.DecodeString(ciphertext)
-	if err != nil {
-		return "", fmt.Errorf("failed to decode base64: %w", err)
+	if strings.HasPrefix(ciphertext, kekPrefix) {
+		plaintext, err := openWithMasterKey(ciphertext)
+		if err != nil {
+			return "", err
+		}
+		return string(plaintext), nil
 	}
 
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
+	var lastErr error
+	for _, k := range masterKeys() {
+		plaintext, err := open(k.key, ciphertext, nil)
+		if err == nil {
+			return string(plaintext), nil
+		}
+		lastErr = err
 	}
-
-	gcm, err := cipher.NewGCM(block)
-	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
-	}
-
-	nonceSize := gcm.NonceSize()
-	if len(data) < nonceSize {
-		return "", fmt.Errorf("ciphertext too short")
-	}
-
-	nonce, ciphertext_bytes := data[:nonceSize], data[nonceSize:]
-	plaintext, err := gcm.Open(nil, nonce, ciphertext_bytes, nil)
-	if err != nil {
-		return "", fmt.Errorf("failed to decrypt: %w", err)
-	}
-
-	return string(plaintext), nil
+	return "", lastErr
 }
@@ -0,0 +1,188 @@
+package encryption
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/lwshen/vault-hub/internal/config"
+)
+
+// Supported master key providers
+const (
+	KeyProviderEnv    = "env"
+	KeyProviderFile   = "file"
+	KeyProviderPKCS11 = "pkcs11"
+	KeyProviderKMS    = "kms"
+)
+
+// KeyProvider supplies the material of the active master key and of retired master keys
+type KeyProvider interface {
+	// MasterKey returns the raw key material. It is called once when the provider is loaded.
+	MasterKey(ctx context.Context) ([]byte, error)
+	// PreviousKeys returns the raw material of retired master keys that are still accepted for
+	// decryption while a key rotation is in progress. It is called once when the provider is loaded.
+	PreviousKeys(ctx context.Context) ([][]byte, error)
+}
+
+var (
+	providerMu       sync.RWMutex
+	providerKey      []byte
+	providerPrevious [][]byte
+	providerLoaded   bool
+)
+
+// NewKeyProvider returns the key provider selected by ENCRYPTION_KEY_PROVIDER
+func NewKeyProvider() (KeyProvider, error) {
+	switch config.EncryptionKeyProvider {
+	case KeyProviderEnv:
+		return EnvKeyProvider{Variable: "ENCRYPTION_KEY"}, nil
+	case KeyProviderFile:
+		return FileKeyProvider{Path: config.EncryptionKeyFile, PreviousPaths: config.EncryptionKeyPreviousFiles}, nil
+	case KeyProviderPKCS11:
+		return &PKCS11KeyProvider{
+			Module:            config.Pkcs11Module,
+			TokenLabel:        config.Pkcs11TokenLabel,
+			PIN:               config.Pkcs11Pin,
+			KeyLabel:          config.Pkcs11KeyLabel,
+			PreviousKeyLabels: config.Pkcs11PreviousKeyLabels,
+		}, nil
+	case KeyProviderKMS:
+		return &KMSKeyProvider{
+			URL:                 config.KmsUrl,
+			KeyID:               config.KmsKeyId,
+			Token:               config.KmsToken,
+			Ciphertext:          config.KmsCiphertext,
+			PreviousCiphertexts: config.KmsPreviousCiphertexts,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key provider: %s", config.EncryptionKeyProvider)
+	}
+}
+
+// LoadKeyProvider fetches the master key and the previous keys from a provider and uses them for
+// all further encryption. Until a provider is loaded, the master key is read from config.EncryptionKey.
+func LoadKeyProvider(ctx context.Context, provider KeyProvider) error {
+	material, err := provider.MasterKey(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load master key: %w", err)
+	}
+	if len(material) == 0 {
+		return fmt.Errorf("failed to load master key: key is empty")
+	}
+
+	previous, err := provider.PreviousKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load previous master keys: %w", err)
+	}
+	for _, key := range previous {
+		if len(key) == 0 {
+			return fmt.Errorf("failed to load previous master keys: key is empty")
+		}
+	}
+
+	providerMu.Lock()
+	defer providerMu.Unlock()
+	providerKey = material
+	providerPrevious = previous
+	providerLoaded = true
+	return nil
+}
+
+// activeKeyMaterial returns the material of the active master key
+func activeKeyMaterial() []byte {
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+	if providerLoaded {
+		return providerKey
+	}
+	return []byte(config.EncryptionKey)
+}
+
+// previousKeyMaterial returns the material of the previous master keys supplied by the loaded
+// provider, followed by those of ENCRYPTION_KEY_PREVIOUS, which are accepted with every provider
+func previousKeyMaterial() [][]byte {
+	providerMu.RLock()
+	materials := append([][]byte(nil), providerPrevious...)
+	providerMu.RUnlock()
+
+	for _, previous := range config.EncryptionKeyPrevious {
+		materials = append(materials, []byte(previous))
+	}
+	return materials
+}
+
+// EnvKeyProvider reads the master key from an environment variable
+type EnvKeyProvider struct {
+	Variable string
+}
+
+// MasterKey implements KeyProvider
+func (p EnvKeyProvider) MasterKey(_ context.Context) ([]byte, error) {
+	value, ok := os.LookupEnv(p.Variable)
+	if !ok || value == "" {
+		return nil, fmt.Errorf("environment variable %s is not set", p.Variable)
+	}
+	return []byte(value), nil
+}
+
+// PreviousKeys implements KeyProvider. The previous keys of this provider are the ones in
+// ENCRYPTION_KEY_PREVIOUS, which are accepted with every provider.
+func (p EnvKeyProvider) PreviousKeys(_ context.Context) ([][]byte, error) {
+	return nil, nil
+}
+
+// FileKeyProvider reads the master key from a file that only its owner may access.
+// Retired keys are read from PreviousPaths, each subject to the same checks.
+type FileKeyProvider struct {
+	Path          string
+	PreviousPaths []string
+}
+
+// MasterKey implements KeyProvider
+func (p FileKeyProvider) MasterKey(_ context.Context) ([]byte, error) {
+	return readKeyFile(p.Path)
+}
+
+// PreviousKeys implements KeyProvider
+func (p FileKeyProvider) PreviousKeys(_ context.Context) ([][]byte, error) {
+	var keys [][]byte
+	for _, path := range p.PreviousPaths {
+		key, err := readKeyFile(path)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// readKeyFile reads a key from a regular file that only its owner may access
+func readKeyFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat key file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("key file %s is not a regular file", path)
+	}
+	// Windows does not report POSIX permission bits
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("key file %s must not be accessible by group or others (mode %04o, want 0600 or 0400)", path, info.Mode().Perm())
+	}
+
+	// #nosec G304 -- the path comes from the server configuration
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	key := strings.TrimRight(string(data), "\r\n")
+	if key == "" {
+		return nil, fmt.Errorf("key file %s is empty", path)
+	}
+	return []byte(key), nil
+}
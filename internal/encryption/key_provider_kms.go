@@ -0,0 +1,109 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// KMSKeyProvider obtains the master key by asking an HTTP key management service to decrypt it.
+// The master key is stored encrypted (Ciphertext) and only the KMS holds the key that can open it.
+//
+// The service is called with POST <URL> and a JSON body {"key_id": ..., "ciphertext": ...},
+// and must answer with {"plaintext": ...}. Ciphertext and plaintext are base64 encoded.
+//
+// Retired keys are given in PreviousCiphertexts, each optionally prefixed with "<key id>:" when
+// it was encrypted by another KMS key than KeyID.
+type KMSKeyProvider struct {
+	URL                 string
+	KeyID               string
+	Token               string // Sent as a bearer token when set
+	Ciphertext          string
+	PreviousCiphertexts []string
+	Client              *http.Client
+}
+
+type kmsDecryptRequest struct {
+	KeyID      string `json:"key_id"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+type kmsDecryptResponse struct {
+	Plaintext string `json:"plaintext"`
+}
+
+// MasterKey implements KeyProvider
+func (p *KMSKeyProvider) MasterKey(ctx context.Context) ([]byte, error) {
+	return p.decrypt(ctx, p.KeyID, p.Ciphertext)
+}
+
+// PreviousKeys implements KeyProvider
+func (p *KMSKeyProvider) PreviousKeys(ctx context.Context) ([][]byte, error) {
+	var keys [][]byte
+	for _, previous := range p.PreviousCiphertexts {
+		keyID, ciphertext := p.KeyID, previous
+		// Base64 has no colon, so the last one separates a key ID that may contain colons itself
+		if i := strings.LastIndex(previous, ":"); i >= 0 {
+			keyID, ciphertext = previous[:i], previous[i+1:]
+		}
+		key, err := p.decrypt(ctx, keyID, ciphertext)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// decrypt asks the KMS to decrypt a ciphertext with one of its keys
+func (p *KMSKeyProvider) decrypt(ctx context.Context, keyID, ciphertext string) ([]byte, error) {
+	body, err := json.Marshal(kmsDecryptRequest{KeyID: keyID, Ciphertext: ciphertext})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create KMS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.Token)
+	}
+
+	client := p.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("KMS request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KMS response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("KMS returned status %d", resp.StatusCode)
+	}
+
+	var decrypted kmsDecryptResponse
+	if err := json.Unmarshal(data, &decrypted); err != nil {
+		return nil, fmt.Errorf("invalid KMS response: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(decrypted.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KMS plaintext: %w", err)
+	}
+	return key, nil
+}
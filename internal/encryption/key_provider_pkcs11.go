@@ -0,0 +1,131 @@
+//go:build cgo
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/miekg/pkcs11"
+)
+
+// pkcs11DeriveLabel is signed with the token key to derive the master key
+const pkcs11DeriveLabel = "vault-hub master key"
+
+// PKCS11KeyProvider derives the master key from a secret key held in a PKCS#11 token.
+// The token key never leaves the device: the master key is an HMAC-SHA256 computed by the token,
+// so the key must be a generic secret that allows CKM_SHA256_HMAC signing.
+// Retired master keys are derived the same way from the token keys in PreviousKeyLabels.
+type PKCS11KeyProvider struct {
+	Module            string // Path to the PKCS#11 library, e.g. /usr/lib/softhsm/libsofthsm2.so
+	TokenLabel        string
+	PIN               string
+	KeyLabel          string
+	PreviousKeyLabels []string
+}
+
+// MasterKey implements KeyProvider
+func (p *PKCS11KeyProvider) MasterKey(_ context.Context) ([]byte, error) {
+	keys, err := p.deriveKeys([]string{p.KeyLabel})
+	if err != nil {
+		return nil, err
+	}
+	return keys[0], nil
+}
+
+// PreviousKeys implements KeyProvider
+func (p *PKCS11KeyProvider) PreviousKeys(_ context.Context) ([][]byte, error) {
+	if len(p.PreviousKeyLabels) == 0 {
+		return nil, nil
+	}
+	return p.deriveKeys(p.PreviousKeyLabels)
+}
+
+// deriveKeys derives one master key from each token key with the given labels, in a single session
+func (p *PKCS11KeyProvider) deriveKeys(labels []string) ([][]byte, error) {
+	ctx := pkcs11.New(p.Module)
+	if ctx == nil {
+		return nil, fmt.Errorf("failed to load PKCS#11 module %s", p.Module)
+	}
+	defer ctx.Destroy()
+
+	if err := ctx.Initialize(); err != nil {
+		return nil, fmt.Errorf("failed to initialize PKCS#11 module: %w", err)
+	}
+	defer func() { _ = ctx.Finalize() }()
+
+	slot, err := p.findSlot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PKCS#11 session: %w", err)
+	}
+	defer func() { _ = ctx.CloseSession(session) }()
+
+	if err := ctx.Login(session, pkcs11.CKU_USER, p.PIN); err != nil {
+		return nil, fmt.Errorf("failed to log in to PKCS#11 token: %w", err)
+	}
+	defer func() { _ = ctx.Logout(session) }()
+
+	var materials [][]byte
+	for _, label := range labels {
+		key, err := p.findKey(ctx, session, label)
+		if err != nil {
+			return nil, err
+		}
+
+		mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_SHA256_HMAC, nil)}
+		if err := ctx.SignInit(session, mechanism, key); err != nil {
+			return nil, fmt.Errorf("failed to initialize PKCS#11 HMAC: %w", err)
+		}
+		material, err := ctx.Sign(session, []byte(pkcs11DeriveLabel))
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive master key with PKCS#11 key %q: %w", label, err)
+		}
+		materials = append(materials, material)
+	}
+
+	return materials, nil
+}
+
+// findSlot returns the slot holding the token with the configured label
+func (p *PKCS11KeyProvider) findSlot(ctx *pkcs11.Ctx) (uint, error) {
+	slots, err := ctx.GetSlotList(true)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list PKCS#11 slots: %w", err)
+	}
+
+	for _, slot := range slots {
+		info, err := ctx.GetTokenInfo(slot)
+		if err != nil {
+			continue
+		}
+		if info.Label == p.TokenLabel {
+			return slot, nil
+		}
+	}
+	return 0, fmt.Errorf("PKCS#11 token %q not found", p.TokenLabel)
+}
+
+// findKey returns the secret key with the given label
+func (p *PKCS11KeyProvider) findKey(ctx *pkcs11.Ctx, session pkcs11.SessionHandle, label string) (pkcs11.ObjectHandle, error) {
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, label),
+	}
+	if err := ctx.FindObjectsInit(session, template); err != nil {
+		return 0, fmt.Errorf("failed to search PKCS#11 token: %w", err)
+	}
+	objects, _, err := ctx.FindObjects(session, 1)
+	_ = ctx.FindObjectsFinal(session)
+	if err != nil {
+		return 0, fmt.Errorf("failed to search PKCS#11 token: %w", err)
+	}
+	if len(objects) == 0 {
+		return 0, fmt.Errorf("PKCS#11 key %q not found", label)
+	}
+	return objects[0], nil
+}
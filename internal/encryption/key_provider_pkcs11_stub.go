@@ -0,0 +1,28 @@
+//go:build !cgo
+
+package encryption
+
+import (
+	"context"
+	"fmt"
+)
+
+// PKCS11KeyProvider derives the master key from a secret key held in a PKCS#11 token.
+// This build was compiled without cgo, which PKCS#11 support requires.
+type PKCS11KeyProvider struct {
+	Module            string
+	TokenLabel        string
+	PIN               string
+	KeyLabel          string
+	PreviousKeyLabels []string
+}
+
+// MasterKey implements KeyProvider
+func (p *PKCS11KeyProvider) MasterKey(_ context.Context) ([]byte, error) {
+	return nil, fmt.Errorf("PKCS#11 key provider is not available: server was built without cgo")
+}
+
+// PreviousKeys implements KeyProvider
+func (p *PKCS11KeyProvider) PreviousKeys(_ context.Context) ([][]byte, error) {
+	return nil, fmt.Errorf("PKCS#11 key provider is not available: server was built without cgo")
+}
@@ -0,0 +1,143 @@
+//go:build cgo
+
+package encryption
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/miekg/pkcs11"
+)
+
+// softHSMModule locates the SoftHSM library, honouring SOFTHSM2_MODULE
+func softHSMModule() string {
+	candidates := []string{
+		os.Getenv("SOFTHSM2_MODULE"),
+		"/usr/lib/softhsm/libsofthsm2.so",
+		"/usr/lib/x86_64-linux-gnu/softhsm/libsofthsm2.so",
+		"/usr/lib64/pkcs11/libsofthsm2.so",
+		"/usr/local/lib/softhsm/libsofthsm2.so",
+		"/opt/homebrew/lib/softhsm/libsofthsm2.so",
+	}
+	for _, candidate := range candidates {
+		if candidate == "" {
+			continue
+		}
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// setupSoftHSMToken initializes a throwaway SoftHSM token holding an HMAC key
+func setupSoftHSMToken(t *testing.T, module, tokenLabel, pin, keyLabel string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	conf := filepath.Join(dir, "softhsm2.conf")
+	tokens := filepath.Join(dir, "tokens")
+	if err := os.Mkdir(tokens, 0o700); err != nil {
+		t.Fatalf("Mkdir() error = %v", err)
+	}
+	if err := os.WriteFile(conf, []byte("directories.tokendir = "+tokens+"\nobjectstore.backend = file\n"), 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	t.Setenv("SOFTHSM2_CONF", conf)
+
+	// #nosec G204 -- test helper with fixed arguments
+	out, err := exec.Command("softhsm2-util", "--init-token", "--free", "--label", tokenLabel, "--pin", pin, "--so-pin", "so-"+pin).CombinedOutput()
+	if err != nil {
+		t.Fatalf("softhsm2-util --init-token failed: %v: %s", err, out)
+	}
+
+	ctx := pkcs11.New(module)
+	if err := ctx.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	defer func() {
+		_ = ctx.Finalize()
+		ctx.Destroy()
+	}()
+
+	provider := &PKCS11KeyProvider{TokenLabel: tokenLabel}
+	slot, err := provider.findSlot(ctx)
+	if err != nil {
+		t.Fatalf("findSlot() error = %v", err)
+	}
+	session, err := ctx.OpenSession(slot, pkcs11.CKF_SERIAL_SESSION|pkcs11.CKF_RW_SESSION)
+	if err != nil {
+		t.Fatalf("OpenSession() error = %v", err)
+	}
+	defer func() { _ = ctx.CloseSession(session) }()
+	if err := ctx.Login(session, pkcs11.CKU_USER, pin); err != nil {
+		t.Fatalf("Login() error = %v", err)
+	}
+
+	template := []*pkcs11.Attribute{
+		pkcs11.NewAttribute(pkcs11.CKA_CLASS, pkcs11.CKO_SECRET_KEY),
+		pkcs11.NewAttribute(pkcs11.CKA_KEY_TYPE, pkcs11.CKK_GENERIC_SECRET),
+		pkcs11.NewAttribute(pkcs11.CKA_VALUE_LEN, 32),
+		pkcs11.NewAttribute(pkcs11.CKA_LABEL, keyLabel),
+		pkcs11.NewAttribute(pkcs11.CKA_TOKEN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SIGN, true),
+		pkcs11.NewAttribute(pkcs11.CKA_SENSITIVE, true),
+		pkcs11.NewAttribute(pkcs11.CKA_EXTRACTABLE, false),
+	}
+	mechanism := []*pkcs11.Mechanism{pkcs11.NewMechanism(pkcs11.CKM_GENERIC_SECRET_KEY_GEN, nil)}
+	if _, err := ctx.GenerateKey(session, mechanism, template); err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+}
+
+func TestPKCS11KeyProviderWithSoftHSM(t *testing.T) {
+	module := softHSMModule()
+	if module == "" {
+		t.Skip("SoftHSM not installed; set SOFTHSM2_MODULE to run this test")
+	}
+	if _, err := exec.LookPath("softhsm2-util"); err != nil {
+		t.Skip("softhsm2-util not found")
+	}
+
+	setupSoftHSMToken(t, module, "vault-hub-test", "1234", "vault-hub-master")
+
+	provider := &PKCS11KeyProvider{Module: module, TokenLabel: "vault-hub-test", PIN: "1234", KeyLabel: "vault-hub-master"}
+	first, err := provider.MasterKey(context.Background())
+	if err != nil {
+		t.Fatalf("MasterKey() error = %v", err)
+	}
+	if len(first) != 32 {
+		t.Fatalf("MasterKey() length = %d, want 32", len(first))
+	}
+
+	// The derived key must be stable across sessions
+	second, err := provider.MasterKey(context.Background())
+	if err != nil {
+		t.Fatalf("MasterKey() second call error = %v", err)
+	}
+	if string(first) != string(second) {
+		t.Error("MasterKey() is not deterministic")
+	}
+
+	wrongPIN := &PKCS11KeyProvider{Module: module, TokenLabel: "vault-hub-test", PIN: "0000", KeyLabel: "vault-hub-master"}
+	if _, err := wrongPIN.MasterKey(context.Background()); err == nil {
+		t.Error("MasterKey() with a wrong PIN should fail")
+	}
+
+	missingKey := &PKCS11KeyProvider{Module: module, TokenLabel: "vault-hub-test", PIN: "1234", KeyLabel: "missing"}
+	if _, err := missingKey.MasterKey(context.Background()); err == nil {
+		t.Error("MasterKey() with a missing key should fail")
+	}
+
+	loadTestKeyProvider(t, provider)
+	ciphertext, err := Encrypt("hsm protected")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	if plaintext, err := Decrypt(ciphertext); err != nil || plaintext != "hsm protected" {
+		t.Errorf("Decrypt() = %v, %v", plaintext, err)
+	}
+}
@@ -0,0 +1,211 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// loadTestKeyProvider loads a provider for the duration of a test
+func loadTestKeyProvider(t *testing.T, provider KeyProvider) {
+	t.Helper()
+	t.Cleanup(func() {
+		providerMu.Lock()
+		defer providerMu.Unlock()
+		providerKey, providerPrevious, providerLoaded = nil, nil, false
+	})
+	if err := LoadKeyProvider(context.Background(), provider); err != nil {
+		t.Fatalf("LoadKeyProvider() error = %v", err)
+	}
+}
+
+func TestEnvKeyProvider(t *testing.T) {
+	t.Setenv("VAULT_HUB_TEST_MASTER_KEY", "env-master-key")
+
+	key, err := EnvKeyProvider{Variable: "VAULT_HUB_TEST_MASTER_KEY"}.MasterKey(context.Background())
+	if err != nil {
+		t.Fatalf("MasterKey() error = %v", err)
+	}
+	if string(key) != "env-master-key" {
+		t.Errorf("MasterKey() = %q, want %q", key, "env-master-key")
+	}
+
+	if _, err := (EnvKeyProvider{Variable: "VAULT_HUB_TEST_MISSING_KEY"}).MasterKey(context.Background()); err == nil {
+		t.Error("MasterKey() with unset variable should fail")
+	}
+}
+
+func TestFileKeyProvider(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name      string
+		content   string
+		mode      os.FileMode
+		want      string
+		wantError bool
+	}{
+		{name: "owner only", content: "file-master-key\n", mode: 0o600, want: "file-master-key"},
+		{name: "read only", content: "file-master-key", mode: 0o400, want: "file-master-key"},
+		{name: "group readable", content: "file-master-key", mode: 0o640, wantError: runtime.GOOS != "windows"},
+		{name: "world readable", content: "file-master-key", mode: 0o644, wantError: runtime.GOOS != "windows"},
+		{name: "empty", content: "\n", mode: 0o600, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "-"))
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatalf("WriteFile() error = %v", err)
+			}
+			// Set the mode explicitly so the umask does not interfere
+			if err := os.Chmod(path, tt.mode); err != nil {
+				t.Fatalf("Chmod() error = %v", err)
+			}
+
+			key, err := FileKeyProvider{Path: path}.MasterKey(context.Background())
+			if (err != nil) != tt.wantError {
+				t.Fatalf("MasterKey() error = %v, wantError %v", err, tt.wantError)
+			}
+			if !tt.wantError && tt.want != "" && string(key) != tt.want {
+				t.Errorf("MasterKey() = %q, want %q", key, tt.want)
+			}
+		})
+	}
+
+	if _, err := (FileKeyProvider{Path: dir}).MasterKey(context.Background()); err == nil {
+		t.Error("MasterKey() with a directory should fail")
+	}
+}
+
+func TestFileKeyProviderPreviousKeys(t *testing.T) {
+	dir := t.TempDir()
+	writeKey := func(name, content string, mode os.FileMode) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), mode); err != nil {
+			t.Fatalf("WriteFile() error = %v", err)
+		}
+		if err := os.Chmod(path, mode); err != nil {
+			t.Fatalf("Chmod() error = %v", err)
+		}
+		return path
+	}
+	active := writeKey("active", "active-key\n", 0o600)
+	old := writeKey("old", "old-key\n", 0o400)
+	older := writeKey("older", "older-key", 0o600)
+
+	provider := FileKeyProvider{Path: active, PreviousPaths: []string{old, older}}
+	keys, err := provider.PreviousKeys(context.Background())
+	if err != nil {
+		t.Fatalf("PreviousKeys() error = %v", err)
+	}
+	if len(keys) != 2 || string(keys[0]) != "old-key" || string(keys[1]) != "older-key" {
+		t.Errorf("PreviousKeys() = %q, want [old-key older-key]", keys)
+	}
+
+	if runtime.GOOS != "windows" {
+		exposed := writeKey("exposed", "exposed-key", 0o644)
+		if _, err := (FileKeyProvider{Path: active, PreviousPaths: []string{exposed}}).PreviousKeys(context.Background()); err == nil {
+			t.Error("PreviousKeys() with a world readable file should fail")
+		}
+	}
+
+	// Values sealed by a previous key stay readable once the provider is loaded
+	useMasterKeys(t, "old-key")
+	ciphertext, err := Encrypt("sealed by the old key")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	loadTestKeyProvider(t, provider)
+	if ActiveKeyID() != newMasterKey([]byte("active-key")).id {
+		t.Error("ActiveKeyID() does not match the key file")
+	}
+	if plaintext, err := Decrypt(ciphertext); err != nil || plaintext != "sealed by the old key" {
+		t.Errorf("Decrypt() = %v, %v", plaintext, err)
+	}
+}
+
+func TestKMSKeyProvider(t *testing.T) {
+	masterKey := []byte("kms-master-key")
+	wrapped := base64.StdEncoding.EncodeToString([]byte("wrapped-master-key"))
+	wrappedPrevious := base64.StdEncoding.EncodeToString([]byte("wrapped-previous-key"))
+	wrappedRetired := base64.StdEncoding.EncodeToString([]byte("wrapped-retired-key"))
+
+	// Local stand-in for a KMS decrypt endpoint
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer kms-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var req kmsDecryptRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch {
+		case req.KeyID == "master" && req.Ciphertext == wrapped:
+			_ = json.NewEncoder(w).Encode(kmsDecryptResponse{Plaintext: base64.StdEncoding.EncodeToString(masterKey)})
+		case req.KeyID == "master" && req.Ciphertext == wrappedPrevious:
+			_ = json.NewEncoder(w).Encode(kmsDecryptResponse{Plaintext: base64.StdEncoding.EncodeToString([]byte("kms-previous-key"))})
+		case req.KeyID == "arn:kms:retired" && req.Ciphertext == wrappedRetired:
+			_ = json.NewEncoder(w).Encode(kmsDecryptResponse{Plaintext: base64.StdEncoding.EncodeToString([]byte("kms-retired-key"))})
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	provider := &KMSKeyProvider{URL: server.URL, KeyID: "master", Token: "kms-token", Ciphertext: wrapped}
+	key, err := provider.MasterKey(context.Background())
+	if err != nil {
+		t.Fatalf("MasterKey() error = %v", err)
+	}
+	if string(key) != string(masterKey) {
+		t.Errorf("MasterKey() = %q, want %q", key, masterKey)
+	}
+
+	// Previous keys use KeyID unless they name the KMS key that encrypted them
+	provider.PreviousCiphertexts = []string{wrappedPrevious, "arn:kms:retired:" + wrappedRetired}
+	previous, err := provider.PreviousKeys(context.Background())
+	if err != nil {
+		t.Fatalf("PreviousKeys() error = %v", err)
+	}
+	if len(previous) != 2 || string(previous[0]) != "kms-previous-key" || string(previous[1]) != "kms-retired-key" {
+		t.Errorf("PreviousKeys() = %q, want [kms-previous-key kms-retired-key]", previous)
+	}
+
+	unauthorized := &KMSKeyProvider{URL: server.URL, KeyID: "master", Token: "wrong", Ciphertext: wrapped}
+	if _, err := unauthorized.MasterKey(context.Background()); err == nil {
+		t.Error("MasterKey() with a rejected token should fail")
+	}
+}
+
+func TestLoadKeyProvider(t *testing.T) {
+	defaultKeyID := ActiveKeyID()
+
+	t.Setenv("VAULT_HUB_TEST_MASTER_KEY", "provided-master-key")
+	loadTestKeyProvider(t, EnvKeyProvider{Variable: "VAULT_HUB_TEST_MASTER_KEY"})
+
+	if ActiveKeyID() == defaultKeyID {
+		t.Fatal("ActiveKeyID() should change once a provider is loaded")
+	}
+	if ActiveKeyID() != newMasterKey([]byte("provided-master-key")).id {
+		t.Error("ActiveKeyID() does not match the provided key")
+	}
+
+	ciphertext, err := Encrypt("provided")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+	plaintext, err := Decrypt(ciphertext)
+	if err != nil || plaintext != "provided" {
+		t.Errorf("Decrypt() = %v, %v", plaintext, err)
+	}
+}
@@ -0,0 +1,242 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Ciphertext formats are identified by a versioned prefix; untagged values predate them
+const (
+	// kekPrefix marks a value sealed directly by a key-encryption key: kek1:<key id>:<base64>
+	kekPrefix = "kek1:"
+	// dekV1Prefix marks a value sealed by a per-vault data key without associated data: dek1:<base64>
+	dekV1Prefix = "dek1:"
+	// dekV2Prefix marks a value sealed by a per-vault data key and bound to associated data: dek2:<base64>
+	dekV2Prefix = "dek2:"
+)
+
+const dataKeySize = 32
+
+// ErrUnknownKeyID is returned when a value was sealed by a master key that is not configured
+var ErrUnknownKeyID = errors.New("unknown encryption key id")
+
+// masterKey is a key-encryption key derived from a configured master key
+type masterKey struct {
+	id  string
+	key []byte
+}
+
+// newMasterKey derives a key-encryption key and its ID from raw key material
+func newMasterKey(material []byte) masterKey {
+	hash := sha256.Sum256(material)
+	key := hash[:]
+
+	// The ID is a keyed hash so that it identifies the key without revealing anything about it
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("vault-hub key id"))
+	return masterKey{id: hex.EncodeToString(mac.Sum(nil)[:8]), key: key}
+}
+
+// masterKeys returns the active master key followed by any previous keys still accepted for decryption
+func masterKeys() []masterKey {
+	keys := []masterKey{newMasterKey(activeKeyMaterial())}
+	for _, previous := range previousKeyMaterial() {
+		keys = append(keys, newMasterKey(previous))
+	}
+	return keys
+}
+
+// findMasterKey looks up a configured master key by ID
+func findMasterKey(id string) (masterKey, error) {
+	for _, k := range masterKeys() {
+		if k.id == id {
+			return k, nil
+		}
+	}
+	return masterKey{}, fmt.Errorf("%w: %s", ErrUnknownKeyID, id)
+}
+
+// ActiveKeyID returns the ID of the master key used for new encryptions
+func ActiveKeyID() string {
+	return newMasterKey(activeKeyMaterial()).id
+}
+
+// GenerateDataKey creates a random data key and wraps it with the active master key.
+// It returns the plaintext data key, the wrapped key for storage and the ID of the wrapping key.
+func GenerateDataKey() ([]byte, string, string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return nil, "", "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	active := masterKeys()[0]
+	wrapped, err := sealWithMasterKey(active, dataKey)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return dataKey, wrapped, active.id, nil
+}
+
+// UnwrapDataKey decrypts a wrapped data key with whichever configured master key wrapped it
+func UnwrapDataKey(wrapped string) ([]byte, error) {
+	if !strings.HasPrefix(wrapped, kekPrefix) {
+		return nil, fmt.Errorf("invalid wrapped data key")
+	}
+	dataKey, err := openWithMasterKey(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if len(dataKey) != dataKeySize {
+		return nil, fmt.Errorf("invalid data key length")
+	}
+	return dataKey, nil
+}
+
+// RewrapDataKey re-wraps a data key with the active master key.
+// The data key itself is unchanged, so values encrypted with it stay readable.
+func RewrapDataKey(wrapped string) (string, string, error) {
+	dataKey, err := UnwrapDataKey(wrapped)
+	if err != nil {
+		return "", "", err
+	}
+
+	active := masterKeys()[0]
+	rewrapped, err := sealWithMasterKey(active, dataKey)
+	if err != nil {
+		return "", "", err
+	}
+
+	return rewrapped, active.id, nil
+}
+
+// EncryptWithDataKey encrypts plaintext using AES-256-GCM with a data key.
+// The associated data is authenticated but not stored; the same data must be passed to decrypt.
+func EncryptWithDataKey(dataKey []byte, plaintext string, associatedData []byte) (string, error) {
+	if plaintext == "" {
+		return "", nil
+	}
+
+	sealed, err := seal(dataKey, []byte(plaintext), associatedData)
+	if err != nil {
+		return "", err
+	}
+	return dekV2Prefix + sealed, nil
+}
+
+// DecryptWithDataKey decrypts a value encrypted with EncryptWithDataKey, detecting the format from its prefix.
+// Values written before associated data was used ignore it, and values written before data keys
+// existed are decrypted with the master keys instead.
+func DecryptWithDataKey(dataKey []byte, ciphertext string, associatedData []byte) (string, error) {
+	var sealed string
+	switch {
+	case strings.HasPrefix(ciphertext, dekV2Prefix):
+		sealed = strings.TrimPrefix(ciphertext, dekV2Prefix)
+	case strings.HasPrefix(ciphertext, dekV1Prefix):
+		sealed = strings.TrimPrefix(ciphertext, dekV1Prefix)
+		associatedData = nil
+	default:
+		return Decrypt(ciphertext)
+	}
+	if dataKey == nil {
+		return "", fmt.Errorf("value requires a data key")
+	}
+
+	plaintext, err := open(dataKey, sealed, associatedData)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// NeedsUpgrade reports whether a value is in an older format than EncryptWithDataKey produces
+func NeedsUpgrade(ciphertext string) bool {
+	return ciphertext != "" && !strings.HasPrefix(ciphertext, dekV2Prefix)
+}
+
+// sealWithMasterKey encrypts data with a master key and tags the result with the key ID
+func sealWithMasterKey(k masterKey, data []byte) (string, error) {
+	sealed, err := seal(k.key, data, nil)
+	if err != nil {
+		return "", err
+	}
+	return kekPrefix + k.id + ":" + sealed, nil
+}
+
+// openWithMasterKey decrypts a value produced by sealWithMasterKey
+func openWithMasterKey(value string) ([]byte, error) {
+	keyID, sealed, ok := strings.Cut(strings.TrimPrefix(value, kekPrefix), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid key-encrypted value")
+	}
+
+	k, err := findMasterKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return open(k.key, sealed, nil)
+}
+
+// seal encrypts data using AES-256-GCM and returns base64(nonce|ciphertext)
+func seal(key, data, associatedData []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := gcm.Seal(nonce, nonce, data, associatedData)
+	return base64.StdEncoding.EncodeToString(ciphertext), nil
+}
+
+// open decrypts base64(nonce|ciphertext) using AES-256-GCM
+func open(key []byte, encoded string, associatedData []byte) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode base64: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonceSize := gcm.NonceSize()
+	if len(data) < nonceSize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+
+	nonce, ciphertext := data[:nonceSize], data[nonceSize:]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, associatedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
@@ -0,0 +1,180 @@
+package encryption
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+
+	"github.com/lwshen/vault-hub/internal/config"
+)
+
+// useMasterKeys switches the configured master keys for the duration of a test
+func useMasterKeys(t *testing.T, active string, previous ...string) {
+	t.Helper()
+	oldActive, oldPrevious := config.EncryptionKey, config.EncryptionKeyPrevious
+	config.EncryptionKey, config.EncryptionKeyPrevious = active, previous
+	t.Cleanup(func() {
+		config.EncryptionKey, config.EncryptionKeyPrevious = oldActive, oldPrevious
+	})
+}
+
+func TestDataKeyRoundTrip(t *testing.T) {
+	dataKey, wrapped, keyID, err := GenerateDataKey()
+	if err != nil {
+		t.Fatalf("GenerateDataKey() error = %v", err)
+	}
+	if keyID != ActiveKeyID() {
+		t.Errorf("GenerateDataKey() keyID = %v, want %v", keyID, ActiveKeyID())
+	}
+
+	unwrapped, err := UnwrapDataKey(wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapDataKey() error = %v", err)
+	}
+	if string(unwrapped) != string(dataKey) {
+		t.Error("UnwrapDataKey() returned a different key")
+	}
+
+	associatedData := []byte("vault-a")
+	ciphertext, err := EncryptWithDataKey(dataKey, "secret value", associatedData)
+	if err != nil {
+		t.Fatalf("EncryptWithDataKey() error = %v", err)
+	}
+	if NeedsUpgrade(ciphertext) {
+		t.Errorf("EncryptWithDataKey() = %v, want current format", ciphertext)
+	}
+
+	plaintext, err := DecryptWithDataKey(unwrapped, ciphertext, associatedData)
+	if err != nil {
+		t.Fatalf("DecryptWithDataKey() error = %v", err)
+	}
+	if plaintext != "secret value" {
+		t.Errorf("DecryptWithDataKey() = %v, want %v", plaintext, "secret value")
+	}
+}
+
+func TestDecryptWithDataKeyFallsBackToMasterKey(t *testing.T) {
+	ciphertext, err := Encrypt("legacy value")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	if !NeedsUpgrade(ciphertext) {
+		t.Error("NeedsUpgrade() = false for a master key ciphertext")
+	}
+
+	plaintext, err := DecryptWithDataKey(nil, ciphertext, []byte("ignored"))
+	if err != nil {
+		t.Fatalf("DecryptWithDataKey() error = %v", err)
+	}
+	if plaintext != "legacy value" {
+		t.Errorf("DecryptWithDataKey() = %v, want %v", plaintext, "legacy value")
+	}
+}
+
+func TestMasterKeyRotation(t *testing.T) {
+	useMasterKeys(t, "old-master-key")
+	oldKeyID := ActiveKeyID()
+
+	_, wrapped, _, err := GenerateDataKey()
+	if err != nil {
+		t.Fatalf("GenerateDataKey() error = %v", err)
+	}
+	sealed, err := Encrypt("sealed with old key")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	// During rotation both keys are accepted
+	useMasterKeys(t, "new-master-key", "old-master-key")
+	if ActiveKeyID() == oldKeyID {
+		t.Fatal("ActiveKeyID() did not change with the master key")
+	}
+
+	if _, err := UnwrapDataKey(wrapped); err != nil {
+		t.Fatalf("UnwrapDataKey() with previous key error = %v", err)
+	}
+	if plaintext, err := Decrypt(sealed); err != nil || plaintext != "sealed with old key" {
+		t.Fatalf("Decrypt() with previous key = %v, %v", plaintext, err)
+	}
+
+	rewrapped, keyID, err := RewrapDataKey(wrapped)
+	if err != nil {
+		t.Fatalf("RewrapDataKey() error = %v", err)
+	}
+	if keyID != ActiveKeyID() {
+		t.Errorf("RewrapDataKey() keyID = %v, want %v", keyID, ActiveKeyID())
+	}
+
+	// Once the old key is retired only re-wrapped keys remain readable
+	useMasterKeys(t, "new-master-key")
+	if _, err := UnwrapDataKey(rewrapped); err != nil {
+		t.Errorf("UnwrapDataKey() after rotation error = %v", err)
+	}
+	if _, err := UnwrapDataKey(wrapped); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("UnwrapDataKey() with retired key error = %v, want %v", err, ErrUnknownKeyID)
+	}
+}
+
+func TestDecryptUntaggedValueWithPreviousKey(t *testing.T) {
+	// Values written before key IDs existed carry no prefix
+	hash := sha256.Sum256([]byte("old-master-key"))
+	untagged, err := seal(hash[:], []byte("untagged value"), nil)
+	if err != nil {
+		t.Fatalf("seal() error = %v", err)
+	}
+
+	useMasterKeys(t, "new-master-key", "old-master-key")
+	plaintext, err := Decrypt(untagged)
+	if err != nil {
+		t.Fatalf("Decrypt() error = %v", err)
+	}
+	if plaintext != "untagged value" {
+		t.Errorf("Decrypt() = %v, want %v", plaintext, "untagged value")
+	}
+}
+
+func TestDataKeyAssociatedData(t *testing.T) {
+	dataKey, _, _, err := GenerateDataKey()
+	if err != nil {
+		t.Fatalf("GenerateDataKey() error = %v", err)
+	}
+
+	ciphertext, err := EncryptWithDataKey(dataKey, "bound value", []byte("vault-a"))
+	if err != nil {
+		t.Fatalf("EncryptWithDataKey() error = %v", err)
+	}
+
+	// A value moved to another vault must not decrypt
+	if _, err := DecryptWithDataKey(dataKey, ciphertext, []byte("vault-b")); err == nil {
+		t.Error("DecryptWithDataKey() with different associated data should fail")
+	}
+	if _, err := DecryptWithDataKey(dataKey, ciphertext, nil); err == nil {
+		t.Error("DecryptWithDataKey() without associated data should fail")
+	}
+}
+
+func TestDecryptDataKeyV1Format(t *testing.T) {
+	dataKey, _, _, err := GenerateDataKey()
+	if err != nil {
+		t.Fatalf("GenerateDataKey() error = %v", err)
+	}
+
+	// Data key values written before associated data was used
+	sealed, err := seal(dataKey, []byte("v1 value"), nil)
+	if err != nil {
+		t.Fatalf("seal() error = %v", err)
+	}
+	ciphertext := dekV1Prefix + sealed
+	if !NeedsUpgrade(ciphertext) {
+		t.Error("NeedsUpgrade() = false for a v1 ciphertext")
+	}
+
+	plaintext, err := DecryptWithDataKey(dataKey, ciphertext, []byte("vault-a"))
+	if err != nil {
+		t.Fatalf("DecryptWithDataKey() error = %v", err)
+	}
+	if plaintext != "v1 value" {
+		t.Errorf("DecryptWithDataKey() = %v, want %v", plaintext, "v1 value")
+	}
+}
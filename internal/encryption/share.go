@@ -0,0 +1,37 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+)
+
+// SealShare encrypts a shared secret with a new random key that is returned instead of kept.
+// The ciphertext is base64(nonce|ciphertext) of AES-256-GCM with the share ID as associated
+// data, and the key is base64url without padding, so a browser can open it with Web Crypto.
+func SealShare(plaintext, shareID string) (key, ciphertext string, err error) {
+	shareKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(shareKey); err != nil {
+		return "", "", fmt.Errorf("failed to generate share key: %w", err)
+	}
+
+	ciphertext, err = seal(shareKey, []byte(plaintext), []byte(shareID))
+	if err != nil {
+		return "", "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(shareKey), ciphertext, nil
+}
+
+// OpenShare decrypts a shared secret sealed by SealShare with the key from its share URL
+func OpenShare(key, ciphertext, shareID string) (string, error) {
+	shareKey, err := base64.RawURLEncoding.DecodeString(key)
+	if err != nil || len(shareKey) != dataKeySize {
+		return "", fmt.Errorf("invalid share key")
+	}
+
+	plaintext, err := open(shareKey, ciphertext, []byte(shareID))
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
@@ -0,0 +1,33 @@
+package encryption
+
+import "testing"
+
+func TestShareRoundTrip(t *testing.T) {
+	key, ciphertext, err := SealShare("shared secret", "share-a")
+	if err != nil {
+		t.Fatalf("SealShare() error = %v", err)
+	}
+
+	plaintext, err := OpenShare(key, ciphertext, "share-a")
+	if err != nil {
+		t.Fatalf("OpenShare() error = %v", err)
+	}
+	if plaintext != "shared secret" {
+		t.Errorf("OpenShare() = %v, want %v", plaintext, "shared secret")
+	}
+
+	// The key is not derived from the master key, so every share gets its own
+	otherKey, _, err := SealShare("shared secret", "share-a")
+	if err != nil {
+		t.Fatalf("SealShare() error = %v", err)
+	}
+	if _, err := OpenShare(otherKey, ciphertext, "share-a"); err == nil {
+		t.Error("OpenShare() with another share's key should fail")
+	}
+	if _, err := OpenShare(key, ciphertext, "share-b"); err == nil {
+		t.Error("OpenShare() with another share ID should fail")
+	}
+	if _, err := OpenShare("not-a-key", ciphertext, "share-a"); err == nil {
+		t.Error("OpenShare() with an invalid key should fail")
+	}
+}
@@ -0,0 +1,184 @@
+// Package ratelimit throttles authentication attempts with sliding windows. Requests are
+// limited per client IP, and failed attempts slow down and eventually lock the account or
+// client they were made for.
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lwshen/vault-hub/internal/config"
+)
+
+// Policy allows Limit requests within any Window
+type Policy struct {
+	Limit  int
+	Window time.Duration
+}
+
+// Lockout locks a key for Duration after MaxFailures failed attempts within Window. Before
+// that, each failure after the first doubles the wait before the next attempt, starting at
+// BaseDelay and capped at MaxDelay. Duration must not exceed Window.
+type Lockout struct {
+	MaxFailures int
+	Window      time.Duration
+	Duration    time.Duration
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var (
+	// AuthRequests limits requests from a client IP to the public authentication endpoints
+	AuthRequests = Policy{Limit: 30, Window: time.Minute}
+	// LoginFailures locks an account after wrong passwords or second factors
+	LoginFailures = Lockout{
+		MaxFailures: 5,
+		Window:      15 * time.Minute,
+		Duration:    15 * time.Minute,
+		BaseDelay:   time.Second,
+		MaxDelay:    8 * time.Second,
+	}
+	// APIKeyFailures locks out a client IP after invalid API keys
+	APIKeyFailures = Lockout{
+		MaxFailures: 10,
+		Window:      15 * time.Minute,
+		Duration:    15 * time.Minute,
+	}
+)
+
+// Limiter applies policies and lockouts to keys recorded in a store
+type Limiter struct {
+	store Store
+	now   func() time.Time
+
+	mu        sync.Mutex
+	maxWindow time.Duration
+	lastPrune time.Time
+}
+
+// New returns a limiter that records attempts in store
+func New(store Store) *Limiter {
+	return &Limiter{store: store, now: time.Now}
+}
+
+var defaultLimiter *Limiter
+
+func init() {
+	if config.RateLimitStore == "database" {
+		defaultLimiter = New(NewDatabaseStore())
+	} else {
+		defaultLimiter = New(NewMemoryStore())
+	}
+}
+
+// Default returns the limiter configured with RATE_LIMIT_STORE
+func Default() *Limiter {
+	return defaultLimiter
+}
+
+// Allow counts a request for key and returns how long to wait when the policy's limit is
+// reached. Rejected requests are not counted.
+func (l *Limiter) Allow(ctx context.Context, key string, policy Policy) (time.Duration, error) {
+	now := l.now()
+	l.prune(ctx, now, policy.Window)
+
+	times, err := l.store.Get(ctx, key, now.Add(-policy.Window))
+	if err != nil {
+		return 0, err
+	}
+	if len(times) >= policy.Limit {
+		// The oldest request in the window has to slide out first
+		return times[len(times)-policy.Limit].Add(policy.Window).Sub(now), nil
+	}
+	return 0, l.store.Add(ctx, key, now)
+}
+
+// Check returns how long to wait before the next attempt for key, and whether the key is
+// locked rather than only delayed
+func (l *Limiter) Check(ctx context.Context, key string, lockout Lockout) (time.Duration, bool, error) {
+	now := l.now()
+	failures, err := l.store.Get(ctx, key, now.Add(-lockout.Window))
+	if err != nil {
+		return 0, false, err
+	}
+	if len(failures) == 0 {
+		return 0, false, nil
+	}
+
+	latest := failures[len(failures)-1]
+	if len(failures) >= lockout.MaxFailures {
+		if wait := latest.Add(lockout.Duration).Sub(now); wait > 0 {
+			return wait, true, nil
+		}
+		return 0, false, nil
+	}
+	if wait := latest.Add(lockout.delay(len(failures))).Sub(now); wait > 0 {
+		return wait, false, nil
+	}
+	return 0, false, nil
+}
+
+// Fail records a failed attempt for key and reports whether it locked the key
+func (l *Limiter) Fail(ctx context.Context, key string, lockout Lockout) (bool, error) {
+	now := l.now()
+	l.prune(ctx, now, lockout.Window)
+
+	if err := l.store.Add(ctx, key, now); err != nil {
+		return false, err
+	}
+	failures, err := l.store.Get(ctx, key, now.Add(-lockout.Window))
+	if err != nil {
+		return false, err
+	}
+	return len(failures) >= lockout.MaxFailures, nil
+}
+
+// Reset forgets the failed attempts for key after a successful one
+func (l *Limiter) Reset(ctx context.Context, key string) error {
+	return l.store.Reset(ctx, key)
+}
+
+// delay returns the wait after a number of failures: none after the first, so a single typo
+// is never punished, then BaseDelay doubling up to MaxDelay
+func (lockout Lockout) delay(failures int) time.Duration {
+	if failures < 2 || lockout.BaseDelay <= 0 {
+		return 0
+	}
+	delay := lockout.BaseDelay
+	for i := 2; i < failures && delay < lockout.MaxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, lockout.MaxDelay)
+}
+
+// prune deletes attempts older than the longest window in use, at most once per pruneInterval
+func (l *Limiter) prune(ctx context.Context, now time.Time, window time.Duration) {
+	l.mu.Lock()
+	l.maxWindow = max(l.maxWindow, window)
+	due := now.Sub(l.lastPrune) >= pruneInterval
+	if due {
+		l.lastPrune = now
+	}
+	maxWindow := l.maxWindow
+	l.mu.Unlock()
+
+	if !due {
+		return
+	}
+	if err := l.store.Prune(ctx, now.Add(-maxWindow)); err != nil {
+		slog.Error("Failed to prune rate limit attempts", "error", err)
+	}
+}
+
+// AccountKey returns the key of the failed logins of an email address
+func AccountKey(email string) string {
+	return "account:" + strings.ToLower(strings.TrimSpace(email))
+}
+
+// IPKey returns the key of a kind of attempt from a client IP
+func IPKey(kind, ip string) string {
+	return kind + ":" + ip
+}
@@ -0,0 +1,103 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// newTestLimiter returns a limiter with a memory store and a clock the test controls
+func newTestLimiter() (*Limiter, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	l := New(NewMemoryStore())
+	l.now = func() time.Time { return now }
+	return l, &now
+}
+
+func TestAllowSlidingWindow(t *testing.T) {
+	ctx := context.Background()
+	l, now := newTestLimiter()
+	policy := Policy{Limit: 3, Window: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		wait, err := l.Allow(ctx, "ip", policy)
+		if err != nil || wait != 0 {
+			t.Fatalf("Allow() request %d = %v, %v, want allowed", i+1, wait, err)
+		}
+		*now = now.Add(10 * time.Second)
+	}
+
+	// The first request leaves the window 60s after it was made, 30s from now
+	wait, err := l.Allow(ctx, "ip", policy)
+	if err != nil || wait != 30*time.Second {
+		t.Fatalf("Allow() over the limit = %v, %v, want 30s", wait, err)
+	}
+	if wait, _ := l.Allow(ctx, "other", policy); wait != 0 {
+		t.Errorf("Allow() for another key = %v, want allowed", wait)
+	}
+
+	*now = now.Add(30 * time.Second)
+	if wait, _ := l.Allow(ctx, "ip", policy); wait != 0 {
+		t.Errorf("Allow() after the window slid = %v, want allowed", wait)
+	}
+}
+
+func TestLockoutProgressiveDelay(t *testing.T) {
+	ctx := context.Background()
+	l, now := newTestLimiter()
+	lockout := Lockout{MaxFailures: 5, Window: 15 * time.Minute, Duration: 15 * time.Minute, BaseDelay: time.Second, MaxDelay: 2 * time.Second}
+
+	// No delay after a single failure, then doubling delays up to the cap
+	for i, want := range []time.Duration{0, time.Second, 2 * time.Second, 2 * time.Second} {
+		locked, err := l.Fail(ctx, "account", lockout)
+		if err != nil || locked {
+			t.Fatalf("Fail() %d = %v, %v, want not locked", i+1, locked, err)
+		}
+		wait, locked, err := l.Check(ctx, "account", lockout)
+		if err != nil || locked || wait != want {
+			t.Fatalf("Check() after %d failures = %v, %v, %v, want %v", i+1, wait, locked, err, want)
+		}
+		*now = now.Add(want)
+	}
+
+	locked, err := l.Fail(ctx, "account", lockout)
+	if err != nil || !locked {
+		t.Fatalf("Fail() at the limit = %v, %v, want locked", locked, err)
+	}
+	wait, locked, _ := l.Check(ctx, "account", lockout)
+	if !locked || wait != 15*time.Minute {
+		t.Fatalf("Check() when locked = %v, %v, want locked for 15m", wait, locked)
+	}
+
+	*now = now.Add(15 * time.Minute)
+	if wait, locked, _ := l.Check(ctx, "account", lockout); locked || wait != 0 {
+		t.Errorf("Check() after the lockout = %v, %v, want allowed", wait, locked)
+	}
+}
+
+func TestLockoutReset(t *testing.T) {
+	ctx := context.Background()
+	l, _ := newTestLimiter()
+	lockout := Lockout{MaxFailures: 2, Window: time.Minute, Duration: time.Minute}
+
+	for i := 0; i < 2; i++ {
+		if _, err := l.Fail(ctx, "account", lockout); err != nil {
+			t.Fatalf("Fail() error = %v", err)
+		}
+	}
+	if _, locked, _ := l.Check(ctx, "account", lockout); !locked {
+		t.Fatal("Check() = not locked, want locked")
+	}
+	if err := l.Reset(ctx, "account"); err != nil {
+		t.Fatalf("Reset() error = %v", err)
+	}
+	if wait, locked, _ := l.Check(ctx, "account", lockout); locked || wait != 0 {
+		t.Errorf("Check() after Reset() = %v, %v, want allowed", wait, locked)
+	}
+}
+
+func TestAccountKeyNormalizesEmail(t *testing.T) {
+	if AccountKey(" User@Example.com ") != AccountKey("user@example.com") {
+		t.Error("AccountKey() differs by case and whitespace")
+	}
+}
@@ -0,0 +1,104 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/lwshen/vault-hub/model"
+)
+
+// pruneInterval is how often attempts that fell out of every window are deleted
+const pruneInterval = time.Minute
+
+// Store records attempts for rate limit keys
+type Store interface {
+	// Add records an attempt for key at a time
+	Add(ctx context.Context, key string, at time.Time) error
+	// Get returns the times of the attempts recorded for key after since, oldest first
+	Get(ctx context.Context, key string, since time.Time) ([]time.Time, error)
+	// Reset forgets the attempts recorded for key
+	Reset(ctx context.Context, key string) error
+	// Prune deletes the attempts recorded before a time
+	Prune(ctx context.Context, before time.Time) error
+}
+
+// MemoryStore keeps attempts in memory. Each server process has its own windows.
+type MemoryStore struct {
+	mu   sync.Mutex
+	hits map[string][]time.Time
+}
+
+// NewMemoryStore returns an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{hits: make(map[string][]time.Time)}
+}
+
+func (s *MemoryStore) Add(_ context.Context, key string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hits[key] = append(s.hits[key], at)
+	return nil
+}
+
+func (s *MemoryStore) Get(_ context.Context, key string, since time.Time) ([]time.Time, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var times []time.Time
+	for _, at := range s.hits[key] {
+		if at.After(since) {
+			times = append(times, at)
+		}
+	}
+	return times, nil
+}
+
+func (s *MemoryStore) Reset(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.hits, key)
+	return nil
+}
+
+func (s *MemoryStore) Prune(_ context.Context, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, times := range s.hits {
+		kept := times[:0]
+		for _, at := range times {
+			if !at.Before(before) {
+				kept = append(kept, at)
+			}
+		}
+		if len(kept) == 0 {
+			delete(s.hits, key)
+		} else {
+			s.hits[key] = kept
+		}
+	}
+	return nil
+}
+
+// DatabaseStore keeps attempts in the database, so all replicas share the same windows
+type DatabaseStore struct{}
+
+// NewDatabaseStore returns a store backed by the rate_limit_hits table
+func NewDatabaseStore() *DatabaseStore {
+	return &DatabaseStore{}
+}
+
+func (DatabaseStore) Add(_ context.Context, key string, at time.Time) error {
+	return model.AddRateLimitHit(key, at)
+}
+
+func (DatabaseStore) Get(_ context.Context, key string, since time.Time) ([]time.Time, error) {
+	return model.GetRateLimitHits(key, since)
+}
+
+func (DatabaseStore) Reset(_ context.Context, key string) error {
+	return model.DeleteRateLimitHits(key)
+}
+
+func (DatabaseStore) Prune(_ context.Context, before time.Time) error {
+	return model.PruneRateLimitHits(before)
+}
@@ -0,0 +1,267 @@
+// Package rotation writes new vault values according to their rotation policies, retrying
+// failed rotations.
+package rotation
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lwshen/vault-hub/internal/config"
+	"github.com/lwshen/vault-hub/internal/email"
+	"github.com/lwshen/vault-hub/internal/webhook"
+	"github.com/lwshen/vault-hub/model"
+)
+
+// EventRotate is the event sent to rotation endpoints in the X-VaultHub-Event header
+const EventRotate = "vault.rotate"
+
+const (
+	// pollInterval is how often vaults due for rotation are looked for
+	pollInterval = time.Minute
+	// requestTimeout bounds a call to a rotation endpoint, which may have to change the
+	// credential at its source before responding
+	requestTimeout = 30 * time.Second
+	// batchSize is the number of policies claimed at a time
+	batchSize = 20
+	// maxResponseBody is how much of a rotation endpoint's response is read
+	maxResponseBody = 1 << 20
+)
+
+// CallbackPayload is the JSON body posted to rotation endpoints. It identifies the vault;
+// its current value is never sent.
+type CallbackPayload struct {
+	ID        string                    `json:"id"`
+	Event     string                    `json:"event"`
+	CreatedAt time.Time                 `json:"createdAt"`
+	Vault     model.WebhookPayloadVault `json:"vault"`
+}
+
+// CallbackResponse is the JSON body rotation endpoints respond with
+type CallbackResponse struct {
+	Value string `json:"value"`
+}
+
+// Generate returns a random value of the given length drawn from the characters of charset
+func Generate(charset string, length int) (string, error) {
+	chars := []rune(charset)
+	if len(chars) == 0 || length <= 0 {
+		return "", errors.New("charset and length are required")
+	}
+
+	size := big.NewInt(int64(len(chars)))
+	var b strings.Builder
+	for i := 0; i < length; i++ {
+		n, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate value: %w", err)
+		}
+		b.WriteRune(chars[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// NewClient returns the HTTP client used for rotation endpoints. Redirects are not
+// followed, so requests only go to the configured URL.
+func NewClient() *http.Client {
+	return &http.Client{
+		Timeout: requestTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Rotate writes a new value to the vault of a claimed policy and records the result on the
+// policy. The new value is written by Update, so it gets a version and starts the next
+// rotation period. An error is only returned when the result could not be recorded.
+func Rotate(ctx context.Context, client *http.Client, policy *model.RotationPolicy, author model.VersionAuthor) error {
+	if err := rotate(ctx, client, policy, author); err != nil {
+		return policy.RecordFailure(err.Error())
+	}
+	return policy.RecordSuccess()
+}
+
+// rotate produces the new value and writes it to the vault
+func rotate(ctx context.Context, client *http.Client, policy *model.RotationPolicy, author model.VersionAuthor) error {
+	var value string
+	var err error
+	switch policy.Strategy {
+	case model.RotationStrategyRandom:
+		value, err = Generate(policy.GeneratedCharset(), policy.GeneratedLength())
+	case model.RotationStrategyHTTP:
+		value, err = callback(ctx, client, policy)
+	default:
+		err = fmt.Errorf("unknown rotation strategy %q", policy.Strategy)
+	}
+	if err != nil {
+		return err
+	}
+
+	params := model.UpdateVaultParams{Value: &value, Author: author}
+	if errors := params.Validate(); len(errors) > 0 {
+		return fmt.Errorf("invalid value: %s", errors["value"])
+	}
+	return policy.Vault.Update(&params)
+}
+
+// callback asks the policy's rotation endpoint for a new value. Requests are signed like
+// webhook deliveries, with the policy's secret.
+func callback(ctx context.Context, client *http.Client, policy *model.RotationPolicy) (string, error) {
+	secret, err := policy.DecryptSecret()
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt rotation secret: %w", err)
+	}
+
+	payload := CallbackPayload{
+		ID:        uuid.NewString(),
+		Event:     EventRotate,
+		CreatedAt: time.Now().UTC(),
+		Vault: model.WebhookPayloadVault{
+			UniqueID:       policy.Vault.UniqueID,
+			Name:           policy.Vault.Name,
+			OrganizationID: policy.Vault.OrganizationID,
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, policy.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vault-hub-rotation")
+	req.Header.Set(webhook.HeaderEvent, EventRotate)
+	req.Header.Set(webhook.HeaderDelivery, payload.ID)
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(secret, time.Now(), body))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return "", fmt.Errorf("rotation endpoint responded with %s", resp.Status)
+	}
+	var result CallbackResponse
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseBody)).Decode(&result); err != nil {
+		return "", fmt.Errorf("invalid response from rotation endpoint: %w", err)
+	}
+	if result.Value == "" {
+		return "", errors.New("rotation endpoint returned no value")
+	}
+	return result.Value, nil
+}
+
+// Rotator rotates the vaults whose rotation deadline passed. Several servers may run one
+// against the same database; each rotation is claimed by one of them.
+type Rotator struct {
+	client *http.Client
+	logger *slog.Logger
+	email  *email.Service
+}
+
+// NewRotator returns a rotator that logs to the given logger
+func NewRotator(logger *slog.Logger) *Rotator {
+	return &Rotator{
+		client: NewClient(),
+		logger: logger,
+		email:  email.NewService(email.NewSender(), "Vault Hub"),
+	}
+}
+
+// Run rotates due vaults until ctx is done
+func (r *Rotator) Run(ctx context.Context) {
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+
+	for {
+		r.rotateDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		}
+	}
+}
+
+// rotateDue rotates claimed batches until nothing is due
+func (r *Rotator) rotateDue(ctx context.Context) {
+	for ctx.Err() == nil {
+		policies, err := model.ClaimDueRotations(batchSize)
+		if err != nil {
+			r.logger.Error("Failed to claim vault rotations", "error", err)
+			return
+		}
+
+		for i := range policies {
+			policy := &policies[i]
+			author := model.VersionAuthor{UserID: policy.UserID, Source: model.SourceSystem}
+			if err := Rotate(ctx, r.client, policy, author); err != nil {
+				r.logger.Error("Failed to record vault rotation", "error", err, "vaultID", policy.VaultID)
+				continue
+			}
+			r.report(policy)
+		}
+
+		if len(policies) < batchSize {
+			return
+		}
+	}
+}
+
+// report logs the result of a scheduled rotation. Rotations that ran out of attempts are
+// audited and their vault's owners are emailed.
+func (r *Rotator) report(policy *model.RotationPolicy) {
+	switch policy.LastStatus {
+	case model.RotationSucceeded:
+		if err := model.LogVaultAction(policy.VaultID, model.ActionRotateVault, policy.UserID, model.SourceSystem, nil, "", ""); err != nil {
+			r.logger.Error("Failed to create audit log for vault rotation", "error", err, "vaultID", policy.VaultID)
+		}
+		r.logger.Info("Rotated vault", "vaultID", policy.VaultID, "strategy", policy.Strategy)
+	case model.RotationRetrying:
+		r.logger.Warn("Vault rotation failed, retrying", "vaultID", policy.VaultID,
+			"attempt", policy.Attempts, "nextAttemptAt", policy.NextAttemptAt, "error", policy.LastError)
+	case model.RotationFailed:
+		if err := model.LogVaultAction(policy.VaultID, model.ActionRotateVaultFailed, policy.UserID, model.SourceSystem, nil, "", ""); err != nil {
+			r.logger.Error("Failed to create audit log for vault rotation", "error", err, "vaultID", policy.VaultID)
+		}
+		r.logger.Error("Vault rotation failed", "vaultID", policy.VaultID, "error", policy.LastError)
+		if config.EmailEnabled {
+			r.notifyFailure(policy)
+		}
+	}
+}
+
+// notifyFailure emails the owners of a vault whose rotation failed
+func (r *Rotator) notifyFailure(policy *model.RotationPolicy) {
+	recipients, err := model.VaultNotificationRecipients(&policy.Vault)
+	if err != nil {
+		r.logger.Error("Failed to find rotation failure recipients", "vaultID", policy.VaultID, "error", err)
+		return
+	}
+	for _, user := range recipients {
+		name := ""
+		if user.Name != nil {
+			name = *user.Name
+		}
+		if err := r.email.SendVaultRotationFailed(user.Email, name, policy.Vault.Name, policy.LastError); err != nil {
+			r.logger.Error("Failed to send rotation failure email", "vaultID", policy.VaultID, "email", user.Email, "error", err)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package rotation
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"github.com/lwshen/vault-hub/internal/webhook"
+	"github.com/lwshen/vault-hub/model"
+)
+
+func TestGenerate(t *testing.T) {
+	value, err := Generate("ab", 64)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(value) != 64 || strings.Trim(value, "ab") != "" {
+		t.Errorf("Generate() = %q, want 64 characters from the charset", value)
+	}
+	if !strings.Contains(value, "a") || !strings.Contains(value, "b") {
+		t.Errorf("Generate() = %q, want both characters used", value)
+	}
+
+	if value, err := Generate("äö€", 10); err != nil || len([]rune(value)) != 10 {
+		t.Errorf("Generate() with multibyte charset = %q, %v", value, err)
+	}
+	if _, err := Generate("", 10); err == nil {
+		t.Error("Generate() with empty charset should fail")
+	}
+}
+
+func TestCallback(t *testing.T) {
+	secret, err := encryption.Encrypt("whsec_test")
+	if err != nil {
+		t.Fatalf("Encrypt() error = %v", err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var payload CallbackPayload
+		if err := json.Unmarshal(body, &payload); err != nil || payload.Vault.UniqueID != "vault-1" {
+			t.Errorf("unexpected payload %s", body)
+		}
+		if r.Header.Get(webhook.HeaderEvent) != EventRotate || !strings.HasPrefix(r.Header.Get(webhook.HeaderSignature), "t=") {
+			t.Errorf("unexpected headers %v", r.Header)
+		}
+		if r.URL.Path == "/fail" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"value":"rotated"}`))
+	}))
+	defer server.Close()
+
+	policy := &model.RotationPolicy{
+		Strategy: model.RotationStrategyHTTP,
+		URL:      server.URL,
+		Secret:   secret,
+		Vault:    model.Vault{UniqueID: "vault-1", Name: "db"},
+	}
+	value, err := callback(t.Context(), NewClient(), policy)
+	if err != nil || value != "rotated" {
+		t.Errorf("callback() = %q, %v, want rotated", value, err)
+	}
+
+	policy.URL = server.URL + "/fail"
+	if _, err := callback(t.Context(), NewClient(), policy); err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("callback() error = %v, want the endpoint's status", err)
+	}
+}
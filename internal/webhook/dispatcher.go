@@ -0,0 +1,164 @@
+// Package webhook signs and delivers queued webhook events, retrying failed deliveries.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/lwshen/vault-hub/model"
+)
+
+// Headers sent with every delivery
+const (
+	HeaderSignature = "X-VaultHub-Signature"
+	HeaderEvent     = "X-VaultHub-Event"
+	HeaderDelivery  = "X-VaultHub-Delivery"
+)
+
+const (
+	// pollInterval is how often due retries are looked for when nothing new was queued
+	pollInterval = 5 * time.Second
+	// requestTimeout bounds a single delivery attempt
+	requestTimeout = 10 * time.Second
+	// batchSize is the number of deliveries claimed at a time
+	batchSize = 20
+	// retention is how long finished deliveries are kept in the delivery log
+	retention = 30 * 24 * time.Hour
+	// maxResponseBody is how much of an endpoint's response is kept for debugging
+	maxResponseBody = 1000
+)
+
+// Sign returns the signature header value for a payload sent at the given time:
+// "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<payload>" keyed with the secret>".
+// Receivers should recompute it and reject old timestamps to prevent replays.
+func Sign(secret string, timestamp time.Time, payload []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(t + "."))
+	mac.Write(payload)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// NewClient returns the HTTP client used for deliveries. Redirects are not followed, so
+// payloads only go to the registered URL.
+func NewClient() *http.Client {
+	return &http.Client{
+		Timeout: requestTimeout,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// Deliver sends a claimed delivery to its webhook and records the result. An error is only
+// returned when the result could not be recorded.
+func Deliver(ctx context.Context, client *http.Client, delivery *model.WebhookDelivery) error {
+	statusCode, body, err := send(ctx, client, delivery)
+	if err != nil {
+		return delivery.RecordFailure(statusCode, body, err.Error())
+	}
+	return delivery.RecordSuccess(statusCode, body)
+}
+
+// send posts the payload and returns the response status and the start of its body
+func send(ctx context.Context, client *http.Client, delivery *model.WebhookDelivery) (int, string, error) {
+	secret, err := delivery.Webhook.DecryptSecret()
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to decrypt webhook secret: %w", err)
+	}
+
+	payload := []byte(delivery.Payload)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "vault-hub-webhook")
+	req.Header.Set(HeaderEvent, string(delivery.Event))
+	req.Header.Set(HeaderDelivery, delivery.UniqueID)
+	req.Header.Set(HeaderSignature, Sign(secret, time.Now(), payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, "", err
+	}
+	defer resp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, string(body), fmt.Errorf("endpoint responded with %s", resp.Status)
+	}
+	return resp.StatusCode, string(body), nil
+}
+
+// Dispatcher delivers queued webhook events. Several servers may run one against the same
+// database; each delivery is claimed by one of them at a time.
+type Dispatcher struct {
+	client *http.Client
+	logger *slog.Logger
+}
+
+// NewDispatcher returns a dispatcher that logs to the given logger
+func NewDispatcher(logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{client: NewClient(), logger: logger}
+}
+
+// Run delivers due events until ctx is done. Events queued on this server are sent right
+// away; retries and events queued on other servers are picked up by polling.
+func (d *Dispatcher) Run(ctx context.Context) {
+	poll := time.NewTicker(pollInterval)
+	defer poll.Stop()
+	prune := time.NewTicker(time.Hour)
+	defer prune.Stop()
+
+	for {
+		d.dispatch(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-model.WebhookDeliveriesQueued():
+		case <-poll.C:
+		case <-prune.C:
+			if pruned, err := model.PruneWebhookDeliveries(time.Now().Add(-retention)); err != nil {
+				d.logger.Error("Failed to prune webhook deliveries", "error", err)
+			} else if pruned > 0 {
+				d.logger.Info("Pruned webhook deliveries", "deliveries", pruned)
+			}
+		}
+	}
+}
+
+// dispatch delivers claimed batches until nothing is due
+func (d *Dispatcher) dispatch(ctx context.Context) {
+	for ctx.Err() == nil {
+		deliveries, err := model.ClaimDueWebhookDeliveries(batchSize)
+		if err != nil {
+			d.logger.Error("Failed to claim webhook deliveries", "error", err)
+			return
+		}
+
+		for i := range deliveries {
+			delivery := &deliveries[i]
+			if err := Deliver(ctx, d.client, delivery); err != nil {
+				d.logger.Error("Failed to record webhook delivery", "error", err, "deliveryID", delivery.ID)
+			} else if delivery.Status != model.WebhookDeliverySucceeded {
+				d.logger.Warn("Webhook delivery failed", "webhookID", delivery.WebhookID, "deliveryID", delivery.ID,
+					"attempt", delivery.Attempts, "status", delivery.Status, "error", delivery.Error)
+			}
+		}
+
+		if len(deliveries) < batchSize {
+			return
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"log/slog"
+	"slices"
 	"strings"
 	"time"
 
@@ -42,6 +43,56 @@ func (v *VaultIDs) Scan(value interface{}) error {
 	}
 }
 
+// APIKeyPermission is an operation an API key is allowed to perform
+type APIKeyPermission string
+
+const (
+	PermissionVaultRead  APIKeyPermission = "vault:read"  // Read vault values
+	PermissionVaultWrite APIKeyPermission = "vault:write" // Update vault values
+	PermissionVaultList  APIKeyPermission = "vault:list"  // List accessible vaults
+)
+
+// AllAPIKeyPermissions lists every permission, granted to keys created without explicit permissions
+var AllAPIKeyPermissions = []APIKeyPermission{PermissionVaultRead, PermissionVaultWrite, PermissionVaultList}
+
+// IsValid reports whether the permission is one of the known permissions
+func (p APIKeyPermission) IsValid() bool {
+	for _, permission := range AllAPIKeyPermissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// APIKeyPermissions represents a custom type for storing permissions as JSON
+type APIKeyPermissions []APIKeyPermission
+
+// Value implements the driver.Valuer interface for storing as JSON in database
+func (p APIKeyPermissions) Value() (driver.Value, error) {
+	if p == nil {
+		return nil, nil
+	}
+	return json.Marshal(p)
+}
+
+// Scan implements the sql.Scanner interface for reading JSON from database
+func (p *APIKeyPermissions) Scan(value interface{}) error {
+	if value == nil {
+		*p = nil
+		return nil
+	}
+
+	switch s := value.(type) {
+	case []byte:
+		return json.Unmarshal(s, p)
+	case string:
+		return json.Unmarshal([]byte(s), p)
+	default:
+		return errors.New("cannot scan APIKeyPermissions from this type")
+	}
+}
+
 // APIKey represents an API key for accessing vaults
 type APIKey struct {
 	gorm.Model
@@ -51,14 +102,44 @@ type APIKey struct {
 	VaultIDs   VaultIDs   `gorm:"type:json"`               // JSON array of vault IDs (null = all user's vaults)
 	ExpiresAt  *time.Time `gorm:"index"`                   // Optional expiration date
 	LastUsedAt *time.Time // Track when it was last used
+
+	Permissions APIKeyPermissions `gorm:"type:json"` // JSON array of permissions (null = all permissions)
+
+	// Workload identity the key was exchanged for; such keys are short-lived and not listed
+	WorkloadIdentityID *uint `gorm:"index"`
 }
 
 // CreateAPIKeyParams defines parameters for creating a new API key
 type CreateAPIKeyParams struct {
-	UserID    uint
-	Name      string
-	VaultIDs  []uint // Empty slice or nil means all user's vaults
-	ExpiresAt *time.Time
+	UserID      uint
+	Name        string
+	VaultIDs    []uint // Empty slice or nil means all user's vaults
+	ExpiresAt   *time.Time
+	Permissions []APIKeyPermission // Nil means all permissions
+}
+
+// validatePermissions checks that an explicit permission set is non-empty and only holds known permissions
+func validatePermissions(permissions []APIKeyPermission) string {
+	if len(permissions) == 0 {
+		return "at least one permission is required"
+	}
+	for _, permission := range permissions {
+		if !permission.IsValid() {
+			return "unknown permission: " + string(permission)
+		}
+	}
+	return ""
+}
+
+// normalizePermissions removes duplicate permissions, keeping their order
+func normalizePermissions(permissions []APIKeyPermission) APIKeyPermissions {
+	normalized := make(APIKeyPermissions, 0, len(permissions))
+	for _, permission := range permissions {
+		if !slices.Contains(normalized, permission) {
+			normalized = append(normalized, permission)
+		}
+	}
+	return normalized
 }
 
 // Validate validates the create API key parameters
@@ -84,6 +165,12 @@ func (params *CreateAPIKeyParams) Validate() map[string]string {
 		errors["expires_at"] = "expiration date must be in the future"
 	}
 
+	if params.Permissions != nil {
+		if msg := validatePermissions(params.Permissions); msg != "" {
+			errors["permissions"] = msg
+		}
+	}
+
 	return errors
 }
 
@@ -129,6 +216,9 @@ func (params *CreateAPIKeyParams) Create() (*APIKey, string, error) {
 		VaultIDs:  vaultIDs,
 		ExpiresAt: params.ExpiresAt,
 	}
+	if params.Permissions != nil {
+		apiKey.Permissions = normalizePermissions(params.Permissions)
+	}
 
 	err = DB.Create(&apiKey).Error
 	if err != nil {
@@ -152,6 +242,17 @@ func GetAPIKeyByHash(keyHash string) (*APIKey, error) {
 	return &apiKey, nil
 }
 
+// GetActiveAPIKey reloads an API key by ID, failing if it has been deleted or has expired
+func GetActiveAPIKey(id uint) (*APIKey, error) {
+	var apiKey APIKey
+	err := DB.Where("id = ? AND (expires_at IS NULL OR expires_at > ?)", id, time.Now()).
+		First(&apiKey).Error
+	if err != nil {
+		return nil, err
+	}
+	return &apiKey, nil
+}
+
 // ValidateAPIKey validates an API key and returns the associated user
 func ValidateAPIKey(key string) (*APIKey, error) {
 	if !strings.HasPrefix(key, "vhub_") {
@@ -180,15 +281,21 @@ func ValidateAPIKey(key string) (*APIKey, error) {
 
 // HasVaultAccess checks if the API key has access to a specific vault
 func (k *APIKey) HasVaultAccess(vaultID uint) bool {
-	// First, verify that the vault belongs to the user who owns this API key
+	return k.hasVaultAccess(DB, vaultID)
+}
+
+// hasVaultAccess checks access to a vault looked up with the given query
+func (k *APIKey) hasVaultAccess(db *gorm.DB, vaultID uint) bool {
+	// First, verify that the user who owns this API key can access the vault,
+	// either as its owner or as a member of the organization that owns it
 	var vault Vault
-	err := DB.Where("id = ? AND user_id = ?", vaultID, k.UserID).First(&vault).Error
+	err := db.Scopes(AccessibleVaults(k.UserID)).Where("id = ?", vaultID).First(&vault).Error
 	if err != nil {
-		// Vault doesn't exist or doesn't belong to the user
+		// Vault doesn't exist or the user can't access it
 		return false
 	}
 
-	// If VaultIDs is empty, it means access to all vaults belonging to the user
+	// If VaultIDs is empty, it means access to all vaults the user can access
 	if len(k.VaultIDs) == 0 {
 		return true
 	}
@@ -203,15 +310,33 @@ func (k *APIKey) HasVaultAccess(vaultID uint) bool {
 	return false
 }
 
+// HasPermission checks if the API key is allowed to perform an operation.
+// Keys without an explicit permission set, including those created before
+// permissions existed, are allowed everything.
+func (k *APIKey) HasPermission(permission APIKeyPermission) bool {
+	if k.Permissions == nil {
+		return true
+	}
+	return slices.Contains(k.Permissions, permission)
+}
+
+// EffectivePermissions returns the permissions the API key is granted
+func (k *APIKey) EffectivePermissions() []APIKeyPermission {
+	if k.Permissions == nil {
+		return AllAPIKeyPermissions
+	}
+	return k.Permissions
+}
+
 // GetAccessibleVaults returns the vaults this API key can access
 func (k *APIKey) GetAccessibleVaults() ([]Vault, error) {
 	var vaults []Vault
 
-	query := DB.Where("user_id = ?", k.UserID)
+	query := DB.Scopes(AccessibleVaults(k.UserID))
 
 	// If VaultIDs is specified, filter by those IDs
 	if len(k.VaultIDs) > 0 {
-		query = query.Where("id IN ?", []uint(k.VaultIDs))
+		query = query.Where("vaults.id IN ?", []uint(k.VaultIDs))
 	}
 
 	err := query.Order("favourite DESC, created_at DESC").Find(&vaults).Error
@@ -220,9 +345,10 @@ func (k *APIKey) GetAccessibleVaults() ([]Vault, error) {
 
 // UpdateAPIKeyParams defines parameters for updating an API key
 type UpdateAPIKeyParams struct {
-	Name      *string
-	VaultIDs  *[]uint
-	ExpiresAt *time.Time
+	Name        *string
+	VaultIDs    *[]uint
+	ExpiresAt   *time.Time
+	Permissions *[]APIKeyPermission
 }
 
 // Validate validates the update API key parameters
@@ -243,6 +369,12 @@ func (params *UpdateAPIKeyParams) Validate() map[string]string {
 		errors["expires_at"] = "expiration date must be in the future"
 	}
 
+	if params.Permissions != nil {
+		if msg := validatePermissions(*params.Permissions); msg != "" {
+			errors["permissions"] = msg
+		}
+	}
+
 	return errors
 }
 
@@ -285,6 +417,10 @@ func (k *APIKey) Update(params UpdateAPIKeyParams) error {
 		k.ExpiresAt = params.ExpiresAt
 	}
 
+	if params.Permissions != nil {
+		k.Permissions = normalizePermissions(*params.Permissions)
+	}
+
 	return DB.Save(k).Error
 }
 
@@ -301,7 +437,8 @@ func GetUserAPIKeysWithPagination(userID uint, pageSize, pageIndex int) ([]APIKe
 	var totalCount int64
 
 	// Get total count
-	err := DB.Model(&APIKey{}).Where("user_id = ?", userID).Count(&totalCount).Error
+	query := DB.Model(&APIKey{}).Where("user_id = ? AND workload_identity_id IS NULL", userID)
+	err := query.Count(&totalCount).Error
 	if err != nil {
 		return nil, 0, err
 	}
@@ -310,8 +447,7 @@ func GetUserAPIKeysWithPagination(userID uint, pageSize, pageIndex int) ([]APIKe
 	offset := (pageIndex - 1) * pageSize
 
 	// Get paginated results
-	err = DB.Where("user_id = ?", userID).
-		Order("created_at DESC").
+	err = query.Order("created_at DESC").
 		Limit(pageSize).
 		Offset(offset).
 		Find(&apiKeys).Error
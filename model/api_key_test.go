@@ -0,0 +1,45 @@
+package model
+
+import (
+	"testing"
+)
+
+func TestAPIKeyPermissions(t *testing.T) {
+	params := CreateAPIKeyParams{
+		UserID:      4343,
+		Name:        "read-only",
+		Permissions: []APIKeyPermission{PermissionVaultRead, PermissionVaultRead, PermissionVaultList},
+	}
+	if errors := params.Validate(); len(errors) > 0 {
+		t.Fatalf("unexpected validation errors: %v", errors)
+	}
+
+	apiKey, _, err := params.Create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	var stored APIKey
+	if err := DB.First(&stored, apiKey.ID).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if len(stored.Permissions) != 2 {
+		t.Fatalf("expected duplicate permissions to be removed, got %v", stored.Permissions)
+	}
+	if !stored.HasPermission(PermissionVaultRead) || stored.HasPermission(PermissionVaultWrite) {
+		t.Fatalf("unexpected permissions: %v", stored.Permissions)
+	}
+
+	// Keys created without permissions keep full access
+	legacy := APIKey{}
+	if !legacy.HasPermission(PermissionVaultWrite) || len(legacy.EffectivePermissions()) != len(AllAPIKeyPermissions) {
+		t.Fatal("expected a key without permissions to be allowed everything")
+	}
+
+	for _, invalid := range [][]APIKeyPermission{{}, {"vault:admin"}} {
+		params := CreateAPIKeyParams{UserID: 4343, Name: "invalid", Permissions: invalid}
+		if errors := params.Validate(); errors["permissions"] == "" {
+			t.Errorf("expected a validation error for permissions %v", invalid)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// auditChainVersion prefixes the canonical contents so the format can change later
+const auditChainVersion = "v1"
+
+// auditChainRetries is how often an insert is retried when another server extended the chain first
+const auditChainRetries = 5
+
+// auditChainBatchSize is the number of entries loaded at a time while verifying
+const auditChainBatchSize = 500
+
+// auditChainMu serializes appends so each user's chain stays linear within this process.
+// Across processes the unique (user_id, prev_hash) index rejects forks.
+var auditChainMu sync.Mutex
+
+// Reasons reported for a broken link
+const (
+	AuditChainHashMismatch     = "entry contents do not match its hash"
+	AuditChainPrevHashMismatch = "entry does not link to the previous entry"
+	AuditChainMissingHash      = "entry has no hash"
+)
+
+// AuditChainReport is the result of verifying a user's audit chain
+type AuditChainReport struct {
+	UserID   uint
+	Verified int    // Entries checked before the first broken link, or all of them
+	LastHash string // Hash of the last verified entry, to compare with a saved copy
+	// Set when a broken link was found
+	BrokenID *uint
+	Reason   string
+}
+
+// Valid reports whether the whole chain verified
+func (r *AuditChainReport) Valid() bool {
+	return r.BrokenID == nil
+}
+
+// auditLogContent is the canonical form of an entry that is hashed
+type auditLogContent struct {
+	Version        string           `json:"v"`
+	PrevHash       string           `json:"prevHash"`
+	CreatedAt      string           `json:"createdAt"`
+	UserID         uint             `json:"userId"`
+	Action         ActionType       `json:"action"`
+	Source         SourceType       `json:"source"`
+	VaultID        *uint            `json:"vaultId"`
+	APIKeyID       *uint            `json:"apiKeyId"`
+	OrganizationID *uint            `json:"organizationId"`
+	MemberRole     OrganizationRole `json:"memberRole"`
+	IPAddress      string           `json:"ipAddress"`
+	UserAgent      string           `json:"userAgent"`
+}
+
+// computeHash returns the hash of the entry's canonical contents and previous hash
+func (a *AuditLog) computeHash() string {
+	prevHash := ""
+	if a.PrevHash != nil {
+		prevHash = *a.PrevHash
+	}
+
+	content, _ := json.Marshal(auditLogContent{
+		Version:        auditChainVersion,
+		PrevHash:       prevHash,
+		CreatedAt:      a.CreatedAt.UTC().Format(time.RFC3339Nano),
+		UserID:         a.UserID,
+		Action:         a.Action,
+		Source:         a.Source,
+		VaultID:        a.VaultID,
+		APIKeyID:       a.APIKeyID,
+		OrganizationID: a.OrganizationID,
+		MemberRole:     a.MemberRole,
+		IPAddress:      a.IPAddress,
+		UserAgent:      a.UserAgent,
+	})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// lastAuditHash returns the hash at the end of a user's chain, or "" if the chain is empty
+func lastAuditHash(db *gorm.DB, userID uint) (string, error) {
+	var last AuditLog
+	err := db.Unscoped().Select("hash").
+		Where("user_id = ? AND hash IS NOT NULL", userID).
+		Order("id DESC").
+		First(&last).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return *last.Hash, nil
+}
+
+// appendToAuditChain links the entry to the end of its user's chain and inserts it
+func appendToAuditChain(auditLog *AuditLog) error {
+	auditChainMu.Lock()
+	defer auditChainMu.Unlock()
+
+	// Stored timestamps are truncated by some databases, so hash what will be read back
+	auditLog.CreatedAt = time.Now().UTC().Truncate(time.Millisecond)
+	auditLog.UpdatedAt = auditLog.CreatedAt
+
+	var err error
+	for range auditChainRetries {
+		var prevHash string
+		prevHash, err = lastAuditHash(DB, auditLog.UserID)
+		if err != nil {
+			return err
+		}
+
+		auditLog.ID = 0
+		auditLog.PrevHash = &prevHash
+		hash := auditLog.computeHash()
+		auditLog.Hash = &hash
+
+		if err = DB.Create(auditLog).Error; err == nil {
+			return nil
+		}
+
+		// Retry only if another server appended to the chain in the meantime
+		current, tailErr := lastAuditHash(DB, auditLog.UserID)
+		if tailErr != nil || current == prevHash {
+			return err
+		}
+	}
+	return err
+}
+
+// VerifyAuditChain walks a user's audit chain from the start and reports the first entry
+// that was modified, or whose predecessor was removed or replaced. Entries written before
+// chaining was introduced are skipped. Removing entries from the end of the chain is only
+// detectable by comparing LastHash with a previously saved value.
+func VerifyAuditChain(userID uint) (*AuditChainReport, error) {
+	report := &AuditChainReport{UserID: userID}
+	started := false
+	var afterID uint
+
+	for {
+		var batch []AuditLog
+		err := DB.Unscoped().
+			Where("user_id = ? AND id > ?", userID, afterID).
+			Order("id ASC").
+			Limit(auditChainBatchSize).
+			Find(&batch).Error
+		if err != nil {
+			return nil, err
+		}
+
+		for i := range batch {
+			entry := &batch[i]
+			afterID = entry.ID
+
+			if entry.Hash == nil {
+				if !started {
+					continue
+				}
+				report.broken(entry.ID, AuditChainMissingHash)
+				return report, nil
+			}
+			started = true
+
+			if entry.PrevHash == nil || *entry.PrevHash != report.LastHash {
+				report.broken(entry.ID, AuditChainPrevHashMismatch)
+				return report, nil
+			}
+			if entry.computeHash() != *entry.Hash {
+				report.broken(entry.ID, AuditChainHashMismatch)
+				return report, nil
+			}
+
+			report.Verified++
+			report.LastHash = *entry.Hash
+		}
+
+		if len(batch) < auditChainBatchSize {
+			return report, nil
+		}
+	}
+}
+
+// VerifyAllAuditChains verifies the audit chain of every user with audit logs
+func VerifyAllAuditChains() ([]*AuditChainReport, error) {
+	var userIDs []uint
+	err := DB.Unscoped().Model(&AuditLog{}).Distinct("user_id").Order("user_id").Pluck("user_id", &userIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	reports := make([]*AuditChainReport, 0, len(userIDs))
+	for _, userID := range userIDs {
+		report, err := VerifyAuditChain(userID)
+		if err != nil {
+			return nil, fmt.Errorf("user %d: %w", userID, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+func (r *AuditChainReport) broken(id uint, reason string) {
+	r.BrokenID = &id
+	r.Reason = reason
+}
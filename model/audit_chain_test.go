@@ -0,0 +1,80 @@
+package model
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAuditChainStaysLinearUnderConcurrency(t *testing.T) {
+	user := createTestUser(t)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 20)
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- LogUserAction(ActionLoginUser, user.ID, SourceWeb, "127.0.0.1", "test")
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("log action: %v", err)
+		}
+	}
+
+	report, err := VerifyAuditChain(user.ID)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if !report.Valid() || report.Verified != 20 {
+		t.Fatalf("expected 20 verified entries, got %d (broken at %v: %s)", report.Verified, report.BrokenID, report.Reason)
+	}
+}
+
+func TestAuditChainDetectsTampering(t *testing.T) {
+	user := createTestUser(t)
+	for range 4 {
+		if err := LogUserAction(ActionLoginUser, user.ID, SourceWeb, "127.0.0.1", "test"); err != nil {
+			t.Fatalf("log action: %v", err)
+		}
+	}
+
+	var logs []AuditLog
+	if err := DB.Where("user_id = ?", user.ID).Order("id ASC").Find(&logs).Error; err != nil {
+		t.Fatalf("load logs: %v", err)
+	}
+
+	// Editing an entry breaks its own hash
+	originalIP := logs[1].IPAddress
+	if err := DB.Model(&logs[1]).Update("ip_address", "10.0.0.1").Error; err != nil {
+		t.Fatalf("tamper: %v", err)
+	}
+	report, err := VerifyAuditChain(user.ID)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if report.Valid() || *report.BrokenID != logs[1].ID || report.Reason != AuditChainHashMismatch {
+		t.Fatalf("expected entry %d to be reported as modified, got %v: %s", logs[1].ID, report.BrokenID, report.Reason)
+	}
+	if report.Verified != 1 || report.LastHash != *logs[0].Hash {
+		t.Fatalf("expected only the first entry to verify, got %d", report.Verified)
+	}
+
+	// Removing an entry breaks the link of the next one
+	if err := DB.Model(&logs[1]).Update("ip_address", originalIP).Error; err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if err := DB.Unscoped().Delete(&logs[2]).Error; err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	report, err = VerifyAuditChain(user.ID)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if report.Valid() || *report.BrokenID != logs[3].ID || report.Reason != AuditChainPrevHashMismatch {
+		t.Fatalf("expected entry %d to be reported as unlinked, got %v: %s", logs[3].ID, report.BrokenID, report.Reason)
+	}
+}
@@ -0,0 +1,142 @@
+package model
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// auditExportBatchSize is the number of entries loaded at a time while exporting
+const auditExportBatchSize = 500
+
+// AuditForwardCursor records how far the audit log has been delivered to an external sink.
+// The audit log itself is the delivery queue, so entries written while the sink is down
+// are sent once it is reachable again, also across restarts.
+type AuditForwardCursor struct {
+	Name      string `gorm:"primaryKey;size:100"`
+	LastID    uint
+	UpdatedAt time.Time
+}
+
+// AuditLogRecord is an audit log entry as exported or forwarded to external systems. It is
+// flat so it can be written as CSV as well as JSON, and includes the chain hashes so the
+// copy can be checked against the database later.
+type AuditLogRecord struct {
+	ID             uint      `json:"id"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UserID         uint      `json:"userId"`
+	UserEmail      string    `json:"userEmail,omitempty"`
+	Action         string    `json:"action"`
+	Source         string    `json:"source"`
+	VaultUniqueID  string    `json:"vaultUniqueId,omitempty"`
+	VaultName      string    `json:"vaultName,omitempty"`
+	APIKeyID       *uint     `json:"apiKeyId,omitempty"`
+	APIKeyName     string    `json:"apiKeyName,omitempty"`
+	OrganizationID *uint     `json:"organizationId,omitempty"`
+	MemberRole     string    `json:"memberRole,omitempty"`
+	IPAddress      string    `json:"ipAddress,omitempty"`
+	UserAgent      string    `json:"userAgent,omitempty"`
+	PrevHash       *string   `json:"prevHash,omitempty"`
+	Hash           *string   `json:"hash,omitempty"`
+}
+
+// Record converts the entry to an AuditLogRecord. The user, vault and API key are
+// included when they were preloaded.
+func (a *AuditLog) Record() AuditLogRecord {
+	record := AuditLogRecord{
+		ID:             a.ID,
+		CreatedAt:      a.CreatedAt,
+		UserID:         a.UserID,
+		UserEmail:      a.User.Email,
+		Action:         string(a.Action),
+		Source:         string(a.Source),
+		APIKeyID:       a.APIKeyID,
+		OrganizationID: a.OrganizationID,
+		MemberRole:     string(a.MemberRole),
+		IPAddress:      a.IPAddress,
+		UserAgent:      a.UserAgent,
+		PrevHash:       a.PrevHash,
+		Hash:           a.Hash,
+	}
+	if a.Vault != nil {
+		record.VaultUniqueID = a.Vault.UniqueID
+		record.VaultName = a.Vault.Name
+	}
+	if a.APIKey != nil {
+		record.APIKeyName = a.APIKey.Name
+	}
+	return record
+}
+
+// ExportAuditLogs calls fn with every audit log matching the filters, oldest first, in
+// batches so large exports are not loaded into memory at once. Limit and Offset are ignored.
+func ExportAuditLogs(params GetAuditLogsWithFiltersParams, fn func([]AuditLog) error) error {
+	var afterID uint
+	for {
+		var batch []AuditLog
+		err := DB.Scopes(auditLogOwner(params), auditLogFilters(params), preloadAuditLogRelations).
+			Where("id > ?", afterID).
+			Order("id ASC").
+			Limit(auditExportBatchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := fn(batch); err != nil {
+			return err
+		}
+		afterID = batch[len(batch)-1].ID
+
+		if len(batch) < auditExportBatchSize {
+			return nil
+		}
+	}
+}
+
+// GetAuditLogsAfter returns up to limit audit logs of all users recorded after the given ID,
+// oldest first
+func GetAuditLogsAfter(afterID uint, limit int) ([]AuditLog, error) {
+	var logs []AuditLog
+	err := DB.Scopes(preloadAuditLogRelations).
+		Where("id > ?", afterID).
+		Order("id ASC").
+		Limit(limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// GetAuditForwardCursor returns the ID of the last audit log delivered by the named forwarder.
+// A new forwarder starts at the current end of the log instead of replaying its history.
+func GetAuditForwardCursor(name string) (uint, error) {
+	var cursor AuditForwardCursor
+	err := DB.Where("name = ?", name).First(&cursor).Error
+	if err == nil {
+		return cursor.LastID, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+
+	var lastID uint
+	err = DB.Unscoped().Model(&AuditLog{}).Select("COALESCE(MAX(id), 0)").Scan(&lastID).Error
+	if err != nil {
+		return 0, err
+	}
+	if err := SaveAuditForwardCursor(name, lastID); err != nil {
+		return 0, err
+	}
+	return lastID, nil
+}
+
+// SaveAuditForwardCursor records that the named forwarder delivered every audit log up to lastID
+func SaveAuditForwardCursor(name string, lastID uint) error {
+	return DB.Save(&AuditForwardCursor{Name: name, LastID: lastID}).Error
+}
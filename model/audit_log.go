@@ -1,6 +1,7 @@
 package model
 
 import (
+	"slices"
 	"time"
 
 	"gorm.io/gorm"
@@ -26,6 +27,44 @@ const (
 	ActionRequestMagicLink     ActionType = "request_magic_link"
 	ActionMagicLinkLogin       ActionType = "magic_link_login"
 	ActionSendSignupEmail      ActionType = "send_signup_email"
+	ActionRestoreVaultVersion  ActionType = "restore_vault_version"
+
+	ActionCreateOrganization       ActionType = "create_organization"
+	ActionUpdateOrganization       ActionType = "update_organization"
+	ActionDeleteOrganization       ActionType = "delete_organization"
+	ActionAddOrganizationMember    ActionType = "add_organization_member"
+	ActionUpdateOrganizationMember ActionType = "update_organization_member"
+	ActionRemoveOrganizationMember ActionType = "remove_organization_member"
+
+	ActionEnableTwoFactor         ActionType = "enable_two_factor"
+	ActionDisableTwoFactor        ActionType = "disable_two_factor"
+	ActionUseRecoveryCode         ActionType = "use_recovery_code"
+	ActionRegenerateRecoveryCodes ActionType = "regenerate_recovery_codes"
+	ActionRegisterCredential      ActionType = "register_credential"
+	ActionDeleteCredential        ActionType = "delete_credential"
+	ActionWebAuthnLogin           ActionType = "webauthn_login"
+	ActionRevokeSession           ActionType = "revoke_session"
+	ActionRevokeAllSessions       ActionType = "revoke_all_sessions"
+	ActionLoginFailed             ActionType = "login_failed"
+	ActionTwoFactorFailed         ActionType = "two_factor_failed"
+	ActionAccountLocked           ActionType = "account_locked"
+
+	ActionCreateWorkloadIdentity ActionType = "create_workload_identity"
+	ActionDeleteWorkloadIdentity ActionType = "delete_workload_identity"
+	ActionTokenExchange          ActionType = "token_exchange"
+
+	ActionCreateShare ActionType = "create_share"
+	ActionViewShare   ActionType = "view_share"
+	ActionWrapVault   ActionType = "wrap_vault"
+	ActionUnwrapVault ActionType = "unwrap_vault"
+
+	ActionSetRotationPolicy    ActionType = "set_rotation_policy"
+	ActionDeleteRotationPolicy ActionType = "delete_rotation_policy"
+	ActionRotateVault          ActionType = "rotate_vault"
+	ActionRotateVaultFailed    ActionType = "rotate_vault_failed"
+
+	ActionIssueDatabaseCredentials  ActionType = "issue_database_credentials"
+	ActionRevokeDatabaseCredentials ActionType = "revoke_database_credentials"
 )
 
 type SourceType string
@@ -33,12 +72,17 @@ type SourceType string
 const (
 	SourceWeb SourceType = "web"
 	SourceCLI SourceType = "cli"
+	// SourceSystem marks actions the server takes on its own, such as scheduled rotations
+	SourceSystem SourceType = "system"
 )
 
 var (
 	vaultActions = []string{
 		string(ActionReadVault), string(ActionUpdateVault),
 		string(ActionDeleteVault), string(ActionCreateVault),
+		string(ActionRestoreVaultVersion), string(ActionWrapVault),
+		string(ActionUnwrapVault), string(ActionRotateVault),
+		string(ActionIssueDatabaseCredentials),
 	}
 	apiKeyActions = []string{
 		string(ActionCreateAPIKey), string(ActionUpdateAPIKey),
@@ -53,11 +97,19 @@ type AuditLog struct {
 	APIKeyID  *uint      `gorm:"index"`
 	APIKey    *APIKey    `gorm:"foreignKey:APIKeyID"`
 	Action    ActionType `gorm:"size:50;index"`
-	UserID    uint       `gorm:"index;constraint:OnDelete:CASCADE"`
+	UserID    uint       `gorm:"index;uniqueIndex:idx_audit_chain,priority:1;constraint:OnDelete:CASCADE"`
 	User      User       `gorm:"foreignKey:UserID"`
 	Source    SourceType `gorm:"size:10;index"`
 	IPAddress string     `gorm:"size:45"`
 	UserAgent string     `gorm:"size:500"`
+	// Organization the action happened in and the role the acting member held at the time
+	OrganizationID *uint            `gorm:"index"`
+	MemberRole     OrganizationRole `gorm:"size:20"`
+
+	// Tamper-evident chain: Hash covers the entry's contents and PrevHash, the hash of the
+	// user's previous entry ("" for the first). Entries written before chaining have neither.
+	PrevHash *string `gorm:"size:64;uniqueIndex:idx_audit_chain,priority:2"`
+	Hash     *string `gorm:"size:64"`
 }
 
 // CreateAuditLogParams defines parameters for creating an audit log entry
@@ -69,31 +121,45 @@ type CreateAuditLogParams struct {
 	Source    SourceType
 	IPAddress string
 	UserAgent string
+
+	OrganizationID *uint
+	MemberRole     OrganizationRole
 }
 
 // CreateAuditLog creates a new audit log entry
 func CreateAuditLog(params CreateAuditLogParams) error {
+	_, err := createAuditLog(params)
+	return err
+}
+
+// createAuditLog appends a new audit log entry to its user's chain and returns it
+func createAuditLog(params CreateAuditLogParams) (*AuditLog, error) {
 	auditLog := AuditLog{
 		VaultID:   params.VaultID,
 		APIKeyID:  params.APIKeyID,
 		Action:    params.Action,
 		UserID:    params.UserID,
 		Source:    params.Source,
-		IPAddress: params.IPAddress,
-		UserAgent: params.UserAgent,
+		IPAddress: truncate(params.IPAddress, 45),
+		UserAgent: truncate(params.UserAgent, 500),
+
+		OrganizationID: params.OrganizationID,
+		MemberRole:     params.MemberRole,
 	}
 
-	err := DB.Create(&auditLog).Error
-	if err != nil {
-		return err
+	if err := appendToAuditChain(&auditLog); err != nil {
+		return nil, err
 	}
 
-	return nil
+	return &auditLog, nil
 }
 
-// LogVaultAction logs a vault-related action
+// LogVaultAction logs a vault-related action. For organization vaults the organization
+// and the role of the acting member are recorded as well. Changes to the vault are
+// broadcast to the subscribers of SubscribeVaultEvents, and the action is queued for
+// delivery to subscribed webhooks.
 func LogVaultAction(vaultID uint, action ActionType, userID uint, source SourceType, apiKeyID *uint, ipAddress, userAgent string) error {
-	return CreateAuditLog(CreateAuditLogParams{
+	params := CreateAuditLogParams{
 		VaultID:   &vaultID,
 		APIKeyID:  apiKeyID,
 		Action:    action,
@@ -101,6 +167,47 @@ func LogVaultAction(vaultID uint, action ActionType, userID uint, source SourceT
 		Source:    source,
 		IPAddress: ipAddress,
 		UserAgent: userAgent,
+	}
+
+	// Deleted vaults are still logged, so look them up unscoped
+	var vault Vault
+	if err := DB.Unscoped().Select("id", "unique_id", "name", "user_id", "organization_id").First(&vault, vaultID).Error; err == nil && vault.OrganizationID != nil {
+		params.OrganizationID = vault.OrganizationID
+		if role, err := vault.RoleFor(userID); err == nil {
+			params.MemberRole = role
+		}
+	}
+
+	auditLog, err := createAuditLog(params)
+	if err != nil {
+		return err
+	}
+
+	queueWebhookDeliveries(auditLog, &vault)
+	if slices.Contains(vaultChangeActions, action) {
+		publishVaultEvent(VaultEvent{
+			ID:             auditLog.ID,
+			Action:         action,
+			VaultID:        vaultID,
+			VaultUniqueID:  vault.UniqueID,
+			VaultName:      vault.Name,
+			OrganizationID: vault.OrganizationID,
+			CreatedAt:      auditLog.CreatedAt,
+		})
+	}
+	return nil
+}
+
+// LogOrganizationAction logs an organization-related action with the role of the acting member
+func LogOrganizationAction(organizationID uint, action ActionType, userID uint, role OrganizationRole, source SourceType, ipAddress, userAgent string) error {
+	return CreateAuditLog(CreateAuditLogParams{
+		Action:         action,
+		UserID:         userID,
+		Source:         source,
+		IPAddress:      ipAddress,
+		UserAgent:      userAgent,
+		OrganizationID: &organizationID,
+		MemberRole:     role,
 	})
 }
 
@@ -115,9 +222,9 @@ func LogUserAction(action ActionType, userID uint, source SourceType, ipAddress,
 	})
 }
 
-// LogAPIKeyAction logs an API key-related action
+// LogAPIKeyAction logs an API key-related action and queues it for delivery to the user's webhooks
 func LogAPIKeyAction(apiKeyID uint, action ActionType, userID uint, source SourceType, ipAddress, userAgent string) error {
-	return CreateAuditLog(CreateAuditLogParams{
+	auditLog, err := createAuditLog(CreateAuditLogParams{
 		APIKeyID:  &apiKeyID,
 		Action:    action,
 		UserID:    userID,
@@ -125,6 +232,12 @@ func LogAPIKeyAction(apiKeyID uint, action ActionType, userID uint, source Sourc
 		IPAddress: ipAddress,
 		UserAgent: userAgent,
 	})
+	if err != nil {
+		return err
+	}
+
+	queueWebhookDeliveries(auditLog, nil)
+	return nil
 }
 
 // GetAuditLogsWithFiltersParams defines parameters for filtering audit logs
@@ -136,39 +249,17 @@ type GetAuditLogsWithFiltersParams struct {
 	EndDate   *time.Time
 	Limit     int
 	Offset    int
+
+	// When set, logs of the whole organization are returned instead of those of UserID
+	OrganizationID *uint
 }
 
 // GetAuditLogsWithFilters retrieves audit logs with optional filtering and pagination
 func GetAuditLogsWithFilters(params GetAuditLogsWithFiltersParams) ([]AuditLog, error) {
 	var logs []AuditLog
-	query := DB.Where("user_id = ?", params.UserID).
-		Preload("User").
-		Preload("Vault", func(db *gorm.DB) *gorm.DB {
-			return db.Unscoped()
-		}).
-		Preload("APIKey", func(db *gorm.DB) *gorm.DB {
-			return db.Unscoped()
-		}).
+	query := DB.Scopes(auditLogOwner(params), auditLogFilters(params), preloadAuditLogRelations).
 		Order("created_at DESC")
 
-	// Add vault filter if specified
-	if params.VaultID != nil {
-		query = query.Where("vault_id = ?", *params.VaultID)
-	}
-
-	// Add source filter if specified
-	if params.Source != nil {
-		query = query.Where("source = ?", *params.Source)
-	}
-
-	// Add date range filter if specified
-	if params.StartDate != nil {
-		query = query.Where("created_at >= ?", *params.StartDate)
-	}
-	if params.EndDate != nil {
-		query = query.Where("created_at <= ?", *params.EndDate)
-	}
-
 	// Add pagination
 	if params.Limit > 0 {
 		query = query.Limit(params.Limit)
@@ -188,25 +279,7 @@ func GetAuditLogsWithFilters(params GetAuditLogsWithFiltersParams) ([]AuditLog,
 // CountAuditLogsWithFilters counts total audit logs matching the filter criteria
 func CountAuditLogsWithFilters(params GetAuditLogsWithFiltersParams) (int64, error) {
 	var count int64
-	query := DB.Model(&AuditLog{}).Where("user_id = ?", params.UserID)
-
-	// Add vault filter if specified
-	if params.VaultID != nil {
-		query = query.Where("vault_id = ?", *params.VaultID)
-	}
-
-	// Add source filter if specified
-	if params.Source != nil {
-		query = query.Where("source = ?", *params.Source)
-	}
-
-	// Add date range filter if specified
-	if params.StartDate != nil {
-		query = query.Where("created_at >= ?", *params.StartDate)
-	}
-	if params.EndDate != nil {
-		query = query.Where("created_at <= ?", *params.EndDate)
-	}
+	query := DB.Model(&AuditLog{}).Scopes(auditLogOwner(params), auditLogFilters(params))
 
 	err := query.Count(&count).Error
 	if err != nil {
@@ -216,6 +289,51 @@ func CountAuditLogsWithFilters(params GetAuditLogsWithFiltersParams) (int64, err
 	return count, nil
 }
 
+// auditLogFilters applies the vault, source and date range filters
+func auditLogFilters(params GetAuditLogsWithFiltersParams) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		// Add vault filter if specified
+		if params.VaultID != nil {
+			db = db.Where("vault_id = ?", *params.VaultID)
+		}
+
+		// Add source filter if specified
+		if params.Source != nil {
+			db = db.Where("source = ?", *params.Source)
+		}
+
+		// Add date range filter if specified
+		if params.StartDate != nil {
+			db = db.Where("created_at >= ?", *params.StartDate)
+		}
+		if params.EndDate != nil {
+			db = db.Where("created_at <= ?", *params.EndDate)
+		}
+		return db
+	}
+}
+
+// preloadAuditLogRelations loads the user, vault and API key of audit logs, including deleted ones
+func preloadAuditLogRelations(db *gorm.DB) *gorm.DB {
+	return db.Preload("User").
+		Preload("Vault", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		}).
+		Preload("APIKey", func(db *gorm.DB) *gorm.DB {
+			return db.Unscoped()
+		})
+}
+
+// auditLogOwner scopes audit logs to an organization if one is given, otherwise to the user
+func auditLogOwner(params GetAuditLogsWithFiltersParams) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if params.OrganizationID != nil {
+			return db.Where("organization_id = ?", *params.OrganizationID)
+		}
+		return db.Where("user_id = ?", params.UserID)
+	}
+}
+
 // AuditMetrics holds all audit metrics for efficient single-query retrieval
 type AuditMetrics struct {
 	TotalEventsLast30Days  int64
@@ -258,3 +376,15 @@ func GetAllAuditMetrics(userID uint) (*AuditMetrics, error) {
 		APIKeyEventsLast30Days: result.APIKeyEventsLast30Days,
 	}, nil
 }
+
+// truncate shortens s to at most n characters so that it is stored, and hashed, unchanged
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
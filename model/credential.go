@@ -0,0 +1,283 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/google/uuid"
+)
+
+// maxCredentialIDLength is the longest base64url credential ID that can be stored
+const maxCredentialIDLength = 512
+
+// ErrCredentialExists is returned when registering a credential that is already registered
+var ErrCredentialExists = errors.New("credential is already registered")
+
+// CredentialTransports is a list of WebAuthn transports (usb, nfc, ble, internal, hybrid)
+type CredentialTransports []string
+
+// Value implements the driver.Valuer interface for storing as JSON in database
+func (t CredentialTransports) Value() (driver.Value, error) {
+	if t == nil {
+		return nil, nil
+	}
+	return json.Marshal(t)
+}
+
+// Scan implements the sql.Scanner interface for reading JSON from database
+func (t *CredentialTransports) Scan(value interface{}) error {
+	if value == nil {
+		*t = nil
+		return nil
+	}
+
+	switch s := value.(type) {
+	case []byte:
+		return json.Unmarshal(s, t)
+	case string:
+		return json.Unmarshal([]byte(s), t)
+	default:
+		return errors.New("cannot scan CredentialTransports from this type")
+	}
+}
+
+// Credential is a WebAuthn public key credential (a passkey or security key) of a user
+type Credential struct {
+	ID              uint `gorm:"primarykey"`
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	UserID          uint                 `gorm:"index;constraint:OnDelete:CASCADE"`
+	User            User                 `gorm:"foreignKey:UserID"`
+	Name            string               `gorm:"size:255"`
+	CredentialID    string               `gorm:"size:512;uniqueIndex"` // base64url encoded
+	PublicKey       []byte               `gorm:"not null"`             // COSE encoded
+	AttestationType string               `gorm:"size:32"`
+	Transports      CredentialTransports `gorm:"type:text"`
+	AAGUID          string               `gorm:"size:36"`
+	SignCount       uint32               `gorm:"not null;default:0"`
+	CloneWarning    bool                 `gorm:"not null;default:false"`
+	UserVerified    bool                 `gorm:"not null;default:false"`
+	BackupEligible  bool                 `gorm:"not null;default:false"`
+	BackupState     bool                 `gorm:"not null;default:false"`
+	LastUsedAt      *time.Time
+}
+
+// WebAuthn converts the stored credential to the form used by WebAuthn ceremonies
+func (c *Credential) WebAuthn() (webauthn.Credential, error) {
+	id, err := base64.RawURLEncoding.DecodeString(c.CredentialID)
+	if err != nil {
+		return webauthn.Credential{}, fmt.Errorf("invalid credential ID: %w", err)
+	}
+
+	transports := make([]protocol.AuthenticatorTransport, 0, len(c.Transports))
+	for _, transport := range c.Transports {
+		transports = append(transports, protocol.AuthenticatorTransport(transport))
+	}
+
+	var aaguid []byte
+	if parsed, err := uuid.Parse(c.AAGUID); err == nil {
+		aaguid = parsed[:]
+	}
+
+	return webauthn.Credential{
+		ID:              id,
+		PublicKey:       c.PublicKey,
+		AttestationType: c.AttestationType,
+		Transport:       transports,
+		Flags: webauthn.CredentialFlags{
+			UserPresent:    true,
+			UserVerified:   c.UserVerified,
+			BackupEligible: c.BackupEligible,
+			BackupState:    c.BackupState,
+		},
+		Authenticator: webauthn.Authenticator{
+			AAGUID:       aaguid,
+			SignCount:    c.SignCount,
+			CloneWarning: c.CloneWarning,
+		},
+	}, nil
+}
+
+// RecordLogin stores the state reported by the authenticator during a successful login
+func (c *Credential) RecordLogin(credential *webauthn.Credential) error {
+	now := time.Now()
+	c.SignCount = credential.Authenticator.SignCount
+	c.CloneWarning = credential.Authenticator.CloneWarning
+	c.BackupState = credential.Flags.BackupState
+	c.LastUsedAt = &now
+	return DB.Model(c).Updates(map[string]interface{}{
+		"sign_count":    c.SignCount,
+		"clone_warning": c.CloneWarning,
+		"backup_state":  c.BackupState,
+		"last_used_at":  now,
+	}).Error
+}
+
+// Delete removes the credential
+func (c *Credential) Delete() error {
+	return DB.Delete(c).Error
+}
+
+// CreateCredentialParams holds a credential verified by a registration ceremony
+type CreateCredentialParams struct {
+	UserID     uint
+	Name       string
+	Credential *webauthn.Credential
+}
+
+func (params *CreateCredentialParams) Validate() map[string]string {
+	errors := map[string]string{}
+	if params.UserID == 0 {
+		errors["userId"] = "user is required"
+	}
+	if len(params.Name) > 255 {
+		errors["name"] = "name must be at most 255 characters"
+	}
+	if params.Credential == nil || len(params.Credential.ID) == 0 {
+		errors["credential"] = "credential is required"
+	} else if base64.RawURLEncoding.EncodedLen(len(params.Credential.ID)) > maxCredentialIDLength {
+		errors["credential"] = "credential ID is too long"
+	}
+	return errors
+}
+
+func (params *CreateCredentialParams) Create() (*Credential, error) {
+	webAuthnCredential := params.Credential
+	credentialID := base64.RawURLEncoding.EncodeToString(webAuthnCredential.ID)
+
+	var count int64
+	if err := DB.Model(&Credential{}).Where("credential_id = ?", credentialID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+	if count > 0 {
+		return nil, ErrCredentialExists
+	}
+
+	transports := make(CredentialTransports, 0, len(webAuthnCredential.Transport))
+	for _, transport := range webAuthnCredential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	var aaguid string
+	if parsed, err := uuid.FromBytes(webAuthnCredential.Authenticator.AAGUID); err == nil {
+		aaguid = parsed.String()
+	}
+
+	name := params.Name
+	if name == "" {
+		name = "Passkey"
+	}
+
+	credential := Credential{
+		UserID:          params.UserID,
+		Name:            name,
+		CredentialID:    credentialID,
+		PublicKey:       webAuthnCredential.PublicKey,
+		AttestationType: webAuthnCredential.AttestationType,
+		Transports:      transports,
+		AAGUID:          aaguid,
+		SignCount:       webAuthnCredential.Authenticator.SignCount,
+		UserVerified:    webAuthnCredential.Flags.UserVerified,
+		BackupEligible:  webAuthnCredential.Flags.BackupEligible,
+		BackupState:     webAuthnCredential.Flags.BackupState,
+	}
+	if err := DB.Create(&credential).Error; err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// GetUserCredentials returns the credentials of a user, oldest first
+func GetUserCredentials(userID uint) ([]Credential, error) {
+	var credentials []Credential
+	err := DB.Where("user_id = ?", userID).Order("id").Find(&credentials).Error
+	return credentials, err
+}
+
+// GetCredential returns a credential of a user
+func GetCredential(id, userID uint) (*Credential, error) {
+	var credential Credential
+	if err := DB.Where("id = ? AND user_id = ?", id, userID).First(&credential).Error; err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// GetCredentialByCredentialID returns the credential with a raw WebAuthn credential ID
+func GetCredentialByCredentialID(userID uint, rawID []byte) (*Credential, error) {
+	var credential Credential
+	err := DB.Where("user_id = ? AND credential_id = ?", userID, base64.RawURLEncoding.EncodeToString(rawID)).
+		First(&credential).Error
+	if err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// WebAuthnAccount is a user together with their credentials, as seen by WebAuthn ceremonies
+type WebAuthnAccount struct {
+	User        *User
+	Credentials []Credential
+}
+
+// NewWebAuthnAccount loads the credentials of a user
+func NewWebAuthnAccount(user *User) (*WebAuthnAccount, error) {
+	credentials, err := GetUserCredentials(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &WebAuthnAccount{User: user, Credentials: credentials}, nil
+}
+
+// GetWebAuthnAccountByHandle returns the account of the user handle an authenticator returned
+func GetWebAuthnAccountByHandle(handle []byte) (*WebAuthnAccount, error) {
+	if len(handle) != 8 {
+		return nil, errors.New("invalid user handle")
+	}
+	var user User
+	if err := DB.First(&user, binary.BigEndian.Uint64(handle)).Error; err != nil {
+		return nil, err
+	}
+	return NewWebAuthnAccount(&user)
+}
+
+// WebAuthnID returns the user handle: the user ID as 8 big-endian bytes. It contains no
+// personal information and never changes.
+func (a *WebAuthnAccount) WebAuthnID() []byte {
+	handle := make([]byte, 8)
+	binary.BigEndian.PutUint64(handle, uint64(a.User.ID))
+	return handle
+}
+
+// WebAuthnName returns the account name shown by authenticators
+func (a *WebAuthnAccount) WebAuthnName() string {
+	return a.User.Email
+}
+
+// WebAuthnDisplayName returns the display name shown by authenticators
+func (a *WebAuthnAccount) WebAuthnDisplayName() string {
+	if a.User.Name != nil && *a.User.Name != "" {
+		return *a.User.Name
+	}
+	return a.User.Email
+}
+
+// WebAuthnCredentials returns the credentials of the account; unreadable ones are skipped
+func (a *WebAuthnAccount) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(a.Credentials))
+	for i := range a.Credentials {
+		credential, err := a.Credentials[i].WebAuthn()
+		if err != nil {
+			continue
+		}
+		credentials = append(credentials, credential)
+	}
+	return credentials
+}
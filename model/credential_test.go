@@ -0,0 +1,111 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+func TestCredential(t *testing.T) {
+	user := createTestUser(t)
+
+	verified := &webauthn.Credential{
+		ID:              []byte{0x01, 0x02, 0x03, byte(user.ID)},
+		PublicKey:       []byte("public-key"),
+		AttestationType: "none",
+		Transport:       []protocol.AuthenticatorTransport{protocol.USB, protocol.Internal},
+		Flags:           webauthn.CredentialFlags{UserPresent: true, UserVerified: true, BackupEligible: true},
+		Authenticator: webauthn.Authenticator{
+			AAGUID:    make([]byte, 16),
+			SignCount: 3,
+		},
+	}
+
+	params := CreateCredentialParams{UserID: user.ID, Credential: verified}
+	if errs := params.Validate(); len(errs) > 0 {
+		t.Fatalf("Validate() = %v", errs)
+	}
+	credential, err := params.Create()
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if credential.Name != "Passkey" || credential.AAGUID != "00000000-0000-0000-0000-000000000000" {
+		t.Fatalf("Create() = %+v", credential)
+	}
+	if _, err := params.Create(); !errors.Is(err, ErrCredentialExists) {
+		t.Fatalf("second Create() error = %v, want ErrCredentialExists", err)
+	}
+
+	// The user handle leads back to the user and their credentials
+	account, err := NewWebAuthnAccount(user)
+	if err != nil {
+		t.Fatalf("NewWebAuthnAccount() error = %v", err)
+	}
+	found, err := GetWebAuthnAccountByHandle(account.WebAuthnID())
+	if err != nil || found.User.ID != user.ID {
+		t.Fatalf("GetWebAuthnAccountByHandle() = %+v, %v", found, err)
+	}
+	credentials := found.WebAuthnCredentials()
+	if len(credentials) != 1 || string(credentials[0].ID) != string(verified.ID) ||
+		len(credentials[0].Transport) != 2 || credentials[0].Authenticator.SignCount != 3 {
+		t.Fatalf("WebAuthnCredentials() = %+v", credentials)
+	}
+
+	verified.Authenticator.SignCount = 4
+	verified.Flags.BackupState = true
+	if err := credential.RecordLogin(verified); err != nil {
+		t.Fatalf("RecordLogin() error = %v", err)
+	}
+	stored, err := GetCredentialByCredentialID(user.ID, verified.ID)
+	if err != nil {
+		t.Fatalf("GetCredentialByCredentialID() error = %v", err)
+	}
+	if stored.SignCount != 4 || !stored.BackupState || stored.LastUsedAt == nil {
+		t.Fatalf("RecordLogin() stored %+v", stored)
+	}
+
+	if err := stored.Delete(); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if _, err := GetCredential(stored.ID, user.ID); err == nil {
+		t.Fatal("GetCredential() found a deleted credential")
+	}
+}
+
+func TestWebAuthnSession(t *testing.T) {
+	user := createTestUser(t)
+	data := &webauthn.SessionData{Challenge: "challenge", UserID: []byte{0x01}}
+
+	token, err := CreateWebAuthnSession(&user.ID, WebAuthnCeremonyRegistration, data, time.Minute)
+	if err != nil {
+		t.Fatalf("CreateWebAuthnSession() error = %v", err)
+	}
+
+	// Sessions are bound to their ceremony and user
+	if _, err := ConsumeWebAuthnSession(token, WebAuthnCeremonyLogin, nil); !errors.Is(err, ErrInvalidWebAuthnSession) {
+		t.Fatalf("ConsumeWebAuthnSession() for another ceremony error = %v", err)
+	}
+	otherUser := user.ID + 1000
+	if _, err := ConsumeWebAuthnSession(token, WebAuthnCeremonyRegistration, &otherUser); !errors.Is(err, ErrInvalidWebAuthnSession) {
+		t.Fatalf("ConsumeWebAuthnSession() for another user error = %v", err)
+	}
+
+	consumed, err := ConsumeWebAuthnSession(token, WebAuthnCeremonyRegistration, &user.ID)
+	if err != nil || consumed.Challenge != data.Challenge {
+		t.Fatalf("ConsumeWebAuthnSession() = %+v, %v", consumed, err)
+	}
+	if _, err := ConsumeWebAuthnSession(token, WebAuthnCeremonyRegistration, &user.ID); !errors.Is(err, ErrInvalidWebAuthnSession) {
+		t.Fatalf("second ConsumeWebAuthnSession() error = %v", err)
+	}
+
+	expired, err := CreateWebAuthnSession(nil, WebAuthnCeremonyLogin, data, -time.Second)
+	if err != nil {
+		t.Fatalf("CreateWebAuthnSession() error = %v", err)
+	}
+	if _, err := ConsumeWebAuthnSession(expired, WebAuthnCeremonyLogin, nil); !errors.Is(err, ErrInvalidWebAuthnSession) {
+		t.Fatalf("ConsumeWebAuthnSession() of an expired session error = %v", err)
+	}
+}
@@ -91,5 +91,5 @@ func checkConnection() error {
 }
 
 func migrate() error {
-	return DB.AutoMigrate(&User{}, &Vault{}, &AuditLog{}, &APIKey{}, &EmailToken{})
+	return DB.AutoMigrate(&User{}, &Vault{}, &AuditLog{}, &APIKey{}, &EmailToken{}, &VaultVersion{}, &Organization{}, &Membership{}, &AuditForwardCursor{}, &Webhook{}, &WebhookDelivery{}, &RecoveryCode{}, &LoginChallenge{}, &Credential{}, &WebAuthnSession{}, &Session{}, &RateLimitHit{}, &WorkloadIdentity{}, &Share{}, &WrappingToken{}, &RotationPolicy{}, &DatabaseLease{})
 }
@@ -0,0 +1,305 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DatabaseEngine is the kind of database a database vault issues credentials for
+type DatabaseEngine string
+
+const (
+	DatabaseEnginePostgres DatabaseEngine = "postgres"
+	DatabaseEngineMySQL    DatabaseEngine = "mysql"
+)
+
+const (
+	// DefaultDatabaseLeaseTTL is how long issued credentials are valid unless configured
+	DefaultDatabaseLeaseTTL = time.Hour
+	// MinDatabaseLeaseTTL and MaxDatabaseLeaseTTL bound the configured lease TTL
+	MinDatabaseLeaseTTL = time.Minute
+	MaxDatabaseLeaseTTL = 24 * time.Hour
+	// databaseRevokeRetryBase is the wait after the first failed revocation; it doubles
+	// with every further failure up to databaseRevokeRetryMax
+	databaseRevokeRetryBase = time.Minute
+	databaseRevokeRetryMax  = time.Hour
+	// databaseLeaseClaimLease is how long a claimed lease is hidden from other servers
+	databaseLeaseClaimLease = 2 * time.Minute
+)
+
+// ErrInvalidDatabaseConfig is returned for a database vault value that is not a valid DatabaseConfig
+var ErrInvalidDatabaseConfig = errors.New("invalid database config")
+
+// errDatabaseVaultRotation is the validation error for a rotation schedule on a database vault
+const errDatabaseVaultRotation = "database vaults issue short-lived credentials and cannot have a rotation schedule"
+
+// keywordPassword matches the password of a keyword/value connection string
+var keywordPassword = regexp.MustCompile(`(?i)(password\s*=\s*)('[^']*'|\S+)`)
+
+// DatabaseConfig is the value of a database vault: how to connect as an administrator and
+// how to create and drop the users handed out on reads. Statements may use the {{name}},
+// {{password}} and {{expiration}} placeholders; empty statements use the engine's defaults.
+type DatabaseConfig struct {
+	Engine               DatabaseEngine `json:"engine"`
+	URL                  string         `json:"url"` // Connection string in the format of DATABASE_URL
+	CreationStatements   []string       `json:"creationStatements,omitempty"`
+	RevocationStatements []string       `json:"revocationStatements,omitempty"`
+	TTL                  string         `json:"ttl,omitempty"` // Lease duration like 30m; empty = DefaultDatabaseLeaseTTL
+}
+
+// ParseDatabaseConfig parses and validates the value of a database vault
+func ParseDatabaseConfig(value string) (*DatabaseConfig, error) {
+	var config DatabaseConfig
+	decoder := json.NewDecoder(strings.NewReader(value))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidDatabaseConfig, err)
+	}
+
+	switch config.Engine {
+	case DatabaseEnginePostgres, DatabaseEngineMySQL:
+	default:
+		return nil, fmt.Errorf("%w: engine must be postgres or mysql", ErrInvalidDatabaseConfig)
+	}
+	if strings.TrimSpace(config.URL) == "" {
+		return nil, fmt.Errorf("%w: url is required", ErrInvalidDatabaseConfig)
+	}
+	if err := validateStatements(config.CreationStatements); err != nil {
+		return nil, fmt.Errorf("%w: creation %v", ErrInvalidDatabaseConfig, err)
+	}
+	if err := validateStatements(config.RevocationStatements); err != nil {
+		return nil, fmt.Errorf("%w: revocation %v", ErrInvalidDatabaseConfig, err)
+	}
+	if config.TTL != "" {
+		ttl, err := time.ParseDuration(config.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid ttl %q", ErrInvalidDatabaseConfig, config.TTL)
+		}
+		if ttl < MinDatabaseLeaseTTL || ttl > MaxDatabaseLeaseTTL {
+			return nil, fmt.Errorf("%w: ttl must be between %s and %s", ErrInvalidDatabaseConfig, MinDatabaseLeaseTTL, MaxDatabaseLeaseTTL)
+		}
+	}
+
+	return &config, nil
+}
+
+// validateStatements checks custom statements, which have to target the issued user
+func validateStatements(statements []string) error {
+	for _, statement := range statements {
+		if strings.TrimSpace(statement) == "" {
+			return errors.New("statements cannot be empty")
+		}
+	}
+	if len(statements) > 0 && !strings.Contains(strings.Join(statements, "\n"), "{{name}}") {
+		return errors.New("statements must use {{name}}")
+	}
+	return nil
+}
+
+// LeaseTTL returns how long issued credentials are valid
+func (c *DatabaseConfig) LeaseTTL() time.Duration {
+	if ttl, err := time.ParseDuration(c.TTL); err == nil && c.TTL != "" {
+		return ttl
+	}
+	return DefaultDatabaseLeaseTTL
+}
+
+// RedactedURL returns the connection string with its password masked
+func (c *DatabaseConfig) RedactedURL() string {
+	if u, err := url.Parse(c.URL); err == nil && u.User != nil {
+		return u.Redacted()
+	}
+	if keywordPassword.MatchString(c.URL) {
+		return keywordPassword.ReplaceAllString(c.URL, "${1}xxxxx")
+	}
+	// MySQL DSNs look like user:password@tcp(host:3306)/db
+	if at := strings.LastIndex(c.URL, "@"); at >= 0 {
+		if user, _, ok := strings.Cut(c.URL[:at], ":"); ok {
+			return user + ":xxxxx" + c.URL[at:]
+		}
+	}
+	return c.URL
+}
+
+// RedactDatabaseConfig returns the value of a database vault with the password of its
+// connection string masked. Values that are not a valid config are hidden entirely.
+func RedactDatabaseConfig(value string) string {
+	config, err := ParseDatabaseConfig(value)
+	if err != nil {
+		return ""
+	}
+	config.URL = config.RedactedURL()
+	redacted, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	return string(redacted)
+}
+
+// IsDynamic reports whether reads of the vault issue credentials instead of returning its value
+func (v *Vault) IsDynamic() bool {
+	return v.Type == VaultTypeDatabase
+}
+
+// ValidateUpdate validates the update parameters against the vault's type. Only kv vaults
+// have keys to change, and their value must stay a JSON object. The value of a database
+// vault must stay a valid config, and its credentials expire on their own, so it has no
+// rotation schedule.
+func (v *Vault) ValidateUpdate(params *UpdateVaultParams) map[string]string {
+	errors := map[string]string{}
+	if v.Type != VaultTypeKV && params.hasKeyUpdates() {
+		errors["keys"] = "setKeys and deleteKeys only apply to kv vaults"
+	}
+	if v.Type == VaultTypeKV && params.Value != nil {
+		if _, err := ParseKVValue(*params.Value); err != nil {
+			errors["value"] = err.Error()
+		}
+	}
+	if v.Type != VaultTypeDatabase {
+		return errors
+	}
+
+	if params.Value != nil {
+		if _, err := ParseDatabaseConfig(*params.Value); err != nil {
+			errors["value"] = err.Error()
+		}
+	}
+	if params.ExpiresAt != nil || (params.RotationInterval != nil && *params.RotationInterval != 0) {
+		errors["rotation_interval"] = errDatabaseVaultRotation
+	}
+	return errors
+}
+
+// DisplayValue returns the value as shown to users, with the admin password of database
+// vaults masked
+func (v *Vault) DisplayValue() string {
+	if v.Type == VaultTypeDatabase {
+		return RedactDatabaseConfig(v.Value)
+	}
+	return v.Value
+}
+
+// DatabaseLease is a database user issued on a read of a database vault. The user is
+// dropped once the lease expires; the lease is kept as a record of who got it.
+type DatabaseLease struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	LeaseID   string         `gorm:"size:36;not null;uniqueIndex"`
+	VaultID   uint           `gorm:"not null;index"`
+	UserID    uint           `gorm:"not null;index"` // User whose API key read the vault
+	APIKeyID  *uint          `gorm:"index"`
+	Engine    DatabaseEngine `gorm:"size:20;not null"`
+	Username  string         `gorm:"size:64;not null"`
+	ExpiresAt time.Time      `gorm:"not null;index"`
+	RevokedAt *time.Time     `gorm:"index"`
+
+	Attempts      int        `gorm:"not null;default:0"` // Failed revocations
+	NextAttemptAt *time.Time `gorm:"index"`              // When a failed revocation is retried
+	LockedUntil   *time.Time // Lease of the server revoking the user
+	LastError     string     `gorm:"size:500"`
+}
+
+// CreateDatabaseLease records a database user issued for the vault. It is recorded before
+// the user is created, so a user that was only partly set up is still dropped.
+func CreateDatabaseLease(vault *Vault, engine DatabaseEngine, username string, ttl time.Duration, userID uint, apiKeyID *uint) (*DatabaseLease, error) {
+	lease := DatabaseLease{
+		LeaseID:   uuid.NewString(),
+		VaultID:   vault.ID,
+		UserID:    userID,
+		APIKeyID:  apiKeyID,
+		Engine:    engine,
+		Username:  username,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := DB.Create(&lease).Error; err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
+
+// GetActiveDatabaseLeases returns the leases of a vault that were not revoked yet
+func GetActiveDatabaseLeases(vaultID uint) ([]DatabaseLease, error) {
+	var leases []DatabaseLease
+	err := DB.Where("vault_id = ? AND revoked_at IS NULL", vaultID).Order("expires_at").Find(&leases).Error
+	return leases, err
+}
+
+// ClaimExpiredDatabaseLeases claims up to limit expired leases whose user was not dropped
+// yet and whose retry, if any, is due. A claimed lease is not handed to other servers until
+// its claim expires.
+func ClaimExpiredDatabaseLeases(limit int) ([]DatabaseLease, error) {
+	now := time.Now()
+	due := func(db *gorm.DB) *gorm.DB {
+		return db.Where("revoked_at IS NULL AND expires_at <= ?", now).
+			Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+			Where("locked_until IS NULL OR locked_until <= ?", now)
+	}
+
+	var leases []DatabaseLease
+	if err := DB.Scopes(due).Order("expires_at").Limit(limit).Find(&leases).Error; err != nil {
+		return nil, err
+	}
+
+	claimed := make([]DatabaseLease, 0, len(leases))
+	for _, lease := range leases {
+		until := now.Add(databaseLeaseClaimLease)
+		result := DB.Model(&DatabaseLease{}).Scopes(due).
+			Where("id = ?", lease.ID).
+			UpdateColumn("locked_until", until)
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Claimed by another server
+			continue
+		}
+		lease.LockedUntil = &until
+		claimed = append(claimed, lease)
+	}
+	return claimed, nil
+}
+
+// DatabaseConfig returns the current config of the lease's vault. Deleted vaults are
+// included, as their users still have to be dropped.
+func (l *DatabaseLease) DatabaseConfig() (*DatabaseConfig, error) {
+	var vault Vault
+	if err := DB.Unscoped().First(&vault, l.VaultID).Error; err != nil {
+		return nil, err
+	}
+	value, err := vault.decryptValue(vault.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	return ParseDatabaseConfig(value)
+}
+
+// RecordRevoked marks the lease's user as dropped
+func (l *DatabaseLease) RecordRevoked() error {
+	now := time.Now()
+	l.RevokedAt = &now
+	l.LockedUntil = nil
+	l.NextAttemptAt = nil
+	l.LastError = ""
+	return DB.Model(l).Select("revoked_at", "locked_until", "next_attempt_at", "last_error").Updates(l).Error
+}
+
+// RecordRevokeFailure releases the lease after its user could not be dropped. It is retried
+// with backoff for as long as it takes; an expired user must not stay behind.
+func (l *DatabaseLease) RecordRevokeFailure(message string) error {
+	l.Attempts++
+	wait := databaseRevokeRetryBase << min(l.Attempts-1, 6)
+	next := time.Now().Add(min(wait, databaseRevokeRetryMax))
+	l.NextAttemptAt = &next
+	l.LockedUntil = nil
+	l.LastError = truncate(message, 500)
+	return DB.Model(l).Select("attempts", "next_attempt_at", "locked_until", "last_error").Updates(l).Error
+}
@@ -0,0 +1,94 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"gorm.io/gorm"
+)
+
+// DefaultKeyRotationBatchSize is the number of vaults re-wrapped per transaction
+const DefaultKeyRotationBatchSize = 100
+
+// KeyRotationResult summarizes a master key rotation run
+type KeyRotationResult struct {
+	Processed int // Vaults whose data key is now wrapped by the active master key
+	Failed    int // Vaults that could not be processed
+}
+
+// RotateMasterKey re-wraps the data key of every vault with the active master key, creating data
+// keys for vaults that predate them and upgrading values still in an older ciphertext format.
+// Vaults are processed in batches of short transactions, so the server can keep running; it must
+// accept both the old and the new master key until this returns. Webhook, rotation endpoint and
+// TOTP secrets and the values of wrapping tokens, which are encrypted with the master key
+// directly, are re-encrypted afterwards.
+func RotateMasterKey(batchSize int, logger *slog.Logger) (*KeyRotationResult, error) {
+	if batchSize <= 0 {
+		batchSize = DefaultKeyRotationBatchSize
+	}
+
+	activeKeyID := encryption.ActiveKeyID()
+	result := &KeyRotationResult{}
+	var lastID uint
+
+	for {
+		var batch []Vault
+		err := DB.Unscoped().Select("id").
+			Where("id > ? AND (key_id <> ? OR value NOT LIKE ?)", lastID, activeKeyID, "dek2:%").
+			Order("id").
+			Limit(batchSize).
+			Find(&batch).Error
+		if err != nil {
+			return result, err
+		}
+		if len(batch) == 0 {
+			break
+		}
+
+		for i := range batch {
+			err := DB.Transaction(func(tx *gorm.DB) error {
+				_, err := ensureVaultDataKey(tx, &batch[i])
+				return err
+			})
+			if err != nil {
+				result.Failed++
+				logger.Error("Failed to rotate vault data key", "vaultID", batch[i].ID, "error", err)
+				continue
+			}
+			result.Processed++
+		}
+
+		lastID = batch[len(batch)-1].ID
+		logger.Info("Rotated vault data keys", "processed", result.Processed, "failed", result.Failed, "lastVaultID", lastID)
+	}
+
+	if result.Failed > 0 {
+		return result, fmt.Errorf("%d vaults could not be rotated", result.Failed)
+	}
+
+	webhooks, err := rotateWebhookSecrets()
+	if err != nil {
+		return result, fmt.Errorf("failed to re-encrypt webhook secrets: %w", err)
+	}
+	logger.Info("Re-encrypted webhook secrets", "webhooks", webhooks)
+
+	policies, err := rotateRotationPolicySecrets()
+	if err != nil {
+		return result, fmt.Errorf("failed to re-encrypt rotation endpoint secrets: %w", err)
+	}
+	logger.Info("Re-encrypted rotation endpoint secrets", "policies", policies)
+
+	users, err := rotateTOTPSecrets()
+	if err != nil {
+		return result, fmt.Errorf("failed to re-encrypt TOTP secrets: %w", err)
+	}
+	logger.Info("Re-encrypted TOTP secrets", "users", users)
+
+	tokens, err := rotateWrappingTokens()
+	if err != nil {
+		return result, fmt.Errorf("failed to re-encrypt wrapping tokens: %w", err)
+	}
+	logger.Info("Re-encrypted wrapping tokens", "tokens", tokens)
+	return result, nil
+}
@@ -0,0 +1,102 @@
+package model
+
+import (
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lwshen/vault-hub/internal/config"
+	"github.com/lwshen/vault-hub/internal/encryption"
+)
+
+func TestRotateMasterKey(t *testing.T) {
+	// RotateMasterKey rotates every vault, so vaults left by other tests must not take part
+	useTestDatabase(t)
+	originalKey, originalPrevious := config.EncryptionKey, config.EncryptionKeyPrevious
+	t.Cleanup(func() {
+		config.EncryptionKey, config.EncryptionKeyPrevious = originalKey, originalPrevious
+	})
+	newKey := "rotated-" + uuid.NewString()
+
+	userID := uint(5151)
+	params := CreateVaultParams{
+		UniqueID: uuid.NewString(),
+		UserID:   userID,
+		Name:     "rotated-" + uuid.NewString(),
+		Value:    "enveloped",
+		Source:   SourceWeb,
+	}
+	enveloped, err := params.Create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if enveloped.DataKey == "" || enveloped.KeyID != encryption.ActiveKeyID() {
+		t.Fatalf("expected new vault to have a data key wrapped by the active key")
+	}
+
+	// A vault written before data keys existed holds a value encrypted with the master key
+	legacyValue, err := encryption.Encrypt("legacy")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	legacy := Vault{UniqueID: uuid.NewString(), UserID: userID, Name: "legacy-" + uuid.NewString(), Value: legacyValue}
+	if err := DB.Create(&legacy).Error; err != nil {
+		t.Fatalf("create legacy vault: %v", err)
+	}
+	if err := DB.Create(&VaultVersion{VaultID: legacy.ID, Version: 1, Value: legacyValue, UserID: userID}).Error; err != nil {
+		t.Fatalf("create legacy version: %v", err)
+	}
+
+	// A value wrapped before the rotation must still be redeemable after it
+	wrapToken, _, err := CreateWrappingToken(enveloped, userID, nil, time.Hour)
+	if err != nil {
+		t.Fatalf("wrap: %v", err)
+	}
+
+	// Rotate to a new master key while the old one is still accepted
+	config.EncryptionKey, config.EncryptionKeyPrevious = newKey, []string{originalKey}
+	result, err := RotateMasterKey(1, slog.Default())
+	if err != nil {
+		t.Fatalf("rotate: %v", err)
+	}
+	if result.Processed < 2 {
+		t.Fatalf("expected at least 2 vaults to be rotated, got %d", result.Processed)
+	}
+
+	// With the old key retired, both vaults and their history must stay readable
+	config.EncryptionKeyPrevious = nil
+	for _, tc := range []struct {
+		uniqueID string
+		want     string
+	}{
+		{enveloped.UniqueID, "enveloped"},
+		{legacy.UniqueID, "legacy"},
+	} {
+		var v Vault
+		if err := v.GetByUniqueID(tc.uniqueID, userID); err != nil {
+			t.Fatalf("get %s after rotation: %v", tc.want, err)
+		}
+		if v.Value != tc.want || v.KeyID != encryption.ActiveKeyID() {
+			t.Fatalf("unexpected vault after rotation: value=%q keyID=%q", v.Value, v.KeyID)
+		}
+
+		version, err := GetVaultVersion(v.ID, 1)
+		if err != nil {
+			t.Fatalf("get version of %s after rotation: %v", tc.want, err)
+		}
+		if version.Value != tc.want {
+			t.Fatalf("expected version value %q, got %q", tc.want, version.Value)
+		}
+	}
+
+	if _, value, err := UnwrapToken(wrapToken); err != nil || value != "enveloped" {
+		t.Fatalf("expected the wrapped value after rotation, got %q, %v", value, err)
+	}
+
+	// Rotate back so other tests sharing the database keep working
+	config.EncryptionKey, config.EncryptionKeyPrevious = originalKey, []string{newKey}
+	if _, err := RotateMasterKey(DefaultKeyRotationBatchSize, slog.Default()); err != nil {
+		t.Fatalf("rotate back: %v", err)
+	}
+}
@@ -0,0 +1,57 @@
+package model
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lwshen/vault-hub/internal/config"
+)
+
+// TestMain opens the database once, so tests do not depend on running after TestDatabaseConnection.
+// A SQLite database is created afresh for each run, so results do not depend on earlier runs.
+func TestMain(m *testing.M) {
+	os.Exit(runTests(m))
+}
+
+func runTests(m *testing.M) int {
+	if config.DatabaseType == config.DatabaseTypeSQLite {
+		dir, err := os.MkdirTemp("", "vault-hub-model-test")
+		if err != nil {
+			slog.Error("Failed to create test database directory", "error", err)
+			return 1
+		}
+		defer func() { _ = os.RemoveAll(dir) }()
+		config.DatabaseUrl = filepath.Join(dir, "data.db")
+	}
+
+	if err := Open(slog.Default()); err != nil {
+		slog.Error("Failed to open test database", "error", err)
+		return 1
+	}
+	defer closeDB()
+	return m.Run()
+}
+
+// useTestDatabase switches the tests to an empty SQLite database for the duration of a test,
+// for tests that act on every row of a table
+func useTestDatabase(t *testing.T) {
+	t.Helper()
+	originalDB, originalType, originalURL := DB, config.DatabaseType, config.DatabaseUrl
+	config.DatabaseType, config.DatabaseUrl = config.DatabaseTypeSQLite, filepath.Join(t.TempDir(), "data.db")
+	t.Cleanup(func() {
+		closeDB()
+		DB, config.DatabaseType, config.DatabaseUrl = originalDB, originalType, originalURL
+	})
+	if err := Open(slog.Default()); err != nil {
+		t.Fatalf("open test database: %v", err)
+	}
+}
+
+// closeDB closes the connections of the current database
+func closeDB() {
+	if sqlDB, err := DB.DB(); err == nil {
+		_ = sqlDB.Close()
+	}
+}
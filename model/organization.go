@@ -0,0 +1,419 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OrganizationRole string
+
+const (
+	RoleOwner  OrganizationRole = "owner"
+	RoleAdmin  OrganizationRole = "admin"
+	RoleMember OrganizationRole = "member"
+	RoleViewer OrganizationRole = "viewer"
+)
+
+var (
+	// ErrLastOwner is returned when a change would leave an organization without an owner
+	ErrLastOwner = errors.New("organization must keep at least one owner")
+	// ErrOrganizationHasVaults is returned when deleting an organization that still owns vaults
+	ErrOrganizationHasVaults = errors.New("organization still owns vaults")
+	// ErrAlreadyMember is returned when adding a user who is already a member
+	ErrAlreadyMember = errors.New("user is already a member of this organization")
+	// ErrVaultPermissionDenied is returned when a user's role does not allow an operation on a vault
+	ErrVaultPermissionDenied = errors.New("insufficient organization role for this vault")
+)
+
+// VaultPermission is an operation on a vault that requires a minimum organization role
+type VaultPermission string
+
+const (
+	VaultPermissionRead   VaultPermission = "read"
+	VaultPermissionWrite  VaultPermission = "write"
+	VaultPermissionDelete VaultPermission = "delete"
+)
+
+// requiredRole returns the minimum role needed for the permission on an organization vault
+func (p VaultPermission) requiredRole() OrganizationRole {
+	switch p {
+	case VaultPermissionWrite:
+		return RoleMember
+	case VaultPermissionDelete:
+		return RoleAdmin
+	default:
+		return RoleViewer
+	}
+}
+
+// roleRank orders roles from least to most privileged
+var roleRank = map[OrganizationRole]int{
+	RoleViewer: 1,
+	RoleMember: 2,
+	RoleAdmin:  3,
+	RoleOwner:  4,
+}
+
+// IsValid reports whether the role is one of the known roles
+func (r OrganizationRole) IsValid() bool {
+	_, ok := roleRank[r]
+	return ok
+}
+
+// AtLeast reports whether the role grants at least the privileges of another role
+func (r OrganizationRole) AtLeast(other OrganizationRole) bool {
+	return roleRank[r] >= roleRank[other]
+}
+
+// Organization is a workspace whose vaults are shared by its members
+type Organization struct {
+	gorm.Model
+	Name        string `gorm:"size:255;not null"` // Human-readable name
+	Description string `gorm:"size:500"`          // Human-readable description
+	CreatedBy   uint   `gorm:"index;not null"`    // User who created the organization
+}
+
+// Membership grants a user a role in an organization
+type Membership struct {
+	ID             uint `gorm:"primarykey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	OrganizationID uint             `gorm:"uniqueIndex:idx_org_member;not null"`
+	Organization   Organization     `gorm:"foreignKey:OrganizationID"`
+	UserID         uint             `gorm:"uniqueIndex:idx_org_member;index;not null"`
+	User           User             `gorm:"foreignKey:UserID"`
+	Role           OrganizationRole `gorm:"size:20;not null"`
+	// Provider is the OIDC provider that manages the membership from the user's groups. It is
+	// empty for members added by organization admins, whose memberships group sync never changes.
+	Provider string `gorm:"size:64"`
+}
+
+// CreateOrganizationParams defines parameters for creating an organization
+type CreateOrganizationParams struct {
+	Name        string
+	Description string
+	UserID      uint // Creator, who becomes the first owner
+}
+
+// UpdateOrganizationParams defines parameters for updating an organization
+type UpdateOrganizationParams struct {
+	Name        *string
+	Description *string
+}
+
+// Validate validates the create organization parameters
+func (params *CreateOrganizationParams) Validate() map[string]string {
+	errors := map[string]string{}
+
+	if strings.TrimSpace(params.Name) == "" {
+		errors["name"] = "name is required"
+	} else if len(params.Name) > 255 {
+		errors["name"] = "name must be less than 255 characters"
+	}
+
+	if len(params.Description) > 500 {
+		errors["description"] = "description must be less than 500 characters"
+	}
+
+	if params.UserID == 0 {
+		errors["user_id"] = "user_id is required"
+	}
+
+	return errors
+}
+
+// Create creates an organization and makes its creator the owner
+func (params *CreateOrganizationParams) Create() (*Organization, error) {
+	org := Organization{
+		Name:        strings.TrimSpace(params.Name),
+		Description: params.Description,
+		CreatedBy:   params.UserID,
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&org).Error; err != nil {
+			return err
+		}
+		return tx.Create(&Membership{OrganizationID: org.ID, UserID: params.UserID, Role: RoleOwner}).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &org, nil
+}
+
+// Validate validates the update organization parameters
+func (params *UpdateOrganizationParams) Validate() map[string]string {
+	errors := map[string]string{}
+
+	if params.Name != nil {
+		if strings.TrimSpace(*params.Name) == "" {
+			errors["name"] = "name cannot be empty"
+		} else if len(*params.Name) > 255 {
+			errors["name"] = "name must be less than 255 characters"
+		}
+	}
+
+	if params.Description != nil && len(*params.Description) > 500 {
+		errors["description"] = "description must be less than 500 characters"
+	}
+
+	return errors
+}
+
+// Update updates an organization
+func (o *Organization) Update(params *UpdateOrganizationParams) error {
+	updates := map[string]interface{}{}
+	if params.Name != nil {
+		updates["name"] = strings.TrimSpace(*params.Name)
+	}
+	if params.Description != nil {
+		updates["description"] = *params.Description
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	if err := DB.Model(o).Updates(updates).Error; err != nil {
+		return err
+	}
+	return DB.First(o, o.ID).Error
+}
+
+// Delete soft deletes an organization and removes its memberships.
+// Organizations that still own vaults cannot be deleted.
+func (o *Organization) Delete() error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&Vault{}).Where("organization_id = ?", o.ID).Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return ErrOrganizationHasVaults
+		}
+
+		if err := tx.Where("organization_id = ?", o.ID).Delete(&Membership{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(o).Error
+	})
+}
+
+// GetMembership returns the membership of a user in an organization
+func GetMembership(organizationID uint, userID uint) (*Membership, error) {
+	var membership Membership
+	err := DB.Preload("Organization").
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(&membership).Error
+	if err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// GetUserMemberships returns the memberships of a user with their organizations
+func GetUserMemberships(userID uint) ([]Membership, error) {
+	var memberships []Membership
+	err := DB.Joins("Organization").
+		Where("memberships.user_id = ?", userID).
+		Order("memberships.created_at ASC").
+		Find(&memberships).Error
+	return memberships, err
+}
+
+// GetOrganizationMembers returns the members of an organization with their users
+func GetOrganizationMembers(organizationID uint) ([]Membership, error) {
+	var memberships []Membership
+	err := DB.Preload("User").
+		Where("organization_id = ?", organizationID).
+		Order("created_at ASC").
+		Find(&memberships).Error
+	return memberships, err
+}
+
+// AddMember adds a user to an organization with the given role
+func AddMember(organizationID uint, userID uint, role OrganizationRole) (*Membership, error) {
+	if !role.IsValid() {
+		return nil, fmt.Errorf("invalid role: %s", role)
+	}
+
+	_, err := GetMembership(organizationID, userID)
+	if err == nil {
+		return nil, ErrAlreadyMember
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	membership := Membership{OrganizationID: organizationID, UserID: userID, Role: role}
+	if err := DB.Create(&membership).Error; err != nil {
+		return nil, err
+	}
+	if err := DB.Preload("User").First(&membership, membership.ID).Error; err != nil {
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// UpdateRole changes the role of a member, keeping at least one owner
+func (m *Membership) UpdateRole(role OrganizationRole) error {
+	if !role.IsValid() {
+		return fmt.Errorf("invalid role: %s", role)
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if m.Role == RoleOwner && role != RoleOwner {
+			if err := ensureAnotherOwner(tx, m); err != nil {
+				return err
+			}
+		}
+		if err := tx.Model(m).Update("role", role).Error; err != nil {
+			return err
+		}
+		m.Role = role
+		return nil
+	})
+}
+
+// Remove removes a member from the organization, keeping at least one owner
+func (m *Membership) Remove() error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if m.Role == RoleOwner {
+			if err := ensureAnotherOwner(tx, m); err != nil {
+				return err
+			}
+		}
+		return tx.Delete(m).Error
+	})
+}
+
+// ensureAnotherOwner fails if the membership is the only owner of its organization
+func ensureAnotherOwner(tx *gorm.DB, m *Membership) error {
+	var owners int64
+	err := tx.Model(&Membership{}).
+		Where("organization_id = ? AND role = ? AND id != ?", m.OrganizationID, RoleOwner, m.ID).
+		Count(&owners).Error
+	if err != nil {
+		return err
+	}
+	if owners == 0 {
+		return ErrLastOwner
+	}
+	return nil
+}
+
+// AccessibleVaults scopes a vault query to the personal vaults of a user and the vaults
+// of every organization the user is a member of
+func AccessibleVaults(userID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("((vaults.user_id = ? AND vaults.organization_id IS NULL) OR vaults.organization_id IN (?))",
+			userID, userOrganizationIDs(userID))
+	}
+}
+
+// RoleFor returns the role a user has on the vault. The creator of a personal vault is its owner;
+// for organization vaults the role comes from the user's membership.
+func (v *Vault) RoleFor(userID uint) (OrganizationRole, error) {
+	if v.OrganizationID == nil {
+		if v.UserID == userID {
+			return RoleOwner, nil
+		}
+		return "", gorm.ErrRecordNotFound
+	}
+
+	membership, err := GetMembership(*v.OrganizationID, userID)
+	if err != nil {
+		return "", err
+	}
+	return membership.Role, nil
+}
+
+// CheckPermission verifies that a user's role on the vault allows the given operation
+func (v *Vault) CheckPermission(userID uint, permission VaultPermission) error {
+	role, err := v.RoleFor(userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return ErrVaultPermissionDenied
+		}
+		return err
+	}
+	if !role.AtLeast(permission.requiredRole()) {
+		return ErrVaultPermissionDenied
+	}
+	return nil
+}
+
+// userOrganizationIDs is a subquery selecting the organizations a user belongs to
+func userOrganizationIDs(userID uint) *gorm.DB {
+	return DB.Model(&Membership{}).Select("organization_id").Where("user_id = ?", userID)
+}
+
+// MembershipChange is a membership that SyncProviderMemberships added, updated or removed
+type MembershipChange struct {
+	OrganizationID uint
+	Action         ActionType
+	Role           OrganizationRole
+}
+
+// SyncProviderMemberships makes the memberships managed by an OIDC provider match the roles its
+// group mappings grant the user, keyed by organization ID. Memberships added by organization
+// admins are left alone. Failed changes, such as removing the last owner, are skipped and
+// returned joined after the others are applied.
+func SyncProviderMemberships(userID uint, provider string, roles map[uint]OrganizationRole) ([]MembershipChange, error) {
+	memberships, err := GetUserMemberships(userID)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[uint]*Membership, len(memberships))
+	for i := range memberships {
+		existing[memberships[i].OrganizationID] = &memberships[i]
+	}
+
+	var changes []MembershipChange
+	var errs []error
+	for organizationID, role := range roles {
+		membership, ok := existing[organizationID]
+		switch {
+		case !ok:
+			var count int64
+			if err := DB.Model(&Organization{}).Where("id = ?", organizationID).Count(&count).Error; err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if count == 0 {
+				errs = append(errs, fmt.Errorf("organization %d of group role mapping not found", organizationID))
+				continue
+			}
+			membership := Membership{OrganizationID: organizationID, UserID: userID, Role: role, Provider: provider}
+			if err := DB.Create(&membership).Error; err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			changes = append(changes, MembershipChange{OrganizationID: organizationID, Action: ActionAddOrganizationMember, Role: role})
+		case membership.Provider == provider && membership.Role != role:
+			if err := membership.UpdateRole(role); err != nil {
+				errs = append(errs, fmt.Errorf("organization %d: %w", organizationID, err))
+				continue
+			}
+			changes = append(changes, MembershipChange{OrganizationID: organizationID, Action: ActionUpdateOrganizationMember, Role: role})
+		}
+	}
+
+	// Members who left all mapped groups of an organization lose the membership
+	for _, membership := range memberships {
+		if _, ok := roles[membership.OrganizationID]; ok || membership.Provider != provider {
+			continue
+		}
+		if err := membership.Remove(); err != nil {
+			errs = append(errs, fmt.Errorf("organization %d: %w", membership.OrganizationID, err))
+			continue
+		}
+		changes = append(changes, MembershipChange{OrganizationID: membership.OrganizationID, Action: ActionRemoveOrganizationMember, Role: membership.Role})
+	}
+
+	return changes, errors.Join(errs...)
+}
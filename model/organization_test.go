@@ -0,0 +1,238 @@
+package model
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func createTestUser(t *testing.T) *User {
+	t.Helper()
+	params := CreateUserParams{Email: uuid.NewString() + "@example.com", Name: "test"}
+	user, err := params.Create()
+	if err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+	return user
+}
+
+func TestOrganizationVaultAccess(t *testing.T) {
+	owner, viewer, outsider := createTestUser(t), createTestUser(t), createTestUser(t)
+
+	orgParams := CreateOrganizationParams{Name: "org-" + uuid.NewString(), UserID: owner.ID}
+	org, err := orgParams.Create()
+	if err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	if _, err := AddMember(org.ID, viewer.ID, RoleViewer); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	if _, err := AddMember(org.ID, viewer.ID, RoleMember); !errors.Is(err, ErrAlreadyMember) {
+		t.Fatalf("expected %v when adding a member twice, got %v", ErrAlreadyMember, err)
+	}
+
+	vaultParams := CreateVaultParams{
+		UniqueID:       uuid.NewString(),
+		UserID:         owner.ID,
+		Name:           "shared-" + uuid.NewString(),
+		Value:          "shared secret",
+		Source:         SourceWeb,
+		OrganizationID: &org.ID,
+	}
+	vault, err := vaultParams.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+
+	// Viewers can only create vaults after being promoted
+	viewerParams := vaultParams
+	viewerParams.UniqueID, viewerParams.UserID, viewerParams.Name = uuid.NewString(), viewer.ID, "viewer-"+uuid.NewString()
+	if _, err := viewerParams.Create(); !errors.Is(err, ErrVaultPermissionDenied) {
+		t.Fatalf("expected viewer create to be denied, got %v", err)
+	}
+
+	var read Vault
+	if err := read.GetByUniqueID(vault.UniqueID, viewer.ID); err != nil {
+		t.Fatalf("viewer read: %v", err)
+	}
+	if read.Value != "shared secret" {
+		t.Fatalf("expected %q, got %q", "shared secret", read.Value)
+	}
+	if err := CheckVaultOwnership(vault.ID, viewer.ID); err != nil {
+		t.Fatalf("expected viewer to have access: %v", err)
+	}
+	if err := CheckVaultOwnership(vault.ID, outsider.ID); err == nil {
+		t.Fatal("expected outsider to have no access")
+	}
+
+	if err := read.CheckPermission(viewer.ID, VaultPermissionRead); err != nil {
+		t.Fatalf("viewer read permission: %v", err)
+	}
+	if err := read.CheckPermission(viewer.ID, VaultPermissionWrite); !errors.Is(err, ErrVaultPermissionDenied) {
+		t.Fatalf("expected viewer write to be denied, got %v", err)
+	}
+	if err := read.CheckPermission(outsider.ID, VaultPermissionRead); !errors.Is(err, ErrVaultPermissionDenied) {
+		t.Fatalf("expected outsider read to be denied, got %v", err)
+	}
+
+	apiKey := APIKey{UserID: viewer.ID}
+	if !apiKey.HasVaultAccess(vault.ID) {
+		t.Fatal("expected API key of a member to have access")
+	}
+
+	// Removing the member revokes access
+	membership, err := GetMembership(org.ID, viewer.ID)
+	if err != nil {
+		t.Fatalf("get membership: %v", err)
+	}
+	if err := membership.Remove(); err != nil {
+		t.Fatalf("remove member: %v", err)
+	}
+	if apiKey.HasVaultAccess(vault.ID) {
+		t.Fatal("expected API key of a removed member to lose access")
+	}
+	var denied Vault
+	if err := denied.GetByUniqueID(vault.UniqueID, viewer.ID); err == nil {
+		t.Fatal("expected removed member to lose access")
+	}
+
+	if err := org.Delete(); !errors.Is(err, ErrOrganizationHasVaults) {
+		t.Fatalf("expected %v, got %v", ErrOrganizationHasVaults, err)
+	}
+}
+
+func TestOrganizationKeepsAnOwner(t *testing.T) {
+	owner, admin := createTestUser(t), createTestUser(t)
+
+	orgParams := CreateOrganizationParams{Name: "org-" + uuid.NewString(), UserID: owner.ID}
+	org, err := orgParams.Create()
+	if err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	if _, err := AddMember(org.ID, admin.ID, RoleAdmin); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+
+	ownership, err := GetMembership(org.ID, owner.ID)
+	if err != nil {
+		t.Fatalf("get membership: %v", err)
+	}
+	if err := ownership.UpdateRole(RoleAdmin); !errors.Is(err, ErrLastOwner) {
+		t.Fatalf("expected %v when demoting the last owner, got %v", ErrLastOwner, err)
+	}
+	if err := ownership.Remove(); !errors.Is(err, ErrLastOwner) {
+		t.Fatalf("expected %v when removing the last owner, got %v", ErrLastOwner, err)
+	}
+
+	promoted, err := GetMembership(org.ID, admin.ID)
+	if err != nil {
+		t.Fatalf("get membership: %v", err)
+	}
+	if err := promoted.UpdateRole(RoleOwner); err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	if err := ownership.Remove(); err != nil {
+		t.Fatalf("expected the original owner to leave once another owner exists: %v", err)
+	}
+}
+
+func TestOrganizationVaultAuditLog(t *testing.T) {
+	owner, member := createTestUser(t), createTestUser(t)
+
+	orgParams := CreateOrganizationParams{Name: "org-" + uuid.NewString(), UserID: owner.ID}
+	org, err := orgParams.Create()
+	if err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	if _, err := AddMember(org.ID, member.ID, RoleMember); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+
+	vaultParams := CreateVaultParams{
+		UniqueID:       uuid.NewString(),
+		UserID:         owner.ID,
+		Name:           "audited-" + uuid.NewString(),
+		Value:          "secret",
+		Source:         SourceWeb,
+		OrganizationID: &org.ID,
+	}
+	vault, err := vaultParams.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+
+	if err := LogVaultAction(vault.ID, ActionReadVault, member.ID, SourceWeb, nil, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log action: %v", err)
+	}
+
+	logs, err := GetAuditLogsWithFilters(GetAuditLogsWithFiltersParams{OrganizationID: &org.ID, Limit: 10})
+	if err != nil {
+		t.Fatalf("get audit logs: %v", err)
+	}
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 organization audit log, got %d", len(logs))
+	}
+	if logs[0].UserID != member.ID || logs[0].MemberRole != RoleMember {
+		t.Fatalf("expected the acting member and role to be recorded, got user %d with role %q", logs[0].UserID, logs[0].MemberRole)
+	}
+}
+
+func TestSyncProviderMemberships(t *testing.T) {
+	owner, user := createTestUser(t), createTestUser(t)
+
+	var orgs []*Organization
+	for i := 0; i < 3; i++ {
+		params := CreateOrganizationParams{Name: "org-" + uuid.NewString(), UserID: owner.ID}
+		org, err := params.Create()
+		if err != nil {
+			t.Fatalf("create organization: %v", err)
+		}
+		orgs = append(orgs, org)
+	}
+	// Memberships added by admins are not managed by group sync
+	if _, err := AddMember(orgs[2].ID, user.ID, RoleViewer); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+
+	changes, err := SyncProviderMemberships(user.ID, "sso", map[uint]OrganizationRole{
+		orgs[0].ID: RoleAdmin,
+		orgs[1].ID: RoleMember,
+		orgs[2].ID: RoleOwner,
+	})
+	if err != nil {
+		t.Fatalf("SyncProviderMemberships() error = %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("SyncProviderMemberships() changes = %+v, want 2 added", changes)
+	}
+	if m, _ := GetMembership(orgs[2].ID, user.ID); m.Role != RoleViewer {
+		t.Errorf("admin-added membership role = %s, want viewer", m.Role)
+	}
+
+	// Group changes update and remove the memberships the provider added
+	changes, err = SyncProviderMemberships(user.ID, "sso", map[uint]OrganizationRole{orgs[0].ID: RoleViewer})
+	if err != nil {
+		t.Fatalf("SyncProviderMemberships() error = %v", err)
+	}
+	if len(changes) != 2 {
+		t.Fatalf("SyncProviderMemberships() changes = %+v, want an update and a removal", changes)
+	}
+	if m, err := GetMembership(orgs[0].ID, user.ID); err != nil || m.Role != RoleViewer || m.Provider != "sso" {
+		t.Errorf("GetMembership() = %+v, %v, want a viewer managed by sso", m, err)
+	}
+	if _, err := GetMembership(orgs[1].ID, user.ID); err == nil {
+		t.Error("membership of a group the user left was not removed")
+	}
+	if _, err := GetMembership(orgs[2].ID, user.ID); err != nil {
+		t.Errorf("admin-added membership was removed: %v", err)
+	}
+
+	// Another provider does not touch these memberships
+	if changes, err := SyncProviderMemberships(user.ID, "other", nil); err != nil || len(changes) != 0 {
+		t.Errorf("SyncProviderMemberships() for another provider = %+v, %v", changes, err)
+	}
+	if _, err := SyncProviderMemberships(user.ID, "sso", map[uint]OrganizationRole{999999: RoleMember}); err == nil {
+		t.Error("SyncProviderMemberships() for a missing organization succeeded")
+	}
+}
@@ -0,0 +1,53 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// RateLimitHit is an attempt counted against a rate limit key. Hits are stored in the
+// database when replicas need to share their rate limit windows. Keys are hashed, since they
+// contain email addresses and IP addresses.
+type RateLimitHit struct {
+	ID        uint      `gorm:"primarykey"`
+	KeyHash   string    `gorm:"size:64;index:idx_rate_limit_hits,priority:1"`
+	CreatedAt time.Time `gorm:"index:idx_rate_limit_hits,priority:2"`
+}
+
+// hashRateLimitKey hashes a rate limit key for storage
+func hashRateLimitKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// AddRateLimitHit records an attempt for key
+func AddRateLimitHit(key string, at time.Time) error {
+	return DB.Create(&RateLimitHit{KeyHash: hashRateLimitKey(key), CreatedAt: at}).Error
+}
+
+// GetRateLimitHits returns the times of the attempts recorded for key after since, oldest first
+func GetRateLimitHits(key string, since time.Time) ([]time.Time, error) {
+	var hits []RateLimitHit
+	err := DB.Where("key_hash = ? AND created_at > ?", hashRateLimitKey(key), since).
+		Order("created_at").
+		Find(&hits).Error
+	if err != nil {
+		return nil, err
+	}
+	times := make([]time.Time, 0, len(hits))
+	for _, hit := range hits {
+		times = append(times, hit.CreatedAt)
+	}
+	return times, nil
+}
+
+// DeleteRateLimitHits forgets the attempts recorded for key
+func DeleteRateLimitHits(key string) error {
+	return DB.Where("key_hash = ?", hashRateLimitKey(key)).Delete(&RateLimitHit{}).Error
+}
+
+// PruneRateLimitHits deletes attempts recorded before a time
+func PruneRateLimitHits(before time.Time) error {
+	return DB.Where("created_at < ?", before).Delete(&RateLimitHit{}).Error
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestRateLimitHits(t *testing.T) {
+	key := "account:" + uuid.NewString() + "@example.com"
+	otherKey := "account:" + uuid.NewString() + "@example.com"
+	now := time.Now()
+
+	for _, ago := range []time.Duration{20 * time.Minute, 2 * time.Minute, time.Minute} {
+		if err := AddRateLimitHit(key, now.Add(-ago)); err != nil {
+			t.Fatalf("AddRateLimitHit() error = %v", err)
+		}
+	}
+	if err := AddRateLimitHit(otherKey, now); err != nil {
+		t.Fatalf("AddRateLimitHit() error = %v", err)
+	}
+
+	times, err := GetRateLimitHits(key, now.Add(-15*time.Minute))
+	if err != nil {
+		t.Fatalf("GetRateLimitHits() error = %v", err)
+	}
+	if len(times) != 2 || !times[0].Before(times[1]) {
+		t.Fatalf("GetRateLimitHits() = %v, want the 2 recent hits oldest first", times)
+	}
+
+	// Pruning keeps the hits still inside a window
+	if err := PruneRateLimitHits(now.Add(-15 * time.Minute)); err != nil {
+		t.Fatalf("PruneRateLimitHits() error = %v", err)
+	}
+	if times, _ := GetRateLimitHits(key, time.Time{}); len(times) != 2 {
+		t.Fatalf("GetRateLimitHits() after prune = %v, want 2 hits", times)
+	}
+
+	if err := DeleteRateLimitHits(key); err != nil {
+		t.Fatalf("DeleteRateLimitHits() error = %v", err)
+	}
+	if times, _ := GetRateLimitHits(key, time.Time{}); len(times) != 0 {
+		t.Errorf("GetRateLimitHits() after delete = %v, want none", times)
+	}
+	if times, _ := GetRateLimitHits(otherKey, time.Time{}); len(times) != 1 {
+		t.Errorf("DeleteRateLimitHits() removed hits of another key: %v", times)
+	}
+}
@@ -0,0 +1,335 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"gorm.io/gorm"
+)
+
+// RotationStrategy is how a rotation policy produces a vault's new value
+type RotationStrategy string
+
+const (
+	RotationStrategyRandom RotationStrategy = "random" // A random value drawn from a charset
+	RotationStrategyHTTP   RotationStrategy = "http"   // The value returned by a rotation endpoint
+)
+
+// RotationStatus is the outcome of a policy's last rotation attempt
+type RotationStatus string
+
+const (
+	RotationSucceeded RotationStatus = "succeeded" // The vault holds the rotated value
+	RotationRetrying  RotationStatus = "retrying"  // The attempt failed and is retried with backoff
+	RotationFailed    RotationStatus = "failed"    // Out of attempts; rotation is tried again after another interval
+)
+
+const (
+	// DefaultRotationCharset and DefaultRotationLength shape generated values
+	DefaultRotationCharset = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+	DefaultRotationLength  = 32
+	// MinRotationLength and MaxRotationLength bound the length of generated values
+	MinRotationLength = 8
+	MaxRotationLength = 1024
+	// RotationMaxAttempts is the number of times a rotation is attempted before it fails
+	RotationMaxAttempts = 5
+	// rotationRetryBase is the wait after the first failed attempt; it doubles with every
+	// further failure
+	rotationRetryBase = time.Minute
+	// rotationClaimLease is how long a claimed rotation is hidden from other servers
+	rotationClaimLease = 2 * time.Minute
+)
+
+// ErrRotationInProgress is returned when a vault is already being rotated
+var ErrRotationInProgress = errors.New("rotation already in progress")
+
+// RotationPolicy rotates a vault's value whenever it reaches its rotation deadline. The
+// schedule is the vault's RotationInterval; every rotated value is written by Update, so
+// it moves the deadline and is kept in the version history.
+type RotationPolicy struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	VaultID   uint             `gorm:"not null;uniqueIndex"`
+	Vault     Vault            `gorm:"foreignKey:VaultID"`
+	UserID    uint             `gorm:"not null;index"` // User who set the policy, the author of rotated values
+	Strategy  RotationStrategy `gorm:"size:20;not null"`
+	Charset   string           `gorm:"size:255"`  // Characters of generated values
+	Length    int              `gorm:"default:0"` // Length of generated values
+	URL       string           `gorm:"size:2048"` // Rotation endpoint
+	Secret    string           `gorm:"type:text"` // Signing secret for the rotation endpoint, encrypted with the master key
+	Enabled   bool             `gorm:"not null"`
+
+	Attempts      int        `gorm:"not null;default:0"` // Failed attempts since the last success
+	NextAttemptAt *time.Time `gorm:"index"`              // When a failed rotation is retried (nil = at the deadline)
+	LockedUntil   *time.Time // Lease of the server rotating the vault
+	LastAttemptAt *time.Time
+	LastRotatedAt *time.Time
+	LastStatus    RotationStatus `gorm:"size:20"`
+	LastError     string         `gorm:"size:500"` // Why the last attempt failed
+}
+
+// SetRotationPolicyParams defines the rotation policy of a vault
+type SetRotationPolicyParams struct {
+	UserID   uint
+	Interval time.Duration // Becomes the vault's rotation interval
+	Strategy RotationStrategy
+	Charset  string // Random strategy; empty = DefaultRotationCharset
+	Length   int    // Random strategy; zero = DefaultRotationLength
+	URL      string // HTTP strategy
+	Enabled  bool
+}
+
+// Validate validates the rotation policy parameters
+func (params *SetRotationPolicyParams) Validate() map[string]string {
+	errors := map[string]string{}
+
+	if params.Interval < MinRotationInterval {
+		errors["interval"] = fmt.Sprintf("rotation interval must be at least %s", MinRotationInterval)
+	}
+
+	switch params.Strategy {
+	case RotationStrategyRandom:
+		if params.Length != 0 && (params.Length < MinRotationLength || params.Length > MaxRotationLength) {
+			errors["length"] = fmt.Sprintf("length must be between %d and %d", MinRotationLength, MaxRotationLength)
+		}
+		if len(params.Charset) > 255 {
+			errors["charset"] = "charset must be less than 255 characters"
+		} else if params.Charset != "" && (!utf8.ValidString(params.Charset) || utf8.RuneCountInString(params.Charset) < 2) {
+			errors["charset"] = "charset must have at least 2 characters"
+		}
+	case RotationStrategyHTTP:
+		validateWebhookURL(errors, params.URL)
+	default:
+		errors["strategy"] = "strategy must be random or http"
+	}
+
+	if params.UserID == 0 {
+		errors["user_id"] = "user_id is required"
+	}
+
+	return errors
+}
+
+// GetRotationPolicy returns the rotation policy of a vault
+func GetRotationPolicy(vaultID uint) (*RotationPolicy, error) {
+	var policy RotationPolicy
+	if err := DB.Where("vault_id = ?", vaultID).First(&policy).Error; err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+// SetRotationPolicy creates or replaces the rotation policy of the vault and makes its
+// interval the vault's rotation interval. The signing secret of a rotation endpoint is
+// created with the first HTTP policy and returned in plaintext once.
+func (v *Vault) SetRotationPolicy(params *SetRotationPolicyParams) (*RotationPolicy, string, error) {
+	if v.RotationInterval != params.Interval {
+		if err := v.Update(&UpdateVaultParams{RotationInterval: &params.Interval}); err != nil {
+			return nil, "", err
+		}
+	}
+
+	policy, err := GetRotationPolicy(v.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, "", err
+	}
+	if policy == nil {
+		policy = &RotationPolicy{VaultID: v.ID}
+	}
+
+	var secret string
+	if params.Strategy == RotationStrategyHTTP && policy.Secret == "" {
+		secret, err = generateWebhookSecret()
+		if err != nil {
+			return nil, "", err
+		}
+		if policy.Secret, err = encryption.Encrypt(secret); err != nil {
+			return nil, "", fmt.Errorf("failed to encrypt rotation secret: %w", err)
+		}
+	}
+
+	policy.UserID = params.UserID
+	policy.Strategy = params.Strategy
+	policy.Charset = ""
+	policy.Length = 0
+	policy.URL = ""
+	switch params.Strategy {
+	case RotationStrategyRandom:
+		policy.Charset = params.Charset
+		policy.Length = params.Length
+	case RotationStrategyHTTP:
+		policy.URL = params.URL
+	}
+	policy.Enabled = params.Enabled
+	policy.Attempts = 0
+	policy.NextAttemptAt = nil
+
+	if err := DB.Omit("Vault").Save(policy).Error; err != nil {
+		return nil, "", err
+	}
+	return policy, secret, nil
+}
+
+// Delete removes the rotation policy. The vault keeps its rotation interval.
+func (p *RotationPolicy) Delete() error {
+	return DB.Delete(p).Error
+}
+
+// DecryptSecret returns the signing secret of the rotation endpoint
+func (p *RotationPolicy) DecryptSecret() (string, error) {
+	return encryption.Decrypt(p.Secret)
+}
+
+// GeneratedCharset returns the characters generated values are drawn from
+func (p *RotationPolicy) GeneratedCharset() string {
+	if p.Charset == "" {
+		return DefaultRotationCharset
+	}
+	return p.Charset
+}
+
+// GeneratedLength returns the length of generated values
+func (p *RotationPolicy) GeneratedLength() int {
+	if p.Length == 0 {
+		return DefaultRotationLength
+	}
+	return p.Length
+}
+
+// ClaimDueRotations claims up to limit enabled policies whose vault passed its rotation
+// deadline and whose retry, if any, is due. A claimed policy is not handed to other servers
+// until its lease expires.
+func ClaimDueRotations(limit int) ([]RotationPolicy, error) {
+	now := time.Now()
+	due := func(db *gorm.DB) *gorm.DB {
+		return db.Where("enabled = ?", true).
+			Where("next_attempt_at IS NULL OR next_attempt_at <= ?", now).
+			Where("locked_until IS NULL OR locked_until <= ?", now).
+			Where("vault_id IN (?)", DB.Model(&Vault{}).Select("id").Where("expires_at <= ?", now))
+	}
+
+	var policies []RotationPolicy
+	if err := DB.Scopes(due).Order("id").Limit(limit).Find(&policies).Error; err != nil {
+		return nil, err
+	}
+
+	claimed := make([]RotationPolicy, 0, len(policies))
+	for _, policy := range policies {
+		lease := now.Add(rotationClaimLease)
+		result := DB.Model(&RotationPolicy{}).Scopes(due).
+			Where("id = ?", policy.ID).
+			UpdateColumn("locked_until", lease)
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Claimed by another server
+			continue
+		}
+
+		policy.LockedUntil = &lease
+		if err := DB.First(&policy.Vault, policy.VaultID).Error; err != nil {
+			return claimed, err
+		}
+		claimed = append(claimed, policy)
+	}
+	return claimed, nil
+}
+
+// Claim claims the policy for a rotation on request, whether or not the vault is due
+func (p *RotationPolicy) Claim() error {
+	now := time.Now()
+	lease := now.Add(rotationClaimLease)
+	result := DB.Model(&RotationPolicy{}).
+		Where("id = ? AND (locked_until IS NULL OR locked_until <= ?)", p.ID, now).
+		UpdateColumn("locked_until", lease)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrRotationInProgress
+	}
+	p.LockedUntil = &lease
+	return DB.First(&p.Vault, p.VaultID).Error
+}
+
+// RecordSuccess releases the policy after its vault was rotated
+func (p *RotationPolicy) RecordSuccess() error {
+	now := time.Now()
+	p.Attempts = 0
+	p.NextAttemptAt = nil
+	p.LockedUntil = nil
+	p.LastAttemptAt = &now
+	p.LastRotatedAt = &now
+	p.LastStatus = RotationSucceeded
+	p.LastError = ""
+	return DB.Model(p).
+		Select("attempts", "next_attempt_at", "locked_until", "last_attempt_at", "last_rotated_at", "last_status", "last_error").
+		Updates(p).Error
+}
+
+// RecordFailure releases the policy after a failed rotation and schedules a retry with
+// exponential backoff. Once out of attempts the rotation fails and is tried again after
+// another rotation interval.
+func (p *RotationPolicy) RecordFailure(reason string) error {
+	now := time.Now()
+	p.Attempts++
+	p.LockedUntil = nil
+	p.LastAttemptAt = &now
+	p.LastError = truncate(reason, 500)
+
+	var next time.Time
+	if p.Attempts >= RotationMaxAttempts {
+		interval := p.Vault.RotationInterval
+		if interval <= 0 {
+			interval = MinRotationInterval
+		}
+		next = now.Add(interval)
+		p.Attempts = 0
+		p.LastStatus = RotationFailed
+	} else {
+		next = now.Add(rotationRetryDelay(p.Attempts))
+		p.LastStatus = RotationRetrying
+	}
+	p.NextAttemptAt = &next
+
+	return DB.Model(p).
+		Select("attempts", "next_attempt_at", "locked_until", "last_attempt_at", "last_status", "last_error").
+		Updates(p).Error
+}
+
+// rotationRetryDelay returns the wait after the given number of failed attempts
+func rotationRetryDelay(attempts int) time.Duration {
+	delay := rotationRetryBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+	}
+	return delay
+}
+
+// rotateRotationPolicySecrets re-encrypts every rotation endpoint secret with the active master key
+func rotateRotationPolicySecrets() (int, error) {
+	var policies []RotationPolicy
+	if err := DB.Select("id", "secret").Where("secret <> ''").Find(&policies).Error; err != nil {
+		return 0, err
+	}
+
+	for _, policy := range policies {
+		secret, err := encryption.Decrypt(policy.Secret)
+		if err != nil {
+			return 0, fmt.Errorf("rotation policy %d: %w", policy.ID, err)
+		}
+		encrypted, err := encryption.Encrypt(secret)
+		if err != nil {
+			return 0, fmt.Errorf("rotation policy %d: %w", policy.ID, err)
+		}
+		if err := DB.Model(&RotationPolicy{}).Where("id = ?", policy.ID).Update("secret", encrypted).Error; err != nil {
+			return 0, fmt.Errorf("rotation policy %d: %w", policy.ID, err)
+		}
+	}
+	return len(policies), nil
+}
@@ -0,0 +1,144 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// claimedPolicy returns the claimed policy of the vault, if any
+func claimedPolicy(policies []RotationPolicy, vaultID uint) *RotationPolicy {
+	for i := range policies {
+		if policies[i].VaultID == vaultID {
+			return &policies[i]
+		}
+	}
+	return nil
+}
+
+func TestSetRotationPolicy(t *testing.T) {
+	user := createTestUser(t)
+	params := CreateVaultParams{UniqueID: uuid.NewString(), UserID: user.ID, Name: "rotated", Value: "v1"}
+	vault, err := params.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+
+	policyParams := SetRotationPolicyParams{UserID: user.ID, Interval: 24 * time.Hour, Strategy: RotationStrategyHTTP, URL: "https://rotate.example.com", Enabled: true}
+	policy, secret, err := vault.SetRotationPolicy(&policyParams)
+	if err != nil {
+		t.Fatalf("set rotation policy: %v", err)
+	}
+	if secret == "" {
+		t.Error("expected a signing secret for the first http policy")
+	}
+	if decrypted, err := policy.DecryptSecret(); err != nil || decrypted != secret {
+		t.Errorf("expected the stored secret to decrypt to the returned one, got %q, %v", decrypted, err)
+	}
+	if vault.RotationInterval != 24*time.Hour || vault.ExpiresAt == nil {
+		t.Errorf("expected the policy interval to schedule the vault, got %s until %v", vault.RotationInterval, vault.ExpiresAt)
+	}
+
+	// Replacing the policy keeps the secret and does not show it again
+	policyParams = SetRotationPolicyParams{UserID: user.ID, Interval: 24 * time.Hour, Strategy: RotationStrategyRandom, Length: 16, Enabled: true}
+	policy, secret, err = vault.SetRotationPolicy(&policyParams)
+	if err != nil {
+		t.Fatalf("set rotation policy: %v", err)
+	}
+	if secret != "" || policy.URL != "" || policy.GeneratedLength() != 16 || policy.GeneratedCharset() != DefaultRotationCharset {
+		t.Errorf("unexpected replaced policy %+v, secret %q", policy, secret)
+	}
+}
+
+func TestClaimDueRotations(t *testing.T) {
+	user := createTestUser(t)
+	params := CreateVaultParams{UniqueID: uuid.NewString(), UserID: user.ID, Name: "due", Value: "v1"}
+	vault, err := params.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+	policyParams := SetRotationPolicyParams{UserID: user.ID, Interval: 24 * time.Hour, Strategy: RotationStrategyRandom, Enabled: true}
+	if _, _, err := vault.SetRotationPolicy(&policyParams); err != nil {
+		t.Fatalf("set rotation policy: %v", err)
+	}
+
+	claimed, err := ClaimDueRotations(100)
+	if err != nil {
+		t.Fatalf("claim rotations: %v", err)
+	}
+	if claimedPolicy(claimed, vault.ID) != nil {
+		t.Fatal("expected a vault before its deadline not to be claimed")
+	}
+
+	if err := DB.Model(vault).Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("expire vault: %v", err)
+	}
+	claimed, err = ClaimDueRotations(100)
+	if err != nil {
+		t.Fatalf("claim rotations: %v", err)
+	}
+	policy := claimedPolicy(claimed, vault.ID)
+	if policy == nil || policy.Vault.ID != vault.ID {
+		t.Fatal("expected the overdue vault to be claimed with its vault")
+	}
+	if claimed, _ := ClaimDueRotations(100); claimedPolicy(claimed, vault.ID) != nil {
+		t.Error("expected a claimed rotation not to be claimed twice")
+	}
+
+	// Failures are retried with backoff until the policy runs out of attempts
+	for attempt := 1; attempt < RotationMaxAttempts; attempt++ {
+		if err := policy.RecordFailure("endpoint down"); err != nil {
+			t.Fatalf("record failure: %v", err)
+		}
+		if policy.LastStatus != RotationRetrying || policy.Attempts != attempt {
+			t.Fatalf("expected retry %d, got %s after %d attempts", attempt, policy.LastStatus, policy.Attempts)
+		}
+	}
+	if err := policy.RecordFailure("endpoint down"); err != nil {
+		t.Fatalf("record failure: %v", err)
+	}
+	if policy.LastStatus != RotationFailed || policy.NextAttemptAt == nil || time.Until(*policy.NextAttemptAt) < 23*time.Hour {
+		t.Errorf("expected the rotation to fail until the next interval, got %s until %v", policy.LastStatus, policy.NextAttemptAt)
+	}
+
+	stored, err := GetRotationPolicy(vault.ID)
+	if err != nil {
+		t.Fatalf("get rotation policy: %v", err)
+	}
+	if stored.LastStatus != RotationFailed || stored.LastError != "endpoint down" || stored.LockedUntil != nil {
+		t.Errorf("expected the failure to be recorded, got %+v", stored)
+	}
+
+	// A rotation on request ignores the schedule
+	if err := stored.Claim(); err != nil {
+		t.Fatalf("claim rotation: %v", err)
+	}
+	if err := stored.Claim(); err != ErrRotationInProgress {
+		t.Errorf("expected a second claim to fail, got %v", err)
+	}
+	if err := stored.RecordSuccess(); err != nil {
+		t.Fatalf("record success: %v", err)
+	}
+	if stored.LastStatus != RotationSucceeded || stored.LastRotatedAt == nil || stored.NextAttemptAt != nil {
+		t.Errorf("expected the success to be recorded, got %+v", stored)
+	}
+}
+
+func TestSetRotationPolicyParamsValidate(t *testing.T) {
+	tests := map[string]struct {
+		params SetRotationPolicyParams
+		field  string
+	}{
+		"short interval":   {SetRotationPolicyParams{UserID: 1, Interval: time.Minute, Strategy: RotationStrategyRandom}, "interval"},
+		"unknown strategy": {SetRotationPolicyParams{UserID: 1, Interval: time.Hour, Strategy: "manual"}, "strategy"},
+		"short length":     {SetRotationPolicyParams{UserID: 1, Interval: time.Hour, Strategy: RotationStrategyRandom, Length: 4}, "length"},
+		"single character": {SetRotationPolicyParams{UserID: 1, Interval: time.Hour, Strategy: RotationStrategyRandom, Charset: "a"}, "charset"},
+		"missing url":      {SetRotationPolicyParams{UserID: 1, Interval: time.Hour, Strategy: RotationStrategyHTTP}, "url"},
+	}
+	for name, tt := range tests {
+		if _, ok := tt.params.Validate()[tt.field]; !ok {
+			t.Errorf("%s: expected %s error", name, tt.field)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lwshen/vault-hub/internal/auth"
+	"gorm.io/gorm"
+)
+
+// RefreshTokenTTL is how long a session lasts without being refreshed
+const RefreshTokenTTL = 30 * 24 * time.Hour
+
+var (
+	// ErrInvalidRefreshToken is returned for unknown, rotated, expired or revoked refresh tokens
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	// ErrSessionRevoked is returned for access tokens of sessions that ended
+	ErrSessionRevoked = errors.New("session has been revoked")
+)
+
+// Session is a login on one device. Access tokens are short-lived JWTs bound to the session;
+// the refresh token is rotated on every use and only its hash is stored.
+type Session struct {
+	ID                       uint `gorm:"primarykey"`
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+	UniqueID                 string     `gorm:"size:36;uniqueIndex"` // "sid" claim of access tokens
+	UserID                   uint       `gorm:"index;constraint:OnDelete:CASCADE"`
+	User                     User       `gorm:"foreignKey:UserID"`
+	RefreshTokenHash         string     `gorm:"size:64;uniqueIndex"`
+	PreviousRefreshTokenHash *string    `gorm:"size:64;index"` // detects reuse of a rotated token
+	IPAddress                string     `gorm:"size:45"`
+	UserAgent                string     `gorm:"size:500"`
+	LastUsedAt               time.Time  `gorm:"not null"`
+	ExpiresAt                time.Time  `gorm:"index"`
+	RevokedAt                *time.Time `gorm:"index"`
+}
+
+// SessionTokens are the credentials handed to a client for a session
+type SessionTokens struct {
+	AccessToken          string
+	AccessTokenExpiresAt time.Time
+	RefreshToken         string
+}
+
+// Active reports whether the session can still be used
+func (s *Session) Active() bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(time.Now())
+}
+
+// Revoke ends the session; its access and refresh tokens stop working immediately
+func (s *Session) Revoke() error {
+	now := time.Now()
+	if err := DB.Model(&Session{}).Where("id = ? AND revoked_at IS NULL", s.ID).Update("revoked_at", now).Error; err != nil {
+		return err
+	}
+	s.RevokedAt = &now
+	return nil
+}
+
+// accessToken mints an access token for the session
+func (s *Session) accessToken(refreshToken string) (*SessionTokens, error) {
+	token, expiresAt, err := auth.GenerateToken(s.UserID, s.UniqueID)
+	if err != nil {
+		return nil, err
+	}
+	return &SessionTokens{
+		AccessToken:          token,
+		AccessTokenExpiresAt: expiresAt,
+		RefreshToken:         refreshToken,
+	}, nil
+}
+
+// CreateSession starts a session for a user who has just logged in
+func CreateSession(userID uint, ipAddress, userAgent string) (*Session, *SessionTokens, error) {
+	refreshToken, hash, err := generateToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	session := Session{
+		UniqueID:         uuid.NewString(),
+		UserID:           userID,
+		RefreshTokenHash: hash,
+		IPAddress:        truncate(ipAddress, 45),
+		UserAgent:        truncate(userAgent, 500),
+		LastUsedAt:       now,
+		ExpiresAt:        now.Add(RefreshTokenTTL),
+	}
+	if err := DB.Create(&session).Error; err != nil {
+		return nil, nil, err
+	}
+
+	tokens, err := session.accessToken(refreshToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &session, tokens, nil
+}
+
+// RefreshSession exchanges a refresh token for a new access token and a new refresh token.
+// Presenting a refresh token that was already rotated means it was copied, so the whole
+// session is revoked.
+func RefreshSession(plaintextToken, ipAddress, userAgent string) (*Session, *SessionTokens, error) {
+	sum := sha256.Sum256([]byte(plaintextToken))
+	hash := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	var session Session
+	err := DB.Where("refresh_token_hash = ?", hash).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		var reused Session
+		if err := DB.Where("previous_refresh_token_hash = ?", hash).First(&reused).Error; err == nil {
+			if err := reused.Revoke(); err != nil {
+				return nil, nil, err
+			}
+		}
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if !session.Active() {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+
+	refreshToken, newHash, err := generateToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The condition makes concurrent refreshes with the same token race for a single update
+	now := time.Now()
+	updates := map[string]interface{}{
+		"refresh_token_hash":          newHash,
+		"previous_refresh_token_hash": hash,
+		"ip_address":                  truncate(ipAddress, 45),
+		"user_agent":                  truncate(userAgent, 500),
+		"last_used_at":                now,
+		"expires_at":                  now.Add(RefreshTokenTTL),
+	}
+	update := DB.Model(&Session{}).
+		Where("id = ? AND refresh_token_hash = ? AND revoked_at IS NULL", session.ID, hash).
+		Updates(updates)
+	if update.Error != nil {
+		return nil, nil, update.Error
+	}
+	if update.RowsAffected == 0 {
+		return nil, nil, ErrInvalidRefreshToken
+	}
+	session.RefreshTokenHash = newHash
+	session.PreviousRefreshTokenHash = &hash
+	session.IPAddress = truncate(ipAddress, 45)
+	session.UserAgent = truncate(userAgent, 500)
+	session.LastUsedAt = now
+	session.ExpiresAt = now.Add(RefreshTokenTTL)
+
+	tokens, err := session.accessToken(refreshToken)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &session, tokens, nil
+}
+
+// GetActiveSession returns the session of an access token if it has not ended
+func GetActiveSession(uniqueID string, userID uint) (*Session, error) {
+	var session Session
+	err := DB.Where("unique_id = ? AND user_id = ?", uniqueID, userID).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrSessionRevoked
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !session.Active() {
+		return nil, ErrSessionRevoked
+	}
+	return &session, nil
+}
+
+// GetUserSessions returns the active sessions of a user, most recently used first
+func GetUserSessions(userID uint) ([]Session, error) {
+	var sessions []Session
+	err := DB.Where("user_id = ? AND revoked_at IS NULL AND expires_at >= ?", userID, time.Now()).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// RevokeUserSessions ends all active sessions of a user except the one with exceptID, if
+// set, and returns how many were revoked
+func RevokeUserSessions(userID uint, exceptID *uint) (int64, error) {
+	query := DB.Model(&Session{}).Where("user_id = ? AND revoked_at IS NULL", userID)
+	if exceptID != nil {
+		query = query.Where("id <> ?", *exceptID)
+	}
+	result := query.Update("revoked_at", time.Now())
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,78 @@
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSessionRefreshRotation(t *testing.T) {
+	user := createTestUser(t)
+
+	session, tokens, err := CreateSession(user.ID, "127.0.0.1", "test-agent")
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Fatalf("CreateSession() tokens = %+v", tokens)
+	}
+	if _, err := GetActiveSession(session.UniqueID, user.ID); err != nil {
+		t.Fatalf("GetActiveSession() error = %v", err)
+	}
+
+	// Refreshing rotates the refresh token
+	refreshed, rotated, err := RefreshSession(tokens.RefreshToken, "127.0.0.2", "other-agent")
+	if err != nil {
+		t.Fatalf("RefreshSession() error = %v", err)
+	}
+	if refreshed.ID != session.ID || rotated.RefreshToken == tokens.RefreshToken || refreshed.IPAddress != "127.0.0.2" {
+		t.Fatalf("RefreshSession() = %+v, %+v", refreshed, rotated)
+	}
+
+	// Reusing the rotated token is rejected and revokes the session
+	if _, _, err := RefreshSession(tokens.RefreshToken, "", ""); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("reused RefreshSession() error = %v, want ErrInvalidRefreshToken", err)
+	}
+	if _, err := GetActiveSession(session.UniqueID, user.ID); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("GetActiveSession() after reuse error = %v, want ErrSessionRevoked", err)
+	}
+	if _, _, err := RefreshSession(rotated.RefreshToken, "", ""); !errors.Is(err, ErrInvalidRefreshToken) {
+		t.Fatalf("RefreshSession() of a revoked session error = %v, want ErrInvalidRefreshToken", err)
+	}
+}
+
+func TestRevokeUserSessions(t *testing.T) {
+	user, other := createTestUser(t), createTestUser(t)
+
+	kept, _, err := CreateSession(user.ID, "", "")
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+	for range 2 {
+		if _, _, err := CreateSession(user.ID, "", ""); err != nil {
+			t.Fatalf("CreateSession() error = %v", err)
+		}
+	}
+	otherSession, _, err := CreateSession(other.ID, "", "")
+	if err != nil {
+		t.Fatalf("CreateSession() error = %v", err)
+	}
+
+	revoked, err := RevokeUserSessions(user.ID, &kept.ID)
+	if err != nil || revoked != 2 {
+		t.Fatalf("RevokeUserSessions() = %d, %v, want 2", revoked, err)
+	}
+	sessions, err := GetUserSessions(user.ID)
+	if err != nil || len(sessions) != 1 || sessions[0].ID != kept.ID {
+		t.Fatalf("GetUserSessions() = %+v, %v", sessions, err)
+	}
+	if _, err := GetActiveSession(otherSession.UniqueID, other.ID); err != nil {
+		t.Fatalf("other user's session was revoked: %v", err)
+	}
+	if _, err := GetActiveSession(otherSession.UniqueID, user.ID); !errors.Is(err, ErrSessionRevoked) {
+		t.Fatalf("GetActiveSession() of another user's session error = %v", err)
+	}
+
+	if revoked, err := RevokeUserSessions(user.ID, nil); err != nil || revoked != 1 {
+		t.Fatalf("RevokeUserSessions(nil) = %d, %v, want 1", revoked, err)
+	}
+}
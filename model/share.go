@@ -0,0 +1,151 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultShareMaxViews and MaxShareMaxViews bound how often a share can be viewed
+	DefaultShareMaxViews = 1
+	MaxShareMaxViews     = 10
+	// DefaultShareExpiry is how long a share lasts when no expiry is given
+	DefaultShareExpiry = 24 * time.Hour
+	// MinShareExpiry and MaxShareExpiry bound the expiry a share may set
+	MinShareExpiry = 5 * time.Minute
+	MaxShareExpiry = 7 * 24 * time.Hour
+	// maxShareValueSize limits ad-hoc secrets, which are meant for single credentials
+	maxShareValueSize = 64 * 1024
+)
+
+// Share is a secret handed out through a link. The ciphertext is sealed with a key that is
+// only part of the link, so the server cannot read it back. A share is deleted with its last
+// view; expired shares are deleted by PruneExpiredShares.
+type Share struct {
+	ID         uint `gorm:"primarykey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	UniqueID   string    `gorm:"size:36;uniqueIndex;not null"` // Share ID in the link
+	UserID     uint      `gorm:"not null;index"`               // User who created the share
+	VaultID    *uint     `gorm:"index"`                        // Vault whose value is shared (null = ad-hoc secret)
+	Ciphertext string    `gorm:"type:text;not null"`
+	MaxViews   int       `gorm:"not null"`
+	Views      int       `gorm:"not null;default:0"`
+	ExpiresAt  time.Time `gorm:"not null;index"`
+}
+
+// CreateShareParams defines parameters for creating a share
+type CreateShareParams struct {
+	UserID    uint
+	VaultID   *uint // Vault the value was read from, recorded for the audit log
+	Value     string
+	MaxViews  int           // Zero means DefaultShareMaxViews
+	ExpiresIn time.Duration // Zero means DefaultShareExpiry
+}
+
+// Validate validates the create share parameters
+func (params *CreateShareParams) Validate() map[string]string {
+	errors := map[string]string{}
+
+	if params.UserID == 0 {
+		errors["user_id"] = "user ID is required"
+	}
+
+	if strings.TrimSpace(params.Value) == "" {
+		errors["value"] = "value is required"
+	} else if params.VaultID == nil && len(params.Value) > maxShareValueSize {
+		errors["value"] = fmt.Sprintf("value must be at most %d bytes", maxShareValueSize)
+	}
+
+	if params.MaxViews < 0 || params.MaxViews > MaxShareMaxViews {
+		errors["max_views"] = fmt.Sprintf("max views must be between 1 and %d", MaxShareMaxViews)
+	}
+
+	if params.ExpiresIn != 0 && (params.ExpiresIn < MinShareExpiry || params.ExpiresIn > MaxShareExpiry) {
+		errors["expires_in"] = fmt.Sprintf("expiry must be between %s and %s", MinShareExpiry, MaxShareExpiry)
+	}
+
+	return errors
+}
+
+// Create encrypts the value and stores the share. It returns the share and the key that
+// decrypts it, which is not stored.
+func (params *CreateShareParams) Create() (*Share, string, error) {
+	uniqueID, err := uuid.NewRandom()
+	if err != nil {
+		return nil, "", err
+	}
+	key, ciphertext, err := encryption.SealShare(params.Value, uniqueID.String())
+	if err != nil {
+		return nil, "", err
+	}
+
+	share := Share{
+		UniqueID:   uniqueID.String(),
+		UserID:     params.UserID,
+		VaultID:    params.VaultID,
+		Ciphertext: ciphertext,
+		MaxViews:   params.MaxViews,
+		ExpiresAt:  time.Now().Add(params.ExpiresIn),
+	}
+	if share.MaxViews == 0 {
+		share.MaxViews = DefaultShareMaxViews
+	}
+	if params.ExpiresIn == 0 {
+		share.ExpiresAt = time.Now().Add(DefaultShareExpiry)
+	}
+
+	if err := DB.Create(&share).Error; err != nil {
+		return nil, "", err
+	}
+	return &share, key, nil
+}
+
+// ViewShare counts a view of an unexpired share and returns it. The share is deleted with
+// its last view. gorm.ErrRecordNotFound is returned for unknown, expired and used up shares,
+// including a share whose last view another request took concurrently.
+func ViewShare(uniqueID string) (*Share, error) {
+	var share Share
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("unique_id = ? AND expires_at > ?", uniqueID, time.Now()).First(&share).Error; err != nil {
+			return err
+		}
+
+		// The view count is compared so that two requests cannot both take the same view
+		query := tx.Where("id = ? AND views = ?", share.ID, share.Views)
+		var result *gorm.DB
+		if share.Views+1 >= share.MaxViews {
+			result = query.Delete(&Share{})
+		} else {
+			result = query.Model(&Share{}).Update("views", share.Views+1)
+		}
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		share.Views++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &share, nil
+}
+
+// RemainingViews returns how often the share can still be viewed
+func (s *Share) RemainingViews() int {
+	return max(s.MaxViews-s.Views, 0)
+}
+
+// PruneExpiredShares deletes the shares that expired before the given time
+func PruneExpiredShares(before time.Time) (int64, error) {
+	result := DB.Where("expires_at < ?", before).Delete(&Share{})
+	return result.RowsAffected, result.Error
+}
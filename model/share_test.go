@@ -0,0 +1,92 @@
+package model
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"gorm.io/gorm"
+)
+
+func TestShareViewLimit(t *testing.T) {
+	user := createTestUser(t)
+
+	params := CreateShareParams{UserID: user.ID, Value: "contractor-password", MaxViews: 2}
+	if errors := params.Validate(); len(errors) > 0 {
+		t.Fatalf("expected valid params, got %v", errors)
+	}
+	share, key, err := params.Create()
+	if err != nil {
+		t.Fatalf("create share: %v", err)
+	}
+	if share.Ciphertext == "" || share.Ciphertext == "contractor-password" {
+		t.Fatalf("expected the value to be encrypted, got %q", share.Ciphertext)
+	}
+
+	for remaining := 1; remaining >= 0; remaining-- {
+		viewed, err := ViewShare(share.UniqueID)
+		if err != nil {
+			t.Fatalf("view share: %v", err)
+		}
+		if viewed.RemainingViews() != remaining {
+			t.Errorf("expected %d remaining views, got %d", remaining, viewed.RemainingViews())
+		}
+		value, err := encryption.OpenShare(key, viewed.Ciphertext, viewed.UniqueID)
+		if err != nil || value != "contractor-password" {
+			t.Fatalf("open share: %q, %v", value, err)
+		}
+	}
+
+	// The last view deletes the share
+	if _, err := ViewShare(share.UniqueID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected used up share to be gone, got %v", err)
+	}
+	if err := DB.First(&Share{}, share.ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected used up share to be deleted, got %v", err)
+	}
+}
+
+func TestShareExpiry(t *testing.T) {
+	user := createTestUser(t)
+
+	params := CreateShareParams{UserID: user.ID, Value: "expiring"}
+	share, _, err := params.Create()
+	if err != nil {
+		t.Fatalf("create share: %v", err)
+	}
+	if share.MaxViews != DefaultShareMaxViews || time.Until(share.ExpiresAt) > DefaultShareExpiry {
+		t.Errorf("expected defaults, got %d views until %s", share.MaxViews, share.ExpiresAt)
+	}
+
+	if err := DB.Model(share).Update("expires_at", time.Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("expire share: %v", err)
+	}
+	if _, err := ViewShare(share.UniqueID); !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected expired share not to be viewable, got %v", err)
+	}
+
+	if _, err := PruneExpiredShares(time.Now()); err != nil {
+		t.Fatalf("prune shares: %v", err)
+	}
+	if err := DB.First(&Share{}, share.ID).Error; !errors.Is(err, gorm.ErrRecordNotFound) {
+		t.Errorf("expected expired share to be pruned, got %v", err)
+	}
+}
+
+func TestCreateShareParamsValidate(t *testing.T) {
+	tests := map[string]struct {
+		params CreateShareParams
+		field  string
+	}{
+		"missing value":  {CreateShareParams{UserID: 1}, "value"},
+		"too many views": {CreateShareParams{UserID: 1, Value: "x", MaxViews: MaxShareMaxViews + 1}, "max_views"},
+		"short expiry":   {CreateShareParams{UserID: 1, Value: "x", ExpiresIn: time.Minute}, "expires_in"},
+		"long expiry":    {CreateShareParams{UserID: 1, Value: "x", ExpiresIn: 30 * 24 * time.Hour}, "expires_in"},
+	}
+	for name, tt := range tests {
+		if _, ok := tt.params.Validate()[tt.field]; !ok {
+			t.Errorf("%s: expected %s error", name, tt.field)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/lwshen/vault-hub/internal/auth"
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"gorm.io/gorm"
+)
+
+const (
+	// TOTPIssuer is the issuer shown by authenticator apps
+	TOTPIssuer = "Vault Hub"
+	// RecoveryCodeCount is the number of recovery codes generated at a time
+	RecoveryCodeCount = 10
+	// MaxLoginChallengeAttempts is the number of wrong codes after which a login challenge is void
+	MaxLoginChallengeAttempts = 5
+
+	// recoveryCodeAlphabet leaves out characters that are easily confused
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+	recoveryCodeLength   = 10
+)
+
+var (
+	// ErrTwoFactorEnabled is returned when enrolling a user who already has two-factor authentication
+	ErrTwoFactorEnabled = errors.New("two-factor authentication is already enabled")
+	// ErrTwoFactorNotEnabled is returned for operations that need two-factor authentication
+	ErrTwoFactorNotEnabled = errors.New("two-factor authentication is not enabled")
+	// ErrTOTPEnrollmentNotStarted is returned when enabling TOTP before a secret was generated
+	ErrTOTPEnrollmentNotStarted = errors.New("TOTP enrollment has not been started")
+	// ErrInvalidTwoFactorCode is returned when a TOTP or recovery code does not match
+	ErrInvalidTwoFactorCode = errors.New("invalid two-factor code")
+	// ErrInvalidLoginChallenge is returned for unknown, expired, used or exhausted login challenges
+	ErrInvalidLoginChallenge = errors.New("invalid or expired login challenge")
+)
+
+// RecoveryCode is a one-time code that completes a login in place of a TOTP code
+type RecoveryCode struct {
+	ID        uint `gorm:"primarykey"`
+	CreatedAt time.Time
+	UserID    uint       `gorm:"index;constraint:OnDelete:CASCADE"`
+	User      User       `gorm:"foreignKey:UserID"`
+	CodeHash  string     `gorm:"size:64;index"`
+	UsedAt    *time.Time `gorm:"index"`
+}
+
+// LoginChallenge is issued after a correct password when the user has two-factor
+// authentication; the login completes once a code is verified against it
+type LoginChallenge struct {
+	ID         uint `gorm:"primarykey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	UserID     uint       `gorm:"index;constraint:OnDelete:CASCADE"`
+	User       User       `gorm:"foreignKey:UserID"`
+	TokenHash  string     `gorm:"size:64;uniqueIndex"`
+	ExpiresAt  time.Time  `gorm:"index"`
+	Attempts   int        `gorm:"not null;default:0"`
+	ConsumedAt *time.Time `gorm:"index"`
+}
+
+// TwoFactorEnabled reports whether the user must complete a login with a second factor
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// StartTOTPEnrollment generates a new shared secret and stores it encrypted until the user
+// confirms it with EnableTOTP. Starting again replaces a secret that was not confirmed.
+func (u *User) StartTOTPEnrollment() (string, error) {
+	if u.TwoFactorEnabled() {
+		return "", ErrTwoFactorEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := encryption.Encrypt(secret)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt TOTP secret: %w", err)
+	}
+	if err := DB.Model(u).Update("totp_secret", encrypted).Error; err != nil {
+		return "", err
+	}
+	u.TOTPSecret = &encrypted
+	return secret, nil
+}
+
+// EnableTOTP confirms enrollment with a code from the authenticator app and returns a fresh
+// set of recovery codes. The codes are only stored hashed, so they cannot be shown again.
+func (u *User) EnableTOTP(code string) ([]string, error) {
+	if u.TwoFactorEnabled() {
+		return nil, ErrTwoFactorEnabled
+	}
+	if u.TOTPSecret == nil {
+		return nil, ErrTOTPEnrollmentNotStarted
+	}
+
+	secret, err := encryption.Decrypt(*u.TOTPSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+	counter, ok := auth.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	var codes []string
+	now := time.Now()
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(u).Updates(map[string]interface{}{
+			"totp_enabled_at":   now,
+			"totp_last_counter": counter,
+		}).Error; err != nil {
+			return err
+		}
+		codes, err = replaceRecoveryCodes(tx, u.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	u.TOTPEnabledAt = &now
+	u.TOTPLastCounter = counter
+	return codes, nil
+}
+
+// DisableTOTP removes the shared secret and all recovery codes
+func (u *User) DisableTOTP() error {
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(u).Updates(map[string]interface{}{
+			"totp_secret":       nil,
+			"totp_enabled_at":   nil,
+			"totp_last_counter": 0,
+		}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", u.ID).Delete(&RecoveryCode{}).Error
+	})
+	if err != nil {
+		return err
+	}
+	u.TOTPSecret = nil
+	u.TOTPEnabledAt = nil
+	u.TOTPLastCounter = 0
+	return nil
+}
+
+// VerifyTOTP checks a code from the authenticator app. Each code is accepted once: the
+// time step is recorded, and codes of that step or an earlier one are rejected afterwards.
+func (u *User) VerifyTOTP(code string) (bool, error) {
+	if !u.TwoFactorEnabled() || u.TOTPSecret == nil {
+		return false, ErrTwoFactorNotEnabled
+	}
+
+	secret, err := encryption.Decrypt(*u.TOTPSecret)
+	if err != nil {
+		return false, fmt.Errorf("failed to decrypt TOTP secret: %w", err)
+	}
+	counter, ok := auth.ValidateTOTP(secret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	// The condition makes concurrent uses of the same code race for a single update
+	update := DB.Model(&User{}).
+		Where("id = ? AND totp_last_counter < ?", u.ID, counter).
+		Update("totp_last_counter", counter)
+	if update.Error != nil {
+		return false, update.Error
+	}
+	if update.RowsAffected == 0 {
+		return false, nil
+	}
+	u.TOTPLastCounter = counter
+	return true, nil
+}
+
+// UseRecoveryCode consumes one of the user's unused recovery codes
+func (u *User) UseRecoveryCode(code string) (bool, error) {
+	if !u.TwoFactorEnabled() {
+		return false, ErrTwoFactorNotEnabled
+	}
+
+	update := DB.Model(&RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", u.ID, hashRecoveryCode(code)).
+		Update("used_at", time.Now())
+	if update.Error != nil {
+		return false, update.Error
+	}
+	return update.RowsAffected > 0, nil
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes of the user with a fresh set
+func (u *User) RegenerateRecoveryCodes() ([]string, error) {
+	if !u.TwoFactorEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	var codes []string
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		codes, err = replaceRecoveryCodes(tx, u.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// RemainingRecoveryCodes returns the number of recovery codes the user has not used
+func (u *User) RemainingRecoveryCodes() (int64, error) {
+	var count int64
+	err := DB.Model(&RecoveryCode{}).Where("user_id = ? AND used_at IS NULL", u.ID).Count(&count).Error
+	return count, err
+}
+
+// replaceRecoveryCodes deletes the user's recovery codes and stores a new set
+func replaceRecoveryCodes(tx *gorm.DB, userID uint) ([]string, error) {
+	if err := tx.Where("user_id = ?", userID).Delete(&RecoveryCode{}).Error; err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, RecoveryCodeCount)
+	records := make([]RecoveryCode, 0, RecoveryCodeCount)
+	for range RecoveryCodeCount {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		records = append(records, RecoveryCode{UserID: userID, CodeHash: hashRecoveryCode(code)})
+	}
+	if err := tx.Create(&records).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// generateRecoveryCode returns a random code formatted as "xxxxx-xxxxx"
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLength)
+	alphabetSize := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+		b[i] = recoveryCodeAlphabet[n.Int64()]
+	}
+	half := recoveryCodeLength / 2
+	return string(b[:half]) + "-" + string(b[half:]), nil
+}
+
+// hashRecoveryCode hashes a recovery code, ignoring case, spaces and dashes
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// rotateTOTPSecrets re-encrypts every TOTP secret with the active master key
+func rotateTOTPSecrets() (int, error) {
+	var users []User
+	if err := DB.Unscoped().Select("id", "totp_secret").Where("totp_secret IS NOT NULL").Find(&users).Error; err != nil {
+		return 0, err
+	}
+
+	for _, user := range users {
+		secret, err := encryption.Decrypt(*user.TOTPSecret)
+		if err != nil {
+			return 0, fmt.Errorf("user %d: %w", user.ID, err)
+		}
+		encrypted, err := encryption.Encrypt(secret)
+		if err != nil {
+			return 0, fmt.Errorf("user %d: %w", user.ID, err)
+		}
+		if err := DB.Unscoped().Model(&User{}).Where("id = ?", user.ID).Update("totp_secret", encrypted).Error; err != nil {
+			return 0, fmt.Errorf("user %d: %w", user.ID, err)
+		}
+	}
+	return len(users), nil
+}
+
+// CreateLoginChallenge issues a challenge token that must be completed with a second factor
+func CreateLoginChallenge(userID uint, ttl time.Duration) (string, *LoginChallenge, error) {
+	token, hash, err := generateToken()
+	if err != nil {
+		return "", nil, err
+	}
+	challenge := &LoginChallenge{
+		UserID:    userID,
+		TokenHash: hash,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := DB.Create(challenge).Error; err != nil {
+		return "", nil, err
+	}
+	return token, challenge, nil
+}
+
+// GetLoginChallenge returns the pending challenge of a token along with its user
+func GetLoginChallenge(plaintextToken string) (*LoginChallenge, error) {
+	sum := sha256.Sum256([]byte(plaintextToken))
+	hash := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	var challenge LoginChallenge
+	err := DB.Preload("User").
+		Where("token_hash = ? AND consumed_at IS NULL AND expires_at >= ? AND attempts < ?", hash, time.Now(), MaxLoginChallengeAttempts).
+		First(&challenge).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrInvalidLoginChallenge
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// RecordFailure counts a wrong code against the challenge
+func (c *LoginChallenge) RecordFailure() error {
+	c.Attempts++
+	return DB.Model(&LoginChallenge{}).Where("id = ?", c.ID).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// Consume marks the challenge as completed. It fails if the challenge was completed or
+// exhausted concurrently.
+func (c *LoginChallenge) Consume() error {
+	now := time.Now()
+	update := DB.Model(&LoginChallenge{}).
+		Where("id = ? AND consumed_at IS NULL AND attempts < ?", c.ID, MaxLoginChallengeAttempts).
+		Update("consumed_at", now)
+	if update.Error != nil {
+		return update.Error
+	}
+	if update.RowsAffected == 0 {
+		return ErrInvalidLoginChallenge
+	}
+	c.ConsumedAt = &now
+	return nil
+}
@@ -0,0 +1,161 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lwshen/vault-hub/internal/auth"
+)
+
+// totpCodeAt returns the code of a secret for a time step
+func totpCodeAt(t *testing.T, secret string, counter int64) string {
+	t.Helper()
+	code, err := auth.TOTPCode(secret, counter)
+	if err != nil {
+		t.Fatalf("TOTPCode() error = %v", err)
+	}
+	return code
+}
+
+func TestTOTPEnrollment(t *testing.T) {
+	user := createTestUser(t)
+
+	if _, err := user.EnableTOTP("123456"); !errors.Is(err, ErrTOTPEnrollmentNotStarted) {
+		t.Fatalf("EnableTOTP() before setup error = %v, want ErrTOTPEnrollmentNotStarted", err)
+	}
+
+	secret, err := user.StartTOTPEnrollment()
+	if err != nil {
+		t.Fatalf("StartTOTPEnrollment() error = %v", err)
+	}
+	var stored User
+	if err := DB.First(&stored, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if stored.TOTPSecret == nil || strings.Contains(*stored.TOTPSecret, secret) || stored.TwoFactorEnabled() {
+		t.Fatalf("secret should be stored encrypted and pending, got %+v", stored.TOTPSecret)
+	}
+
+	// Fixed once, so the test does not depend on crossing a step boundary
+	step := auth.TOTPCounter(time.Now())
+
+	wrong := totpCodeAt(t, secret, step-5)
+	if _, err := stored.EnableTOTP(wrong); !errors.Is(err, ErrInvalidTwoFactorCode) {
+		t.Fatalf("EnableTOTP() with a wrong code error = %v, want ErrInvalidTwoFactorCode", err)
+	}
+
+	codes, err := stored.EnableTOTP(totpCodeAt(t, secret, step))
+	if err != nil {
+		t.Fatalf("EnableTOTP() error = %v", err)
+	}
+	if len(codes) != RecoveryCodeCount || !stored.TwoFactorEnabled() {
+		t.Fatalf("EnableTOTP() returned %d codes, enabled %v", len(codes), stored.TwoFactorEnabled())
+	}
+	if _, err := stored.StartTOTPEnrollment(); !errors.Is(err, ErrTwoFactorEnabled) {
+		t.Fatalf("StartTOTPEnrollment() when enabled error = %v, want ErrTwoFactorEnabled", err)
+	}
+
+	// The code used for enrollment cannot log in, a later one can but only once
+	if ok, err := stored.VerifyTOTP(totpCodeAt(t, secret, step)); err != nil || ok {
+		t.Fatalf("VerifyTOTP() with the enrollment code = %v, %v, want rejected", ok, err)
+	}
+	next := totpCodeAt(t, secret, step+1)
+	if ok, err := stored.VerifyTOTP(next); err != nil || !ok {
+		t.Fatalf("VerifyTOTP() = %v, %v, want accepted", ok, err)
+	}
+	if ok, _ := stored.VerifyTOTP(next); ok {
+		t.Fatal("VerifyTOTP() accepted a replayed code")
+	}
+
+	// Recovery codes work once, regardless of case and dashes
+	variant := strings.ToUpper(strings.ReplaceAll(codes[0], "-", ""))
+	if ok, err := stored.UseRecoveryCode(variant); err != nil || !ok {
+		t.Fatalf("UseRecoveryCode() = %v, %v, want accepted", ok, err)
+	}
+	if ok, _ := stored.UseRecoveryCode(codes[0]); ok {
+		t.Fatal("UseRecoveryCode() accepted a used code")
+	}
+	if remaining, err := stored.RemainingRecoveryCodes(); err != nil || remaining != RecoveryCodeCount-1 {
+		t.Fatalf("RemainingRecoveryCodes() = %d, %v", remaining, err)
+	}
+
+	regenerated, err := stored.RegenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("RegenerateRecoveryCodes() error = %v", err)
+	}
+	if ok, _ := stored.UseRecoveryCode(codes[1]); ok {
+		t.Fatal("an old recovery code still works after regenerating")
+	}
+	if ok, _ := stored.UseRecoveryCode(regenerated[0]); !ok {
+		t.Fatal("a regenerated recovery code was rejected")
+	}
+
+	if err := stored.DisableTOTP(); err != nil {
+		t.Fatalf("DisableTOTP() error = %v", err)
+	}
+	if err := DB.First(&stored, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+	if stored.TwoFactorEnabled() || stored.TOTPSecret != nil {
+		t.Fatal("DisableTOTP() left two-factor authentication configured")
+	}
+	if remaining, _ := stored.RemainingRecoveryCodes(); remaining != 0 {
+		t.Fatalf("%d recovery codes left after disabling", remaining)
+	}
+}
+
+func TestLoginChallenge(t *testing.T) {
+	user := createTestUser(t)
+
+	token, _, err := CreateLoginChallenge(user.ID, time.Minute)
+	if err != nil {
+		t.Fatalf("CreateLoginChallenge() error = %v", err)
+	}
+	challenge, err := GetLoginChallenge(token)
+	if err != nil || challenge.User.ID != user.ID {
+		t.Fatalf("GetLoginChallenge() = %+v, %v", challenge, err)
+	}
+
+	// Wrong codes exhaust the challenge
+	for range MaxLoginChallengeAttempts {
+		if err := challenge.RecordFailure(); err != nil {
+			t.Fatalf("RecordFailure() error = %v", err)
+		}
+	}
+	if _, err := GetLoginChallenge(token); !errors.Is(err, ErrInvalidLoginChallenge) {
+		t.Fatalf("GetLoginChallenge() after too many failures error = %v", err)
+	}
+	if err := challenge.Consume(); !errors.Is(err, ErrInvalidLoginChallenge) {
+		t.Fatalf("Consume() of an exhausted challenge error = %v", err)
+	}
+
+	// A challenge completes once
+	token, _, err = CreateLoginChallenge(user.ID, time.Minute)
+	if err != nil {
+		t.Fatalf("CreateLoginChallenge() error = %v", err)
+	}
+	challenge, err = GetLoginChallenge(token)
+	if err != nil {
+		t.Fatalf("GetLoginChallenge() error = %v", err)
+	}
+	if err := challenge.Consume(); err != nil {
+		t.Fatalf("Consume() error = %v", err)
+	}
+	if err := challenge.Consume(); !errors.Is(err, ErrInvalidLoginChallenge) {
+		t.Fatalf("second Consume() error = %v", err)
+	}
+	if _, err := GetLoginChallenge(token); !errors.Is(err, ErrInvalidLoginChallenge) {
+		t.Fatalf("GetLoginChallenge() of a used challenge error = %v", err)
+	}
+
+	// Expired challenges are rejected
+	token, _, err = CreateLoginChallenge(user.ID, -time.Second)
+	if err != nil {
+		t.Fatalf("CreateLoginChallenge() error = %v", err)
+	}
+	if _, err := GetLoginChallenge(token); !errors.Is(err, ErrInvalidLoginChallenge) {
+		t.Fatalf("GetLoginChallenge() of an expired challenge error = %v", err)
+	}
+}
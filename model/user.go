@@ -3,8 +3,8 @@ package model
 import (
 	"fmt"
 	"regexp"
+	"time"
 
-	"github.com/lwshen/vault-hub/internal/auth"
 	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
@@ -15,6 +15,11 @@ type User struct {
 	Password *string `gorm:"type:text"`
 	Name     *string `gorm:"size:255"`
 	Avatar   *string `gorm:"type:text"`
+
+	// TOTP two-factor authentication; the secret is encrypted and set once enrollment starts
+	TOTPSecret      *string `gorm:"type:text"`
+	TOTPEnabledAt   *time.Time
+	TOTPLastCounter int64 `gorm:"not null;default:0"`
 }
 
 func (u *User) GetByEmail() error {
@@ -111,10 +116,6 @@ func (u *User) ComparePassword(password string) bool {
 	return err == nil
 }
 
-func (u *User) GenerateToken() (string, error) {
-	return auth.GenerateToken(u.ID)
-}
-
 // Demo user constants
 const (
 	DemoUserEmail    = "mock@demo.com"
@@ -10,6 +10,15 @@ import (
 	"gorm.io/gorm"
 )
 
+// VaultType is what a vault's value holds
+type VaultType string
+
+const (
+	VaultTypeStatic   VaultType = "static"   // The value is the secret itself
+	VaultTypeKV       VaultType = "kv"       // The value is a JSON object of keys and their secrets
+	VaultTypeDatabase VaultType = "database" // The value is a DatabaseConfig; reads issue database credentials
+)
+
 type Vault struct {
 	gorm.Model
 	UniqueID    string `gorm:"size:255;not null;unique"`                                             // Unique identifier for the vault
@@ -19,6 +28,17 @@ type Vault struct {
 	Description string `gorm:"size:500"`                                                             // Human-readable description
 	Category    string `gorm:"size:100;index"`                                                       // Category/type of vault
 	Favourite   bool   `gorm:"default:false;not null"`                                               // Favourite flag
+	DataKey     string `gorm:"size:255;not null;default:''"`                                         // Data key wrapped by a master key
+	KeyID       string `gorm:"size:32;index;not null;default:''"`                                    // ID of the master key wrapping DataKey
+	// What the value holds; reads of database vaults issue credentials instead of returning it
+	Type VaultType `gorm:"size:20;not null;default:'static'"`
+	// Organization that owns this vault; nil for personal vaults. UserID is then the member who created it.
+	OrganizationID *uint `gorm:"index"`
+	// Rotation: the value should be replaced by ExpiresAt. With a RotationInterval every new value
+	// moves ExpiresAt that far ahead. ExpiryRemindedAt is set once owners were reminded of ExpiresAt.
+	ExpiresAt        *time.Time    `gorm:"index"`
+	RotationInterval time.Duration `gorm:"not null;default:0"`
+	ExpiryRemindedAt *time.Time
 }
 
 // CreateVaultParams defines parameters for creating a new vault
@@ -29,15 +49,31 @@ type CreateVaultParams struct {
 	Value       string
 	Description string
 	Category    string
+	Type        VaultType  // Empty = VaultTypeStatic
+	Source      SourceType // Source of the initial value, recorded in version history
+	APIKeyID    *uint      // API key used to create the vault, if any
+	// Organization to create the vault in; the user must be at least a member
+	OrganizationID   *uint
+	ExpiresAt        *time.Time    // When the value should be rotated (nil = never, or RotationInterval from now)
+	RotationInterval time.Duration // How long each value is valid (zero = no rotation schedule)
 }
 
 // UpdateVaultParams defines parameters for updating a vault
 type UpdateVaultParams struct {
-	Name        *string
-	Value       *string
-	Description *string
-	Category    *string
-	Favourite   *bool
+	Name             *string
+	Value            *string
+	Description      *string
+	Category         *string
+	Favourite        *bool
+	Author           VersionAuthor  // Recorded in version history when Value changes
+	ExpiresAt        *time.Time     // New rotation deadline
+	ClearExpiry      bool           // Remove the rotation deadline
+	RotationInterval *time.Duration // New rotation interval (zero = no rotation schedule)
+	// Keys of a kv vault to set or remove, leaving the other keys as they are
+	SetKeys    map[string]string
+	DeleteKeys []string
+
+	restoredFrom *uint
 }
 
 // Validate validates the create vault parameters
@@ -60,6 +96,23 @@ func (params *CreateVaultParams) Validate() map[string]string {
 		errors["value"] = "value is required"
 	}
 
+	switch params.Type {
+	case "", VaultTypeStatic:
+	case VaultTypeKV:
+		if _, err := ParseKVValue(params.Value); err != nil {
+			errors["value"] = err.Error()
+		}
+	case VaultTypeDatabase:
+		if _, err := ParseDatabaseConfig(params.Value); err != nil {
+			errors["value"] = err.Error()
+		}
+		if params.ExpiresAt != nil || params.RotationInterval != 0 {
+			errors["rotation_interval"] = errDatabaseVaultRotation
+		}
+	default:
+		errors["type"] = "type must be static, kv or database"
+	}
+
 	if len(params.Description) > 500 {
 		errors["description"] = "description must be less than 500 characters"
 	}
@@ -72,6 +125,8 @@ func (params *CreateVaultParams) Validate() map[string]string {
 		errors["user_id"] = "user_id is required"
 	}
 
+	validateVaultExpiry(errors, params.ExpiresAt, &params.RotationInterval)
+
 	return errors
 }
 
@@ -99,6 +154,13 @@ func (params *UpdateVaultParams) Validate() map[string]string {
 		errors["category"] = "category must be less than 100 characters"
 	}
 
+	params.validateKeyUpdates(errors)
+
+	if params.ClearExpiry && params.ExpiresAt != nil {
+		errors["expires_at"] = "expiresAt cannot be set and cleared at once"
+	}
+	validateVaultExpiry(errors, params.ExpiresAt, params.RotationInterval)
+
 	return errors
 }
 
@@ -119,29 +181,84 @@ func (params *CreateVaultParams) Create() (*Vault, error) {
 		return nil, err
 	}
 
-	// Encrypt the value before storing
-	encryptedValue, err := encryption.Encrypt(params.Value)
+	if params.OrganizationID != nil {
+		membership, err := GetMembership(*params.OrganizationID, params.UserID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil, ErrVaultPermissionDenied
+			}
+			return nil, err
+		}
+		if !membership.Role.AtLeast(VaultPermissionWrite.requiredRole()) {
+			return nil, ErrVaultPermissionDenied
+		}
+		if err := checkOrganizationVaultNameUnique(params.Name, *params.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Each vault gets its own data key, wrapped by the active master key
+	dataKey, wrappedKey, keyID, err := encryption.GenerateDataKey()
 	if err != nil {
-		return nil, fmt.Errorf("failed to encrypt value: %w", err)
+		return nil, err
 	}
 
+	if params.Type == "" {
+		params.Type = VaultTypeStatic
+	}
 	vault := Vault{
 		UniqueID:    params.UniqueID,
 		UserID:      params.UserID,
 		Name:        params.Name,
-		Value:       encryptedValue,
 		Description: params.Description,
 		Category:    params.Category,
+		Type:        params.Type,
 		Favourite:   false,
+		DataKey:     wrappedKey,
+		KeyID:       keyID,
+
+		OrganizationID: params.OrganizationID,
+
+		ExpiresAt:        params.ExpiresAt,
+		RotationInterval: params.RotationInterval,
+	}
+	if vault.ExpiresAt == nil && vault.RotationInterval > 0 {
+		expiresAt := time.Now().Add(vault.RotationInterval)
+		vault.ExpiresAt = &expiresAt
+	}
+
+	value := params.Value
+	if vault.Type == VaultTypeKV {
+		data, err := ParseKVValue(value)
+		if err != nil {
+			return nil, err
+		}
+		if value, err = FormatKVValue(data); err != nil {
+			return nil, err
+		}
+	}
+
+	// Encrypt the value before storing
+	vault.Value, err = vault.encryptValue(dataKey, value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt value: %w", err)
 	}
 
-	err = DB.Create(&vault).Error
+	err = DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&vault).Error; err != nil {
+			return err
+		}
+
+		author := VersionAuthor{UserID: params.UserID, APIKeyID: params.APIKeyID, Source: params.Source}
+		_, err := createVaultVersion(tx, vault.ID, vault.Value, author, nil)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	// Decrypt the value for the response
-	vault.Value, err = encryption.Decrypt(vault.Value)
+	vault.Value, err = vault.decryptValue(vault.Value)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt value for response: %w", err)
 	}
@@ -149,44 +266,50 @@ func (params *CreateVaultParams) Create() (*Vault, error) {
 	return &vault, nil
 }
 
-// GetByUniqueID retrieves a vault by unique_id for a specific user
+// GetByUniqueID retrieves a vault by unique_id that a specific user can access
 func (v *Vault) GetByUniqueID(uniqueID string, userID uint) error {
-	err := DB.Where("unique_id = ? AND user_id = ?", uniqueID, userID).First(v).Error
+	err := DB.Scopes(AccessibleVaults(userID)).Where("unique_id = ?", uniqueID).First(v).Error
 	if err != nil {
 		return err
 	}
 
 	// Decrypt the value
-	decryptedValue, err := encryption.Decrypt(v.Value)
+	decryptedValue, err := v.decryptValue(v.Value)
 	if err != nil {
 		return fmt.Errorf("failed to decrypt value: %w", err)
 	}
+	upgradeVaultOnRead(v, v.Value)
 	v.Value = decryptedValue
 
 	return nil
 }
 
-// GetByName retrieves a vault by name for a specific user
+// GetByName retrieves a vault by name that a specific user can access.
+// Personal vaults take precedence over organization vaults with the same name.
 func (v *Vault) GetByName(name string, userID uint) error {
-	err := DB.Where("name = ? AND user_id = ?", name, userID).First(v).Error
+	err := DB.Scopes(AccessibleVaults(userID)).
+		Where("name = ?", name).
+		Order("CASE WHEN organization_id IS NULL THEN 0 ELSE 1 END, id").
+		First(v).Error
 	if err != nil {
 		return err
 	}
 
 	// Decrypt the value
-	decryptedValue, err := encryption.Decrypt(v.Value)
+	decryptedValue, err := v.decryptValue(v.Value)
 	if err != nil {
 		return fmt.Errorf("failed to decrypt value: %w", err)
 	}
+	upgradeVaultOnRead(v, v.Value)
 	v.Value = decryptedValue
 
 	return nil
 }
 
-// GetAllByUser retrieves all vaults for a user, optionally filtered by category
+// GetAllByUser retrieves all vaults a user can access, including those of their organizations
 func GetVaultsByUser(userID uint, decrypt bool) ([]Vault, error) {
 	var vaults []Vault
-	query := DB.Where("user_id = ?", userID)
+	query := DB.Scopes(AccessibleVaults(userID))
 
 	err := query.Order("favourite DESC, created_at DESC").Find(&vaults).Error
 	if err != nil {
@@ -196,10 +319,11 @@ func GetVaultsByUser(userID uint, decrypt bool) ([]Vault, error) {
 	// Decrypt all values if requested
 	if decrypt {
 		for i := range vaults {
-			decryptedValue, err := encryption.Decrypt(vaults[i].Value)
+			decryptedValue, err := vaults[i].decryptValue(vaults[i].Value)
 			if err != nil {
 				return nil, fmt.Errorf("failed to decrypt value for vault %d: %w", vaults[i].ID, err)
 			}
+			upgradeVaultOnRead(&vaults[i], vaults[i].Value)
 			vaults[i].Value = decryptedValue
 		}
 	}
@@ -207,14 +331,22 @@ func GetVaultsByUser(userID uint, decrypt bool) ([]Vault, error) {
 	return vaults, nil
 }
 
-// GetUserVaultsWithPagination returns vaults for a user with pagination
-func GetUserVaultsWithPagination(userID uint, pageSize, pageIndex int) ([]Vault, int64, error) {
+// GetUserVaultsWithPagination returns vaults for a user with pagination. A non-zero
+// expiringWithin only returns the vaults due for rotation within that time, soonest first.
+func GetUserVaultsWithPagination(userID uint, pageSize, pageIndex int, expiringWithin time.Duration) ([]Vault, int64, error) {
 	var vaults []Vault
 	var totalCount int64
 
+	scopes := []func(*gorm.DB) *gorm.DB{AccessibleVaults(userID)}
+	order := "favourite DESC, created_at DESC"
+	if expiringWithin > 0 {
+		scopes = append(scopes, ExpiringWithin(expiringWithin))
+		order = "expires_at, created_at DESC"
+	}
+
 	// Count total vaults for the user, explicitly excluding soft-deleted records
-	if err := DB.Model(&Vault{}).
-		Where("user_id = ? AND deleted_at IS NULL", userID).
+	if err := DB.Model(&Vault{}).Scopes(scopes...).
+		Where("deleted_at IS NULL").
 		Count(&totalCount).Error; err != nil {
 		return nil, 0, err
 	}
@@ -222,9 +354,10 @@ func GetUserVaultsWithPagination(userID uint, pageSize, pageIndex int) ([]Vault,
 	// Calculate offset (pageIndex is 1-based)
 	offset := (pageIndex - 1) * pageSize
 
-	// Fetch paginated vaults, favourites first, then newest
-	if err := DB.Where("user_id = ? AND deleted_at IS NULL", userID).
-		Order("favourite DESC, created_at DESC").
+	// Fetch paginated vaults, favourites first, then newest, or soonest due when filtering by expiry
+	if err := DB.Scopes(scopes...).
+		Where("deleted_at IS NULL").
+		Order(order).
 		Limit(pageSize).
 		Offset(offset).
 		Find(&vaults).Error; err != nil {
@@ -242,6 +375,11 @@ func (v *Vault) Update(params *UpdateVaultParams) error {
 		if err != nil {
 			return err
 		}
+		if v.OrganizationID != nil {
+			if err := checkOrganizationVaultNameUnique(*params.Name, *v.OrganizationID, v.ID); err != nil {
+				return err
+			}
+		}
 	}
 
 	updates := map[string]interface{}{}
@@ -250,15 +388,6 @@ func (v *Vault) Update(params *UpdateVaultParams) error {
 		updates["name"] = *params.Name
 	}
 
-	if params.Value != nil {
-		// Encrypt the new value before storing
-		encryptedValue, err := encryption.Encrypt(*params.Value)
-		if err != nil {
-			return fmt.Errorf("failed to encrypt value: %w", err)
-		}
-		updates["value"] = encryptedValue
-	}
-
 	if params.Description != nil {
 		updates["description"] = *params.Description
 	}
@@ -271,10 +400,49 @@ func (v *Vault) Update(params *UpdateVaultParams) error {
 		updates["favourite"] = *params.Favourite
 	}
 
+	v.applyExpiryUpdates(params, updates)
+
 	// Always update the updated_at timestamp
 	updates["updated_at"] = time.Now()
 
-	err := DB.Model(v).Updates(updates).Error
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		value, err := v.updatedValue(tx, params)
+		if err != nil {
+			return err
+		}
+
+		var encryptedValue string
+		if value != nil {
+			// Keep the value being replaced if the vault predates version history
+			if err := ensureVaultVersionBaseline(tx, v); err != nil {
+				return err
+			}
+
+			dataKey, err := ensureVaultDataKey(tx, v)
+			if err != nil {
+				return err
+			}
+
+			// Encrypt the new value before storing
+			encryptedValue, err = v.encryptValue(dataKey, *value)
+			if err != nil {
+				return fmt.Errorf("failed to encrypt value: %w", err)
+			}
+			updates["value"] = encryptedValue
+		}
+
+		if err := tx.Model(v).Updates(updates).Error; err != nil {
+			return err
+		}
+
+		if value != nil {
+			if _, err := createVaultVersion(tx, v.ID, encryptedValue, params.Author, params.restoredFrom); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		return err
 	}
@@ -286,7 +454,7 @@ func (v *Vault) Update(params *UpdateVaultParams) error {
 	}
 
 	// Decrypt the value for the response
-	decryptedValue, err := encryption.Decrypt(v.Value)
+	decryptedValue, err := v.decryptValue(v.Value)
 	if err != nil {
 		return fmt.Errorf("failed to decrypt value: %w", err)
 	}
@@ -304,10 +472,11 @@ func (v *Vault) Delete() error {
 	return nil
 }
 
-// CheckVaultOwnership verifies if a vault with the given ID belongs to the specified user
+// CheckVaultOwnership verifies if a vault with the given ID belongs to the specified user,
+// either personally or through membership of the organization that owns it
 func CheckVaultOwnership(vaultID uint, userID uint) error {
 	var count int64
-	err := DB.Model(&Vault{}).Where("id = ? AND user_id = ?", vaultID, userID).Count(&count).Error
+	err := DB.Model(&Vault{}).Scopes(AccessibleVaults(userID)).Where("id = ?", vaultID).Count(&count).Error
 	if err != nil {
 		return err
 	}
@@ -338,3 +507,20 @@ func CheckVaultNameUnique(name string, userID uint, excludeVaultID ...uint) erro
 
 	return nil
 }
+
+// checkOrganizationVaultNameUnique verifies if a vault name is unique within an organization
+func checkOrganizationVaultNameUnique(name string, organizationID uint, excludeVaultID ...uint) error {
+	var count int64
+	query := DB.Model(&Vault{}).Where("name = ? AND organization_id = ?", name, organizationID)
+	if len(excludeVaultID) > 0 {
+		query = query.Where("id != ?", excludeVaultID[0])
+	}
+
+	if err := query.Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return fmt.Errorf("vault with name '%s' already exists in this organization", name)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// vaultChangeActions are the vault actions that are broadcast as change events
+var vaultChangeActions = []ActionType{ActionCreateVault, ActionUpdateVault, ActionDeleteVault, ActionRestoreVaultVersion, ActionRotateVault}
+
+// vaultEventBuffer is the number of events a subscriber may fall behind before it is dropped
+const vaultEventBuffer = 64
+
+// VaultEvent is a change to a vault. Its ID is the ID of the audit log entry recording
+// the change, so events are ordered and can be replayed from the audit log.
+type VaultEvent struct {
+	ID             uint
+	Action         ActionType
+	VaultID        uint
+	VaultUniqueID  string
+	VaultName      string
+	OrganizationID *uint
+	CreatedAt      time.Time
+}
+
+// vaultEventBroker fans vault events out to the subscribers of this server instance
+type vaultEventBroker struct {
+	mu          sync.Mutex
+	subscribers map[chan VaultEvent]struct{}
+}
+
+var vaultEvents = &vaultEventBroker{subscribers: map[chan VaultEvent]struct{}{}}
+
+// SubscribeVaultEvents returns a channel receiving every vault change made on this server
+// and a function to unsubscribe. A subscriber that falls too far behind has its channel
+// closed and is expected to resume from its last event with GetVaultEventsAfter.
+func SubscribeVaultEvents() (<-chan VaultEvent, func()) {
+	ch := make(chan VaultEvent, vaultEventBuffer)
+
+	vaultEvents.mu.Lock()
+	vaultEvents.subscribers[ch] = struct{}{}
+	vaultEvents.mu.Unlock()
+
+	return ch, func() {
+		vaultEvents.mu.Lock()
+		defer vaultEvents.mu.Unlock()
+		if _, ok := vaultEvents.subscribers[ch]; ok {
+			delete(vaultEvents.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publishVaultEvent sends an event to every subscriber without blocking
+func publishVaultEvent(event VaultEvent) {
+	vaultEvents.mu.Lock()
+	defer vaultEvents.mu.Unlock()
+
+	for ch := range vaultEvents.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(vaultEvents.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// GetVaultEventsAfter returns up to limit vault change events recorded after the given
+// event ID, oldest first
+func GetVaultEventsAfter(afterID uint, limit int) ([]VaultEvent, error) {
+	var logs []AuditLog
+	err := DB.Preload("Vault", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).
+		Where("id > ? AND vault_id IS NOT NULL AND action IN ?", afterID, vaultChangeActions).
+		Order("id ASC").
+		Limit(limit).
+		Find(&logs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]VaultEvent, 0, len(logs))
+	for _, log := range logs {
+		event := VaultEvent{
+			ID:        log.ID,
+			Action:    log.Action,
+			VaultID:   *log.VaultID,
+			CreatedAt: log.CreatedAt,
+		}
+		if log.Vault != nil {
+			event.VaultUniqueID = log.Vault.UniqueID
+			event.VaultName = log.Vault.Name
+			event.OrganizationID = log.Vault.OrganizationID
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// CanReceiveVaultEvent reports whether the API key may be told about the event.
+// Vaults deleted since are checked as they were before deletion.
+func (k *APIKey) CanReceiveVaultEvent(event VaultEvent) bool {
+	return k.hasVaultAccess(DB.Unscoped(), event.VaultID)
+}
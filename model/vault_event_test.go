@@ -0,0 +1,81 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestVaultEvents(t *testing.T) {
+	owner, outsider := createTestUser(t), createTestUser(t)
+
+	events, unsubscribe := SubscribeVaultEvents()
+	defer unsubscribe()
+
+	vaultParams := CreateVaultParams{
+		UniqueID: uuid.NewString(),
+		UserID:   owner.ID,
+		Name:     "events-" + uuid.NewString(),
+		Value:    "secret",
+		Source:   SourceWeb,
+	}
+	vault, err := vaultParams.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+
+	// Reads are not broadcast
+	if err := LogVaultAction(vault.ID, ActionReadVault, owner.ID, SourceWeb, nil, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log read: %v", err)
+	}
+	if err := LogVaultAction(vault.ID, ActionUpdateVault, owner.ID, SourceWeb, nil, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log update: %v", err)
+	}
+
+	var received VaultEvent
+	for received.VaultID != vault.ID {
+		select {
+		case received = <-events:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the update event")
+		}
+	}
+	if received.Action != ActionUpdateVault || received.VaultUniqueID != vault.UniqueID || received.VaultName != vault.Name {
+		t.Fatalf("unexpected event %+v", received)
+	}
+
+	if err := vault.Delete(); err != nil {
+		t.Fatalf("delete vault: %v", err)
+	}
+	if err := LogVaultAction(vault.ID, ActionDeleteVault, owner.ID, SourceWeb, nil, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log delete: %v", err)
+	}
+
+	// Missed events can be replayed from the audit log, including those of deleted vaults
+	replayed, err := GetVaultEventsAfter(received.ID-1, 100)
+	if err != nil {
+		t.Fatalf("get events: %v", err)
+	}
+	var actions []ActionType
+	for _, event := range replayed {
+		if event.VaultID == vault.ID {
+			actions = append(actions, event.Action)
+		}
+	}
+	if len(actions) != 2 || actions[0] != ActionUpdateVault || actions[1] != ActionDeleteVault {
+		t.Fatalf("expected update and delete events to be replayed, got %v", actions)
+	}
+	if replayed[len(replayed)-1].VaultName != vault.Name {
+		t.Fatalf("expected the deleted vault's name in the event, got %q", replayed[len(replayed)-1].VaultName)
+	}
+
+	ownerKey := APIKey{UserID: owner.ID}
+	outsiderKey := APIKey{UserID: outsider.ID}
+	if !ownerKey.CanReceiveVaultEvent(received) {
+		t.Fatal("expected the owner's key to receive events of the deleted vault")
+	}
+	if outsiderKey.CanReceiveVaultEvent(received) {
+		t.Fatal("expected other users' keys not to receive the event")
+	}
+}
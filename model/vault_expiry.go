@@ -0,0 +1,136 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// MinRotationInterval is the shortest rotation schedule a vault can have
+	MinRotationInterval = time.Hour
+	// expiryReminderBatchSize is how many vaults ClaimExpiryReminders claims at once
+	expiryReminderBatchSize = 100
+)
+
+// validateVaultExpiry adds errors for a rotation deadline in the past or a too short rotation interval
+func validateVaultExpiry(errors map[string]string, expiresAt *time.Time, rotationInterval *time.Duration) {
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		errors["expires_at"] = "expiresAt must be in the future"
+	}
+	if rotationInterval != nil && *rotationInterval != 0 && *rotationInterval < MinRotationInterval {
+		errors["rotation_interval"] = fmt.Sprintf("rotation interval must be at least %s", MinRotationInterval)
+	}
+}
+
+// applyExpiryUpdates adds the rotation changes of an update. A new value or a new rotation
+// interval starts a new rotation period unless a deadline is given, and every new deadline
+// gets its own reminder.
+func (v *Vault) applyExpiryUpdates(params *UpdateVaultParams, updates map[string]interface{}) {
+	interval := v.RotationInterval
+	if params.RotationInterval != nil {
+		interval = *params.RotationInterval
+		updates["rotation_interval"] = interval
+	}
+
+	var expiresAt *time.Time
+	switch {
+	case params.ClearExpiry:
+		updates["expires_at"] = nil
+	case params.ExpiresAt != nil:
+		expiresAt = params.ExpiresAt
+	case interval > 0 && (params.changesValue() || params.RotationInterval != nil):
+		next := time.Now().Add(interval)
+		expiresAt = &next
+	}
+	if expiresAt != nil {
+		updates["expires_at"] = *expiresAt
+	}
+	if _, ok := updates["expires_at"]; ok {
+		updates["expiry_reminded_at"] = nil
+	}
+}
+
+// ExpiringWithin limits a vault query to vaults whose rotation deadline is within d from now,
+// including those already past it
+func ExpiringWithin(d time.Duration) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("expires_at IS NOT NULL AND expires_at <= ?", time.Now().Add(d))
+	}
+}
+
+// ClaimExpiryReminders marks the vaults whose rotation deadline is within window and whose
+// owners were not reminded of it yet, and returns them. Each vault is claimed by only one
+// server, so reminders are sent once.
+func ClaimExpiryReminders(window time.Duration) ([]Vault, error) {
+	var candidates []Vault
+	err := DB.Scopes(ExpiringWithin(window)).
+		Where("expiry_reminded_at IS NULL").
+		Order("expires_at").
+		Limit(expiryReminderBatchSize).
+		Find(&candidates).Error
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	claimed := make([]Vault, 0, len(candidates))
+	for _, vault := range candidates {
+		result := DB.Model(&Vault{}).
+			Where("id = ? AND expiry_reminded_at IS NULL", vault.ID).
+			UpdateColumn("expiry_reminded_at", now)
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 1 {
+			vault.ExpiryRemindedAt = &now
+			claimed = append(claimed, vault)
+		}
+	}
+	return claimed, nil
+}
+
+// ReleaseExpiryReminder gives up the claim on a vault's expiry reminder when it could not be
+// sent, so a later run claims it again. A claim that was replaced since, by a new rotation
+// period or another server, is left alone.
+func ReleaseExpiryReminder(vault *Vault) error {
+	if vault.ExpiryRemindedAt == nil {
+		return nil
+	}
+	err := DB.Model(&Vault{}).
+		Where("id = ? AND expiry_reminded_at = ?", vault.ID, *vault.ExpiryRemindedAt).
+		UpdateColumn("expiry_reminded_at", nil).Error
+	if err != nil {
+		return err
+	}
+	vault.ExpiryRemindedAt = nil
+	return nil
+}
+
+// VaultNotificationRecipients returns the users notified about a vault's rotation: the owner
+// of a personal vault, or the creator and the admins and owners of an organization vault
+func VaultNotificationRecipients(vault *Vault) ([]User, error) {
+	userIDs := []uint{vault.UserID}
+	if vault.OrganizationID != nil {
+		var admins []uint
+		err := DB.Model(&Membership{}).
+			Where("organization_id = ? AND role IN ?", *vault.OrganizationID, []OrganizationRole{RoleAdmin, RoleOwner}).
+			Pluck("user_id", &admins).Error
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range admins {
+			if !slices.Contains(userIDs, id) {
+				userIDs = append(userIDs, id)
+			}
+		}
+	}
+
+	var users []User
+	if err := DB.Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
@@ -0,0 +1,158 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// claimedVault reports whether the vault is among the claimed ones
+func claimedVault(vaults []Vault, id uint) bool {
+	for _, vault := range vaults {
+		if vault.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestVaultRotationInterval(t *testing.T) {
+	user := createTestUser(t)
+
+	params := CreateVaultParams{UniqueID: uuid.NewString(), UserID: user.ID, Name: "rotated", Value: "v1", RotationInterval: 30 * 24 * time.Hour}
+	vault, err := params.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+	if vault.ExpiresAt == nil || time.Until(*vault.ExpiresAt) < 29*24*time.Hour {
+		t.Fatalf("expected the deadline to follow the rotation interval, got %v", vault.ExpiresAt)
+	}
+
+	// Moving the deadline close brings up a reminder
+	soon := time.Now().Add(48 * time.Hour)
+	if err := vault.Update(&UpdateVaultParams{ExpiresAt: &soon}); err != nil {
+		t.Fatalf("update vault: %v", err)
+	}
+	claimed, err := ClaimExpiryReminders(7 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("claim reminders: %v", err)
+	}
+	if !claimedVault(claimed, vault.ID) {
+		t.Fatalf("expected vault due in two days to be claimed")
+	}
+	// A reminder that could not be sent is claimed again by the next run
+	var reminded Vault
+	for _, v := range claimed {
+		if v.ID == vault.ID {
+			reminded = v
+		}
+	}
+	if err := ReleaseExpiryReminder(&reminded); err != nil {
+		t.Fatalf("release reminder: %v", err)
+	}
+	claimed, err = ClaimExpiryReminders(7 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("claim reminders: %v", err)
+	}
+	if !claimedVault(claimed, vault.ID) {
+		t.Fatalf("expected a released reminder to be claimed again")
+	}
+
+	claimed, err = ClaimExpiryReminders(7 * 24 * time.Hour)
+	if err != nil {
+		t.Fatalf("claim reminders: %v", err)
+	}
+	if claimedVault(claimed, vault.ID) {
+		t.Errorf("expected each deadline to be reminded of once")
+	}
+
+	// A new value starts the next rotation period
+	value := "v2"
+	if err := vault.Update(&UpdateVaultParams{Value: &value, Author: VersionAuthor{UserID: user.ID}}); err != nil {
+		t.Fatalf("update vault: %v", err)
+	}
+	if vault.ExpiresAt == nil || time.Until(*vault.ExpiresAt) < 29*24*time.Hour || vault.ExpiryRemindedAt != nil {
+		t.Errorf("expected a new rotation period, got deadline %v reminded at %v", vault.ExpiresAt, vault.ExpiryRemindedAt)
+	}
+
+	if err := vault.Update(&UpdateVaultParams{ClearExpiry: true}); err != nil {
+		t.Fatalf("update vault: %v", err)
+	}
+	if vault.ExpiresAt != nil {
+		t.Errorf("expected the deadline to be cleared, got %v", vault.ExpiresAt)
+	}
+}
+
+func TestGetUserVaultsExpiringWithin(t *testing.T) {
+	user := createTestUser(t)
+
+	later := time.Now().Add(60 * 24 * time.Hour)
+	soon := time.Now().Add(24 * time.Hour)
+	for name, expiresAt := range map[string]*time.Time{"later": &later, "soon": &soon, "never": nil} {
+		params := CreateVaultParams{UniqueID: uuid.NewString(), UserID: user.ID, Name: name, Value: "x", ExpiresAt: expiresAt}
+		if _, err := params.Create(); err != nil {
+			t.Fatalf("create vault: %v", err)
+		}
+	}
+
+	vaults, total, err := GetUserVaultsWithPagination(user.ID, 20, 1, 7*24*time.Hour)
+	if err != nil {
+		t.Fatalf("get vaults: %v", err)
+	}
+	if total != 1 || len(vaults) != 1 || vaults[0].Name != "soon" {
+		t.Errorf("expected only the vault due within a week, got %d: %+v", total, vaults)
+	}
+
+	if _, total, _ := GetUserVaultsWithPagination(user.ID, 20, 1, 0); total != 3 {
+		t.Errorf("expected all vaults without a filter, got %d", total)
+	}
+}
+
+func TestVaultNotificationRecipients(t *testing.T) {
+	owner, admin, viewer := createTestUser(t), createTestUser(t), createTestUser(t)
+
+	orgParams := CreateOrganizationParams{Name: "org-" + uuid.NewString(), UserID: owner.ID}
+	org, err := orgParams.Create()
+	if err != nil {
+		t.Fatalf("create organization: %v", err)
+	}
+	if _, err := AddMember(org.ID, admin.ID, RoleAdmin); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+	if _, err := AddMember(org.ID, viewer.ID, RoleViewer); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+
+	recipients, err := VaultNotificationRecipients(&Vault{UserID: owner.ID, OrganizationID: &org.ID})
+	if err != nil {
+		t.Fatalf("get recipients: %v", err)
+	}
+	ids := map[uint]bool{}
+	for _, user := range recipients {
+		ids[user.ID] = true
+	}
+	if len(ids) != 2 || !ids[owner.ID] || !ids[admin.ID] {
+		t.Errorf("expected the creator and the admin, got %v", ids)
+	}
+}
+
+func TestVaultExpiryValidate(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+	short := time.Minute
+	tests := map[string]struct {
+		errors map[string]string
+		field  string
+	}{
+		"past deadline":    {(&CreateVaultParams{Name: "x", Value: "x", UserID: 1, ExpiresAt: &past}).Validate(), "expires_at"},
+		"short interval":   {(&CreateVaultParams{Name: "x", Value: "x", UserID: 1, RotationInterval: short}).Validate(), "rotation_interval"},
+		"set and cleared":  {(&UpdateVaultParams{ExpiresAt: &future, ClearExpiry: true}).Validate(), "expires_at"},
+		"update too short": {(&UpdateVaultParams{RotationInterval: &short}).Validate(), "rotation_interval"},
+	}
+	for name, tt := range tests {
+		if _, ok := tt.errors[tt.field]; !ok {
+			t.Errorf("%s: expected %s error", name, tt.field)
+		}
+	}
+}
@@ -0,0 +1,162 @@
+package model
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"gorm.io/gorm"
+)
+
+// dataKey returns the plaintext data key of the vault, or nil if the vault predates data keys
+func (v *Vault) dataKey() ([]byte, error) {
+	if v.DataKey == "" {
+		return nil, nil
+	}
+
+	dataKey, err := encryption.UnwrapDataKey(v.DataKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	return dataKey, nil
+}
+
+// associatedData binds ciphertext to the vault it belongs to, so that a value copied
+// into another vault row fails authentication instead of being decrypted
+func (v *Vault) associatedData() []byte {
+	return []byte("vault\x00" + v.UniqueID + "\x00" + strconv.FormatUint(uint64(v.UserID), 10))
+}
+
+// encryptValue encrypts a value for the vault with its data key
+func (v *Vault) encryptValue(dataKey []byte, plaintext string) (string, error) {
+	return encryption.EncryptWithDataKey(dataKey, plaintext, v.associatedData())
+}
+
+// decryptValue decrypts a value belonging to the vault, either its current value or one of its versions
+func (v *Vault) decryptValue(ciphertext string) (string, error) {
+	dataKey, err := v.dataKey()
+	if err != nil {
+		return "", err
+	}
+	return encryption.DecryptWithDataKey(dataKey, ciphertext, v.associatedData())
+}
+
+// ensureVaultDataKey returns the data key of a vault, creating one if the vault predates data keys
+// and re-wrapping it if it is wrapped by a previous master key. Values of the vault and its versions
+// that are still in an older ciphertext format are upgraded in the same transaction.
+func ensureVaultDataKey(tx *gorm.DB, v *Vault) ([]byte, error) {
+	var current Vault
+	err := tx.Unscoped().Select("id", "unique_id", "user_id", "value", "data_key", "key_id").Where("id = ?", v.ID).First(&current).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var dataKey []byte
+	if current.DataKey != "" {
+		dataKey, err = current.dataKey()
+		if err != nil {
+			return nil, err
+		}
+
+		if current.KeyID != encryption.ActiveKeyID() {
+			// The data key itself does not change, so concurrent readers are unaffected
+			wrapped, keyID, err := encryption.RewrapDataKey(current.DataKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to rewrap data key: %w", err)
+			}
+			err = tx.Model(&Vault{}).Unscoped().Where("id = ?", v.ID).
+				Updates(map[string]interface{}{"data_key": wrapped, "key_id": keyID}).Error
+			if err != nil {
+				return nil, err
+			}
+			current.DataKey, current.KeyID = wrapped, keyID
+		}
+	} else {
+		var wrapped, keyID string
+		dataKey, wrapped, keyID, err = encryption.GenerateDataKey()
+		if err != nil {
+			return nil, err
+		}
+
+		// Only claim the vault if no other writer has given it a data key in the meantime
+		result := tx.Model(&Vault{}).Unscoped().Where("id = ? AND data_key = ?", v.ID, "").
+			Updates(map[string]interface{}{"data_key": wrapped, "key_id": keyID})
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ensureVaultDataKey(tx, v)
+		}
+		current.DataKey, current.KeyID = wrapped, keyID
+	}
+
+	if err := upgradeVaultValues(tx, &current, dataKey); err != nil {
+		return nil, err
+	}
+
+	v.UniqueID, v.UserID = current.UniqueID, current.UserID
+	v.DataKey, v.KeyID = current.DataKey, current.KeyID
+	return dataKey, nil
+}
+
+// upgradeVaultValues re-encrypts values of a vault and its versions that are in an older
+// ciphertext format, so that they are protected by the data key and bound to the vault
+func upgradeVaultValues(tx *gorm.DB, v *Vault, dataKey []byte) error {
+	if encryption.NeedsUpgrade(v.Value) {
+		upgraded, err := v.reencryptValue(v.Value, dataKey)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade value of vault %d: %w", v.ID, err)
+		}
+		err = tx.Model(&Vault{}).Unscoped().Where("id = ? AND value = ?", v.ID, v.Value).
+			Update("value", upgraded).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	var versions []VaultVersion
+	if err := tx.Where("vault_id = ? AND value NOT LIKE ?", v.ID, "dek2:%").Find(&versions).Error; err != nil {
+		return err
+	}
+
+	for _, version := range versions {
+		if !encryption.NeedsUpgrade(version.Value) {
+			continue
+		}
+		upgraded, err := v.reencryptValue(version.Value, dataKey)
+		if err != nil {
+			return fmt.Errorf("failed to upgrade version %d of vault %d: %w", version.Version, v.ID, err)
+		}
+		if err := tx.Model(&VaultVersion{}).Where("id = ?", version.ID).Update("value", upgraded).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// reencryptValue decrypts a value in an older format and encrypts it again in the current one
+func (v *Vault) reencryptValue(ciphertext string, dataKey []byte) (string, error) {
+	plaintext, err := encryption.DecryptWithDataKey(dataKey, ciphertext, v.associatedData())
+	if err != nil {
+		return "", err
+	}
+	return v.encryptValue(dataKey, plaintext)
+}
+
+// upgradeVaultOnRead upgrades a vault read in an older ciphertext format. Failures are only
+// logged, since the value was already decrypted and the next write retries the upgrade.
+func upgradeVaultOnRead(v *Vault, ciphertext string) {
+	if !encryption.NeedsUpgrade(ciphertext) {
+		return
+	}
+
+	err := DB.Transaction(func(tx *gorm.DB) error {
+		_, err := ensureVaultDataKey(tx, &Vault{Model: gorm.Model{ID: v.ID}})
+		return err
+	})
+	if err != nil {
+		slog.Warn("Failed to upgrade vault encryption", "vaultID", v.ID, "error", err)
+	}
+}
@@ -0,0 +1,82 @@
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/lwshen/vault-hub/internal/encryption"
+)
+
+func TestVaultValueBoundToVault(t *testing.T) {
+	userID := uint(6161)
+	create := func(value string) *Vault {
+		params := CreateVaultParams{
+			UniqueID: uuid.NewString(),
+			UserID:   userID,
+			Name:     "bound-" + uuid.NewString(),
+			Value:    value,
+			Source:   SourceWeb,
+		}
+		vault, err := params.Create()
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		return vault
+	}
+	first, second := create("first secret"), create("second secret")
+
+	// Copy the stored ciphertext and data key of the first vault into the second
+	var stored Vault
+	if err := DB.First(&stored, first.ID).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	err := DB.Model(&Vault{}).Where("id = ?", second.ID).
+		Updates(map[string]interface{}{"value": stored.Value, "data_key": stored.DataKey, "key_id": stored.KeyID}).Error
+	if err != nil {
+		t.Fatalf("swap: %v", err)
+	}
+	// The tampered row can no longer be decrypted, so keep it from affecting other tests
+	t.Cleanup(func() { DB.Unscoped().Delete(&Vault{}, second.ID) })
+
+	var swapped Vault
+	if err := swapped.GetByUniqueID(second.UniqueID, userID); err == nil {
+		t.Fatalf("expected a value copied from another vault to fail decryption, got %q", swapped.Value)
+	}
+}
+
+func TestLegacyVaultUpgradedOnRead(t *testing.T) {
+	userID := uint(6262)
+	legacyValue, err := encryption.Encrypt("legacy secret")
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	legacy := Vault{UniqueID: uuid.NewString(), UserID: userID, Name: "legacy-" + uuid.NewString(), Value: legacyValue}
+	if err := DB.Create(&legacy).Error; err != nil {
+		t.Fatalf("create legacy vault: %v", err)
+	}
+
+	var read Vault
+	if err := read.GetByName(legacy.Name, userID); err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if read.Value != "legacy secret" {
+		t.Fatalf("expected %q, got %q", "legacy secret", read.Value)
+	}
+
+	var stored Vault
+	if err := DB.First(&stored, legacy.ID).Error; err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if stored.DataKey == "" || !strings.HasPrefix(stored.Value, "dek2:") {
+		t.Fatalf("expected vault to be upgraded on read, got data key %q and value %q", stored.DataKey, stored.Value)
+	}
+
+	var reread Vault
+	if err := reread.GetByUniqueID(legacy.UniqueID, userID); err != nil {
+		t.Fatalf("get after upgrade: %v", err)
+	}
+	if reread.Value != "legacy secret" {
+		t.Fatalf("expected %q after upgrade, got %q", "legacy secret", reread.Value)
+	}
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MaxVaultKeyLength is the longest key a kv vault can have
+const MaxVaultKeyLength = 255
+
+// ErrVaultKeyNotFound is returned when a kv vault has no such key
+var ErrVaultKeyNotFound = errors.New("key not found")
+
+// ErrNotKVVault is returned for key operations on vaults that are not kv vaults
+var ErrNotKVVault = errors.New("vault is not a kv vault")
+
+// vaultKeyPattern matches keys of kv vaults; besides environment variable names it allows
+// dots and dashes
+var vaultKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// ValidateVaultKey checks a key of a kv vault
+func ValidateVaultKey(key string) error {
+	if len(key) > MaxVaultKeyLength {
+		return fmt.Errorf("key must be less than %d characters", MaxVaultKeyLength)
+	}
+	if !vaultKeyPattern.MatchString(key) {
+		return fmt.Errorf("invalid key %q, use letters, digits, _, . and - and start with a letter or _", key)
+	}
+	return nil
+}
+
+// ParseKVValue parses the value of a kv vault, a JSON object of string values
+func ParseKVValue(value string) (map[string]string, error) {
+	var data map[string]string
+	if err := json.Unmarshal([]byte(value), &data); err != nil || data == nil {
+		return nil, errors.New("value of a kv vault must be a JSON object of strings")
+	}
+	for key := range data {
+		if err := ValidateVaultKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return data, nil
+}
+
+// FormatKVValue returns the stored value of a kv vault, with its keys sorted
+func FormatKVValue(data map[string]string) (string, error) {
+	if data == nil {
+		data = map[string]string{}
+	}
+	value, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// Data returns the keys and values of a kv vault whose value is decrypted
+func (v *Vault) Data() (map[string]string, error) {
+	if v.Type != VaultTypeKV {
+		return nil, ErrNotKVVault
+	}
+	return ParseKVValue(v.Value)
+}
+
+// GetKey returns the value of one key of a kv vault whose value is decrypted
+func (v *Vault) GetKey(key string) (string, error) {
+	data, err := v.Data()
+	if err != nil {
+		return "", err
+	}
+	value, ok := data[key]
+	if !ok {
+		return "", ErrVaultKeyNotFound
+	}
+	return value, nil
+}
+
+// hasKeyUpdates reports whether the update changes single keys of a kv vault
+func (params *UpdateVaultParams) hasKeyUpdates() bool {
+	return len(params.SetKeys) > 0 || len(params.DeleteKeys) > 0
+}
+
+// changesValue reports whether the update writes a new value
+func (params *UpdateVaultParams) changesValue() bool {
+	return params.Value != nil || params.hasKeyUpdates()
+}
+
+// validateKeyUpdates adds errors for invalid key changes of an update
+func (params *UpdateVaultParams) validateKeyUpdates(errors map[string]string) {
+	if !params.hasKeyUpdates() {
+		return
+	}
+	if params.Value != nil {
+		errors["value"] = "value cannot be combined with setKeys or deleteKeys"
+	}
+	for _, key := range slices.Sorted(maps.Keys(params.SetKeys)) {
+		if err := ValidateVaultKey(key); err != nil {
+			errors["keys"] = err.Error()
+			return
+		}
+		if slices.Contains(params.DeleteKeys, key) {
+			errors["keys"] = fmt.Sprintf("key %q cannot be set and deleted at once", key)
+			return
+		}
+	}
+}
+
+// updatedValue returns the new value an update writes, if any. Values of kv vaults are
+// stored with their keys sorted; key changes are applied to the stored value, which is read
+// with the row locked, so concurrent changes to other keys are not lost.
+func (v *Vault) updatedValue(tx *gorm.DB, params *UpdateVaultParams) (*string, error) {
+	if v.Type != VaultTypeKV || !params.changesValue() {
+		return params.Value, nil
+	}
+
+	var data map[string]string
+	var err error
+	if params.Value != nil {
+		data, err = ParseKVValue(*params.Value)
+	} else {
+		var stored Vault
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "unique_id", "user_id", "value", "data_key", "key_id").First(&stored, v.ID).Error; err != nil {
+			return nil, err
+		}
+		current, decryptErr := stored.decryptValue(stored.Value)
+		if decryptErr != nil {
+			return nil, fmt.Errorf("failed to decrypt value: %w", decryptErr)
+		}
+		data, err = ParseKVValue(current)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	maps.Copy(data, params.SetKeys)
+	for _, key := range params.DeleteKeys {
+		delete(data, key)
+	}
+	value, err := FormatKVValue(data)
+	if err != nil {
+		return nil, err
+	}
+	return &value, nil
+}
@@ -0,0 +1,96 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestParseKVValue(t *testing.T) {
+	data, err := ParseKVValue(`{"DATABASE_URL":"postgres://db","app.mode":"prod"}`)
+	if err != nil || data["DATABASE_URL"] != "postgres://db" || data["app.mode"] != "prod" {
+		t.Errorf("ParseKVValue() = %v, %v", data, err)
+	}
+
+	invalid := map[string]string{
+		"not json":      "DATABASE_URL=postgres://db",
+		"not an object": `["a"]`,
+		"null":          "null",
+		"number value":  `{"PORT":5432}`,
+		"invalid key":   `{"1KEY":"v"}`,
+		"space in key":  `{"MY KEY":"v"}`,
+	}
+	for name, value := range invalid {
+		if _, err := ParseKVValue(value); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestKVVaultUpdateKeys(t *testing.T) {
+	user := createTestUser(t)
+	params := CreateVaultParams{UniqueID: uuid.NewString(), UserID: user.ID, Name: "app-env", Type: VaultTypeKV, Value: `{"B":"2","A":"1"}`}
+	if _, ok := params.Validate()["value"]; ok {
+		t.Fatalf("unexpected validation errors %v", params.Validate())
+	}
+	vault, err := params.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+	if vault.Value != `{"A":"1","B":"2"}` {
+		t.Errorf("expected the value to be stored with sorted keys, got %s", vault.Value)
+	}
+
+	// Changing one key keeps the others
+	update := UpdateVaultParams{SetKeys: map[string]string{"C": "3", "A": "one"}, DeleteKeys: []string{"B"}}
+	if errors := update.Validate(); len(errors) > 0 {
+		t.Fatalf("unexpected validation errors %v", errors)
+	}
+	if errors := vault.ValidateUpdate(&update); len(errors) > 0 {
+		t.Fatalf("unexpected validation errors %v", errors)
+	}
+	if err := vault.Update(&update); err != nil {
+		t.Fatalf("update keys: %v", err)
+	}
+	if value, err := vault.GetKey("A"); err != nil || value != "one" {
+		t.Errorf("GetKey(A) = %q, %v", value, err)
+	}
+	if _, err := vault.GetKey("B"); err != ErrVaultKeyNotFound {
+		t.Errorf("expected the deleted key to be gone, got %v", err)
+	}
+	if vault.Value != `{"A":"one","C":"3"}` {
+		t.Errorf("unexpected value %s", vault.Value)
+	}
+
+	versions, err := GetVaultVersions(vault.ID)
+	if err != nil || len(versions) != 2 {
+		t.Errorf("expected a version for the key change, got %d, %v", len(versions), err)
+	}
+
+	value := "plain"
+	if _, ok := vault.ValidateUpdate(&UpdateVaultParams{Value: &value})["value"]; !ok {
+		t.Error("expected the value of a kv vault to stay a JSON object")
+	}
+}
+
+func TestUpdateVaultParamsKeys(t *testing.T) {
+	value := `{"A":"1"}`
+	tests := map[string]struct {
+		params UpdateVaultParams
+		field  string
+	}{
+		"value and keys": {UpdateVaultParams{Value: &value, SetKeys: map[string]string{"A": "2"}}, "value"},
+		"invalid key":    {UpdateVaultParams{SetKeys: map[string]string{"A B": "2"}}, "keys"},
+		"set and delete": {UpdateVaultParams{SetKeys: map[string]string{"A": "2"}, DeleteKeys: []string{"A"}}, "keys"},
+	}
+	for name, tt := range tests {
+		if _, ok := tt.params.Validate()[tt.field]; !ok {
+			t.Errorf("%s: expected %s error", name, tt.field)
+		}
+	}
+
+	static := Vault{Type: VaultTypeStatic}
+	if _, ok := static.ValidateUpdate(&UpdateVaultParams{SetKeys: map[string]string{"A": "1"}})["keys"]; !ok {
+		t.Error("expected keys to only apply to kv vaults")
+	}
+}
@@ -0,0 +1,133 @@
+package model
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// VaultVersion records an encrypted value a vault has held, so that earlier
+// secrets can be inspected and restored after they are overwritten
+type VaultVersion struct {
+	ID           uint `gorm:"primarykey"`
+	CreatedAt    time.Time
+	VaultID      uint       `gorm:"not null;uniqueIndex:idx_vault_version"` // Vault this version belongs to
+	Version      uint       `gorm:"not null;uniqueIndex:idx_vault_version"` // Sequential version number, starting from 1
+	Value        string     `gorm:"type:text;not null"`                     // Encrypted value
+	UserID       uint       `gorm:"index;not null"`                         // User who wrote this value
+	APIKeyID     *uint      `gorm:"index"`                                  // API key used to write this value, if any
+	Source       SourceType `gorm:"size:10"`                                // Source of the write (empty for backfilled versions)
+	RestoredFrom *uint      // Version number this value was restored from, if any
+}
+
+// VersionAuthor identifies who wrote a vault value
+type VersionAuthor struct {
+	UserID   uint
+	APIKeyID *uint
+	Source   SourceType
+}
+
+// createVaultVersion appends a new version holding the given encrypted value
+func createVaultVersion(tx *gorm.DB, vaultID uint, encryptedValue string, author VersionAuthor, restoredFrom *uint) (*VaultVersion, error) {
+	var latest uint
+	err := tx.Model(&VaultVersion{}).
+		Where("vault_id = ?", vaultID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		return nil, err
+	}
+
+	version := VaultVersion{
+		VaultID:      vaultID,
+		Version:      latest + 1,
+		Value:        encryptedValue,
+		UserID:       author.UserID,
+		APIKeyID:     author.APIKeyID,
+		Source:       author.Source,
+		RestoredFrom: restoredFrom,
+	}
+	if err := tx.Create(&version).Error; err != nil {
+		return nil, err
+	}
+
+	return &version, nil
+}
+
+// ensureVaultVersionBaseline records the current value of a vault created before
+// version history existed, so that the first update does not discard it
+func ensureVaultVersionBaseline(tx *gorm.DB, v *Vault) error {
+	var count int64
+	if err := tx.Model(&VaultVersion{}).Where("vault_id = ?", v.ID).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var current Vault
+	if err := tx.Select("id", "user_id", "value", "updated_at").Where("id = ?", v.ID).First(&current).Error; err != nil {
+		return err
+	}
+
+	baseline := VaultVersion{
+		CreatedAt: current.UpdatedAt,
+		VaultID:   current.ID,
+		Version:   1,
+		Value:     current.Value,
+		UserID:    current.UserID,
+	}
+	return tx.Create(&baseline).Error
+}
+
+// GetVaultVersions returns all versions of a vault, newest first. Values stay encrypted.
+func GetVaultVersions(vaultID uint) ([]VaultVersion, error) {
+	var versions []VaultVersion
+	err := DB.Where("vault_id = ?", vaultID).
+		Omit("value").
+		Order("version DESC").
+		Find(&versions).Error
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// GetVaultVersion returns a single version of a vault with its value decrypted
+func GetVaultVersion(vaultID uint, version uint) (*VaultVersion, error) {
+	var v VaultVersion
+	err := DB.Where("vault_id = ? AND version = ?", vaultID, version).First(&v).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var vault Vault
+	if err := DB.Unscoped().Select("id", "unique_id", "user_id", "data_key").Where("id = ?", vaultID).First(&vault).Error; err != nil {
+		return nil, err
+	}
+
+	decryptedValue, err := vault.decryptValue(v.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt value: %w", err)
+	}
+	upgradeVaultOnRead(&vault, v.Value)
+	v.Value = decryptedValue
+
+	return &v, nil
+}
+
+// RestoreVersion writes the value of an earlier version back to the vault.
+// The restore is itself recorded as a new version, so it can be undone.
+func (v *Vault) RestoreVersion(version uint, author VersionAuthor) error {
+	source, err := GetVaultVersion(v.ID, version)
+	if err != nil {
+		return err
+	}
+
+	return v.Update(&UpdateVaultParams{
+		Value:        &source.Value,
+		Author:       author,
+		restoredFrom: &source.Version,
+	})
+}
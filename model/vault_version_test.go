@@ -0,0 +1,105 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestVaultVersionHistory(t *testing.T) {
+	userID := uint(4242)
+	params := CreateVaultParams{
+		UniqueID: uuid.NewString(),
+		UserID:   userID,
+		Name:     "versioned-" + uuid.NewString(),
+		Value:    "first",
+		Source:   SourceWeb,
+	}
+	vault, err := params.Create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	apiKeyID := uint(7)
+	second := "second"
+	err = vault.Update(&UpdateVaultParams{
+		Value:  &second,
+		Author: VersionAuthor{UserID: userID, APIKeyID: &apiKeyID, Source: SourceCLI},
+	})
+	if err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	versions, err := GetVaultVersions(vault.ID)
+	if err != nil {
+		t.Fatalf("list versions: %v", err)
+	}
+	if len(versions) != 2 {
+		t.Fatalf("expected 2 versions, got %d", len(versions))
+	}
+	if versions[0].Version != 2 || versions[0].Source != SourceCLI || versions[0].APIKeyID == nil || *versions[0].APIKeyID != apiKeyID {
+		t.Fatalf("unexpected latest version: %+v", versions[0])
+	}
+	if versions[0].Value != "" {
+		t.Fatalf("expected listed versions to omit values")
+	}
+
+	first, err := GetVaultVersion(vault.ID, 1)
+	if err != nil {
+		t.Fatalf("get version: %v", err)
+	}
+	if first.Value != "first" {
+		t.Fatalf("expected decrypted value %q, got %q", "first", first.Value)
+	}
+
+	if err := vault.RestoreVersion(1, VersionAuthor{UserID: userID, Source: SourceWeb}); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	if vault.Value != "first" {
+		t.Fatalf("expected restored value %q, got %q", "first", vault.Value)
+	}
+
+	restored, err := GetVaultVersion(vault.ID, 3)
+	if err != nil {
+		t.Fatalf("get restored version: %v", err)
+	}
+	if restored.RestoredFrom == nil || *restored.RestoredFrom != 1 {
+		t.Fatalf("expected version 3 to be restored from 1, got %v", restored.RestoredFrom)
+	}
+
+	if err := vault.RestoreVersion(99, VersionAuthor{UserID: userID}); err == nil {
+		t.Fatalf("expected error restoring missing version")
+	}
+}
+
+func TestVaultVersionBaselineForLegacyVault(t *testing.T) {
+	userID := uint(4243)
+	params := CreateVaultParams{
+		UniqueID: uuid.NewString(),
+		UserID:   userID,
+		Name:     "legacy-" + uuid.NewString(),
+		Value:    "original",
+	}
+	vault, err := params.Create()
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Simulate a vault created before version history existed
+	if err := DB.Where("vault_id = ?", vault.ID).Delete(&VaultVersion{}).Error; err != nil {
+		t.Fatalf("clear versions: %v", err)
+	}
+
+	updated := "updated"
+	if err := vault.Update(&UpdateVaultParams{Value: &updated, Author: VersionAuthor{UserID: userID, Source: SourceWeb}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+
+	baseline, err := GetVaultVersion(vault.ID, 1)
+	if err != nil {
+		t.Fatalf("get baseline: %v", err)
+	}
+	if baseline.Value != "original" {
+		t.Fatalf("expected baseline to keep %q, got %q", "original", baseline.Value)
+	}
+}
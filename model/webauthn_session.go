@@ -0,0 +1,90 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// WebAuthnCeremony is the kind of WebAuthn ceremony a session belongs to
+type WebAuthnCeremony string
+
+const (
+	WebAuthnCeremonyRegistration WebAuthnCeremony = "registration"
+	WebAuthnCeremonyLogin        WebAuthnCeremony = "login"
+)
+
+// ErrInvalidWebAuthnSession is returned for unknown, expired or used ceremony sessions
+var ErrInvalidWebAuthnSession = errors.New("invalid or expired WebAuthn session")
+
+// WebAuthnSession keeps the challenge of a WebAuthn ceremony between its begin and finish
+// requests. Only the hash of the session token is stored.
+type WebAuthnSession struct {
+	ID         uint `gorm:"primarykey"`
+	CreatedAt  time.Time
+	UserID     *uint            `gorm:"index;constraint:OnDelete:CASCADE"` // nil for discoverable logins
+	TokenHash  string           `gorm:"size:64;uniqueIndex"`
+	Ceremony   WebAuthnCeremony `gorm:"size:16"`
+	Data       string           `gorm:"type:text"`
+	ExpiresAt  time.Time        `gorm:"index"`
+	ConsumedAt *time.Time       `gorm:"index"`
+}
+
+// CreateWebAuthnSession stores the session data of a ceremony and returns its token
+func CreateWebAuthnSession(userID *uint, ceremony WebAuthnCeremony, data *webauthn.SessionData, ttl time.Duration) (string, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", err
+	}
+	token, hash, err := generateToken()
+	if err != nil {
+		return "", err
+	}
+	session := WebAuthnSession{
+		UserID:    userID,
+		TokenHash: hash,
+		Ceremony:  ceremony,
+		Data:      string(encoded),
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := DB.Create(&session).Error; err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// ConsumeWebAuthnSession returns the session data of a ceremony and marks it used, so each
+// challenge can only be answered once. Registration sessions must belong to userID.
+func ConsumeWebAuthnSession(plaintextToken string, ceremony WebAuthnCeremony, userID *uint) (*webauthn.SessionData, error) {
+	sum := sha256.Sum256([]byte(plaintextToken))
+	hash := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	var session WebAuthnSession
+	if err := DB.Where("token_hash = ? AND ceremony = ?", hash, ceremony).First(&session).Error; err != nil {
+		return nil, ErrInvalidWebAuthnSession
+	}
+	if userID != nil && (session.UserID == nil || *session.UserID != *userID) {
+		return nil, ErrInvalidWebAuthnSession
+	}
+
+	now := time.Now()
+	update := DB.Model(&WebAuthnSession{}).
+		Where("id = ? AND consumed_at IS NULL AND expires_at >= ?", session.ID, now).
+		Update("consumed_at", now)
+	if update.Error != nil {
+		return nil, update.Error
+	}
+	if update.RowsAffected == 0 {
+		return nil, ErrInvalidWebAuthnSession
+	}
+
+	var data webauthn.SessionData
+	if err := json.Unmarshal([]byte(session.Data), &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
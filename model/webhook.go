@@ -0,0 +1,635 @@
+package model
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"gorm.io/gorm"
+)
+
+// WebhookEvent is the type of an event delivered to webhooks
+type WebhookEvent string
+
+const (
+	WebhookVaultCreated  WebhookEvent = "vault.created"
+	WebhookVaultUpdated  WebhookEvent = "vault.updated"
+	WebhookVaultRead     WebhookEvent = "vault.read"
+	WebhookVaultDeleted  WebhookEvent = "vault.deleted"
+	WebhookAPIKeyCreated WebhookEvent = "api_key.created"
+	WebhookAPIKeyUpdated WebhookEvent = "api_key.updated"
+	WebhookAPIKeyDeleted WebhookEvent = "api_key.deleted"
+	// WebhookTest is sent on request to check an endpoint and is never retried
+	WebhookTest WebhookEvent = "webhook.test"
+)
+
+// AllWebhookEvents lists the events a webhook can subscribe to
+var AllWebhookEvents = []WebhookEvent{
+	WebhookVaultCreated, WebhookVaultUpdated, WebhookVaultRead, WebhookVaultDeleted,
+	WebhookAPIKeyCreated, WebhookAPIKeyUpdated, WebhookAPIKeyDeleted,
+}
+
+// webhookEventActions maps audit actions to the webhook event they are delivered as
+var webhookEventActions = map[ActionType]WebhookEvent{
+	ActionCreateVault:              WebhookVaultCreated,
+	ActionUpdateVault:              WebhookVaultUpdated,
+	ActionRestoreVaultVersion:      WebhookVaultUpdated,
+	ActionRotateVault:              WebhookVaultUpdated,
+	ActionReadVault:                WebhookVaultRead,
+	ActionUnwrapVault:              WebhookVaultRead,
+	ActionIssueDatabaseCredentials: WebhookVaultRead,
+	ActionDeleteVault:              WebhookVaultDeleted,
+	ActionCreateAPIKey:             WebhookAPIKeyCreated,
+	ActionUpdateAPIKey:             WebhookAPIKeyUpdated,
+	ActionDeleteAPIKey:             WebhookAPIKeyDeleted,
+}
+
+// IsValid reports whether the event is one webhooks can subscribe to
+func (e WebhookEvent) IsValid() bool {
+	return slices.Contains(AllWebhookEvents, e)
+}
+
+// WebhookEvents represents a custom type for storing webhook events as JSON
+type WebhookEvents []WebhookEvent
+
+// Value implements the driver.Valuer interface for storing as JSON in database
+func (e WebhookEvents) Value() (driver.Value, error) {
+	if e == nil {
+		return nil, nil
+	}
+	return json.Marshal(e)
+}
+
+// Scan implements the sql.Scanner interface for reading JSON from database
+func (e *WebhookEvents) Scan(value interface{}) error {
+	if value == nil {
+		*e = nil
+		return nil
+	}
+
+	switch s := value.(type) {
+	case []byte:
+		return json.Unmarshal(s, e)
+	case string:
+		return json.Unmarshal([]byte(s), e)
+	default:
+		return errors.New("cannot scan WebhookEvents from this type")
+	}
+}
+
+// WebhookDeliveryStatus is the state of a delivery
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"   // Waiting for its first or next attempt
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded" // The endpoint responded with 2xx
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"    // Out of attempts, or the webhook was removed
+)
+
+const (
+	// WebhookMaxAttempts is the number of times a delivery is attempted before it fails
+	WebhookMaxAttempts = 8
+	// webhookRetryBase is the wait after the first failed attempt; it doubles with every
+	// further failure, so all attempts span a little over an hour
+	webhookRetryBase = 30 * time.Second
+	// webhookClaimLease is how long a claimed delivery is hidden from other dispatchers
+	webhookClaimLease = time.Minute
+	// webhookSecretPrefix marks webhook signing secrets
+	webhookSecretPrefix = "whsec_"
+)
+
+// Webhook is an endpoint that receives signed event payloads. A webhook without a vault
+// receives the events of its user's own actions, including those of their API keys; a
+// webhook on a vault receives that vault's events, whoever caused them.
+type Webhook struct {
+	gorm.Model
+	UserID  uint          `gorm:"not null;index"` // User who owns this webhook
+	VaultID *uint         `gorm:"index"`          // Vault whose events are sent (null = the user's actions)
+	Vault   *Vault        `gorm:"foreignKey:VaultID"`
+	Name    string        `gorm:"size:255;not null"`
+	URL     string        `gorm:"size:2048;not null"`
+	Secret  string        `gorm:"type:text;not null"` // Signing secret, encrypted with the master key
+	Events  WebhookEvents `gorm:"type:json"`          // JSON array of events (null = all events)
+	Enabled bool          `gorm:"not null"`
+}
+
+// WebhookDelivery is one event sent, or to be sent, to a webhook
+type WebhookDelivery struct {
+	ID             uint `gorm:"primarykey"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	UniqueID       string                `gorm:"size:36;uniqueIndex;not null"` // Sent to the endpoint to detect redeliveries
+	WebhookID      uint                  `gorm:"not null;index"`
+	Webhook        Webhook               `gorm:"foreignKey:WebhookID"`
+	Event          WebhookEvent          `gorm:"size:50;not null"`
+	Payload        string                `gorm:"type:text;not null"`
+	Status         WebhookDeliveryStatus `gorm:"size:20;not null;index:idx_webhook_delivery_due,priority:1"`
+	Attempts       int                   `gorm:"not null;default:0"`
+	NextAttemptAt  *time.Time            `gorm:"index:idx_webhook_delivery_due,priority:2"`
+	LastAttemptAt  *time.Time
+	DeliveredAt    *time.Time
+	ResponseStatus int
+	ResponseBody   string `gorm:"size:1000"` // Start of the last response, for debugging
+	Error          string `gorm:"size:500"`  // Why the last attempt failed
+}
+
+// WebhookPayload is the JSON body sent to webhook endpoints
+type WebhookPayload struct {
+	ID        string                `json:"id"` // Unique ID of the delivery
+	Event     WebhookEvent          `json:"event"`
+	CreatedAt time.Time             `json:"createdAt"`
+	UserID    uint                  `json:"userId"` // User who caused the event
+	Source    SourceType            `json:"source,omitempty"`
+	Vault     *WebhookPayloadVault  `json:"vault,omitempty"`
+	APIKey    *WebhookPayloadAPIKey `json:"apiKey,omitempty"`
+}
+
+// WebhookPayloadVault identifies the vault an event is about. Values are never sent.
+type WebhookPayloadVault struct {
+	UniqueID       string `json:"uniqueId"`
+	Name           string `json:"name"`
+	OrganizationID *uint  `json:"organizationId,omitempty"`
+}
+
+// WebhookPayloadAPIKey identifies the API key an event is about, or that caused it
+type WebhookPayloadAPIKey struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// webhookWake is signalled when deliveries are queued, so they are sent without waiting
+// for the next poll
+var webhookWake = make(chan struct{}, 1)
+
+// WebhookDeliveriesQueued returns a channel that receives a value after new deliveries
+// are queued on this server
+func WebhookDeliveriesQueued() <-chan struct{} {
+	return webhookWake
+}
+
+// CreateWebhookParams defines parameters for creating a webhook
+type CreateWebhookParams struct {
+	UserID  uint
+	VaultID *uint
+	Name    string
+	URL     string
+	Events  []WebhookEvent // Nil means all events
+	Enabled bool
+}
+
+// UpdateWebhookParams defines parameters for updating a webhook
+type UpdateWebhookParams struct {
+	Name    *string
+	URL     *string
+	Events  *[]WebhookEvent // Empty means all events
+	Enabled *bool
+}
+
+// Validate validates the create webhook parameters
+func (params *CreateWebhookParams) Validate() map[string]string {
+	errors := map[string]string{}
+
+	validateWebhookName(errors, params.Name)
+	validateWebhookURL(errors, params.URL)
+	if params.Events != nil {
+		validateWebhookEvents(errors, params.Events)
+	}
+
+	if params.UserID == 0 {
+		errors["user_id"] = "user_id is required"
+	}
+
+	return errors
+}
+
+// Validate validates the update webhook parameters
+func (params *UpdateWebhookParams) Validate() map[string]string {
+	errors := map[string]string{}
+
+	if params.Name != nil {
+		validateWebhookName(errors, *params.Name)
+	}
+	if params.URL != nil {
+		validateWebhookURL(errors, *params.URL)
+	}
+	if params.Events != nil && len(*params.Events) > 0 {
+		validateWebhookEvents(errors, *params.Events)
+	}
+
+	return errors
+}
+
+func validateWebhookName(errors map[string]string, name string) {
+	if strings.TrimSpace(name) == "" {
+		errors["name"] = "name is required"
+	} else if len(name) > 255 {
+		errors["name"] = "name must be less than 255 characters"
+	}
+}
+
+func validateWebhookURL(errors map[string]string, rawURL string) {
+	u, err := url.Parse(rawURL)
+	switch {
+	case err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https"):
+		errors["url"] = "url must be an absolute http or https URL"
+	case len(rawURL) > 2048:
+		errors["url"] = "url must be less than 2048 characters"
+	}
+}
+
+func validateWebhookEvents(errors map[string]string, events []WebhookEvent) {
+	for _, event := range events {
+		if !event.IsValid() {
+			errors["events"] = "unknown event: " + string(event)
+			return
+		}
+	}
+}
+
+// Create creates a webhook with a new signing secret, which is returned in plaintext once
+func (params *CreateWebhookParams) Create() (*Webhook, string, error) {
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, "", err
+	}
+	encrypted, err := encryption.Encrypt(secret)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to encrypt webhook secret: %w", err)
+	}
+
+	webhook := Webhook{
+		UserID:  params.UserID,
+		VaultID: params.VaultID,
+		Name:    strings.TrimSpace(params.Name),
+		URL:     params.URL,
+		Secret:  encrypted,
+		Enabled: params.Enabled,
+	}
+	if params.Events != nil {
+		webhook.Events = normalizeWebhookEvents(params.Events)
+	}
+
+	if err := DB.Create(&webhook).Error; err != nil {
+		return nil, "", err
+	}
+
+	return &webhook, secret, nil
+}
+
+// Update updates a webhook. Disabling it cancels its pending deliveries.
+func (w *Webhook) Update(params *UpdateWebhookParams) error {
+	updates := map[string]interface{}{}
+	if params.Name != nil {
+		updates["name"] = strings.TrimSpace(*params.Name)
+	}
+	if params.URL != nil {
+		updates["url"] = *params.URL
+	}
+	if params.Events != nil {
+		var events WebhookEvents
+		if len(*params.Events) > 0 {
+			events = normalizeWebhookEvents(*params.Events)
+		}
+		updates["events"] = events
+	}
+	if params.Enabled != nil {
+		updates["enabled"] = *params.Enabled
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(w).Updates(updates).Error; err != nil {
+			return err
+		}
+		if params.Enabled != nil && !*params.Enabled {
+			return cancelWebhookDeliveries(tx, w.ID, "webhook disabled")
+		}
+		return nil
+	})
+}
+
+// Delete deletes a webhook and cancels its pending deliveries
+func (w *Webhook) Delete() error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(w).Error; err != nil {
+			return err
+		}
+		return cancelWebhookDeliveries(tx, w.ID, "webhook deleted")
+	})
+}
+
+// DecryptSecret returns the webhook's signing secret
+func (w *Webhook) DecryptSecret() (string, error) {
+	return encryption.Decrypt(w.Secret)
+}
+
+// Subscribes reports whether the webhook receives the event
+func (w *Webhook) Subscribes(event WebhookEvent) bool {
+	return event == WebhookTest || len(w.Events) == 0 || slices.Contains(w.Events, event)
+}
+
+// GetWebhook returns a webhook of the user
+func GetWebhook(id uint, userID uint) (*Webhook, error) {
+	var webhook Webhook
+	err := DB.Preload("Vault", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("id = ? AND user_id = ?", id, userID).First(&webhook).Error
+	if err != nil {
+		return nil, err
+	}
+	return &webhook, nil
+}
+
+// GetUserWebhooks returns the webhooks of a user, newest first
+func GetUserWebhooks(userID uint) ([]Webhook, error) {
+	var webhooks []Webhook
+	err := DB.Preload("Vault", func(db *gorm.DB) *gorm.DB {
+		return db.Unscoped()
+	}).Where("user_id = ?", userID).Order("created_at DESC").Find(&webhooks).Error
+	if err != nil {
+		return nil, err
+	}
+	return webhooks, nil
+}
+
+// GetWebhookDeliveriesWithPagination returns the deliveries of a webhook, newest first
+func GetWebhookDeliveriesWithPagination(webhookID uint, pageSize, pageIndex int) ([]WebhookDelivery, int64, error) {
+	var deliveries []WebhookDelivery
+	var totalCount int64
+
+	query := DB.Model(&WebhookDelivery{}).Where("webhook_id = ?", webhookID)
+	if err := query.Count(&totalCount).Error; err != nil {
+		return nil, 0, err
+	}
+
+	offset := (pageIndex - 1) * pageSize
+	err := query.Order("id DESC").Limit(pageSize).Offset(offset).Find(&deliveries).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return deliveries, totalCount, nil
+}
+
+// CreateTestDelivery queues a test event for the webhook and claims it, so the caller can
+// deliver it right away
+func (w *Webhook) CreateTestDelivery(userID uint) (*WebhookDelivery, error) {
+	delivery, err := newWebhookDelivery(w, WebhookPayload{
+		Event:     WebhookTest,
+		CreatedAt: time.Now().UTC(),
+		UserID:    userID,
+		Source:    SourceWeb,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	lease := now.Add(webhookClaimLease)
+	delivery.Attempts = 1
+	delivery.LastAttemptAt = &now
+	delivery.NextAttemptAt = &lease
+	if err := DB.Create(delivery).Error; err != nil {
+		return nil, err
+	}
+	delivery.Webhook = *w
+	return delivery, nil
+}
+
+// ClaimDueWebhookDeliveries claims up to limit deliveries whose next attempt is due and
+// counts the attempt. A claimed delivery is not handed to other dispatchers until its
+// lease expires, which retries it if this one stops before recording the result.
+func ClaimDueWebhookDeliveries(limit int) ([]WebhookDelivery, error) {
+	var due []WebhookDelivery
+	err := DB.Where("status = ? AND next_attempt_at <= ?", WebhookDeliveryPending, time.Now()).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(&due).Error
+	if err != nil {
+		return nil, err
+	}
+
+	claimed := make([]WebhookDelivery, 0, len(due))
+	for _, delivery := range due {
+		now := time.Now()
+		lease := now.Add(webhookClaimLease)
+		result := DB.Model(&WebhookDelivery{}).
+			Where("id = ? AND status = ? AND attempts = ?", delivery.ID, WebhookDeliveryPending, delivery.Attempts).
+			Updates(map[string]interface{}{
+				"attempts":        delivery.Attempts + 1,
+				"last_attempt_at": now,
+				"next_attempt_at": lease,
+			})
+		if result.Error != nil {
+			return claimed, result.Error
+		}
+		if result.RowsAffected == 0 {
+			// Claimed by another dispatcher
+			continue
+		}
+
+		delivery.Attempts++
+		delivery.LastAttemptAt = &now
+		delivery.NextAttemptAt = &lease
+		if err := DB.First(&delivery.Webhook, delivery.WebhookID).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				_ = delivery.recordFailure(0, "", "webhook deleted", true)
+				continue
+			}
+			return claimed, err
+		}
+		claimed = append(claimed, delivery)
+	}
+	return claimed, nil
+}
+
+// RecordSuccess marks the delivery as delivered
+func (d *WebhookDelivery) RecordSuccess(statusCode int, responseBody string) error {
+	now := time.Now()
+	d.Status = WebhookDeliverySucceeded
+	d.DeliveredAt = &now
+	d.NextAttemptAt = nil
+	d.ResponseStatus = statusCode
+	d.ResponseBody = truncate(responseBody, 1000)
+	d.Error = ""
+	return DB.Model(d).Select("status", "delivered_at", "next_attempt_at", "response_status", "response_body", "error").Updates(d).Error
+}
+
+// RecordFailure records a failed attempt and schedules the next one with exponential
+// backoff, or marks the delivery as failed once it is out of attempts. Test events are
+// not retried.
+func (d *WebhookDelivery) RecordFailure(statusCode int, responseBody, reason string) error {
+	return d.recordFailure(statusCode, responseBody, reason, d.Event == WebhookTest || d.Attempts >= WebhookMaxAttempts)
+}
+
+func (d *WebhookDelivery) recordFailure(statusCode int, responseBody, reason string, final bool) error {
+	d.ResponseStatus = statusCode
+	d.ResponseBody = truncate(responseBody, 1000)
+	d.Error = truncate(reason, 500)
+	if final {
+		d.Status = WebhookDeliveryFailed
+		d.NextAttemptAt = nil
+	} else {
+		next := time.Now().Add(webhookRetryDelay(d.Attempts))
+		d.NextAttemptAt = &next
+	}
+	return DB.Model(d).Select("status", "next_attempt_at", "response_status", "response_body", "error").Updates(d).Error
+}
+
+// webhookRetryDelay returns the wait after the given number of failed attempts
+func webhookRetryDelay(attempts int) time.Duration {
+	delay := webhookRetryBase
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+	}
+	return delay
+}
+
+// PruneWebhookDeliveries deletes finished deliveries created before the given time
+func PruneWebhookDeliveries(before time.Time) (int64, error) {
+	result := DB.Where("status <> ? AND created_at < ?", WebhookDeliveryPending, before).Delete(&WebhookDelivery{})
+	return result.RowsAffected, result.Error
+}
+
+// queueWebhookDeliveries queues the event recorded by an audit log entry for every webhook
+// subscribed to it. The vault, if any, must be loaded. Failures are logged, as webhooks
+// must not break the action that triggered them.
+func queueWebhookDeliveries(auditLog *AuditLog, vault *Vault) {
+	event, ok := webhookEventActions[auditLog.Action]
+	if !ok {
+		return
+	}
+
+	query := DB.Where("enabled = ?", true)
+	if auditLog.VaultID != nil {
+		query = query.Where("(user_id = ? AND vault_id IS NULL) OR vault_id = ?", auditLog.UserID, *auditLog.VaultID)
+	} else {
+		query = query.Where("user_id = ? AND vault_id IS NULL", auditLog.UserID)
+	}
+	var webhooks []Webhook
+	if err := query.Find(&webhooks).Error; err != nil {
+		slog.Error("Failed to find webhooks", "error", err, "auditLogID", auditLog.ID)
+		return
+	}
+
+	payload := WebhookPayload{
+		Event:     event,
+		CreatedAt: auditLog.CreatedAt,
+		UserID:    auditLog.UserID,
+		Source:    auditLog.Source,
+	}
+	if vault != nil && auditLog.VaultID != nil {
+		payload.Vault = &WebhookPayloadVault{UniqueID: vault.UniqueID, Name: vault.Name, OrganizationID: vault.OrganizationID}
+	}
+	if auditLog.APIKeyID != nil {
+		var apiKey APIKey
+		if err := DB.Unscoped().Select("id", "name").First(&apiKey, *auditLog.APIKeyID).Error; err == nil {
+			payload.APIKey = &WebhookPayloadAPIKey{ID: apiKey.ID, Name: apiKey.Name}
+		}
+	}
+
+	queued := false
+	for i := range webhooks {
+		if !webhooks[i].Subscribes(event) {
+			continue
+		}
+		delivery, err := newWebhookDelivery(&webhooks[i], payload)
+		if err == nil {
+			now := time.Now()
+			delivery.NextAttemptAt = &now
+			err = DB.Create(delivery).Error
+		}
+		if err != nil {
+			slog.Error("Failed to queue webhook delivery", "error", err, "webhookID", webhooks[i].ID, "event", event)
+			continue
+		}
+		queued = true
+	}
+
+	if queued {
+		select {
+		case webhookWake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// newWebhookDelivery builds a pending delivery of the payload to the webhook
+func newWebhookDelivery(webhook *Webhook, payload WebhookPayload) (*WebhookDelivery, error) {
+	payload.ID = uuid.NewString()
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	return &WebhookDelivery{
+		UniqueID:  payload.ID,
+		WebhookID: webhook.ID,
+		Event:     payload.Event,
+		Payload:   string(body),
+		Status:    WebhookDeliveryPending,
+	}, nil
+}
+
+// cancelWebhookDeliveries fails the pending deliveries of a webhook
+func cancelWebhookDeliveries(tx *gorm.DB, webhookID uint, reason string) error {
+	return tx.Model(&WebhookDelivery{}).
+		Where("webhook_id = ? AND status = ?", webhookID, WebhookDeliveryPending).
+		Updates(map[string]interface{}{
+			"status":          WebhookDeliveryFailed,
+			"next_attempt_at": nil,
+			"error":           reason,
+		}).Error
+}
+
+// rotateWebhookSecrets re-encrypts every webhook secret with the active master key
+func rotateWebhookSecrets() (int, error) {
+	var webhooks []Webhook
+	if err := DB.Unscoped().Select("id", "secret").Find(&webhooks).Error; err != nil {
+		return 0, err
+	}
+
+	for _, webhook := range webhooks {
+		secret, err := encryption.Decrypt(webhook.Secret)
+		if err != nil {
+			return 0, fmt.Errorf("webhook %d: %w", webhook.ID, err)
+		}
+		encrypted, err := encryption.Encrypt(secret)
+		if err != nil {
+			return 0, fmt.Errorf("webhook %d: %w", webhook.ID, err)
+		}
+		if err := DB.Unscoped().Model(&Webhook{}).Where("id = ?", webhook.ID).Update("secret", encrypted).Error; err != nil {
+			return 0, fmt.Errorf("webhook %d: %w", webhook.ID, err)
+		}
+	}
+	return len(webhooks), nil
+}
+
+func normalizeWebhookEvents(events []WebhookEvent) WebhookEvents {
+	normalized := make(WebhookEvents, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(normalized, event) {
+			normalized = append(normalized, event)
+		}
+	}
+	return normalized
+}
+
+// generateWebhookSecret returns a random signing secret
+func generateWebhookSecret() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(bytes), nil
+}
@@ -0,0 +1,220 @@
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func createTestWebhook(t *testing.T, params CreateWebhookParams) (*Webhook, string) {
+	t.Helper()
+	params.Name = "hook-" + uuid.NewString()
+	params.URL = "https://example.com/hook"
+	if errs := params.Validate(); len(errs) > 0 {
+		t.Fatalf("invalid webhook params: %v", errs)
+	}
+	webhook, secret, err := params.Create()
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	return webhook, secret
+}
+
+func webhookDeliveries(t *testing.T, webhookID uint) []WebhookDelivery {
+	t.Helper()
+	var deliveries []WebhookDelivery
+	if err := DB.Where("webhook_id = ?", webhookID).Order("id").Find(&deliveries).Error; err != nil {
+		t.Fatalf("load deliveries: %v", err)
+	}
+	return deliveries
+}
+
+func TestWebhookDeliveriesQueued(t *testing.T) {
+	owner := createTestUser(t)
+
+	vaultParams := CreateVaultParams{
+		UniqueID: uuid.NewString(),
+		UserID:   owner.ID,
+		Name:     "webhook-" + uuid.NewString(),
+		Value:    "super-secret-value",
+		Source:   SourceWeb,
+	}
+	vault, err := vaultParams.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+
+	userHook, secret := createTestWebhook(t, CreateWebhookParams{UserID: owner.ID, Enabled: true})
+	vaultHook, _ := createTestWebhook(t, CreateWebhookParams{
+		UserID: owner.ID, VaultID: &vault.ID, Enabled: true,
+		Events: []WebhookEvent{WebhookVaultDeleted},
+	})
+	disabledHook, _ := createTestWebhook(t, CreateWebhookParams{UserID: owner.ID, Enabled: false})
+
+	// The secret is stored encrypted and can be recovered for signing
+	if !strings.HasPrefix(secret, webhookSecretPrefix) || strings.Contains(userHook.Secret, secret) {
+		t.Fatalf("secret %q stored as %q", secret, userHook.Secret)
+	}
+	if decrypted, err := userHook.DecryptSecret(); err != nil || decrypted != secret {
+		t.Fatalf("DecryptSecret() = %q, %v", decrypted, err)
+	}
+
+	if err := LogVaultAction(vault.ID, ActionUpdateVault, owner.ID, SourceWeb, nil, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log update: %v", err)
+	}
+	if err := vault.Delete(); err != nil {
+		t.Fatalf("delete vault: %v", err)
+	}
+	if err := LogVaultAction(vault.ID, ActionDeleteVault, owner.ID, SourceWeb, nil, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log delete: %v", err)
+	}
+	if err := LogAPIKeyAction(1, ActionCreateAPIKey, owner.ID, SourceWeb, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log API key action: %v", err)
+	}
+	// Login is not a webhook event
+	if err := LogUserAction(ActionLoginUser, owner.ID, SourceWeb, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log login: %v", err)
+	}
+
+	userDeliveries := webhookDeliveries(t, userHook.ID)
+	if len(userDeliveries) != 3 {
+		t.Fatalf("user webhook got %d deliveries, want 3", len(userDeliveries))
+	}
+	wantEvents := []WebhookEvent{WebhookVaultUpdated, WebhookVaultDeleted, WebhookAPIKeyCreated}
+	for i, delivery := range userDeliveries {
+		if delivery.Event != wantEvents[i] || delivery.Status != WebhookDeliveryPending || delivery.NextAttemptAt == nil {
+			t.Errorf("delivery %d = %s %s, want pending %s", i, delivery.Event, delivery.Status, wantEvents[i])
+		}
+	}
+
+	var payload WebhookPayload
+	if err := json.Unmarshal([]byte(userDeliveries[0].Payload), &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.ID != userDeliveries[0].UniqueID || payload.Vault == nil || payload.Vault.UniqueID != vault.UniqueID {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+	if strings.Contains(userDeliveries[0].Payload, "super-secret-value") {
+		t.Error("payload must not contain the vault value")
+	}
+
+	vaultDeliveries := webhookDeliveries(t, vaultHook.ID)
+	if len(vaultDeliveries) != 1 || vaultDeliveries[0].Event != WebhookVaultDeleted {
+		t.Fatalf("vault webhook deliveries = %+v, want one vault.deleted", vaultDeliveries)
+	}
+	if deliveries := webhookDeliveries(t, disabledHook.ID); len(deliveries) != 0 {
+		t.Fatalf("disabled webhook got %d deliveries", len(deliveries))
+	}
+
+	// Deleting the webhook cancels what is still pending
+	if err := userHook.Delete(); err != nil {
+		t.Fatalf("delete webhook: %v", err)
+	}
+	for _, delivery := range webhookDeliveries(t, userHook.ID) {
+		if delivery.Status != WebhookDeliveryFailed || delivery.Error != "webhook deleted" {
+			t.Errorf("delivery after delete = %s %q, want failed", delivery.Status, delivery.Error)
+		}
+	}
+}
+
+func TestWebhookDeliveryRetries(t *testing.T) {
+	owner := createTestUser(t)
+	hook, _ := createTestWebhook(t, CreateWebhookParams{UserID: owner.ID, Enabled: true})
+
+	if err := LogAPIKeyAction(1, ActionDeleteAPIKey, owner.ID, SourceWeb, "127.0.0.1", "test"); err != nil {
+		t.Fatalf("log API key action: %v", err)
+	}
+
+	claim := func() *WebhookDelivery {
+		t.Helper()
+		claimed, err := ClaimDueWebhookDeliveries(100)
+		if err != nil {
+			t.Fatalf("claim deliveries: %v", err)
+		}
+		for i := range claimed {
+			if claimed[i].WebhookID == hook.ID {
+				return &claimed[i]
+			}
+		}
+		return nil
+	}
+
+	delivery := claim()
+	if delivery == nil || delivery.Attempts != 1 || delivery.Webhook.URL != hook.URL {
+		t.Fatalf("claimed delivery = %+v, want first attempt with its webhook", delivery)
+	}
+	// A claimed delivery is leased and not handed out again
+	if again := claim(); again != nil {
+		t.Fatal("claimed delivery was handed out twice")
+	}
+
+	for attempt := 1; attempt < WebhookMaxAttempts; attempt++ {
+		before := time.Now()
+		if err := delivery.RecordFailure(503, "unavailable", "endpoint responded with 503"); err != nil {
+			t.Fatalf("record failure: %v", err)
+		}
+		wantDelay := webhookRetryDelay(attempt)
+		if delivery.Status != WebhookDeliveryPending || delivery.NextAttemptAt.Before(before.Add(wantDelay)) {
+			t.Fatalf("after attempt %d: status %s, next attempt %v, want pending after %v", attempt, delivery.Status, delivery.NextAttemptAt, wantDelay)
+		}
+
+		// Make the retry due now
+		if err := DB.Model(delivery).Update("next_attempt_at", time.Now().Add(-time.Second)).Error; err != nil {
+			t.Fatalf("reschedule: %v", err)
+		}
+		if delivery = claim(); delivery == nil || delivery.Attempts != attempt+1 {
+			t.Fatalf("retry %d was not claimed: %+v", attempt, delivery)
+		}
+	}
+
+	if err := delivery.RecordFailure(0, "", "connection refused"); err != nil {
+		t.Fatalf("record failure: %v", err)
+	}
+	if delivery.Status != WebhookDeliveryFailed || delivery.NextAttemptAt != nil {
+		t.Fatalf("after the last attempt: status %s, next attempt %v, want failed", delivery.Status, delivery.NextAttemptAt)
+	}
+
+	// Test events are attempted once
+	test, err := hook.CreateTestDelivery(owner.ID)
+	if err != nil {
+		t.Fatalf("create test delivery: %v", err)
+	}
+	if err := test.RecordFailure(500, "", "endpoint responded with 500"); err != nil {
+		t.Fatalf("record failure: %v", err)
+	}
+	if test.Status != WebhookDeliveryFailed {
+		t.Fatalf("test delivery status = %s, want failed", test.Status)
+	}
+
+	if got := webhookRetryDelay(3); got != 4*webhookRetryBase {
+		t.Errorf("webhookRetryDelay(3) = %v, want %v", got, 4*webhookRetryBase)
+	}
+}
+
+func TestWebhookParamsValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		params CreateWebhookParams
+		field  string
+	}{
+		{name: "valid", params: CreateWebhookParams{UserID: 1, Name: "hook", URL: "https://example.com/hook"}},
+		{name: "missing name", params: CreateWebhookParams{UserID: 1, URL: "https://example.com"}, field: "name"},
+		{name: "relative URL", params: CreateWebhookParams{UserID: 1, Name: "hook", URL: "/hook"}, field: "url"},
+		{name: "other scheme", params: CreateWebhookParams{UserID: 1, Name: "hook", URL: "ftp://example.com"}, field: "url"},
+		{name: "unknown event", params: CreateWebhookParams{UserID: 1, Name: "hook", URL: "https://example.com", Events: []WebhookEvent{"vault.exploded"}}, field: "events"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.params.Validate()
+			if tt.field == "" && len(errs) > 0 {
+				t.Fatalf("Validate() = %v, want no errors", errs)
+			}
+			if tt.field != "" && errs[tt.field] == "" {
+				t.Fatalf("Validate() = %v, want an error for %s", errs, tt.field)
+			}
+		})
+	}
+}
@@ -0,0 +1,283 @@
+package model
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lwshen/vault-hub/internal/auth"
+	"gorm.io/gorm"
+)
+
+const (
+	// DefaultWorkloadTokenTTL is how long exchanged credentials last when the identity sets no TTL
+	DefaultWorkloadTokenTTL = 15 * time.Minute
+	// MinWorkloadTokenTTL and MaxWorkloadTokenTTL bound the TTL an identity may set
+	MinWorkloadTokenTTL = time.Minute
+	MaxWorkloadTokenTTL = time.Hour
+)
+
+// BoundClaims maps token claim names to the patterns their values must match
+type BoundClaims map[string]string
+
+// Value implements the driver.Valuer interface for storing as JSON in database
+func (b BoundClaims) Value() (driver.Value, error) {
+	if b == nil {
+		return nil, nil
+	}
+	return json.Marshal(b)
+}
+
+// Scan implements the sql.Scanner interface for reading JSON from database
+func (b *BoundClaims) Scan(value interface{}) error {
+	if value == nil {
+		*b = nil
+		return nil
+	}
+
+	switch s := value.(type) {
+	case []byte:
+		return json.Unmarshal(s, b)
+	case string:
+		return json.Unmarshal([]byte(s), b)
+	default:
+		return errors.New("cannot scan BoundClaims from this type")
+	}
+}
+
+// WorkloadIdentity lets a CI job or service exchange an OIDC token from a workload issuer for a
+// short-lived API key. The token must come from Issuer, be issued for Audience, and its subject
+// and bound claims must match the patterns, where * matches any run of characters. The API keys
+// are scoped like a regular API key of the identity's user.
+type WorkloadIdentity struct {
+	gorm.Model
+	UserID      uint              `gorm:"not null;index"` // User the exchanged API keys act for
+	Name        string            `gorm:"size:255;not null"`
+	Issuer      string            `gorm:"size:2048;not null;index"`
+	Audience    string            `gorm:"size:255;not null"`
+	Subject     string            `gorm:"size:1024;not null"`
+	Claims      BoundClaims       `gorm:"type:json"` // Extra claims the token must match (null = none)
+	VaultIDs    VaultIDs          `gorm:"type:json"` // JSON array of vault IDs (null = all user's vaults)
+	Permissions APIKeyPermissions `gorm:"type:json"` // JSON array of permissions (null = all permissions)
+	TokenTTL    time.Duration     `gorm:"not null"`
+}
+
+// CreateWorkloadIdentityParams defines parameters for creating a workload identity
+type CreateWorkloadIdentityParams struct {
+	UserID      uint
+	Name        string
+	Issuer      string
+	Audience    string
+	Subject     string
+	Claims      map[string]string
+	VaultIDs    []uint             // Empty slice or nil means all user's vaults
+	Permissions []APIKeyPermission // Nil means all permissions
+	TokenTTL    time.Duration      // Zero means DefaultWorkloadTokenTTL
+}
+
+// Validate validates the create workload identity parameters against the allowed issuers
+func (params *CreateWorkloadIdentityParams) Validate(allowedIssuers []string) map[string]string {
+	errors := map[string]string{}
+
+	if params.UserID == 0 {
+		errors["user_id"] = "user ID is required"
+	}
+
+	name := strings.TrimSpace(params.Name)
+	if name == "" {
+		errors["name"] = "name is required"
+	} else if len(name) > 255 {
+		errors["name"] = "name must be less than 255 characters"
+	}
+
+	if !slices.Contains(allowedIssuers, params.Issuer) {
+		errors["issuer"] = "issuer is not allowed on this server: " + params.Issuer
+	}
+
+	if strings.TrimSpace(params.Audience) == "" {
+		errors["audience"] = "audience is required"
+	} else if len(params.Audience) > 255 {
+		errors["audience"] = "audience must be less than 255 characters"
+	}
+
+	// A subject of only wildcards would accept every workload of a shared issuer like GitHub
+	if strings.Trim(params.Subject, "* ") == "" {
+		errors["subject"] = "subject must name the workload, not only wildcards"
+	} else if len(params.Subject) > 1024 {
+		errors["subject"] = "subject must be less than 1024 characters"
+	}
+
+	for claim, pattern := range params.Claims {
+		if claim == "" || pattern == "" {
+			errors["claims"] = "claims need a name and a pattern"
+			break
+		}
+		switch claim {
+		case "iss", "sub", "aud":
+			errors["claims"] = "bind " + claim + " with its own field"
+		}
+	}
+
+	if params.Permissions != nil {
+		if msg := validatePermissions(params.Permissions); msg != "" {
+			errors["permissions"] = msg
+		}
+	}
+
+	if params.TokenTTL != 0 && (params.TokenTTL < MinWorkloadTokenTTL || params.TokenTTL > MaxWorkloadTokenTTL) {
+		errors["token_ttl"] = fmt.Sprintf("token TTL must be between %s and %s", MinWorkloadTokenTTL, MaxWorkloadTokenTTL)
+	}
+
+	return errors
+}
+
+// Create creates a new workload identity
+func (params *CreateWorkloadIdentityParams) Create() (*WorkloadIdentity, error) {
+	identity := WorkloadIdentity{
+		UserID:   params.UserID,
+		Name:     strings.TrimSpace(params.Name),
+		Issuer:   params.Issuer,
+		Audience: params.Audience,
+		Subject:  params.Subject,
+		TokenTTL: params.TokenTTL,
+	}
+	if len(params.Claims) > 0 {
+		identity.Claims = BoundClaims(params.Claims)
+	}
+	if len(params.VaultIDs) > 0 {
+		identity.VaultIDs = VaultIDs(params.VaultIDs)
+	}
+	if params.Permissions != nil {
+		identity.Permissions = normalizePermissions(params.Permissions)
+	}
+	if identity.TokenTTL == 0 {
+		identity.TokenTTL = DefaultWorkloadTokenTTL
+	}
+
+	if err := DB.Create(&identity).Error; err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// GetUserWorkloadIdentities returns the workload identities of a user
+func GetUserWorkloadIdentities(userID uint) ([]WorkloadIdentity, error) {
+	var identities []WorkloadIdentity
+	err := DB.Where("user_id = ?", userID).Order("created_at DESC").Find(&identities).Error
+	return identities, err
+}
+
+// GetWorkloadIdentity returns a workload identity of a user
+func GetWorkloadIdentity(id, userID uint) (*WorkloadIdentity, error) {
+	var identity WorkloadIdentity
+	if err := DB.Where("id = ? AND user_id = ?", id, userID).First(&identity).Error; err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+// FindWorkloadIdentities returns the workload identities a verified token matches
+func FindWorkloadIdentities(token *auth.WorkloadClaims) ([]WorkloadIdentity, error) {
+	var candidates []WorkloadIdentity
+	if err := DB.Where("issuer = ?", token.Issuer).Find(&candidates).Error; err != nil {
+		return nil, err
+	}
+
+	var matches []WorkloadIdentity
+	for _, identity := range candidates {
+		if identity.Matches(token) {
+			matches = append(matches, identity)
+		}
+	}
+	return matches, nil
+}
+
+// Matches reports whether a verified token satisfies the identity's bindings
+func (w *WorkloadIdentity) Matches(token *auth.WorkloadClaims) bool {
+	if token.Issuer != w.Issuer || !slices.Contains(token.Audience, w.Audience) {
+		return false
+	}
+	if !matchPattern(w.Subject, token.Subject) {
+		return false
+	}
+	for claim, pattern := range w.Claims {
+		value, ok := claimString(token.Claims[claim])
+		if !ok || !matchPattern(pattern, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// IssueAPIKey creates a short-lived API key with the identity's scope. It is not listed with
+// the user's API keys and is revoked when the identity is deleted.
+func (w *WorkloadIdentity) IssueAPIKey() (*APIKey, string, error) {
+	plainKey, err := GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	expiresAt := time.Now().Add(w.TokenTTL)
+	apiKey := APIKey{
+		UserID:             w.UserID,
+		Name:               "Workload identity: " + w.Name,
+		KeyHash:            HashAPIKey(plainKey),
+		VaultIDs:           w.VaultIDs,
+		ExpiresAt:          &expiresAt,
+		Permissions:        w.Permissions,
+		WorkloadIdentityID: &w.ID,
+	}
+	if err := DB.Create(&apiKey).Error; err != nil {
+		return nil, "", err
+	}
+	return &apiKey, plainKey, nil
+}
+
+// Delete soft deletes the identity and revokes the API keys exchanged with it
+func (w *WorkloadIdentity) Delete() error {
+	return DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("workload_identity_id = ?", w.ID).Delete(&APIKey{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(w).Error
+	})
+}
+
+// matchPattern reports whether value matches pattern, where * matches any run of characters
+// and everything else matches literally
+func matchPattern(pattern, value string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == value
+	}
+	if !strings.HasPrefix(value, parts[0]) {
+		return false
+	}
+	value = value[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(value, part)
+		if i < 0 {
+			return false
+		}
+		value = value[i+len(part):]
+	}
+	return strings.HasSuffix(value, last)
+}
+
+// claimString returns a claim value as a string. Booleans and numbers are formatted; objects
+// and arrays never match.
+func claimString(value interface{}) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case bool, float64, json.Number:
+		return fmt.Sprint(v), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,168 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lwshen/vault-hub/internal/auth"
+)
+
+const testWorkloadIssuer = "https://token.actions.githubusercontent.com"
+
+func TestMatchPattern(t *testing.T) {
+	tests := []struct {
+		pattern, value string
+		want           bool
+	}{
+		{"repo:my-org/app:ref:refs/heads/main", "repo:my-org/app:ref:refs/heads/main", true},
+		{"repo:my-org/app:ref:refs/heads/main", "repo:my-org/app:ref:refs/heads/dev", false},
+		{"repo:my-org/*", "repo:my-org/app:ref:refs/heads/main", true},
+		{"repo:my-org/*", "repo:other-org/app", false},
+		{"repo:*:ref:refs/heads/main", "repo:my-org/app:ref:refs/heads/main", true},
+		{"repo:*:ref:refs/heads/main", "repo:my-org/app:ref:refs/heads/main-fork", false},
+		{"a*bc*c", "abc", false},
+		{"a*b*c", "abbc", true},
+		{"*", "", true},
+	}
+	for _, tt := range tests {
+		if got := matchPattern(tt.pattern, tt.value); got != tt.want {
+			t.Errorf("matchPattern(%q, %q) = %v, want %v", tt.pattern, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestWorkloadIdentityMatches(t *testing.T) {
+	identity := WorkloadIdentity{
+		Issuer:   testWorkloadIssuer,
+		Audience: "vault-hub",
+		Subject:  "repo:my-org/app:*",
+		Claims:   BoundClaims{"repository_owner": "my-org", "protected": "true"},
+	}
+	token := func() *auth.WorkloadClaims {
+		return &auth.WorkloadClaims{
+			Issuer:   testWorkloadIssuer,
+			Subject:  "repo:my-org/app:ref:refs/heads/main",
+			Audience: []string{"other", "vault-hub"},
+			Claims:   map[string]interface{}{"repository_owner": "my-org", "protected": true},
+		}
+	}
+
+	if !identity.Matches(token()) {
+		t.Fatal("expected token to match")
+	}
+
+	wrongAudience := token()
+	wrongAudience.Audience = []string{"other"}
+	wrongSubject := token()
+	wrongSubject.Subject = "repo:my-org/other:ref:refs/heads/main"
+	wrongIssuer := token()
+	wrongIssuer.Issuer = "https://issuer.example.com"
+	wrongClaim := token()
+	wrongClaim.Claims["protected"] = false
+	missingClaim := token()
+	delete(missingClaim.Claims, "repository_owner")
+	objectClaim := token()
+	objectClaim.Claims["repository_owner"] = map[string]interface{}{"name": "my-org"}
+
+	for name, tok := range map[string]*auth.WorkloadClaims{
+		"audience":      wrongAudience,
+		"subject":       wrongSubject,
+		"issuer":        wrongIssuer,
+		"claim value":   wrongClaim,
+		"missing claim": missingClaim,
+		"object claim":  objectClaim,
+	} {
+		if identity.Matches(tok) {
+			t.Errorf("expected token with wrong %s not to match", name)
+		}
+	}
+}
+
+func TestCreateWorkloadIdentityParamsValidate(t *testing.T) {
+	valid := func() CreateWorkloadIdentityParams {
+		return CreateWorkloadIdentityParams{
+			UserID:   1,
+			Name:     "deploy",
+			Issuer:   testWorkloadIssuer,
+			Audience: "vault-hub",
+			Subject:  "repo:my-org/app:*",
+		}
+	}
+	allowed := []string{testWorkloadIssuer}
+
+	params := valid()
+	if errors := params.Validate(allowed); len(errors) > 0 {
+		t.Fatalf("expected valid params, got %v", errors)
+	}
+
+	tests := map[string]struct {
+		modify func(*CreateWorkloadIdentityParams)
+		field  string
+	}{
+		"issuer not allowed": {func(p *CreateWorkloadIdentityParams) { p.Issuer = "https://issuer.example.com" }, "issuer"},
+		"missing audience":   {func(p *CreateWorkloadIdentityParams) { p.Audience = "" }, "audience"},
+		"wildcard subject":   {func(p *CreateWorkloadIdentityParams) { p.Subject = "**" }, "subject"},
+		"sub claim":          {func(p *CreateWorkloadIdentityParams) { p.Claims = map[string]string{"sub": "x"} }, "claims"},
+		"empty pattern":      {func(p *CreateWorkloadIdentityParams) { p.Claims = map[string]string{"ref": ""} }, "claims"},
+		"short TTL":          {func(p *CreateWorkloadIdentityParams) { p.TokenTTL = time.Second }, "token_ttl"},
+		"long TTL":           {func(p *CreateWorkloadIdentityParams) { p.TokenTTL = 2 * time.Hour }, "token_ttl"},
+		"no permissions":     {func(p *CreateWorkloadIdentityParams) { p.Permissions = []APIKeyPermission{} }, "permissions"},
+	}
+	for name, tt := range tests {
+		params := valid()
+		tt.modify(&params)
+		if _, ok := params.Validate(allowed)[tt.field]; !ok {
+			t.Errorf("%s: expected %s error", name, tt.field)
+		}
+	}
+}
+
+func TestWorkloadIdentityIssueAPIKey(t *testing.T) {
+	user := createTestUser(t)
+
+	params := CreateWorkloadIdentityParams{
+		UserID:      user.ID,
+		Name:        "deploy-" + uuid.NewString(),
+		Issuer:      testWorkloadIssuer,
+		Audience:    "vault-hub",
+		Subject:     "repo:my-org/app:*",
+		Permissions: []APIKeyPermission{PermissionVaultRead},
+	}
+	identity, err := params.Create()
+	if err != nil {
+		t.Fatalf("create workload identity: %v", err)
+	}
+	if identity.TokenTTL != DefaultWorkloadTokenTTL {
+		t.Errorf("expected default TTL, got %s", identity.TokenTTL)
+	}
+
+	apiKey, plainKey, err := identity.IssueAPIKey()
+	if err != nil {
+		t.Fatalf("issue API key: %v", err)
+	}
+	if apiKey.ExpiresAt == nil || time.Until(*apiKey.ExpiresAt) > DefaultWorkloadTokenTTL {
+		t.Errorf("expected key to expire within the TTL, got %v", apiKey.ExpiresAt)
+	}
+	if !apiKey.HasPermission(PermissionVaultRead) || apiKey.HasPermission(PermissionVaultWrite) {
+		t.Errorf("expected key scoped to vault:read, got %v", apiKey.Permissions)
+	}
+	if _, err := ValidateAPIKey(plainKey); err != nil {
+		t.Fatalf("validate issued key: %v", err)
+	}
+
+	keys, total, err := GetUserAPIKeysWithPagination(user.ID, 10, 1)
+	if err != nil {
+		t.Fatalf("list API keys: %v", err)
+	}
+	if total != 0 || len(keys) != 0 {
+		t.Errorf("expected exchanged key to be hidden from API keys, got %d", total)
+	}
+
+	if err := identity.Delete(); err != nil {
+		t.Fatalf("delete workload identity: %v", err)
+	}
+	if _, err := ValidateAPIKey(plainKey); err == nil {
+		t.Error("expected exchanged key to be revoked with its identity")
+	}
+}
@@ -0,0 +1,151 @@
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lwshen/vault-hub/internal/encryption"
+	"gorm.io/gorm"
+)
+
+const (
+	// WrappingTokenPrefix marks wrapping tokens, which are redeemed without an API key
+	WrappingTokenPrefix = "vhwrap_"
+	// MinWrapTTL and MaxWrapTTL bound how long a wrapping token can be redeemed
+	MinWrapTTL = time.Second
+	MaxWrapTTL = 24 * time.Hour
+)
+
+var (
+	// ErrWrappingTokenInvalid is returned for unknown and expired wrapping tokens
+	ErrWrappingTokenInvalid = errors.New("invalid or expired wrapping token")
+	// ErrWrappingTokenUsed is returned for wrapping tokens that were already redeemed. Unless the
+	// caller redeemed it before, someone else has seen the wrapped value.
+	ErrWrappingTokenUsed = errors.New("wrapping token already used")
+)
+
+// WrappingToken holds a vault value read with response wrapping until it is redeemed once.
+// Like EmailToken only the token's hash is stored. The value is encrypted with the master key
+// and cleared when the token is redeemed.
+type WrappingToken struct {
+	ID         uint `gorm:"primarykey"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	UserID     uint       `gorm:"index;constraint:OnDelete:CASCADE"` // User who wrapped the value
+	VaultID    uint       `gorm:"index"`
+	APIKeyID   *uint      `gorm:"index"` // API key that wrapped the value
+	TokenHash  string     `gorm:"size:64;uniqueIndex"`
+	Value      string     `gorm:"type:text"`
+	ExpiresAt  time.Time  `gorm:"index"`
+	ConsumedAt *time.Time `gorm:"index"`
+}
+
+// hashWrappingToken hashes a wrapping token the way email tokens are hashed
+func hashWrappingToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// CreateWrappingToken wraps the decrypted value of a vault for the user who read it and returns
+// the token that redeems it within ttl
+func CreateWrappingToken(vault *Vault, userID uint, apiKeyID *uint, ttl time.Duration) (string, *WrappingToken, error) {
+	token, _, err := generateToken()
+	if err != nil {
+		return "", nil, err
+	}
+	token = WrappingTokenPrefix + token
+
+	value, err := encryption.Encrypt(vault.Value)
+	if err != nil {
+		return "", nil, err
+	}
+	t := &WrappingToken{
+		UserID:    userID,
+		VaultID:   vault.ID,
+		APIKeyID:  apiKeyID,
+		TokenHash: hashWrappingToken(token),
+		Value:     value,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	if err := DB.Create(t).Error; err != nil {
+		return "", nil, err
+	}
+	return token, t, nil
+}
+
+// UnwrapToken redeems a wrapping token and returns it with the wrapped value. A token can
+// only be redeemed once, even by concurrent requests.
+func UnwrapToken(plaintextToken string) (*WrappingToken, string, error) {
+	hash := hashWrappingToken(plaintextToken)
+	now := time.Now()
+
+	var t WrappingToken
+	if err := DB.Where("token_hash = ?", hash).First(&t).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, "", ErrWrappingTokenInvalid
+		}
+		return nil, "", err
+	}
+	if t.ConsumedAt != nil {
+		return nil, "", ErrWrappingTokenUsed
+	}
+	if now.After(t.ExpiresAt) {
+		return nil, "", ErrWrappingTokenInvalid
+	}
+
+	update := DB.Model(&WrappingToken{}).
+		Where("id = ? AND consumed_at IS NULL", t.ID).
+		Updates(map[string]interface{}{
+			"consumed_at": now,
+			"value":       "",
+			"updated_at":  now,
+		})
+	if update.Error != nil {
+		return nil, "", update.Error
+	}
+	if update.RowsAffected == 0 {
+		return nil, "", ErrWrappingTokenUsed
+	}
+
+	value, err := encryption.Decrypt(t.Value)
+	if err != nil {
+		return nil, "", err
+	}
+	t.ConsumedAt = &now
+	t.Value = ""
+	return &t, value, nil
+}
+
+// rotateWrappingTokens re-encrypts the values of wrapping tokens that were not redeemed yet
+// with the active master key. A token redeemed in the meantime keeps its cleared value.
+func rotateWrappingTokens() (int, error) {
+	var tokens []WrappingToken
+	if err := DB.Select("id", "value").Where("consumed_at IS NULL AND value <> ''").Find(&tokens).Error; err != nil {
+		return 0, err
+	}
+
+	for _, t := range tokens {
+		value, err := encryption.Decrypt(t.Value)
+		if err != nil {
+			return 0, fmt.Errorf("wrapping token %d: %w", t.ID, err)
+		}
+		encrypted, err := encryption.Encrypt(value)
+		if err != nil {
+			return 0, fmt.Errorf("wrapping token %d: %w", t.ID, err)
+		}
+		if err := DB.Model(&WrappingToken{}).Where("id = ? AND consumed_at IS NULL", t.ID).Update("value", encrypted).Error; err != nil {
+			return 0, fmt.Errorf("wrapping token %d: %w", t.ID, err)
+		}
+	}
+	return len(tokens), nil
+}
+
+// PruneWrappingTokens deletes the wrapping tokens that expired before the given time, along
+// with the values of those that were never redeemed
+func PruneWrappingTokens(before time.Time) (int64, error) {
+	result := DB.Where("expires_at < ?", before).Delete(&WrappingToken{})
+	return result.RowsAffected, result.Error
+}
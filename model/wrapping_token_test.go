@@ -0,0 +1,77 @@
+package model
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func TestWrappingTokenLifecycle(t *testing.T) {
+	user := createTestUser(t)
+	params := CreateVaultParams{
+		UniqueID: uuid.NewString(),
+		UserID:   user.ID,
+		Name:     "wrapped-" + uuid.NewString(),
+		Value:    "db-password",
+	}
+	vault, err := params.Create()
+	if err != nil {
+		t.Fatalf("create vault: %v", err)
+	}
+	// Members of an organization wrap vaults created by others
+	reader := createTestUser(t)
+
+	token, saved, err := CreateWrappingToken(vault, reader.ID, nil, time.Minute)
+	if err != nil {
+		t.Fatalf("wrap: %v", err)
+	}
+	if !strings.HasPrefix(token, WrappingTokenPrefix) {
+		t.Errorf("expected token prefix, got %q", token)
+	}
+	if saved.Value == "" || strings.Contains(saved.Value, "db-password") || strings.Contains(saved.TokenHash, token) {
+		t.Fatalf("expected only the encrypted value and token hash to be stored")
+	}
+
+	got, value, err := UnwrapToken(token)
+	if err != nil {
+		t.Fatalf("unwrap: %v", err)
+	}
+	if got.VaultID != vault.ID || value != "db-password" {
+		t.Fatalf("unexpected unwrap result: vault %d value %q", got.VaultID, value)
+	}
+	if got.UserID != reader.ID {
+		t.Errorf("expected the token to belong to the user who wrapped it, got %d", got.UserID)
+	}
+
+	// A second redemption means someone else saw the value
+	if _, _, err := UnwrapToken(token); !errors.Is(err, ErrWrappingTokenUsed) {
+		t.Fatalf("expected used error on second unwrap, got %v", err)
+	}
+	var stored WrappingToken
+	if err := DB.First(&stored, saved.ID).Error; err != nil || stored.Value != "" {
+		t.Fatalf("expected the value to be cleared after unwrap, got %q, %v", stored.Value, err)
+	}
+
+	// expired token
+	token2, saved2, err := CreateWrappingToken(vault, user.ID, nil, 10*time.Millisecond)
+	if err != nil {
+		t.Fatalf("wrap2: %v", err)
+	}
+	time.Sleep(15 * time.Millisecond)
+	if _, _, err := UnwrapToken(token2); !errors.Is(err, ErrWrappingTokenInvalid) {
+		t.Fatalf("expected invalid error for expired token, got %v", err)
+	}
+	if _, _, err := UnwrapToken(WrappingTokenPrefix + "unknown"); !errors.Is(err, ErrWrappingTokenInvalid) {
+		t.Fatalf("expected invalid error for unknown token, got %v", err)
+	}
+
+	if _, err := PruneWrappingTokens(time.Now()); err != nil {
+		t.Fatalf("prune: %v", err)
+	}
+	if err := DB.First(&WrappingToken{}, saved2.ID).Error; err == nil {
+		t.Fatalf("expected expired token to be pruned")
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
@@ -51,6 +52,12 @@ func convertToApiAPIKey(apiKey *model.APIKey) (*VaultAPIKey, error) {
 		lastUsedAt = apiKey.LastUsedAt
 	}
 
+	// Convert permissions
+	permissions := make([]APIKeyPermission, 0, len(apiKey.EffectivePermissions()))
+	for _, permission := range apiKey.EffectivePermissions() {
+		permissions = append(permissions, APIKeyPermission(permission))
+	}
+
 	// #nosec G115
 	id := int64(apiKey.ID)
 	return &VaultAPIKey{
@@ -62,6 +69,8 @@ func convertToApiAPIKey(apiKey *model.APIKey) (*VaultAPIKey, error) {
 		IsActive:   !apiKey.DeletedAt.Valid,
 		CreatedAt:  apiKey.CreatedAt,
 		UpdatedAt:  &apiKey.UpdatedAt,
+
+		Permissions: permissions,
 	}, nil
 }
 
@@ -145,7 +154,7 @@ func (s Server) CreateAPIKey(c *fiber.Ctx) error {
 
 	// Validate parameters
 	if err := validateCreateAPIKeyParams(params); err != nil {
-		return handler.SendError(c, fiber.StatusBadRequest, "validation failed")
+		return handler.SendError(c, fiber.StatusBadRequest, "validation failed: "+err.Error())
 	}
 
 	// Create the API key
@@ -195,10 +204,10 @@ func convertVaultUniqueIDs(vaultUniqueIds *[]string, userID uint) ([]uint, error
 	return vaultIDs, nil
 }
 
-// findVaultByUniqueID finds a vault by unique ID and user ID
+// findVaultByUniqueID finds a vault by unique ID among the vaults a user can access
 func findVaultByUniqueID(uniqueID string, userID uint) (*model.Vault, error) {
 	var vault model.Vault
-	err := model.DB.Where("unique_id = ? AND user_id = ?", uniqueID, userID).First(&vault).Error
+	err := model.DB.Scopes(model.AccessibleVaults(userID)).Where("unique_id = ?", uniqueID).First(&vault).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, fmt.Errorf("vault not found: %s", uniqueID)
@@ -220,13 +229,26 @@ func buildCreateAPIKeyParams(req CreateAPIKeyRequest, userID uint, vaultIDs []ui
 		params.ExpiresAt = req.ExpiresAt
 	}
 
+	if req.Permissions != nil {
+		params.Permissions = convertAPIKeyPermissions(*req.Permissions)
+	}
+
 	return params
 }
 
+// convertAPIKeyPermissions converts API permissions to model permissions
+func convertAPIKeyPermissions(permissions []APIKeyPermission) []model.APIKeyPermission {
+	converted := make([]model.APIKeyPermission, 0, len(permissions))
+	for _, permission := range permissions {
+		converted = append(converted, model.APIKeyPermission(permission))
+	}
+	return converted
+}
+
 // validateCreateAPIKeyParams validates the API key creation parameters
 func validateCreateAPIKeyParams(params model.CreateAPIKeyParams) error {
 	if validationErrors := params.Validate(); len(validationErrors) > 0 {
-		return fmt.Errorf("validation errors occurred")
+		return errors.New(joinValidationErrors(validationErrors))
 	}
 	return nil
 }
@@ -268,7 +290,7 @@ func (s Server) UpdateAPIKey(c *fiber.Ctx, id int64) error {
 
 	// Validate update parameters
 	if err := validateUpdateAPIKeyParams(updateParams, user.ID, apiKey.ID); err != nil {
-		return handler.SendError(c, fiber.StatusBadRequest, "validation failed")
+		return handler.SendError(c, fiber.StatusBadRequest, "validation failed: "+err.Error())
 	}
 
 	// Perform the update
@@ -327,17 +349,24 @@ func convertOptionalVaultUniqueIDs(vaultUniqueIds *[]string, userID uint) (*[]ui
 
 // buildUpdateAPIKeyParams constructs API key update parameters
 func buildUpdateAPIKeyParams(req UpdateAPIKeyRequest, vaultIDs *[]uint) model.UpdateAPIKeyParams {
-	return model.UpdateAPIKeyParams{
+	params := model.UpdateAPIKeyParams{
 		Name:      req.Name,
 		VaultIDs:  vaultIDs,
 		ExpiresAt: req.ExpiresAt,
 	}
+
+	if req.Permissions != nil {
+		permissions := convertAPIKeyPermissions(*req.Permissions)
+		params.Permissions = &permissions
+	}
+
+	return params
 }
 
 // validateUpdateAPIKeyParams validates the API key update parameters
 func validateUpdateAPIKeyParams(params model.UpdateAPIKeyParams, userID uint, apiKeyID uint) error {
 	if validationErrors := params.ValidateForUpdate(userID, apiKeyID); len(validationErrors) > 0 {
-		return fmt.Errorf("validation errors occurred")
+		return errors.New(joinValidationErrors(validationErrors))
 	}
 	return nil
 }
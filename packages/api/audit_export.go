@@ -0,0 +1,158 @@
+package api
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/model"
+)
+
+// auditExportColumns is the header row of CSV exports, in the order of auditLogCSVRow
+var auditExportColumns = []string{
+	"id", "createdAt", "userId", "userEmail", "action", "source",
+	"vaultUniqueId", "vaultName", "apiKeyId", "apiKeyName",
+	"organizationId", "memberRole", "ipAddress", "userAgent", "prevHash", "hash",
+}
+
+// ExportAuditLogs streams the authenticated user's audit logs matching the filters as CSV
+// or JSON lines, oldest first
+func (Server) ExportAuditLogs(c *fiber.Ctx, params ExportAuditLogsParams) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var contentType, extension string
+	switch params.Format {
+	case Csv:
+		contentType, extension = "text/csv; charset=utf-8", "csv"
+	case Jsonl:
+		contentType, extension = "application/x-ndjson", "jsonl"
+	default:
+		return handler.SendError(c, fiber.StatusBadRequest, "format must be csv or jsonl")
+	}
+
+	var source *model.SourceType
+	if params.Source != nil {
+		sourceType := model.SourceType(*params.Source)
+		source = &sourceType
+	}
+
+	filterParams, err := buildAuditLogFilters(user.ID, params.VaultUniqueId, source, params.StartDate, params.EndDate)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	// Organization-wide logs are only visible to admins and owners
+	if params.OrganizationId != nil {
+		membership, err := requireMembership(c, *params.OrganizationId, user.ID, model.RoleAdmin)
+		if err != nil || membership == nil {
+			return err
+		}
+		filterParams.OrganizationID = &membership.OrganizationID
+	}
+
+	filename := fmt.Sprintf("audit-logs-%s.%s", time.Now().UTC().Format("20060102T150405Z"), extension)
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", filename))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+
+	userID := user.ID
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// Headers are already sent, so a failure can only end the response early
+		if err := writeAuditLogExport(w, params.Format, filterParams); err != nil {
+			slog.Error("Failed to export audit logs", "error", err, "userID", userID)
+		}
+	})
+	return nil
+}
+
+// writeAuditLogExport writes every audit log matching the filters in the given format
+func writeAuditLogExport(w *bufio.Writer, format ExportAuditLogsParamsFormat, filterParams model.GetAuditLogsWithFiltersParams) error {
+	var csvWriter *csv.Writer
+	if format == Csv {
+		csvWriter = csv.NewWriter(w)
+		if err := csvWriter.Write(auditExportColumns); err != nil {
+			return err
+		}
+	}
+	encoder := json.NewEncoder(w)
+
+	err := model.ExportAuditLogs(filterParams, func(logs []model.AuditLog) error {
+		for i := range logs {
+			record := logs[i].Record()
+			if csvWriter != nil {
+				if err := csvWriter.Write(auditLogCSVRow(record)); err != nil {
+					return err
+				}
+			} else if err := encoder.Encode(record); err != nil {
+				return err
+			}
+		}
+
+		// Flush each batch so the client receives the export while it is being read
+		if csvWriter != nil {
+			csvWriter.Flush()
+			if err := csvWriter.Error(); err != nil {
+				return err
+			}
+		}
+		return w.Flush()
+	})
+	if err != nil {
+		return err
+	}
+	return w.Flush()
+}
+
+// auditLogCSVRow returns the record's fields in the order of auditExportColumns
+func auditLogCSVRow(r model.AuditLogRecord) []string {
+	return []string{
+		strconv.FormatUint(uint64(r.ID), 10),
+		r.CreatedAt.UTC().Format(time.RFC3339Nano),
+		strconv.FormatUint(uint64(r.UserID), 10),
+		csvSafe(r.UserEmail),
+		r.Action,
+		r.Source,
+		r.VaultUniqueID,
+		csvSafe(r.VaultName),
+		optionalID(r.APIKeyID),
+		csvSafe(r.APIKeyName),
+		optionalID(r.OrganizationID),
+		r.MemberRole,
+		r.IPAddress,
+		csvSafe(r.UserAgent),
+		optionalString(r.PrevHash),
+		optionalString(r.Hash),
+	}
+}
+
+// csvSafe prefixes user-controlled values that spreadsheets would evaluate as formulas
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+func optionalID(id *uint) string {
+	if id == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*id), 10)
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -2,6 +2,8 @@ package api
 
 import (
 	"fmt"
+	"log/slog"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/lwshen/vault-hub/handler"
@@ -22,7 +24,9 @@ func convertToApiAuditLog(auditLog *model.AuditLog) AuditLog {
 		apiKey = apiKeyLocal
 	}
 
-	return AuditLog{
+	// #nosec G115
+	userID := int64(auditLog.UserID)
+	apiLog := AuditLog{
 		Action:    AuditLogAction(auditLog.Action),
 		CreatedAt: auditLog.CreatedAt,
 		Vault:     vault,
@@ -30,7 +34,18 @@ func convertToApiAuditLog(auditLog *model.AuditLog) AuditLog {
 		Source:    AuditLogSource(auditLog.Source),
 		IpAddress: &auditLog.IPAddress,
 		UserAgent: &auditLog.UserAgent,
+		UserId:    userID,
+
+		OrganizationId: organizationIDPtr(auditLog.OrganizationID),
+	}
+	if auditLog.User.Email != "" {
+		apiLog.UserEmail = &auditLog.User.Email
+	}
+	if auditLog.MemberRole != "" {
+		memberRole := string(auditLog.MemberRole)
+		apiLog.MemberRole = &memberRole
 	}
+	return apiLog
 }
 
 // GetAuditLogs retrieves filtered and paginated audit logs for the authenticated user
@@ -53,6 +68,15 @@ func (Server) GetAuditLogs(c *fiber.Ctx, params GetAuditLogsParams) error {
 		return err
 	}
 
+	// Organization-wide logs are only visible to admins and owners
+	if params.OrganizationId != nil {
+		membership, err := requireMembership(c, *params.OrganizationId, user.ID, model.RoleAdmin)
+		if err != nil || membership == nil {
+			return err
+		}
+		filterParams.OrganizationID = &membership.OrganizationID
+	}
+
 	// Fetch audit logs and total count
 	logs, totalCount, err := fetchAuditLogsWithCount(filterParams)
 	if err != nil {
@@ -78,15 +102,6 @@ func validateAuditLogParams(params GetAuditLogsParams) error {
 
 // buildAuditLogFilterParams constructs filter parameters for audit log queries
 func buildAuditLogFilterParams(params GetAuditLogsParams, userID uint) (model.GetAuditLogsWithFiltersParams, error) {
-	// Convert pageIndex from 1-based to 0-based for offset calculation
-	offset := (params.PageIndex - 1) * params.PageSize
-
-	// Resolve vault ID if vault unique ID is provided
-	vaultID, err := resolveVaultID(params.VaultUniqueId, userID)
-	if err != nil {
-		return model.GetAuditLogsWithFiltersParams{}, err
-	}
-
 	// Convert source parameter to model type
 	var source *model.SourceType
 	if params.Source != nil {
@@ -94,14 +109,31 @@ func buildAuditLogFilterParams(params GetAuditLogsParams, userID uint) (model.Ge
 		source = &sourceType
 	}
 
+	filterParams, err := buildAuditLogFilters(userID, params.VaultUniqueId, source, params.StartDate, params.EndDate)
+	if err != nil {
+		return model.GetAuditLogsWithFiltersParams{}, err
+	}
+
+	// Convert pageIndex from 1-based to 0-based for offset calculation
+	filterParams.Limit = params.PageSize
+	filterParams.Offset = (params.PageIndex - 1) * params.PageSize
+	return filterParams, nil
+}
+
+// buildAuditLogFilters constructs the filters shared by listing and exporting audit logs
+func buildAuditLogFilters(userID uint, vaultUniqueId *string, source *model.SourceType, startDate, endDate *time.Time) (model.GetAuditLogsWithFiltersParams, error) {
+	// Resolve vault ID if vault unique ID is provided
+	vaultID, err := resolveVaultID(vaultUniqueId, userID)
+	if err != nil {
+		return model.GetAuditLogsWithFiltersParams{}, err
+	}
+
 	return model.GetAuditLogsWithFiltersParams{
 		UserID:    userID,
 		VaultID:   vaultID,
 		Source:    source,
-		StartDate: params.StartDate,
-		EndDate:   params.EndDate,
-		Limit:     params.PageSize,
-		Offset:    offset,
+		StartDate: startDate,
+		EndDate:   endDate,
 	}, nil
 }
 
@@ -178,3 +210,31 @@ func (Server) GetAuditMetrics(c *fiber.Ctx) error {
 
 	return c.Status(fiber.StatusOK).JSON(response)
 }
+
+// VerifyAuditLogs verifies the hash chain of the authenticated user's audit logs
+func (Server) VerifyAuditLogs(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	report, err := model.VerifyAuditChain(user.ID)
+	if err != nil {
+		slog.Error("Failed to verify audit chain", "error", err, "userID", user.ID)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to verify audit logs")
+	}
+
+	response := AuditChainVerification{
+		Valid:           report.Valid(),
+		VerifiedEntries: report.Verified,
+		LastHash:        report.LastHash,
+	}
+	if !report.Valid() {
+		// #nosec G115
+		brokenID := int64(*report.BrokenID)
+		response.BrokenEntryId = &brokenID
+		response.Reason = &report.Reason
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/auth"
 	"github.com/lwshen/vault-hub/internal/email"
 	"github.com/lwshen/vault-hub/model"
 	openapi_types "github.com/oapi-codegen/runtime/types"
@@ -21,6 +22,7 @@ const (
 	PasswordResetTTL  = 30 * time.Minute
 	MagicLinkTTL      = 15 * time.Minute
 	EmailSendCooldown = time.Minute
+	LoginChallengeTTL = 5 * time.Minute
 
 	emailTokenCodeSent        = "email_token_sent"
 	emailTokenCodeRateLimited = "email_token_rate_limited"
@@ -52,29 +54,74 @@ func (Server) Login(c *fiber.Ctx) error {
 
 	clientIP, userAgent := getClientInfo(c)
 
+	if ok, err := checkLoginAllowed(c, email); !ok {
+		return err
+	}
+
 	user := model.User{
 		Email: email,
 	}
-	if err := user.GetByEmail(); err != nil {
-		return handler.SendError(c, fiber.StatusBadRequest, "Invalid email or password")
+	var known *model.User
+	if err := user.GetByEmail(); err == nil {
+		known = &user
 	}
 
-	if !user.ComparePassword(input.Password) {
+	// Users without a local password are checked against the directory when LDAP is enabled
+	switch {
+	case known != nil && user.Password != nil:
+		if !user.ComparePassword(input.Password) {
+			recordLoginFailure(c, email, known, model.ActionLoginFailed, clientIP, userAgent)
+			return handler.SendError(c, fiber.StatusBadRequest, "Invalid email or password")
+		}
+	case auth.LDAP() != nil:
+		ldapUser, err := authenticateLDAP(email, input.Password)
+		if errors.Is(err, auth.ErrLDAPInvalidCredentials) {
+			recordLoginFailure(c, email, known, model.ActionLoginFailed, clientIP, userAgent)
+			return handler.SendError(c, fiber.StatusBadRequest, "Invalid email or password")
+		} else if err != nil {
+			slog.Error("LDAP login failed", "error", err)
+			return handler.SendError(c, fiber.StatusServiceUnavailable, "directory unavailable")
+		}
+		user = *ldapUser
+	default:
+		recordLoginFailure(c, email, known, model.ActionLoginFailed, clientIP, userAgent)
 		return handler.SendError(c, fiber.StatusBadRequest, "Invalid email or password")
 	}
 
-	token, err := user.GenerateToken()
+	// Users with two-factor authentication complete the login at /api/auth/login/2fa. LDAP users
+	// cannot enroll a second factor, so directory logins always complete here.
+	if user.TwoFactorEnabled() {
+		challengeToken, challenge, err := model.CreateLoginChallenge(user.ID, LoginChallengeTTL)
+		if err != nil {
+			return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		return c.Status(fiber.StatusOK).JSON(LoginResponse{
+			TwoFactorRequired:  true,
+			ChallengeToken:     &challengeToken,
+			ChallengeExpiresAt: &challenge.ExpiresAt,
+		})
+	}
+
+	return completeLogin(c, &user, model.ActionLoginUser, clientIP, userAgent)
+}
+
+// completeLogin starts a session for a successful login and records it as action
+func completeLogin(c *fiber.Ctx, user *model.User, action model.ActionType, clientIP, userAgent string) error {
+	_, tokens, err := model.CreateSession(user.ID, clientIP, userAgent)
 	if err != nil {
 		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
 	}
+	resetLoginFailures(c, user.Email)
 
 	// Record successful login audit log
-	if err := model.LogUserAction(model.ActionLoginUser, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+	if err := model.LogUserAction(action, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
 		slog.Error("Failed to create audit log for login", "error", err, "userID", user.ID)
 	}
 
 	resp := LoginResponse{
-		Token: token,
+		Token:        &tokens.AccessToken,
+		RefreshToken: &tokens.RefreshToken,
+		ExpiresAt:    &tokens.AccessTokenExpiresAt,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
@@ -131,14 +178,16 @@ func (Server) Signup(c *fiber.Ctx) error {
 		_ = model.LogUserAction(model.ActionSendSignupEmail, u.ID, model.SourceWeb, clientIP, userAgent)
 	}(user)
 
-	// Generate authentication token
-	token, err := user.GenerateToken()
+	// Start a session for the new user
+	_, tokens, err := model.CreateSession(user.ID, clientIP, userAgent)
 	if err != nil {
 		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
 	}
 
 	resp := SignupResponse{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.AccessTokenExpiresAt,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(resp)
@@ -202,6 +251,12 @@ func (Server) Logout(c *fiber.Ctx) error {
 	// If there is no authentication information, this should not prevent logout operation
 	user, ok := c.Locals("user").(*model.User)
 	if ok && user != nil {
+		if session := getSessionFromContext(c); session != nil {
+			if err := session.Revoke(); err != nil {
+				return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+			}
+		}
+
 		clientIP, userAgent := getClientInfo(c)
 		if err := model.LogUserAction(model.ActionLogoutUser, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
 			slog.Error("Failed to create audit log for logout", "error", err, "userID", user.ID)
@@ -213,6 +268,30 @@ func (Server) Logout(c *fiber.Ctx) error {
 	})
 }
 
+// RefreshToken exchanges a refresh token for a new access token and rotates the refresh token
+func (Server) RefreshToken(c *fiber.Ctx) error {
+	var input RefreshTokenRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+
+	_, tokens, err := model.RefreshSession(input.RefreshToken, clientIP, userAgent)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidRefreshToken) {
+			return handler.SendError(c, fiber.StatusUnauthorized, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(TokenResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		ExpiresAt:    tokens.AccessTokenExpiresAt,
+	})
+}
+
 func getEmail(email openapi_types.Email) (string, error) {
 	return string(email), nil
 }
@@ -315,6 +394,11 @@ func (Server) ConfirmPasswordReset(c *fiber.Ctx) error {
 		return handler.SendError(c, fiber.StatusInternalServerError, "failed to update password")
 	}
 
+	// Whoever knew the old password may still be logged in somewhere
+	if _, err := model.RevokeUserSessions(user.ID, nil); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
 	// Log password reset audit
 	if err := model.LogUserAction(model.ActionPasswordReset, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
 		slog.Error("Failed to create audit log for password reset", "error", err, "userID", user.ID)
@@ -421,7 +505,8 @@ func (Server) ConsumeMagicLink(c *fiber.Ctx, params ConsumeMagicLinkParams) erro
 		}
 		return c.SendStatus(fiber.StatusInternalServerError)
 	}
-	jwtToken, err := user.GenerateToken()
+	clientIP, userAgent := getClientInfo(c)
+	_, tokens, err := model.CreateSession(user.ID, clientIP, userAgent)
 	if err != nil {
 		if acceptsJSON {
 			return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
@@ -431,14 +516,17 @@ func (Server) ConsumeMagicLink(c *fiber.Ctx, params ConsumeMagicLinkParams) erro
 		}
 		return c.SendStatus(fiber.StatusInternalServerError)
 	}
-	redirectFragment := "/login#token=" + url.QueryEscape(jwtToken) + "&source=magic"
+	redirectFragment := "/login#token=" + url.QueryEscape(tokens.AccessToken) +
+		"&refreshToken=" + url.QueryEscape(tokens.RefreshToken) + "&source=magic"
 
 	if acceptsJSON {
 		return c.JSON(fiber.Map{
-			"token":       jwtToken,
-			"redirectUrl": fmt.Sprintf("%s/dashboard", c.BaseURL()),
-			"code":        emailTokenCodeSent,
-			"success":     true,
+			"token":        tokens.AccessToken,
+			"refreshToken": tokens.RefreshToken,
+			"expiresAt":    tokens.AccessTokenExpiresAt,
+			"redirectUrl":  fmt.Sprintf("%s/dashboard", c.BaseURL()),
+			"code":         emailTokenCodeSent,
+			"success":      true,
 		})
 	}
 
@@ -506,6 +594,15 @@ func (Server) ChangePassword(c *fiber.Ctx) error {
 		return handler.SendError(c, fiber.StatusInternalServerError, "failed to update password")
 	}
 
+	// Log out everywhere else; the session that changed the password stays logged in
+	var currentSessionID *uint
+	if session := getSessionFromContext(c); session != nil {
+		currentSessionID = &session.ID
+	}
+	if _, err := model.RevokeUserSessions(fullUser.ID, currentSessionID); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
 	// Audit log
 	clientIP, userAgent := getClientInfo(c)
 	if err := model.LogUserAction(model.ActionChangePassword, fullUser.ID, model.SourceWeb, clientIP, userAgent); err != nil {
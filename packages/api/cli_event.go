@@ -0,0 +1,187 @@
+package api
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/model"
+)
+
+const (
+	// vaultEventHeartbeat is how often a comment is sent on an idle stream, which also
+	// detects closed connections and re-checks the API key
+	vaultEventHeartbeat = 15 * time.Second
+	// vaultEventReplayBatch is the number of missed events loaded at a time when resuming
+	vaultEventReplayBatch = 500
+)
+
+// StreamVaultEvents - Stream vault change events for a given API key as Server-Sent Events
+func (s Server) StreamVaultEvents(c *fiber.Ctx, params StreamVaultEventsParams) error {
+	apiKey, ok := c.Locals("api_key").(*model.APIKey)
+	if !ok {
+		return handler.SendError(c, fiber.StatusUnauthorized, "API key not found in context")
+	}
+
+	if ok, err := requireAPIKeyPermission(c, apiKey, model.PermissionVaultList); !ok {
+		return err
+	}
+
+	// The header sent by EventSource on reconnect takes precedence over the query parameter
+	var lastEventID *int64
+	if header := c.Get("Last-Event-ID"); header != "" {
+		id, err := strconv.ParseInt(header, 10, 64)
+		if err != nil {
+			return handler.SendError(c, fiber.StatusBadRequest, "invalid Last-Event-ID header")
+		}
+		lastEventID = &id
+	} else {
+		lastEventID = params.LastEventId
+	}
+	if lastEventID != nil && *lastEventID < 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, "last event ID cannot be negative")
+	}
+
+	// Subscribe before replaying so no event falls between the replay and the live stream
+	events, unsubscribe := model.SubscribeVaultEvents()
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	c.Set("X-Accel-Buffering", "no")
+
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		defer unsubscribe()
+		stream := vaultEventStream{w: w, apiKey: apiKey, sent: map[uint]bool{}}
+		if err := stream.run(events, lastEventID); err != nil {
+			slog.Debug("Vault event stream closed", "error", err, "apiKeyID", apiKey.ID)
+		}
+	})
+	return nil
+}
+
+// vaultEventStream writes the vault events an API key may see to one client
+type vaultEventStream struct {
+	w      *bufio.Writer
+	apiKey *model.APIKey
+	// IDs of the replayed events, which may be received again from the live stream
+	sent map[uint]bool
+}
+
+// run replays the events after lastEventID, if given, then forwards live events until
+// the client disconnects, the API key stops being valid or the subscription is dropped
+func (s *vaultEventStream) run(events <-chan model.VaultEvent, lastEventID *int64) error {
+	// Ask clients to wait a few seconds before reconnecting
+	if err := s.write("retry: 5000\n\n"); err != nil {
+		return err
+	}
+
+	if lastEventID != nil {
+		// #nosec G115
+		if err := s.replay(uint(*lastEventID)); err != nil {
+			return err
+		}
+	}
+
+	heartbeat := time.NewTicker(vaultEventHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// Dropped for falling behind; the client resumes from its last event
+				return fmt.Errorf("subscriber fell behind")
+			}
+			if s.sent[event.ID] {
+				continue
+			}
+			if err := s.send(event); err != nil {
+				return err
+			}
+		case <-heartbeat.C:
+			apiKey, err := model.GetActiveAPIKey(s.apiKey.ID)
+			if err != nil {
+				return fmt.Errorf("API key is no longer valid: %w", err)
+			}
+			if !apiKey.HasPermission(model.PermissionVaultList) {
+				return fmt.Errorf("API key no longer has the %s permission", model.PermissionVaultList)
+			}
+			s.apiKey = apiKey
+			if err := s.write(": heartbeat\n\n"); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// replay sends the events recorded after the given event ID
+func (s *vaultEventStream) replay(afterID uint) error {
+	for {
+		events, err := model.GetVaultEventsAfter(afterID, vaultEventReplayBatch)
+		if err != nil {
+			return fmt.Errorf("failed to load missed events: %w", err)
+		}
+		for _, event := range events {
+			if err := s.send(event); err != nil {
+				return err
+			}
+			s.sent[event.ID] = true
+			afterID = event.ID
+		}
+		if len(events) < vaultEventReplayBatch {
+			return nil
+		}
+	}
+}
+
+// send writes an event if the API key may see it
+func (s *vaultEventStream) send(event model.VaultEvent) error {
+	if !s.apiKey.CanReceiveVaultEvent(event) {
+		return nil
+	}
+
+	data, err := json.Marshal(convertToApiVaultEvent(event))
+	if err != nil {
+		return err
+	}
+	eventType := vaultEventType(event.Action)
+	return s.write(fmt.Sprintf("id: %d\nevent: %s\ndata: %s\n\n", event.ID, eventType, data))
+}
+
+// write writes to the client and flushes, failing once the client has disconnected
+func (s *vaultEventStream) write(message string) error {
+	if _, err := s.w.WriteString(message); err != nil {
+		return err
+	}
+	return s.w.Flush()
+}
+
+// vaultEventType maps an audit action to the type of the event it is broadcast as
+func vaultEventType(action model.ActionType) VaultEventType {
+	switch action {
+	case model.ActionCreateVault:
+		return VaultCreated
+	case model.ActionDeleteVault:
+		return VaultDeleted
+	default:
+		return VaultUpdated
+	}
+}
+
+func convertToApiVaultEvent(event model.VaultEvent) VaultEvent {
+	return VaultEvent{
+		// #nosec G115
+		Id:             int64(event.ID),
+		Type:           vaultEventType(event.Action),
+		UniqueId:       event.VaultUniqueID,
+		Name:           event.VaultName,
+		OrganizationId: organizationIDPtr(event.OrganizationID),
+		CreatedAt:      event.CreatedAt,
+	}
+}
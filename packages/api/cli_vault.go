@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/lwshen/vault-hub/handler"
@@ -27,6 +29,10 @@ func (s Server) GetVaultsByAPIKey(c *fiber.Ctx) error {
 		return handler.SendError(c, fiber.StatusUnauthorized, "API key not found in context")
 	}
 
+	if ok, err := requireAPIKeyPermission(c, apiKey, model.PermissionVaultList); !ok {
+		return err
+	}
+
 	// Get all accessible vaults for this API key (encrypted)
 	vaults, err := apiKey.GetAccessibleVaults()
 	if err != nil {
@@ -34,17 +40,19 @@ func (s Server) GetVaultsByAPIKey(c *fiber.Ctx) error {
 		return handler.SendError(c, fiber.StatusInternalServerError, "failed to retrieve vaults")
 	}
 
-	// Convert to API VaultLite format (no decryption needed)
+	// Convert to API VaultLite format (no decryption needed), without the vault type that
+	// released CLIs do not know
 	apiVaults := make([]VaultLite, len(vaults))
 	for i, vault := range vaults {
 		apiVaults[i] = convertToApiVaultLite(&vault)
+		apiVaults[i].Type = nil
 	}
 
 	return c.Status(fiber.StatusOK).JSON(apiVaults)
 }
 
 // GetVaultByAPIKey - Get a single vault by unique ID for a given API key
-func (s Server) GetVaultByAPIKey(c *fiber.Ctx, uniqueId string) error {
+func (s Server) GetVaultByAPIKey(c *fiber.Ctx, uniqueId string, params GetVaultByAPIKeyParams) error {
 	// Read X-Enable-Client-Encryption header directly
 	headerValue := c.Get(constants.HeaderClientEncryption)
 	var enableClientEncryptionParam *string
@@ -52,7 +60,7 @@ func (s Server) GetVaultByAPIKey(c *fiber.Ctx, uniqueId string) error {
 		enableClientEncryptionParam = &headerValue
 	}
 
-	return s.getVaultByAPIKey(c, uniqueId, enableClientEncryptionParam, func(apiKey *model.APIKey) (*model.Vault, error) {
+	return s.getVaultByAPIKey(c, uniqueId, enableClientEncryptionParam, params.WrapTtl, func(apiKey *model.APIKey) (*model.Vault, error) {
 		var vault model.Vault
 		err := vault.GetByUniqueID(uniqueId, apiKey.UserID)
 		return &vault, err
@@ -60,7 +68,7 @@ func (s Server) GetVaultByAPIKey(c *fiber.Ctx, uniqueId string) error {
 }
 
 // GetVaultByNameAPIKey - Get a single vault by name for a given API key
-func (s Server) GetVaultByNameAPIKey(c *fiber.Ctx, name string) error {
+func (s Server) GetVaultByNameAPIKey(c *fiber.Ctx, name string, params GetVaultByNameAPIKeyParams) error {
 	// Read X-Enable-Client-Encryption header directly
 	headerValue := c.Get(constants.HeaderClientEncryption)
 	var enableClientEncryptionParam *string
@@ -68,20 +76,33 @@ func (s Server) GetVaultByNameAPIKey(c *fiber.Ctx, name string) error {
 		enableClientEncryptionParam = &headerValue
 	}
 
-	return s.getVaultByAPIKey(c, name, enableClientEncryptionParam, func(apiKey *model.APIKey) (*model.Vault, error) {
+	return s.getVaultByAPIKey(c, name, enableClientEncryptionParam, params.WrapTtl, func(apiKey *model.APIKey) (*model.Vault, error) {
 		var vault model.Vault
 		err := vault.GetByName(name, apiKey.UserID)
 		return &vault, err
 	})
 }
 
-// getVaultByAPIKey - Common logic for getting a vault via API key
-func (s Server) getVaultByAPIKey(c *fiber.Ctx, encryptSalt string, enableClientEncryptionParam *string, vaultGetter func(*model.APIKey) (*model.Vault, error)) error {
+// getVaultByAPIKey - Common logic for getting a vault via API key. With a wrap TTL the value
+// is wrapped and only a token to redeem it is returned.
+func (s Server) getVaultByAPIKey(c *fiber.Ctx, encryptSalt string, enableClientEncryptionParam *string, wrapTTLParam *string, vaultGetter func(*model.APIKey) (*model.Vault, error)) error {
 	apiKey, ok := c.Locals("api_key").(*model.APIKey)
 	if !ok {
 		return handler.SendError(c, fiber.StatusUnauthorized, "API key not found in context")
 	}
 
+	if ok, err := requireAPIKeyPermission(c, apiKey, model.PermissionVaultRead); !ok {
+		return err
+	}
+
+	var wrapTTL time.Duration
+	if wrapTTLParam != nil {
+		var err error
+		if wrapTTL, err = parseWrapTTL(*wrapTTLParam); err != nil {
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+	}
+
 	// Get the vault using the provided getter function
 	vault, err := vaultGetter(apiKey)
 	if err != nil {
@@ -97,10 +118,23 @@ func (s Server) getVaultByAPIKey(c *fiber.Ctx, encryptSalt string, enableClientE
 		return handler.SendError(c, fiber.StatusForbidden, "API key does not have access to this vault")
 	}
 
-	// Log read action (using the API key user ID)
-	ip, userAgent := getClientInfo(c)
-	if err := model.LogVaultAction(vault.ID, model.ActionReadVault, apiKey.UserID, model.SourceCLI, &apiKey.ID, ip, userAgent); err != nil {
-		slog.Error("Failed to create audit log for read vault", "error", err, "vaultID", vault.ID)
+	// Reads of database vaults hand out new credentials instead of the stored config
+	if vault.IsDynamic() {
+		if ok, err := issueDatabaseCredentials(c, vault, apiKey); !ok {
+			return err
+		}
+	}
+
+	if wrapTTLParam != nil {
+		return wrapVault(c, vault, apiKey, wrapTTL)
+	}
+
+	// Log read action (using the API key user ID); issuing credentials was logged already
+	if !vault.IsDynamic() {
+		ip, userAgent := getClientInfo(c)
+		if err := model.LogVaultAction(vault.ID, model.ActionReadVault, apiKey.UserID, model.SourceCLI, &apiKey.ID, ip, userAgent); err != nil {
+			slog.Error("Failed to create audit log for read vault", "error", err, "vaultID", vault.ID)
+		}
 	}
 
 	// Enhanced security: Apply additional client-side encryption if requested
@@ -135,7 +169,7 @@ func (s Server) getVaultByAPIKey(c *fiber.Ctx, encryptSalt string, enableClientE
 		}
 	}
 
-	return c.Status(fiber.StatusOK).JSON(convertToApiVault(vault))
+	return c.Status(fiber.StatusOK).JSON(convertToApiKeyVault(vault))
 }
 
 // encryptForClientWithDerivedKey encrypts the vault value using a key derived from the API key
@@ -223,6 +257,15 @@ func getAPIKeyFromContext(c *fiber.Ctx) (*model.APIKey, error) {
 	return apiKey, nil
 }
 
+// requireAPIKeyPermission verifies that the API key is allowed to perform an operation.
+// It returns false after writing a 403 response naming the missing permission.
+func requireAPIKeyPermission(c *fiber.Ctx, apiKey *model.APIKey, permission model.APIKeyPermission) (bool, error) {
+	if apiKey.HasPermission(permission) {
+		return true, nil
+	}
+	return false, handler.SendError(c, fiber.StatusForbidden, fmt.Sprintf("API key does not have the %s permission", permission))
+}
+
 // getVaultForAPIKey retrieves a vault by unique ID and verifies API key access
 func getVaultForAPIKey(c *fiber.Ctx, uniqueId string, apiKey *model.APIKey) (*model.Vault, error) {
 	var vault model.Vault
@@ -269,8 +312,13 @@ func (s Server) UpdateVaultByAPIKey(c *fiber.Ctx, uniqueId string) error {
 		return err
 	}
 
+	if ok, err := requireAPIKeyPermission(c, apiKey, model.PermissionVaultWrite); !ok {
+		return err
+	}
+
+	// getVaultForAPIKey has already written the error response when vault is nil
 	vault, err := getVaultForAPIKey(c, uniqueId, apiKey)
-	if err != nil {
+	if err != nil || vault == nil {
 		return err
 	}
 
@@ -284,6 +332,10 @@ func (s Server) UpdateVaultByNameAPIKey(c *fiber.Ctx, name string) error {
 		return err
 	}
 
+	if ok, err := requireAPIKeyPermission(c, apiKey, model.PermissionVaultWrite); !ok {
+		return err
+	}
+
 	var vault model.Vault
 	if err := vault.GetByName(name, apiKey.UserID); err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -302,6 +354,11 @@ func (s Server) UpdateVaultByNameAPIKey(c *fiber.Ctx, name string) error {
 
 // updateVaultByAPIKeyCommon contains the shared update logic for API key vault updates
 func (s Server) updateVaultByAPIKeyCommon(c *fiber.Ctx, vault *model.Vault, apiKey *model.APIKey, encryptSalt string) error {
+	// API keys act with the organization role of their owner
+	if ok, err := checkVaultPermission(c, vault, apiKey.UserID, model.VaultPermissionWrite); !ok {
+		return err
+	}
+
 	var input UpdateVaultRequest
 	if err := c.BodyParser(&input); err != nil {
 		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
@@ -321,9 +378,24 @@ func (s Server) updateVaultByAPIKeyCommon(c *fiber.Ctx, vault *model.Vault, apiK
 		Description: input.Description,
 		Category:    input.Category,
 		Favourite:   input.Favourite,
+		Author:      model.VersionAuthor{UserID: apiKey.UserID, APIKeyID: &apiKey.ID, Source: model.SourceCLI},
+	}
+	if input.SetKeys != nil {
+		updateParams.SetKeys = make(map[string]string, len(*input.SetKeys))
+		for key, value := range *input.SetKeys {
+			decryptedValue, err := decryptClientValueIfNeeded(c, &value, encryptSalt, vault.ID, enableClientEncryption)
+			if err != nil {
+				return err
+			}
+			updateParams.SetKeys[key] = *decryptedValue
+		}
+	}
+	if input.DeleteKeys != nil {
+		updateParams.DeleteKeys = *input.DeleteKeys
 	}
 
 	validationErrors := updateParams.Validate()
+	maps.Copy(validationErrors, vault.ValidateUpdate(&updateParams))
 	if len(validationErrors) > 0 {
 		var errorMsgs []string
 		for _, msg := range validationErrors {
@@ -342,6 +414,9 @@ func (s Server) updateVaultByAPIKeyCommon(c *fiber.Ctx, vault *model.Vault, apiK
 		slog.Error("Failed to create audit log for update vault", "error", err, "vaultID", vault.ID)
 	}
 
+	// The config of a database vault holds the admin password and is only returned redacted
+	vault.Value = vault.DisplayValue()
+
 	// Re-encrypt the response value if client-side encryption is enabled
 	if enableClientEncryption {
 		originalAPIKey, err := extractBearerToken(c)
@@ -357,5 +432,5 @@ func (s Server) updateVaultByAPIKeyCommon(c *fiber.Ctx, vault *model.Vault, apiK
 		vault.Value = encryptedValue
 	}
 
-	return c.Status(fiber.StatusOK).JSON(convertToApiVault(vault))
+	return c.Status(fiber.StatusOK).JSON(convertToApiKeyVault(vault))
 }
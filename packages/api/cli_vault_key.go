@@ -0,0 +1,180 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/constants"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/gorm"
+)
+
+// GetVaultKeyByAPIKey - Get one key of a kv vault by unique ID for a given API key
+func (s Server) GetVaultKeyByAPIKey(c *fiber.Ctx, uniqueId string, key string) error {
+	return s.getVaultKeyByAPIKey(c, key, uniqueId, func(apiKey *model.APIKey) (*model.Vault, error) {
+		var vault model.Vault
+		err := vault.GetByUniqueID(uniqueId, apiKey.UserID)
+		return &vault, err
+	})
+}
+
+// GetVaultKeyByNameAPIKey - Get one key of a kv vault by name for a given API key
+func (s Server) GetVaultKeyByNameAPIKey(c *fiber.Ctx, name string, key string) error {
+	return s.getVaultKeyByAPIKey(c, key, name, func(apiKey *model.APIKey) (*model.Vault, error) {
+		var vault model.Vault
+		err := vault.GetByName(name, apiKey.UserID)
+		return &vault, err
+	})
+}
+
+// SetVaultKeyByAPIKey - Set one key of a kv vault by unique ID for a given API key
+func (s Server) SetVaultKeyByAPIKey(c *fiber.Ctx, uniqueId string, key string) error {
+	return s.setVaultKeyByAPIKey(c, key, uniqueId, func(apiKey *model.APIKey) (*model.Vault, error) {
+		var vault model.Vault
+		err := vault.GetByUniqueID(uniqueId, apiKey.UserID)
+		return &vault, err
+	})
+}
+
+// SetVaultKeyByNameAPIKey - Set one key of a kv vault by name for a given API key
+func (s Server) SetVaultKeyByNameAPIKey(c *fiber.Ctx, name string, key string) error {
+	return s.setVaultKeyByAPIKey(c, key, name, func(apiKey *model.APIKey) (*model.Vault, error) {
+		var vault model.Vault
+		err := vault.GetByName(name, apiKey.UserID)
+		return &vault, err
+	})
+}
+
+// getVaultKeyByAPIKey - Common logic for reading one key of a kv vault via API key
+func (s Server) getVaultKeyByAPIKey(c *fiber.Ctx, key string, encryptSalt string, vaultGetter func(*model.APIKey) (*model.Vault, error)) error {
+	apiKey, err := getAPIKeyFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := requireAPIKeyPermission(c, apiKey, model.PermissionVaultRead); !ok {
+		return err
+	}
+
+	// getKVVaultForAPIKey has already written the error response when vault is nil
+	vault, err := getKVVaultForAPIKey(c, apiKey, vaultGetter)
+	if err != nil || vault == nil {
+		return err
+	}
+
+	value, err := vault.GetKey(key)
+	if err != nil {
+		if errors.Is(err, model.ErrVaultKeyNotFound) {
+			return handler.SendError(c, fiber.StatusNotFound, "key not found")
+		}
+		slog.Error("Failed to read vault key", "error", err, "vaultID", vault.ID)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to read key")
+	}
+
+	if c.Get(constants.HeaderClientEncryption) == "true" {
+		originalAPIKey, err := extractBearerToken(c)
+		if err != nil {
+			slog.Error("Invalid Authorization header format for client-side encryption", "vaultID", vault.ID)
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+		if value, err = encryptForClientWithDerivedKey(value, originalAPIKey, encryptSalt); err != nil {
+			slog.Error("Failed to encrypt vault key for client", "error", err, "vaultID", vault.ID)
+			return handler.SendError(c, fiber.StatusInternalServerError, "failed to encrypt value for client")
+		}
+	}
+
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionReadVault, apiKey.UserID, model.SourceCLI, &apiKey.ID, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for read vault", "error", err, "vaultID", vault.ID)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(VaultKeyValue{Key: key, Value: value})
+}
+
+// setVaultKeyByAPIKey - Common logic for setting one key of a kv vault via API key. The
+// other keys are kept as they are.
+func (s Server) setVaultKeyByAPIKey(c *fiber.Ctx, key string, encryptSalt string, vaultGetter func(*model.APIKey) (*model.Vault, error)) error {
+	apiKey, err := getAPIKeyFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := requireAPIKeyPermission(c, apiKey, model.PermissionVaultWrite); !ok {
+		return err
+	}
+
+	vault, err := getKVVaultForAPIKey(c, apiKey, vaultGetter)
+	if err != nil || vault == nil {
+		return err
+	}
+
+	// API keys act with the organization role of their owner
+	if ok, err := checkVaultPermission(c, vault, apiKey.UserID, model.VaultPermissionWrite); !ok {
+		return err
+	}
+
+	var input SetVaultKeyRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	enableClientEncryption := c.Get(constants.HeaderClientEncryption) == "true"
+	value, err := decryptClientValueIfNeeded(c, &input.Value, encryptSalt, vault.ID, enableClientEncryption)
+	if err != nil {
+		return err
+	}
+
+	updateParams := model.UpdateVaultParams{
+		SetKeys: map[string]string{key: *value},
+		Author:  model.VersionAuthor{UserID: apiKey.UserID, APIKeyID: &apiKey.ID, Source: model.SourceCLI},
+	}
+
+	validationErrors := updateParams.Validate()
+	maps.Copy(validationErrors, vault.ValidateUpdate(&updateParams))
+	if len(validationErrors) > 0 {
+		var errorMsgs []string
+		for _, msg := range validationErrors {
+			errorMsgs = append(errorMsgs, msg)
+		}
+		return handler.SendError(c, fiber.StatusBadRequest, strings.Join(errorMsgs, "; "))
+	}
+
+	if err := vault.Update(&updateParams); err != nil {
+		slog.Error("Failed to update vault key", "error", err, "vaultID", vault.ID)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to update vault")
+	}
+
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionUpdateVault, apiKey.UserID, model.SourceCLI, &apiKey.ID, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for update vault", "error", err, "vaultID", vault.ID)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// getKVVaultForAPIKey retrieves a vault with the getter and verifies that the API key can access
+// it and that it is a kv vault
+func getKVVaultForAPIKey(c *fiber.Ctx, apiKey *model.APIKey, vaultGetter func(*model.APIKey) (*model.Vault, error)) (*model.Vault, error) {
+	vault, err := vaultGetter(apiKey)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, handler.SendError(c, fiber.StatusNotFound, "vault not found")
+		}
+		slog.Error("Failed to get vault", "error", err)
+		return nil, handler.SendError(c, fiber.StatusInternalServerError, "failed to retrieve vault")
+	}
+
+	if !apiKey.HasVaultAccess(vault.ID) {
+		return nil, handler.SendError(c, fiber.StatusForbidden, "API key does not have access to this vault")
+	}
+
+	if vault.Type != model.VaultTypeKV {
+		return nil, handler.SendError(c, fiber.StatusBadRequest, "vault is not a kv vault")
+	}
+
+	return vault, nil
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/internal/auth"
 	"github.com/lwshen/vault-hub/internal/config"
 )
 
@@ -11,9 +12,18 @@ import (
 // This endpoint performs NO database operations and is safe for public access
 func (s Server) GetConfig(ctx *fiber.Ctx) error {
 	resp := ConfigResponse{
-		OidcEnabled:  config.OidcEnabled,
-		EmailEnabled: config.EmailEnabled,
-		DemoEnabled:  config.DemoEnabled,
+		OidcEnabled:     config.OidcEnabled,
+		OidcProviders:   []OidcProvider{},
+		EmailEnabled:    config.EmailEnabled,
+		DemoEnabled:     config.DemoEnabled,
+		WebauthnEnabled: config.WebAuthnEnabled,
+	}
+	for _, provider := range auth.OIDCProviders() {
+		resp.OidcProviders = append(resp.OidcProviders, OidcProvider{
+			Name:        provider.Name,
+			DisplayName: provider.DisplayName,
+			LoginUrl:    provider.LoginPath(),
+		})
 	}
 
 	return ctx.
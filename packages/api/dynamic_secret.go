@@ -0,0 +1,34 @@
+package api
+
+import (
+	"encoding/json"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/dynamic"
+	"github.com/lwshen/vault-hub/model"
+)
+
+// issueDatabaseCredentials issues a database user for an API key read of a database vault
+// and replaces the vault's value with its credentials. It writes an error response and
+// returns false if the database could not issue them.
+func issueDatabaseCredentials(c *fiber.Ctx, vault *model.Vault, apiKey *model.APIKey) (bool, error) {
+	credentials, err := dynamic.Issue(c.Context(), vault, apiKey.UserID, &apiKey.ID)
+	if err != nil {
+		slog.Error("Failed to issue database credentials", "error", err, "vaultID", vault.ID)
+		return false, handler.SendError(c, fiber.StatusBadGateway, "failed to issue database credentials: "+err.Error())
+	}
+
+	value, err := json.Marshal(credentials)
+	if err != nil {
+		return false, handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	vault.Value = string(value)
+
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionIssueDatabaseCredentials, apiKey.UserID, model.SourceCLI, &apiKey.ID, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for issue database credentials", "error", err, "vaultID", vault.ID)
+	}
+	return true, nil
+}
@@ -5,7 +5,9 @@ package api
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"time"
 
@@ -18,30 +20,70 @@ const (
 	ApiKeyAuthScopes = "ApiKeyAuth.Scopes"
 )
 
+// Defines values for APIKeyPermission.
+const (
+	APIKeyPermissionVaultList  APIKeyPermission = "vault:list"
+	APIKeyPermissionVaultRead  APIKeyPermission = "vault:read"
+	APIKeyPermissionVaultWrite APIKeyPermission = "vault:write"
+)
+
 // Defines values for AuditLogAction.
 const (
-	ChangePassword       AuditLogAction = "change_password"
-	CreateApiKey         AuditLogAction = "create_api_key"
-	CreateVault          AuditLogAction = "create_vault"
-	DeleteApiKey         AuditLogAction = "delete_api_key"
-	DeleteVault          AuditLogAction = "delete_vault"
-	LoginUser            AuditLogAction = "login_user"
-	LogoutUser           AuditLogAction = "logout_user"
-	MagicLinkLogin       AuditLogAction = "magic_link_login"
-	PasswordReset        AuditLogAction = "password_reset"
-	ReadVault            AuditLogAction = "read_vault"
-	RegisterUser         AuditLogAction = "register_user"
-	RequestMagicLink     AuditLogAction = "request_magic_link"
-	RequestPasswordReset AuditLogAction = "request_password_reset"
-	SendSignupEmail      AuditLogAction = "send_signup_email"
-	UpdateApiKey         AuditLogAction = "update_api_key"
-	UpdateVault          AuditLogAction = "update_vault"
+	AccountLocked             AuditLogAction = "account_locked"
+	AddOrganizationMember     AuditLogAction = "add_organization_member"
+	ChangePassword            AuditLogAction = "change_password"
+	CreateApiKey              AuditLogAction = "create_api_key"
+	CreateOrganization        AuditLogAction = "create_organization"
+	CreateShare               AuditLogAction = "create_share"
+	CreateVault               AuditLogAction = "create_vault"
+	CreateWorkloadIdentity    AuditLogAction = "create_workload_identity"
+	DeleteApiKey              AuditLogAction = "delete_api_key"
+	DeleteCredential          AuditLogAction = "delete_credential"
+	DeleteOrganization        AuditLogAction = "delete_organization"
+	DeleteRotationPolicy      AuditLogAction = "delete_rotation_policy"
+	DeleteVault               AuditLogAction = "delete_vault"
+	DeleteWorkloadIdentity    AuditLogAction = "delete_workload_identity"
+	DisableTwoFactor          AuditLogAction = "disable_two_factor"
+	EnableTwoFactor           AuditLogAction = "enable_two_factor"
+	IssueDatabaseCredentials  AuditLogAction = "issue_database_credentials"
+	LoginFailed               AuditLogAction = "login_failed"
+	LoginUser                 AuditLogAction = "login_user"
+	LogoutUser                AuditLogAction = "logout_user"
+	MagicLinkLogin            AuditLogAction = "magic_link_login"
+	PasswordReset             AuditLogAction = "password_reset"
+	ReadVault                 AuditLogAction = "read_vault"
+	RegenerateRecoveryCodes   AuditLogAction = "regenerate_recovery_codes"
+	RegisterCredential        AuditLogAction = "register_credential"
+	RegisterUser              AuditLogAction = "register_user"
+	RemoveOrganizationMember  AuditLogAction = "remove_organization_member"
+	RequestMagicLink          AuditLogAction = "request_magic_link"
+	RequestPasswordReset      AuditLogAction = "request_password_reset"
+	RestoreVaultVersion       AuditLogAction = "restore_vault_version"
+	RevokeAllSessions         AuditLogAction = "revoke_all_sessions"
+	RevokeDatabaseCredentials AuditLogAction = "revoke_database_credentials"
+	RevokeSession             AuditLogAction = "revoke_session"
+	RotateVault               AuditLogAction = "rotate_vault"
+	RotateVaultFailed         AuditLogAction = "rotate_vault_failed"
+	SendSignupEmail           AuditLogAction = "send_signup_email"
+	SetRotationPolicy         AuditLogAction = "set_rotation_policy"
+	TokenExchange             AuditLogAction = "token_exchange"
+	TwoFactorFailed           AuditLogAction = "two_factor_failed"
+	UnwrapVault               AuditLogAction = "unwrap_vault"
+	UpdateApiKey              AuditLogAction = "update_api_key"
+	UpdateOrganization        AuditLogAction = "update_organization"
+	UpdateOrganizationMember  AuditLogAction = "update_organization_member"
+	UpdateVault               AuditLogAction = "update_vault"
+	UseRecoveryCode           AuditLogAction = "use_recovery_code"
+	ViewShare                 AuditLogAction = "view_share"
+	WebauthnLogin             AuditLogAction = "webauthn_login"
+	WrapVault                 AuditLogAction = "wrap_vault"
 )
 
 // Defines values for AuditLogSource.
 const (
-	AuditLogSourceCli AuditLogSource = "cli"
-	AuditLogSourceWeb AuditLogSource = "web"
+	AuditLogSourceCli    AuditLogSource = "cli"
+	AuditLogSourceSystem AuditLogSource = "system"
+	AuditLogSourceWeb    AuditLogSource = "web"
 )
 
 // Defines values for EmailTokenResponseCode.
@@ -51,6 +93,33 @@ const (
 	EmailTokenSent        EmailTokenResponseCode = "email_token_sent"
 )
 
+// Defines values for OrganizationRole.
+const (
+	Admin  OrganizationRole = "admin"
+	Member OrganizationRole = "member"
+	Owner  OrganizationRole = "owner"
+	Viewer OrganizationRole = "viewer"
+)
+
+// Defines values for RotationPolicyLastStatus.
+const (
+	RotationPolicyLastStatusFailed    RotationPolicyLastStatus = "failed"
+	RotationPolicyLastStatusRetrying  RotationPolicyLastStatus = "retrying"
+	RotationPolicyLastStatusSucceeded RotationPolicyLastStatus = "succeeded"
+)
+
+// Defines values for RotationPolicyStrategy.
+const (
+	RotationPolicyStrategyHttp   RotationPolicyStrategy = "http"
+	RotationPolicyStrategyRandom RotationPolicyStrategy = "random"
+)
+
+// Defines values for SetRotationPolicyRequestStrategy.
+const (
+	SetRotationPolicyRequestStrategyHttp   SetRotationPolicyRequestStrategy = "http"
+	SetRotationPolicyRequestStrategyRandom SetRotationPolicyRequestStrategy = "random"
+)
+
 // Defines values for StatusResponseDatabaseStatus.
 const (
 	StatusResponseDatabaseStatusDegraded    StatusResponseDatabaseStatus = "degraded"
@@ -65,12 +134,69 @@ const (
 	StatusResponseSystemStatusUnavailable StatusResponseSystemStatus = "unavailable"
 )
 
+// Defines values for VaultEventType.
+const (
+	VaultCreated VaultEventType = "vault.created"
+	VaultDeleted VaultEventType = "vault.deleted"
+	VaultUpdated VaultEventType = "vault.updated"
+)
+
+// Defines values for VaultType.
+const (
+	Database VaultType = "database"
+	Kv       VaultType = "kv"
+	Static   VaultType = "static"
+)
+
+// Defines values for VaultVersionSource.
+const (
+	VaultVersionSourceCli    VaultVersionSource = "cli"
+	VaultVersionSourceSystem VaultVersionSource = "system"
+	VaultVersionSourceWeb    VaultVersionSource = "web"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+)
+
+// Defines values for WebhookEventType.
+const (
+	WebhookEventTypeApiKeyCreated WebhookEventType = "api_key.created"
+	WebhookEventTypeApiKeyDeleted WebhookEventType = "api_key.deleted"
+	WebhookEventTypeApiKeyUpdated WebhookEventType = "api_key.updated"
+	WebhookEventTypeVaultCreated  WebhookEventType = "vault.created"
+	WebhookEventTypeVaultDeleted  WebhookEventType = "vault.deleted"
+	WebhookEventTypeVaultRead     WebhookEventType = "vault.read"
+	WebhookEventTypeVaultUpdated  WebhookEventType = "vault.updated"
+)
+
 // Defines values for GetAuditLogsParamsSource.
 const (
-	GetAuditLogsParamsSourceCli GetAuditLogsParamsSource = "cli"
-	GetAuditLogsParamsSourceWeb GetAuditLogsParamsSource = "web"
+	GetAuditLogsParamsSourceCli    GetAuditLogsParamsSource = "cli"
+	GetAuditLogsParamsSourceSystem GetAuditLogsParamsSource = "system"
+	GetAuditLogsParamsSourceWeb    GetAuditLogsParamsSource = "web"
+)
+
+// Defines values for ExportAuditLogsParamsFormat.
+const (
+	Csv   ExportAuditLogsParamsFormat = "csv"
+	Jsonl ExportAuditLogsParamsFormat = "jsonl"
+)
+
+// Defines values for ExportAuditLogsParamsSource.
+const (
+	ExportAuditLogsParamsSourceCli    ExportAuditLogsParamsSource = "cli"
+	ExportAuditLogsParamsSourceSystem ExportAuditLogsParamsSource = "system"
+	ExportAuditLogsParamsSourceWeb    ExportAuditLogsParamsSource = "web"
 )
 
+// APIKeyPermission Operation an API key may perform. vault:read reads vault values, vault:write updates them
+// and vault:list lists the vaults the key can access.
+type APIKeyPermission string
+
 // APIKeysResponse defines model for APIKeysResponse.
 type APIKeysResponse struct {
 	ApiKeys []VaultAPIKey `json:"apiKeys"`
@@ -85,6 +211,35 @@ type APIKeysResponse struct {
 	TotalCount int `json:"totalCount"`
 }
 
+// AddOrganizationMemberRequest defines model for AddOrganizationMemberRequest.
+type AddOrganizationMemberRequest struct {
+	// Email Email of a registered user
+	Email openapi_types.Email `json:"email"`
+
+	// Role Role of a member. Viewers can read vaults, members can also create and update them,
+	// admins can also delete vaults and manage members, and owners can also manage owners
+	// and delete the organization.
+	Role OrganizationRole `json:"role"`
+}
+
+// AuditChainVerification defines model for AuditChainVerification.
+type AuditChainVerification struct {
+	// BrokenEntryId ID of the first broken entry
+	BrokenEntryId *int64 `json:"brokenEntryId,omitempty"`
+
+	// LastHash Hash of the last verified entry. Entries removed from the end of the chain can only be detected by comparing it with a previously saved value.
+	LastHash string `json:"lastHash"`
+
+	// Reason Why the entry is broken
+	Reason *string `json:"reason,omitempty"`
+
+	// Valid Whether every entry of the chain verified
+	Valid bool `json:"valid"`
+
+	// VerifiedEntries Number of entries verified before the first broken link, or all of them
+	VerifiedEntries int `json:"verifiedEntries"`
+}
+
 // AuditLog defines model for AuditLog.
 type AuditLog struct {
 	// Action Type of action performed
@@ -97,18 +252,30 @@ type AuditLog struct {
 	// IpAddress IP address from which the action was performed
 	IpAddress *string `json:"ipAddress,omitempty"`
 
-	// Source Source of the request (web interface or CLI)
+	// MemberRole Role the acting user held in the organization at the time of the action
+	MemberRole *string `json:"memberRole,omitempty"`
+
+	// OrganizationId ID of the organization the action happened in, if any
+	OrganizationId *int64 `json:"organizationId,omitempty"`
+
+	// Source Source of the request (web interface, CLI, or the server itself for scheduled actions)
 	Source AuditLogSource `json:"source"`
 
 	// UserAgent User agent string from the client
-	UserAgent *string    `json:"userAgent,omitempty"`
-	Vault     *VaultLite `json:"vault,omitempty"`
+	UserAgent *string `json:"userAgent,omitempty"`
+
+	// UserEmail Email of the user who performed the action
+	UserEmail *string `json:"userEmail,omitempty"`
+
+	// UserId ID of the user who performed the action
+	UserId int64      `json:"userId"`
+	Vault  *VaultLite `json:"vault,omitempty"`
 }
 
 // AuditLogAction Type of action performed
 type AuditLogAction string
 
-// AuditLogSource Source of the request (web interface or CLI)
+// AuditLogSource Source of the request (web interface, CLI, or the server itself for scheduled actions)
 type AuditLogSource string
 
 // AuditLogsResponse defines model for AuditLogsResponse.
@@ -159,6 +326,12 @@ type ConfigResponse struct {
 
 	// OidcEnabled Whether OIDC authentication is enabled
 	OidcEnabled bool `json:"oidcEnabled"`
+
+	// OidcProviders Identity providers users can log in with, in configuration order
+	OidcProviders []OidcProvider `json:"oidcProviders"`
+
+	// WebauthnEnabled Whether passkey (WebAuthn) login is enabled
+	WebauthnEnabled bool `json:"webauthnEnabled"`
 }
 
 // CreateAPIKeyRequest defines model for CreateAPIKeyRequest.
@@ -169,6 +342,9 @@ type CreateAPIKeyRequest struct {
 	// Name Human-readable name for the API key
 	Name string `json:"name"`
 
+	// Permissions Operations this key is allowed to perform (omitted = all permissions)
+	Permissions *[]APIKeyPermission `json:"permissions,omitempty"`
+
 	// VaultUniqueIds Array of vault unique IDs this key can access (empty = all user's vaults)
 	VaultUniqueIds *[]string `json:"vaultUniqueIds,omitempty"`
 }
@@ -181,22 +357,132 @@ type CreateAPIKeyResponse struct {
 	Key string `json:"key"`
 }
 
+// CreateOrganizationRequest defines model for CreateOrganizationRequest.
+type CreateOrganizationRequest struct {
+	// Description Human-readable description
+	Description *string `json:"description,omitempty"`
+
+	// Name Human-readable name
+	Name string `json:"name"`
+}
+
+// CreateShareRequest Either vaultUniqueId or value must be set
+type CreateShareRequest struct {
+	// ExpiresIn Seconds until the share expires (default 86400)
+	ExpiresIn *int `json:"expiresIn,omitempty"`
+
+	// MaxViews Number of times the secret can be viewed (default 1)
+	MaxViews *int `json:"maxViews,omitempty"`
+
+	// Value Ad-hoc secret to share
+	Value *string `json:"value,omitempty"`
+
+	// VaultUniqueId Vault whose current value is shared
+	VaultUniqueId *string `json:"vaultUniqueId,omitempty"`
+}
+
+// CreateShareResponse defines model for CreateShareResponse.
+type CreateShareResponse struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// Id Share ID
+	Id       string `json:"id"`
+	MaxViews int    `json:"maxViews"`
+
+	// Url Link to give to the recipient, with the decryption key in its fragment
+	Url string `json:"url"`
+}
+
 // CreateVaultRequest defines model for CreateVaultRequest.
 type CreateVaultRequest struct {
 	// Category Category/type of vault
 	Category *string `json:"category,omitempty"`
 
+	// Data Keys and values of a kv vault, instead of value; implies the kv type
+	Data *map[string]string `json:"data,omitempty"`
+
 	// Description Human-readable description
 	Description *string `json:"description,omitempty"`
 
+	// ExpiresAt Rotation deadline of the value; defaults to now plus rotationInterval
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
 	// Favourite Favourite flag
 	Favourite *bool `json:"favourite,omitempty"`
 
 	// Name Human-readable name
 	Name string `json:"name"`
 
-	// Value Value to be encrypted and stored
-	Value string `json:"value"`
+	// OrganizationId Create the vault in this organization instead of as a personal vault (requires member role or higher)
+	OrganizationId *int64 `json:"organizationId,omitempty"`
+
+	// RotationInterval Rotation schedule in seconds (at least 3600); every new value is due this long after it is written
+	RotationInterval *int64 `json:"rotationInterval,omitempty"`
+
+	// Type What the value holds. Static vaults store a secret; kv vaults store named secrets as a JSON object of strings, readable and writable one key at a time; database vaults store a database config and issue short-lived database credentials on API key reads. Absent for static vaults and in responses to API keys.
+	Type *VaultType `json:"type,omitempty"`
+
+	// Value Value to be encrypted and stored; for kv vaults a JSON object of strings, for database vaults a JSON database config. Required unless data is set.
+	Value *string `json:"value,omitempty"`
+}
+
+// CreateWebhookRequest defines model for CreateWebhookRequest.
+type CreateWebhookRequest struct {
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Events Events to send (omitted or empty = all events)
+	Events *[]WebhookEventType `json:"events,omitempty"`
+	Name   string              `json:"name"`
+
+	// Url Absolute http or https URL the events are posted to
+	Url string `json:"url"`
+
+	// VaultUniqueId Send the events of this vault instead of those of your own actions
+	VaultUniqueId *string `json:"vaultUniqueId,omitempty"`
+}
+
+// CreateWebhookResponse defines model for CreateWebhookResponse.
+type CreateWebhookResponse struct {
+	// Secret Secret used to sign payloads (only shown once). Each delivery has an X-VaultHub-Signature header of the form t=<unix seconds>,v1=<hex>, where v1 is the HMAC-SHA256 of "<t>.<raw body>" keyed with this secret.
+	Secret  string  `json:"secret"`
+	Webhook Webhook `json:"webhook"`
+}
+
+// CreateWorkloadIdentityRequest defines model for CreateWorkloadIdentityRequest.
+type CreateWorkloadIdentityRequest struct {
+	// Audience Audience the workload requests its token for
+	Audience string `json:"audience"`
+
+	// Claims Patterns other token claims must match, e.g. {"repository_owner": "my-org"}
+	Claims *map[string]string `json:"claims,omitempty"`
+
+	// Issuer One of the issuers allowed on this server, e.g. https://token.actions.githubusercontent.com
+	Issuer string `json:"issuer"`
+	Name   string `json:"name"`
+
+	// Permissions Operations the exchanged API keys are allowed to perform (omitted = all permissions)
+	Permissions *[]APIKeyPermission `json:"permissions,omitempty"`
+
+	// Subject Pattern the token subject must match, where * matches any characters, e.g. repo:my-org/my-repo:ref:refs/heads/main. It cannot be only wildcards.
+	Subject string `json:"subject"`
+
+	// TokenTtl Lifetime of the exchanged API keys in seconds (default 900)
+	TokenTtl *int `json:"tokenTtl,omitempty"`
+
+	// VaultUniqueIds Vaults the exchanged API keys can access (empty = all user's vaults)
+	VaultUniqueIds *[]string `json:"vaultUniqueIds,omitempty"`
+}
+
+// DisableTwoFactorRequest defines model for DisableTwoFactorRequest.
+type DisableTwoFactorRequest struct {
+	// Code Code from the authenticator app
+	Code *string `json:"code,omitempty"`
+
+	// Password Current password for verification
+	Password string `json:"password"`
+
+	// RecoveryCode One-time recovery code, used instead of a code from the authenticator app
+	RecoveryCode *string `json:"recoveryCode,omitempty"`
 }
 
 // EmailTokenResponse defines model for EmailTokenResponse.
@@ -211,6 +497,12 @@ type EmailTokenResponse struct {
 // EmailTokenResponseCode Machine-readable status code describing the outcome
 type EmailTokenResponseCode string
 
+// EnableTOTPRequest defines model for EnableTOTPRequest.
+type EnableTOTPRequest struct {
+	// Code Current code from the authenticator app
+	Code string `json:"code"`
+}
+
 // GetUserResponse defines model for GetUserResponse.
 type GetUserResponse struct {
 	Avatar *string             `json:"avatar,omitempty"`
@@ -232,7 +524,34 @@ type LoginRequest struct {
 
 // LoginResponse defines model for LoginResponse.
 type LoginResponse struct {
-	Token string `json:"token"`
+	ChallengeExpiresAt *time.Time `json:"challengeExpiresAt,omitempty"`
+
+	// ChallengeToken Token to complete the login with at /api/auth/login/2fa
+	ChallengeToken *string `json:"challengeToken,omitempty"`
+
+	// ExpiresAt When the access token expires
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// RefreshToken Refresh token to renew the access token at /api/auth/refresh
+	RefreshToken *string `json:"refreshToken,omitempty"`
+
+	// Token Short-lived JWT access token of the session; absent when a second factor is required
+	Token *string `json:"token,omitempty"`
+
+	// TwoFactorRequired Whether the login must be completed with a two-factor code
+	TwoFactorRequired bool `json:"twoFactorRequired"`
+}
+
+// LoginTwoFactorRequest defines model for LoginTwoFactorRequest.
+type LoginTwoFactorRequest struct {
+	// ChallengeToken Challenge token returned by /api/auth/login
+	ChallengeToken string `json:"challengeToken"`
+
+	// Code Code from the authenticator app
+	Code *string `json:"code,omitempty"`
+
+	// RecoveryCode One-time recovery code, used instead of a code from the authenticator app
+	RecoveryCode *string `json:"recoveryCode,omitempty"`
 }
 
 // MagicLinkRequest defines model for MagicLinkRequest.
@@ -240,6 +559,70 @@ type MagicLinkRequest struct {
 	Email openapi_types.Email `json:"email"`
 }
 
+// OidcProvider defines model for OidcProvider.
+type OidcProvider struct {
+	// DisplayName Name to show on the login button
+	DisplayName string `json:"displayName"`
+
+	// LoginUrl Path that starts a login with the provider
+	LoginUrl string `json:"loginUrl"`
+
+	// Name Provider name used in the login and callback paths
+	Name string `json:"name"`
+}
+
+// Organization defines model for Organization.
+type Organization struct {
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+
+	// Description Human-readable description
+	Description *string `json:"description,omitempty"`
+
+	// Id Unique organization ID
+	Id int64 `json:"id"`
+
+	// Name Human-readable name
+	Name string `json:"name"`
+
+	// Role Role of a member. Viewers can read vaults, members can also create and update them,
+	// admins can also delete vaults and manage members, and owners can also manage owners
+	// and delete the organization.
+	Role      OrganizationRole `json:"role"`
+	UpdatedAt *time.Time       `json:"updatedAt,omitempty"`
+}
+
+// OrganizationMember defines model for OrganizationMember.
+type OrganizationMember struct {
+	// CreatedAt When the user joined the organization
+	CreatedAt *time.Time          `json:"createdAt,omitempty"`
+	Email     openapi_types.Email `json:"email"`
+	Name      *string             `json:"name,omitempty"`
+
+	// Role Role of a member. Viewers can read vaults, members can also create and update them,
+	// admins can also delete vaults and manage members, and owners can also manage owners
+	// and delete the organization.
+	Role OrganizationRole `json:"role"`
+
+	// UserId ID of the member
+	UserId int64 `json:"userId"`
+}
+
+// OrganizationMembersResponse defines model for OrganizationMembersResponse.
+type OrganizationMembersResponse struct {
+	Members []OrganizationMember `json:"members"`
+}
+
+// OrganizationRole Role of a member. Viewers can read vaults, members can also create and update them,
+// admins can also delete vaults and manage members, and owners can also manage owners
+// and delete the organization.
+type OrganizationRole string
+
+// OrganizationsResponse defines model for OrganizationsResponse.
+type OrganizationsResponse struct {
+	// Organizations Organizations the current user is a member of
+	Organizations []Organization `json:"organizations"`
+}
+
 // PasswordResetConfirmRequest defines model for PasswordResetConfirmRequest.
 type PasswordResetConfirmRequest struct {
 	NewPassword string `json:"newPassword"`
@@ -251,6 +634,137 @@ type PasswordResetRequest struct {
 	Email openapi_types.Email `json:"email"`
 }
 
+// RecoveryCodesResponse defines model for RecoveryCodesResponse.
+type RecoveryCodesResponse struct {
+	// RecoveryCodes One-time recovery codes; they are shown only once
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// RefreshTokenRequest defines model for RefreshTokenRequest.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// RegenerateRecoveryCodesRequest defines model for RegenerateRecoveryCodesRequest.
+type RegenerateRecoveryCodesRequest struct {
+	// Code Current code from the authenticator app
+	Code string `json:"code"`
+}
+
+// RevokeSessionsResponse defines model for RevokeSessionsResponse.
+type RevokeSessionsResponse struct {
+	// Revoked Number of sessions revoked
+	Revoked int64 `json:"revoked"`
+}
+
+// RotationPolicy defines model for RotationPolicy.
+type RotationPolicy struct {
+	// Attempts Failed attempts since the last success
+	Attempts int `json:"attempts"`
+
+	// Charset Characters generated values are drawn from (random strategy)
+	Charset *string `json:"charset,omitempty"`
+
+	// Enabled Whether the vault is rotated when it reaches its rotation deadline
+	Enabled bool `json:"enabled"`
+
+	// Interval Rotation schedule in seconds, the vault's rotation interval
+	Interval      int64      `json:"interval"`
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty"`
+
+	// LastError Why the last attempt failed
+	LastError *string `json:"lastError,omitempty"`
+
+	// LastRotatedAt When the vault was last rotated
+	LastRotatedAt *time.Time `json:"lastRotatedAt,omitempty"`
+
+	// LastStatus Outcome of the last rotation; failed means it ran out of attempts
+	LastStatus *RotationPolicyLastStatus `json:"lastStatus,omitempty"`
+
+	// Length Length of generated values (random strategy)
+	Length *int `json:"length,omitempty"`
+
+	// NextAttemptAt When a failed rotation is tried again
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	// Secret Secret used to sign requests to the rotation endpoint (only shown once, when the first http policy is set). Requests carry an X-VaultHub-Signature header like webhook deliveries.
+	Secret *string `json:"secret,omitempty"`
+
+	// Strategy How new values are produced, generated at random or returned by a rotation endpoint
+	Strategy RotationPolicyStrategy `json:"strategy"`
+
+	// Url Rotation endpoint (http strategy)
+	Url *string `json:"url,omitempty"`
+}
+
+// RotationPolicyLastStatus Outcome of the last rotation; failed means it ran out of attempts
+type RotationPolicyLastStatus string
+
+// RotationPolicyStrategy How new values are produced, generated at random or returned by a rotation endpoint
+type RotationPolicyStrategy string
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Current Whether this is the session of the request
+	Current bool `json:"current"`
+
+	// ExpiresAt When the session ends unless it is refreshed
+	ExpiresAt time.Time `json:"expiresAt"`
+	Id        string    `json:"id"`
+
+	// IpAddress IP address of the last login or refresh
+	IpAddress *string `json:"ipAddress,omitempty"`
+
+	// LastUsedAt When the session was last logged in or refreshed
+	LastUsedAt time.Time `json:"lastUsedAt"`
+
+	// UserAgent User agent of the last login or refresh
+	UserAgent *string `json:"userAgent,omitempty"`
+}
+
+// SessionsResponse defines model for SessionsResponse.
+type SessionsResponse struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// SetRotationPolicyRequest defines model for SetRotationPolicyRequest.
+type SetRotationPolicyRequest struct {
+	// Charset Characters to draw generated values from (random strategy, default letters and digits)
+	Charset *string `json:"charset,omitempty"`
+	Enabled *bool   `json:"enabled,omitempty"`
+
+	// Interval Rotation schedule in seconds; becomes the vault's rotation interval
+	Interval int64 `json:"interval"`
+
+	// Length Length of generated values (random strategy, default 32)
+	Length   *int                             `json:"length,omitempty"`
+	Strategy SetRotationPolicyRequestStrategy `json:"strategy"`
+
+	// Url Absolute http or https URL of the rotation endpoint (http strategy). It receives a signed POST identifying the vault and must respond with {"value": "<new value>"}.
+	Url *string `json:"url,omitempty"`
+}
+
+// SetRotationPolicyRequestStrategy defines model for SetRotationPolicyRequest.Strategy.
+type SetRotationPolicyRequestStrategy string
+
+// SetVaultKeyRequest defines model for SetVaultKeyRequest.
+type SetVaultKeyRequest struct {
+	// Value New value of the key
+	Value string `json:"value"`
+}
+
+// SharedSecret defines model for SharedSecret.
+type SharedSecret struct {
+	// Ciphertext Base64 of the 12-byte nonce followed by the AES-256-GCM ciphertext and tag. Decrypt it with the base64url key from the URL fragment and the share ID as additional data.
+	Ciphertext string    `json:"ciphertext"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+
+	// RemainingViews Views left after this one; 0 means the share is gone
+	RemainingViews int `json:"remainingViews"`
+}
+
 // SignupRequest defines model for SignupRequest.
 type SignupRequest struct {
 	Email    openapi_types.Email `json:"email"`
@@ -260,7 +774,9 @@ type SignupRequest struct {
 
 // SignupResponse defines model for SignupResponse.
 type SignupResponse struct {
-	Token string `json:"token"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+	RefreshToken string    `json:"refreshToken"`
+	Token        string    `json:"token"`
 }
 
 // StatusResponse defines model for StatusResponse.
@@ -284,6 +800,61 @@ type StatusResponseDatabaseStatus string
 // StatusResponseSystemStatus System operational status
 type StatusResponseSystemStatus string
 
+// TOTPSetupResponse defines model for TOTPSetupResponse.
+type TOTPSetupResponse struct {
+	// ProvisioningUri otpauth:// URI to render as a QR code for authenticator apps
+	ProvisioningUri string `json:"provisioningUri"`
+
+	// Secret Base32 shared secret for manual entry
+	Secret string `json:"secret"`
+}
+
+// TokenExchangeRequest defines model for TokenExchangeRequest.
+type TokenExchangeRequest struct {
+	// IdentityId Workload identity to use when the token matches several
+	IdentityId *int64 `json:"identityId,omitempty"`
+
+	// Token OIDC token (JWT) issued to the workload
+	Token string `json:"token"`
+}
+
+// TokenExchangeResponse defines model for TokenExchangeResponse.
+type TokenExchangeResponse struct {
+	// ApiKey Short-lived API key for the /api/cli endpoints
+	ApiKey    string    `json:"apiKey"`
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// IdentityId Workload identity the token matched
+	IdentityId int64 `json:"identityId"`
+}
+
+// TokenResponse defines model for TokenResponse.
+type TokenResponse struct {
+	// ExpiresAt When the access token expires
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// RefreshToken Replacement refresh token; the one that was sent can no longer be used
+	RefreshToken string `json:"refreshToken"`
+
+	// Token Short-lived JWT access token
+	Token string `json:"token"`
+}
+
+// TwoFactorStatusResponse defines model for TwoFactorStatusResponse.
+type TwoFactorStatusResponse struct {
+	Enabled   bool       `json:"enabled"`
+	EnabledAt *time.Time `json:"enabledAt,omitempty"`
+
+	// RecoveryCodesRemaining Number of recovery codes that have not been used
+	RecoveryCodesRemaining int `json:"recoveryCodesRemaining"`
+}
+
+// UnwrapRequest defines model for UnwrapRequest.
+type UnwrapRequest struct {
+	// Token Wrapping token returned for a wrap_ttl request
+	Token string `json:"token"`
+}
+
 // UpdateAPIKeyRequest defines model for UpdateAPIKeyRequest.
 type UpdateAPIKeyRequest struct {
 	// ExpiresAt Optional expiration date
@@ -292,51 +863,112 @@ type UpdateAPIKeyRequest struct {
 	// Name Human-readable name for the API key
 	Name *string `json:"name,omitempty"`
 
+	// Permissions Replace the operations this key is allowed to perform
+	Permissions *[]APIKeyPermission `json:"permissions,omitempty"`
+
 	// VaultUniqueIds Array of vault unique IDs this key can access (empty = all user's vaults)
 	VaultUniqueIds *[]string `json:"vaultUniqueIds,omitempty"`
 }
 
+// UpdateOrganizationMemberRequest defines model for UpdateOrganizationMemberRequest.
+type UpdateOrganizationMemberRequest struct {
+	// Role Role of a member. Viewers can read vaults, members can also create and update them,
+	// admins can also delete vaults and manage members, and owners can also manage owners
+	// and delete the organization.
+	Role OrganizationRole `json:"role"`
+}
+
+// UpdateOrganizationRequest defines model for UpdateOrganizationRequest.
+type UpdateOrganizationRequest struct {
+	// Description Human-readable description
+	Description *string `json:"description,omitempty"`
+
+	// Name Human-readable name
+	Name *string `json:"name,omitempty"`
+}
+
 // UpdateVaultRequest defines model for UpdateVaultRequest.
 type UpdateVaultRequest struct {
 	// Category Category/type of vault
 	Category *string `json:"category,omitempty"`
 
+	// ClearExpiry Remove the rotation deadline (cannot be combined with expiresAt)
+	ClearExpiry *bool `json:"clearExpiry,omitempty"`
+
+	// DeleteKeys Keys of a kv vault to remove (cannot be combined with value)
+	DeleteKeys *[]string `json:"deleteKeys,omitempty"`
+
 	// Description Human-readable description
 	Description *string `json:"description,omitempty"`
 
+	// ExpiresAt New rotation deadline of the value
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
 	// Favourite Favourite flag
 	Favourite *bool `json:"favourite,omitempty"`
 
 	// Name Human-readable name
 	Name *string `json:"name,omitempty"`
 
+	// RotationInterval New rotation schedule in seconds (at least 3600, or 0 to remove it); starts a new rotation period
+	RotationInterval *int64 `json:"rotationInterval,omitempty"`
+
+	// SetKeys Keys of a kv vault to add or change; other keys are kept (cannot be combined with value)
+	SetKeys *map[string]string `json:"setKeys,omitempty"`
+
 	// Value Value to be encrypted and stored
 	Value *string `json:"value,omitempty"`
 }
 
+// UpdateWebhookRequest defines model for UpdateWebhookRequest.
+type UpdateWebhookRequest struct {
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Events Replace the events to send (empty = all events)
+	Events *[]WebhookEventType `json:"events,omitempty"`
+	Name   *string             `json:"name,omitempty"`
+	Url    *string             `json:"url,omitempty"`
+}
+
 // Vault defines model for Vault.
 type Vault struct {
 	// Category Category/type of vault
 	Category  *string    `json:"category,omitempty"`
 	CreatedAt *time.Time `json:"createdAt,omitempty"`
 
+	// Data Keys and values of a kv vault (absent for other vaults and in responses to API keys)
+	Data *map[string]string `json:"data,omitempty"`
+
 	// Description Human-readable description
 	Description *string `json:"description,omitempty"`
 
+	// ExpiresAt Rotation deadline of the value (absent when it does not expire)
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
 	// Favourite Favourite flag
 	Favourite *bool `json:"favourite,omitempty"`
 
 	// Name Human-readable name
 	Name string `json:"name"`
 
+	// OrganizationId ID of the organization that owns this vault (absent for personal vaults)
+	OrganizationId *int64 `json:"organizationId,omitempty"`
+
+	// RotationInterval Rotation schedule in seconds; a new value is due this long after each update (absent when not set)
+	RotationInterval *int64          `json:"rotationInterval,omitempty"`
+	RotationPolicy   *RotationPolicy `json:"rotationPolicy,omitempty"`
+
+	// Type What the value holds. Static vaults store a secret; kv vaults store named secrets as a JSON object of strings, readable and writable one key at a time; database vaults store a database config and issue short-lived database credentials on API key reads. Absent for static vaults and in responses to API keys.
+	Type *VaultType `json:"type,omitempty"`
+
 	// UniqueId Unique identifier for the vault
 	UniqueId  string     `json:"uniqueId"`
 	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 
-	// UserId ID of the user who owns this vault
+	// UserId ID of the user who owns this vault (the creator, for organization vaults)
 	UserId *int64 `json:"userId,omitempty"`
 
-	// Value Encrypted value
+	// Value Encrypted value. For kv vaults this is the JSON object of all keys; for database vaults it is the config with its password masked, or the issued credentials on API key reads.
 	Value string `json:"value"`
 }
 
@@ -360,6 +992,9 @@ type VaultAPIKey struct {
 	// Name Human-readable name for the API key
 	Name string `json:"name"`
 
+	// Permissions Operations this key is allowed to perform
+	Permissions []APIKeyPermission `json:"permissions"`
+
 	// UpdatedAt When the key was last updated
 	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 
@@ -367,6 +1002,30 @@ type VaultAPIKey struct {
 	Vaults *[]VaultLite `json:"vaults,omitempty"`
 }
 
+// VaultEvent A change to a vault, sent as the data of a Server-Sent Event whose event name is the type and whose ID is the id
+type VaultEvent struct {
+	// CreatedAt When the change happened
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Id Event ID, increasing over time; send it back as Last-Event-ID to resume after it
+	Id int64 `json:"id"`
+
+	// Name Name of the vault
+	Name string `json:"name"`
+
+	// OrganizationId Organization that owns the vault, if any
+	OrganizationId *int64 `json:"organizationId,omitempty"`
+
+	// Type Kind of change
+	Type VaultEventType `json:"type"`
+
+	// UniqueId Unique ID of the vault
+	UniqueId string `json:"uniqueId"`
+}
+
+// VaultEventType Kind of change
+type VaultEventType string
+
 // VaultFilterOption defines model for VaultFilterOption.
 type VaultFilterOption struct {
 	// Name Human-readable name
@@ -382,6 +1041,15 @@ type VaultFilterOptionsResponse struct {
 	Vaults []VaultFilterOption `json:"vaults"`
 }
 
+// VaultKeyValue defines model for VaultKeyValue.
+type VaultKeyValue struct {
+	// Key Key of the kv vault
+	Key string `json:"key"`
+
+	// Value Value of the key
+	Value string `json:"value"`
+}
+
 // VaultLite defines model for VaultLite.
 type VaultLite struct {
 	// Category Category/type of vault
@@ -390,17 +1058,65 @@ type VaultLite struct {
 	// Description Human-readable description
 	Description *string `json:"description,omitempty"`
 
+	// ExpiresAt Rotation deadline of the value (absent when it does not expire)
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
 	// Favourite Favourite flag
 	Favourite *bool `json:"favourite,omitempty"`
 
 	// Name Human-readable name
 	Name string `json:"name"`
 
+	// OrganizationId ID of the organization that owns this vault (absent for personal vaults)
+	OrganizationId *int64 `json:"organizationId,omitempty"`
+
+	// RotationInterval Rotation schedule in seconds; a new value is due this long after each update (absent when not set)
+	RotationInterval *int64 `json:"rotationInterval,omitempty"`
+
+	// Type What the value holds. Static vaults store a secret; kv vaults store named secrets as a JSON object of strings, readable and writable one key at a time; database vaults store a database config and issue short-lived database credentials on API key reads. Absent for static vaults and in responses to API keys.
+	Type *VaultType `json:"type,omitempty"`
+
 	// UniqueId Unique identifier for the vault
 	UniqueId  string     `json:"uniqueId"`
 	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
+// VaultType What the value holds. Static vaults store a secret; kv vaults store named secrets as a JSON object of strings, readable and writable one key at a time; database vaults store a database config and issue short-lived database credentials on API key reads. Absent for static vaults and in responses to API keys.
+type VaultType string
+
+// VaultVersion defines model for VaultVersion.
+type VaultVersion struct {
+	// ApiKeyId ID of the API key used to write this value, if any
+	ApiKeyId *int64 `json:"apiKeyId,omitempty"`
+
+	// CreatedAt When this value was written
+	CreatedAt time.Time `json:"createdAt"`
+
+	// RestoredFrom Version number this value was restored from, if any
+	RestoredFrom *int `json:"restoredFrom,omitempty"`
+
+	// Source Source of the write (absent for versions recorded before history was enabled)
+	Source *VaultVersionSource `json:"source,omitempty"`
+
+	// UserId ID of the user who wrote this value
+	UserId int64 `json:"userId"`
+
+	// Value Value held by this version (only included when fetching a single version)
+	Value *string `json:"value,omitempty"`
+
+	// Version Sequential version number, starting from 1
+	Version int `json:"version"`
+}
+
+// VaultVersionSource Source of the write (absent for versions recorded before history was enabled)
+type VaultVersionSource string
+
+// VaultVersionsResponse defines model for VaultVersionsResponse.
+type VaultVersionsResponse struct {
+	// Versions Versions of the vault, newest first
+	Versions []VaultVersion `json:"versions"`
+}
+
 // VaultsResponse defines model for VaultsResponse.
 type VaultsResponse struct {
 	// PageIndex Current page index (starting from 1)
@@ -416,6 +1132,173 @@ type VaultsResponse struct {
 	Vaults []VaultLite `json:"vaults"`
 }
 
+// WebAuthnBeginResponse defines model for WebAuthnBeginResponse.
+type WebAuthnBeginResponse struct {
+	// Options Options to pass to the browser's WebAuthn API, in the WebAuthn JSON format
+	Options map[string]interface{} `json:"options"`
+
+	// SessionToken Token to send with the finish request of the ceremony
+	SessionToken string `json:"sessionToken"`
+}
+
+// WebAuthnCredential defines model for WebAuthnCredential.
+type WebAuthnCredential struct {
+	// Aaguid Model identifier of the authenticator
+	Aaguid *string `json:"aaguid,omitempty"`
+
+	// BackupEligible Whether the passkey can be synced between devices
+	BackupEligible bool `json:"backupEligible"`
+
+	// BackupState Whether the passkey is currently synced
+	BackupState bool       `json:"backupState"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	Id          int64      `json:"id"`
+	LastUsedAt  *time.Time `json:"lastUsedAt,omitempty"`
+	Name        string     `json:"name"`
+	SignCount   int64      `json:"signCount"`
+	Transports  []string   `json:"transports"`
+}
+
+// WebAuthnCredentialsResponse defines model for WebAuthnCredentialsResponse.
+type WebAuthnCredentialsResponse struct {
+	Credentials []WebAuthnCredential `json:"credentials"`
+}
+
+// WebAuthnLoginFinishRequest defines model for WebAuthnLoginFinishRequest.
+type WebAuthnLoginFinishRequest struct {
+	// Credential PublicKeyCredential returned by navigator.credentials.get, in the WebAuthn JSON format
+	Credential   map[string]interface{} `json:"credential"`
+	SessionToken string                 `json:"sessionToken"`
+}
+
+// WebAuthnRegistrationFinishRequest defines model for WebAuthnRegistrationFinishRequest.
+type WebAuthnRegistrationFinishRequest struct {
+	// Credential PublicKeyCredential returned by navigator.credentials.create, in the WebAuthn JSON format
+	Credential map[string]interface{} `json:"credential"`
+
+	// Name Name to recognize the passkey by
+	Name         *string `json:"name,omitempty"`
+	SessionToken string  `json:"sessionToken"`
+}
+
+// Webhook defines model for Webhook.
+type Webhook struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Enabled Whether events are sent to the webhook
+	Enabled bool `json:"enabled"`
+
+	// Events Events sent to the webhook (empty = all events)
+	Events []WebhookEventType `json:"events"`
+
+	// Id Unique webhook ID
+	Id int64 `json:"id"`
+
+	// Name Human-readable name for the webhook
+	Name      string     `json:"name"`
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+
+	// Url Endpoint the events are posted to
+	Url   string     `json:"url"`
+	Vault *VaultLite `json:"vault,omitempty"`
+}
+
+// WebhookDeliveriesResponse defines model for WebhookDeliveriesResponse.
+type WebhookDeliveriesResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+	PageIndex  int               `json:"pageIndex"`
+	PageSize   int               `json:"pageSize"`
+	TotalCount int               `json:"totalCount"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	// Attempts Number of attempts so far
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	DeliveredAt *time.Time `json:"deliveredAt,omitempty"`
+
+	// Error Why the last attempt failed
+	Error *string `json:"error,omitempty"`
+
+	// Event Event type, or webhook.test for test events
+	Event string `json:"event"`
+
+	// Id Unique delivery ID, sent in the X-VaultHub-Delivery header and the payload
+	Id            string     `json:"id"`
+	LastAttemptAt *time.Time `json:"lastAttemptAt,omitempty"`
+
+	// NextAttemptAt When the next attempt is due, while pending
+	NextAttemptAt *time.Time `json:"nextAttemptAt,omitempty"`
+
+	// Payload JSON body that is sent
+	Payload *string `json:"payload,omitempty"`
+
+	// ResponseBody Start of the last response body
+	ResponseBody *string `json:"responseBody,omitempty"`
+
+	// ResponseStatus HTTP status of the last response
+	ResponseStatus *int                  `json:"responseStatus,omitempty"`
+	Status         WebhookDeliveryStatus `json:"status"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDelivery.Status.
+type WebhookDeliveryStatus string
+
+// WebhookEventType Event a webhook can subscribe to
+type WebhookEventType string
+
+// WebhooksResponse defines model for WebhooksResponse.
+type WebhooksResponse struct {
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+// WorkloadIdentitiesResponse defines model for WorkloadIdentitiesResponse.
+type WorkloadIdentitiesResponse struct {
+	// Issuers Issuers allowed on this server
+	Issuers            []string           `json:"issuers"`
+	WorkloadIdentities []WorkloadIdentity `json:"workloadIdentities"`
+}
+
+// WorkloadIdentity defines model for WorkloadIdentity.
+type WorkloadIdentity struct {
+	// Audience Audience the tokens must be issued for
+	Audience string `json:"audience"`
+
+	// Claims Patterns other token claims must match, by claim name
+	Claims    *map[string]string `json:"claims,omitempty"`
+	CreatedAt time.Time          `json:"createdAt"`
+
+	// Id Unique workload identity ID
+	Id int64 `json:"id"`
+
+	// Issuer Issuer the tokens must come from
+	Issuer string `json:"issuer"`
+
+	// Name Human-readable name for the workload identity
+	Name string `json:"name"`
+
+	// Permissions Operations the exchanged API keys are allowed to perform
+	Permissions []APIKeyPermission `json:"permissions"`
+
+	// Subject Pattern the token subject must match, where * matches any characters
+	Subject string `json:"subject"`
+
+	// TokenTtl Lifetime of the exchanged API keys in seconds
+	TokenTtl int `json:"tokenTtl"`
+
+	// Vaults Vaults the exchanged API keys can access (empty = all user's vaults)
+	Vaults *[]VaultLite `json:"vaults,omitempty"`
+}
+
+// WrapInfo defines model for WrapInfo.
+type WrapInfo struct {
+	ExpiresAt time.Time `json:"expiresAt"`
+
+	// WrapToken Single-use token that redeems the value at POST /api/cli/unwrap
+	WrapToken string `json:"wrapToken"`
+}
+
 // GetAPIKeysParams defines parameters for GetAPIKeys.
 type GetAPIKeysParams struct {
 	// PageSize Number of API keys per page (default 20, max 1000)
@@ -436,9 +1319,12 @@ type GetAuditLogsParams struct {
 	// VaultUniqueId Filter logs by vault unique ID
 	VaultUniqueId *string `form:"vaultUniqueId,omitempty" json:"vaultUniqueId,omitempty"`
 
-	// Source Filter logs by source (web interface or CLI)
+	// Source Filter logs by source (web interface, CLI or system)
 	Source *GetAuditLogsParamsSource `form:"source,omitempty" json:"source,omitempty"`
 
+	// OrganizationId Return the logs of all members of this organization instead of your own (admin or owner)
+	OrganizationId *int64 `form:"organizationId,omitempty" json:"organizationId,omitempty"`
+
 	// PageSize Number of logs per page (default 100, max 1000)
 	PageSize int `form:"pageSize" json:"pageSize"`
 
@@ -449,11 +1335,62 @@ type GetAuditLogsParams struct {
 // GetAuditLogsParamsSource defines parameters for GetAuditLogs.
 type GetAuditLogsParamsSource string
 
+// ExportAuditLogsParams defines parameters for ExportAuditLogs.
+type ExportAuditLogsParams struct {
+	// Format Export format, CSV with a header row or one JSON object per line
+	Format ExportAuditLogsParamsFormat `form:"format" json:"format"`
+
+	// StartDate Export logs from this date (ISO 8601 format)
+	StartDate *time.Time `form:"startDate,omitempty" json:"startDate,omitempty"`
+
+	// EndDate Export logs until this date (ISO 8601 format)
+	EndDate *time.Time `form:"endDate,omitempty" json:"endDate,omitempty"`
+
+	// VaultUniqueId Export logs of this vault only
+	VaultUniqueId *string `form:"vaultUniqueId,omitempty" json:"vaultUniqueId,omitempty"`
+
+	// Source Export logs by source (web interface, CLI or system)
+	Source *ExportAuditLogsParamsSource `form:"source,omitempty" json:"source,omitempty"`
+
+	// OrganizationId Export the logs of all members of this organization instead of your own (admin or owner)
+	OrganizationId *int64 `form:"organizationId,omitempty" json:"organizationId,omitempty"`
+}
+
+// ExportAuditLogsParamsFormat defines parameters for ExportAuditLogs.
+type ExportAuditLogsParamsFormat string
+
+// ExportAuditLogsParamsSource defines parameters for ExportAuditLogs.
+type ExportAuditLogsParamsSource string
+
 // ConsumeMagicLinkParams defines parameters for ConsumeMagicLink.
 type ConsumeMagicLinkParams struct {
 	Token string `form:"token" json:"token"`
 }
 
+// StreamVaultEventsParams defines parameters for StreamVaultEvents.
+type StreamVaultEventsParams struct {
+	// LastEventId ID of the last event received, for clients that cannot set headers. The Last-Event-ID header, sent automatically by EventSource clients on reconnect, takes precedence.
+	LastEventId *int64 `form:"lastEventId,omitempty" json:"lastEventId,omitempty"`
+}
+
+// GetVaultByNameAPIKeyParams defines parameters for GetVaultByNameAPIKey.
+type GetVaultByNameAPIKeyParams struct {
+	// WrapTtl Return a single-use wrapping token instead of the value, redeemable with POST /api/cli/unwrap for this long, e.g. 5m or 300 (seconds, at most 24h)
+	WrapTtl *string `form:"wrap_ttl,omitempty" json:"wrap_ttl,omitempty"`
+}
+
+// GetVaultByAPIKeyParams defines parameters for GetVaultByAPIKey.
+type GetVaultByAPIKeyParams struct {
+	// WrapTtl Return a single-use wrapping token instead of the value, redeemable with POST /api/cli/unwrap for this long, e.g. 5m or 300 (seconds, at most 24h)
+	WrapTtl *string `form:"wrap_ttl,omitempty" json:"wrap_ttl,omitempty"`
+}
+
+// RevokeSessionsParams defines parameters for RevokeSessions.
+type RevokeSessionsParams struct {
+	// KeepCurrent Keep the session of the request
+	KeepCurrent *bool `form:"keepCurrent,omitempty" json:"keepCurrent,omitempty"`
+}
+
 // GetVaultsParams defines parameters for GetVaults.
 type GetVaultsParams struct {
 	// PageSize Number of vaults per page (default 20, max 1000)
@@ -461,6 +1398,18 @@ type GetVaultsParams struct {
 
 	// PageIndex Page index, starting from 1 (default 1)
 	PageIndex *int `form:"pageIndex,omitempty" json:"pageIndex,omitempty"`
+
+	// ExpiringWithin Only list vaults whose rotation deadline is within this period, such as 7d or 12h, including overdue ones, soonest first
+	ExpiringWithin *string `form:"expiring_within,omitempty" json:"expiring_within,omitempty"`
+}
+
+// GetWebhookDeliveriesParams defines parameters for GetWebhookDeliveries.
+type GetWebhookDeliveriesParams struct {
+	// PageSize Number of deliveries per page (default 20, max 1000)
+	PageSize *int `form:"pageSize,omitempty" json:"pageSize,omitempty"`
+
+	// PageIndex Page index, starting from 1 (default 1)
+	PageIndex *int `form:"pageIndex,omitempty" json:"pageIndex,omitempty"`
 }
 
 // CreateAPIKeyJSONRequestBody defines body for CreateAPIKey for application/json ContentType.
@@ -469,9 +1418,21 @@ type CreateAPIKeyJSONRequestBody = CreateAPIKeyRequest
 // UpdateAPIKeyJSONRequestBody defines body for UpdateAPIKey for application/json ContentType.
 type UpdateAPIKeyJSONRequestBody = UpdateAPIKeyRequest
 
+// RegenerateRecoveryCodesJSONRequestBody defines body for RegenerateRecoveryCodes for application/json ContentType.
+type RegenerateRecoveryCodesJSONRequestBody = RegenerateRecoveryCodesRequest
+
+// DisableTOTPJSONRequestBody defines body for DisableTOTP for application/json ContentType.
+type DisableTOTPJSONRequestBody = DisableTwoFactorRequest
+
+// EnableTOTPJSONRequestBody defines body for EnableTOTP for application/json ContentType.
+type EnableTOTPJSONRequestBody = EnableTOTPRequest
+
 // LoginJSONRequestBody defines body for Login for application/json ContentType.
 type LoginJSONRequestBody = LoginRequest
 
+// LoginTwoFactorJSONRequestBody defines body for LoginTwoFactor for application/json ContentType.
+type LoginTwoFactorJSONRequestBody = LoginTwoFactorRequest
+
 // RequestMagicLinkJSONRequestBody defines body for RequestMagicLink for application/json ContentType.
 type RequestMagicLinkJSONRequestBody = MagicLinkRequest
 
@@ -484,21 +1445,69 @@ type ConfirmPasswordResetJSONRequestBody = PasswordResetConfirmRequest
 // RequestPasswordResetJSONRequestBody defines body for RequestPasswordReset for application/json ContentType.
 type RequestPasswordResetJSONRequestBody = PasswordResetRequest
 
+// RefreshTokenJSONRequestBody defines body for RefreshToken for application/json ContentType.
+type RefreshTokenJSONRequestBody = RefreshTokenRequest
+
 // SignupJSONRequestBody defines body for Signup for application/json ContentType.
 type SignupJSONRequestBody = SignupRequest
 
+// TokenExchangeJSONRequestBody defines body for TokenExchange for application/json ContentType.
+type TokenExchangeJSONRequestBody = TokenExchangeRequest
+
+// FinishWebAuthnLoginJSONRequestBody defines body for FinishWebAuthnLogin for application/json ContentType.
+type FinishWebAuthnLoginJSONRequestBody = WebAuthnLoginFinishRequest
+
+// FinishWebAuthnRegistrationJSONRequestBody defines body for FinishWebAuthnRegistration for application/json ContentType.
+type FinishWebAuthnRegistrationJSONRequestBody = WebAuthnRegistrationFinishRequest
+
+// UnwrapJSONRequestBody defines body for Unwrap for application/json ContentType.
+type UnwrapJSONRequestBody = UnwrapRequest
+
 // UpdateVaultByNameAPIKeyJSONRequestBody defines body for UpdateVaultByNameAPIKey for application/json ContentType.
 type UpdateVaultByNameAPIKeyJSONRequestBody = UpdateVaultRequest
 
+// SetVaultKeyByNameAPIKeyJSONRequestBody defines body for SetVaultKeyByNameAPIKey for application/json ContentType.
+type SetVaultKeyByNameAPIKeyJSONRequestBody = SetVaultKeyRequest
+
 // UpdateVaultByAPIKeyJSONRequestBody defines body for UpdateVaultByAPIKey for application/json ContentType.
 type UpdateVaultByAPIKeyJSONRequestBody = UpdateVaultRequest
 
+// SetVaultKeyByAPIKeyJSONRequestBody defines body for SetVaultKeyByAPIKey for application/json ContentType.
+type SetVaultKeyByAPIKeyJSONRequestBody = SetVaultKeyRequest
+
+// CreateOrganizationJSONRequestBody defines body for CreateOrganization for application/json ContentType.
+type CreateOrganizationJSONRequestBody = CreateOrganizationRequest
+
+// UpdateOrganizationJSONRequestBody defines body for UpdateOrganization for application/json ContentType.
+type UpdateOrganizationJSONRequestBody = UpdateOrganizationRequest
+
+// AddOrganizationMemberJSONRequestBody defines body for AddOrganizationMember for application/json ContentType.
+type AddOrganizationMemberJSONRequestBody = AddOrganizationMemberRequest
+
+// UpdateOrganizationMemberJSONRequestBody defines body for UpdateOrganizationMember for application/json ContentType.
+type UpdateOrganizationMemberJSONRequestBody = UpdateOrganizationMemberRequest
+
+// CreateShareJSONRequestBody defines body for CreateShare for application/json ContentType.
+type CreateShareJSONRequestBody = CreateShareRequest
+
 // CreateVaultJSONRequestBody defines body for CreateVault for application/json ContentType.
 type CreateVaultJSONRequestBody = CreateVaultRequest
 
 // UpdateVaultJSONRequestBody defines body for UpdateVault for application/json ContentType.
 type UpdateVaultJSONRequestBody = UpdateVaultRequest
 
+// SetVaultRotationPolicyJSONRequestBody defines body for SetVaultRotationPolicy for application/json ContentType.
+type SetVaultRotationPolicyJSONRequestBody = SetRotationPolicyRequest
+
+// CreateWebhookJSONRequestBody defines body for CreateWebhook for application/json ContentType.
+type CreateWebhookJSONRequestBody = CreateWebhookRequest
+
+// UpdateWebhookJSONRequestBody defines body for UpdateWebhook for application/json ContentType.
+type UpdateWebhookJSONRequestBody = UpdateWebhookRequest
+
+// CreateWorkloadIdentityJSONRequestBody defines body for CreateWorkloadIdentity for application/json ContentType.
+type CreateWorkloadIdentityJSONRequestBody = CreateWorkloadIdentityRequest
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 
@@ -517,12 +1526,36 @@ type ServerInterface interface {
 	// (GET /api/audit-logs)
 	GetAuditLogs(c *fiber.Ctx, params GetAuditLogsParams) error
 
+	// (GET /api/audit-logs/export)
+	ExportAuditLogs(c *fiber.Ctx, params ExportAuditLogsParams) error
+
 	// (GET /api/audit-logs/metrics)
 	GetAuditMetrics(c *fiber.Ctx) error
 
+	// (GET /api/audit-logs/verify)
+	VerifyAuditLogs(c *fiber.Ctx) error
+
+	// (GET /api/auth/2fa)
+	GetTwoFactorStatus(c *fiber.Ctx) error
+
+	// (POST /api/auth/2fa/recovery-codes)
+	RegenerateRecoveryCodes(c *fiber.Ctx) error
+
+	// (POST /api/auth/2fa/totp/disable)
+	DisableTOTP(c *fiber.Ctx) error
+
+	// (POST /api/auth/2fa/totp/enable)
+	EnableTOTP(c *fiber.Ctx) error
+
+	// (POST /api/auth/2fa/totp/setup)
+	SetupTOTP(c *fiber.Ctx) error
+
 	// (POST /api/auth/login)
 	Login(c *fiber.Ctx) error
 
+	// (POST /api/auth/login/2fa)
+	LoginTwoFactor(c *fiber.Ctx) error
+
 	// (GET /api/auth/logout)
 	Logout(c *fiber.Ctx) error
 
@@ -541,21 +1574,66 @@ type ServerInterface interface {
 	// (POST /api/auth/password/reset/request)
 	RequestPasswordReset(c *fiber.Ctx) error
 
+	// (POST /api/auth/refresh)
+	RefreshToken(c *fiber.Ctx) error
+
 	// (POST /api/auth/signup)
 	Signup(c *fiber.Ctx) error
 
+	// (POST /api/auth/token-exchange)
+	TokenExchange(c *fiber.Ctx) error
+
+	// (GET /api/auth/webauthn/credentials)
+	GetWebAuthnCredentials(c *fiber.Ctx) error
+
+	// (DELETE /api/auth/webauthn/credentials/{id})
+	DeleteWebAuthnCredential(c *fiber.Ctx, id int64) error
+
+	// (POST /api/auth/webauthn/login/begin)
+	BeginWebAuthnLogin(c *fiber.Ctx) error
+
+	// (POST /api/auth/webauthn/login/finish)
+	FinishWebAuthnLogin(c *fiber.Ctx) error
+
+	// (POST /api/auth/webauthn/register/begin)
+	BeginWebAuthnRegistration(c *fiber.Ctx) error
+
+	// (POST /api/auth/webauthn/register/finish)
+	FinishWebAuthnRegistration(c *fiber.Ctx) error
+
+	// (GET /api/cli/events)
+	StreamVaultEvents(c *fiber.Ctx, params StreamVaultEventsParams) error
+
+	// (POST /api/cli/unwrap)
+	Unwrap(c *fiber.Ctx) error
+
 	// (GET /api/cli/vault/name/{name})
-	GetVaultByNameAPIKey(c *fiber.Ctx, name string) error
+	GetVaultByNameAPIKey(c *fiber.Ctx, name string, params GetVaultByNameAPIKeyParams) error
 
 	// (PUT /api/cli/vault/name/{name})
 	UpdateVaultByNameAPIKey(c *fiber.Ctx, name string) error
 
+	// (GET /api/cli/vault/name/{name}/keys/{key})
+	GetVaultKeyByNameAPIKey(c *fiber.Ctx, name string, key string) error
+
+	// (PUT /api/cli/vault/name/{name}/keys/{key})
+	SetVaultKeyByNameAPIKey(c *fiber.Ctx, name string, key string) error
+
 	// (GET /api/cli/vault/{uniqueId})
-	GetVaultByAPIKey(c *fiber.Ctx, uniqueId string) error
+	GetVaultByAPIKey(c *fiber.Ctx, uniqueId string, params GetVaultByAPIKeyParams) error
 
 	// (PUT /api/cli/vault/{uniqueId})
 	UpdateVaultByAPIKey(c *fiber.Ctx, uniqueId string) error
 
+	// (GET /api/cli/vault/{uniqueId}/keys/{key})
+	GetVaultKeyByAPIKey(c *fiber.Ctx, uniqueId string, key string) error
+
+	// (PUT /api/cli/vault/{uniqueId}/keys/{key})
+	SetVaultKeyByAPIKey(c *fiber.Ctx, uniqueId string, key string) error
+
+	// (GET /api/cli/vault/{uniqueId}/versions)
+	GetVaultVersionsByAPIKey(c *fiber.Ctx, uniqueId string) error
+
 	// (GET /api/cli/vaults)
 	GetVaultsByAPIKey(c *fiber.Ctx) error
 	// Get public configuration
@@ -564,6 +1642,48 @@ type ServerInterface interface {
 
 	// (GET /api/health)
 	Health(c *fiber.Ctx) error
+
+	// (GET /api/organizations)
+	GetOrganizations(c *fiber.Ctx) error
+
+	// (POST /api/organizations)
+	CreateOrganization(c *fiber.Ctx) error
+
+	// (DELETE /api/organizations/{id})
+	DeleteOrganization(c *fiber.Ctx, id int64) error
+
+	// (GET /api/organizations/{id})
+	GetOrganization(c *fiber.Ctx, id int64) error
+
+	// (PUT /api/organizations/{id})
+	UpdateOrganization(c *fiber.Ctx, id int64) error
+
+	// (GET /api/organizations/{id}/members)
+	GetOrganizationMembers(c *fiber.Ctx, id int64) error
+
+	// (POST /api/organizations/{id}/members)
+	AddOrganizationMember(c *fiber.Ctx, id int64) error
+
+	// (DELETE /api/organizations/{id}/members/{userId})
+	RemoveOrganizationMember(c *fiber.Ctx, id int64, userId int64) error
+
+	// (PUT /api/organizations/{id}/members/{userId})
+	UpdateOrganizationMember(c *fiber.Ctx, id int64, userId int64) error
+
+	// (DELETE /api/sessions)
+	RevokeSessions(c *fiber.Ctx, params RevokeSessionsParams) error
+
+	// (GET /api/sessions)
+	GetSessions(c *fiber.Ctx) error
+
+	// (DELETE /api/sessions/{id})
+	RevokeSession(c *fiber.Ctx, id string) error
+
+	// (POST /api/shares)
+	CreateShare(c *fiber.Ctx) error
+
+	// (GET /api/shares/{id})
+	GetShare(c *fiber.Ctx, id string) error
 	// Get system status
 	// (GET /api/status)
 	GetStatus(c *fiber.Ctx) error
@@ -588,6 +1708,57 @@ type ServerInterface interface {
 
 	// (PUT /api/vaults/{uniqueId})
 	UpdateVault(c *fiber.Ctx, uniqueId string) error
+
+	// (DELETE /api/vaults/{uniqueId}/rotation)
+	DeleteVaultRotationPolicy(c *fiber.Ctx, uniqueId string) error
+
+	// (GET /api/vaults/{uniqueId}/rotation)
+	GetVaultRotationPolicy(c *fiber.Ctx, uniqueId string) error
+
+	// (PUT /api/vaults/{uniqueId}/rotation)
+	SetVaultRotationPolicy(c *fiber.Ctx, uniqueId string) error
+
+	// (POST /api/vaults/{uniqueId}/rotation/rotate)
+	RotateVault(c *fiber.Ctx, uniqueId string) error
+
+	// (GET /api/vaults/{uniqueId}/versions)
+	GetVaultVersions(c *fiber.Ctx, uniqueId string) error
+
+	// (GET /api/vaults/{uniqueId}/versions/{version})
+	GetVaultVersion(c *fiber.Ctx, uniqueId string, version int) error
+
+	// (POST /api/vaults/{uniqueId}/versions/{version}/restore)
+	RestoreVaultVersion(c *fiber.Ctx, uniqueId string, version int) error
+
+	// (GET /api/webhooks)
+	GetWebhooks(c *fiber.Ctx) error
+
+	// (POST /api/webhooks)
+	CreateWebhook(c *fiber.Ctx) error
+
+	// (DELETE /api/webhooks/{id})
+	DeleteWebhook(c *fiber.Ctx, id int64) error
+
+	// (GET /api/webhooks/{id})
+	GetWebhook(c *fiber.Ctx, id int64) error
+
+	// (PUT /api/webhooks/{id})
+	UpdateWebhook(c *fiber.Ctx, id int64) error
+
+	// (GET /api/webhooks/{id}/deliveries)
+	GetWebhookDeliveries(c *fiber.Ctx, id int64, params GetWebhookDeliveriesParams) error
+
+	// (POST /api/webhooks/{id}/test)
+	TestWebhook(c *fiber.Ctx, id int64) error
+
+	// (GET /api/workload-identities)
+	GetWorkloadIdentities(c *fiber.Ctx) error
+
+	// (POST /api/workload-identities)
+	CreateWorkloadIdentity(c *fiber.Ctx) error
+
+	// (DELETE /api/workload-identities/{id})
+	DeleteWorkloadIdentity(c *fiber.Ctx, id int64) error
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -724,6 +1895,13 @@ func (siw *ServerInterfaceWrapper) GetAuditLogs(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter source: %w", err).Error())
 	}
 
+	// ------------- Optional query parameter "organizationId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "organizationId", query, &params.OrganizationId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter organizationId: %w", err).Error())
+	}
+
 	// ------------- Required query parameter "pageSize" -------------
 
 	if paramValue := c.Query("pageSize"); paramValue != "" {
@@ -757,18 +1935,127 @@ func (siw *ServerInterfaceWrapper) GetAuditLogs(c *fiber.Ctx) error {
 	return siw.Handler.GetAuditLogs(c, params)
 }
 
+// ExportAuditLogs operation middleware
+func (siw *ServerInterfaceWrapper) ExportAuditLogs(c *fiber.Ctx) error {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ExportAuditLogsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Required query parameter "format" -------------
+
+	if paramValue := c.Query("format"); paramValue != "" {
+
+	} else {
+		err = fmt.Errorf("Query argument format is required, but not found")
+		c.Status(fiber.StatusBadRequest).JSON(err)
+		return err
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "format", query, &params.Format)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter format: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "startDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "startDate", query, &params.StartDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter startDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "endDate" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "endDate", query, &params.EndDate)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter endDate: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "vaultUniqueId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "vaultUniqueId", query, &params.VaultUniqueId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter vaultUniqueId: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "source" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source", query, &params.Source)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter source: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "organizationId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "organizationId", query, &params.OrganizationId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter organizationId: %w", err).Error())
+	}
+
+	return siw.Handler.ExportAuditLogs(c, params)
+}
+
 // GetAuditMetrics operation middleware
 func (siw *ServerInterfaceWrapper) GetAuditMetrics(c *fiber.Ctx) error {
 
 	return siw.Handler.GetAuditMetrics(c)
 }
 
+// VerifyAuditLogs operation middleware
+func (siw *ServerInterfaceWrapper) VerifyAuditLogs(c *fiber.Ctx) error {
+
+	return siw.Handler.VerifyAuditLogs(c)
+}
+
+// GetTwoFactorStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetTwoFactorStatus(c *fiber.Ctx) error {
+
+	return siw.Handler.GetTwoFactorStatus(c)
+}
+
+// RegenerateRecoveryCodes operation middleware
+func (siw *ServerInterfaceWrapper) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+
+	return siw.Handler.RegenerateRecoveryCodes(c)
+}
+
+// DisableTOTP operation middleware
+func (siw *ServerInterfaceWrapper) DisableTOTP(c *fiber.Ctx) error {
+
+	return siw.Handler.DisableTOTP(c)
+}
+
+// EnableTOTP operation middleware
+func (siw *ServerInterfaceWrapper) EnableTOTP(c *fiber.Ctx) error {
+
+	return siw.Handler.EnableTOTP(c)
+}
+
+// SetupTOTP operation middleware
+func (siw *ServerInterfaceWrapper) SetupTOTP(c *fiber.Ctx) error {
+
+	return siw.Handler.SetupTOTP(c)
+}
+
 // Login operation middleware
 func (siw *ServerInterfaceWrapper) Login(c *fiber.Ctx) error {
 
 	return siw.Handler.Login(c)
 }
 
+// LoginTwoFactor operation middleware
+func (siw *ServerInterfaceWrapper) LoginTwoFactor(c *fiber.Ctx) error {
+
+	return siw.Handler.LoginTwoFactor(c)
+}
+
 // Logout operation middleware
 func (siw *ServerInterfaceWrapper) Logout(c *fiber.Ctx) error {
 
@@ -831,12 +2118,102 @@ func (siw *ServerInterfaceWrapper) RequestPasswordReset(c *fiber.Ctx) error {
 	return siw.Handler.RequestPasswordReset(c)
 }
 
+// RefreshToken operation middleware
+func (siw *ServerInterfaceWrapper) RefreshToken(c *fiber.Ctx) error {
+
+	return siw.Handler.RefreshToken(c)
+}
+
 // Signup operation middleware
 func (siw *ServerInterfaceWrapper) Signup(c *fiber.Ctx) error {
 
 	return siw.Handler.Signup(c)
 }
 
+// TokenExchange operation middleware
+func (siw *ServerInterfaceWrapper) TokenExchange(c *fiber.Ctx) error {
+
+	return siw.Handler.TokenExchange(c)
+}
+
+// GetWebAuthnCredentials operation middleware
+func (siw *ServerInterfaceWrapper) GetWebAuthnCredentials(c *fiber.Ctx) error {
+
+	return siw.Handler.GetWebAuthnCredentials(c)
+}
+
+// DeleteWebAuthnCredential operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebAuthnCredential(c *fiber.Ctx) error {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteWebAuthnCredential(c, id)
+}
+
+// BeginWebAuthnLogin operation middleware
+func (siw *ServerInterfaceWrapper) BeginWebAuthnLogin(c *fiber.Ctx) error {
+
+	return siw.Handler.BeginWebAuthnLogin(c)
+}
+
+// FinishWebAuthnLogin operation middleware
+func (siw *ServerInterfaceWrapper) FinishWebAuthnLogin(c *fiber.Ctx) error {
+
+	return siw.Handler.FinishWebAuthnLogin(c)
+}
+
+// BeginWebAuthnRegistration operation middleware
+func (siw *ServerInterfaceWrapper) BeginWebAuthnRegistration(c *fiber.Ctx) error {
+
+	return siw.Handler.BeginWebAuthnRegistration(c)
+}
+
+// FinishWebAuthnRegistration operation middleware
+func (siw *ServerInterfaceWrapper) FinishWebAuthnRegistration(c *fiber.Ctx) error {
+
+	return siw.Handler.FinishWebAuthnRegistration(c)
+}
+
+// StreamVaultEvents operation middleware
+func (siw *ServerInterfaceWrapper) StreamVaultEvents(c *fiber.Ctx) error {
+
+	var err error
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params StreamVaultEventsParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "lastEventId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lastEventId", query, &params.LastEventId)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter lastEventId: %w", err).Error())
+	}
+
+	return siw.Handler.StreamVaultEvents(c, params)
+}
+
+// Unwrap operation middleware
+func (siw *ServerInterfaceWrapper) Unwrap(c *fiber.Ctx) error {
+
+	return siw.Handler.Unwrap(c)
+}
+
 // GetVaultByNameAPIKey operation middleware
 func (siw *ServerInterfaceWrapper) GetVaultByNameAPIKey(c *fiber.Ctx) error {
 
@@ -852,7 +2229,23 @@ func (siw *ServerInterfaceWrapper) GetVaultByNameAPIKey(c *fiber.Ctx) error {
 
 	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
 
-	return siw.Handler.GetVaultByNameAPIKey(c, name)
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetVaultByNameAPIKeyParams
+
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
+
+	// ------------- Optional query parameter "wrap_ttl" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "wrap_ttl", query, &params.WrapTtl)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter wrap_ttl: %w", err).Error())
+	}
+
+	return siw.Handler.GetVaultByNameAPIKey(c, name, params)
 }
 
 // UpdateVaultByNameAPIKey operation middleware
@@ -873,81 +2266,75 @@ func (siw *ServerInterfaceWrapper) UpdateVaultByNameAPIKey(c *fiber.Ctx) error {
 	return siw.Handler.UpdateVaultByNameAPIKey(c, name)
 }
 
-// GetVaultByAPIKey operation middleware
-func (siw *ServerInterfaceWrapper) GetVaultByAPIKey(c *fiber.Ctx) error {
+// GetVaultKeyByNameAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultKeyByNameAPIKey(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "uniqueId" -------------
-	var uniqueId string
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Params("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter name: %w", err).Error())
+	}
+
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", c.Params("key"), &key, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter key: %w", err).Error())
 	}
 
 	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
 
-	return siw.Handler.GetVaultByAPIKey(c, uniqueId)
+	return siw.Handler.GetVaultKeyByNameAPIKey(c, name, key)
 }
 
-// UpdateVaultByAPIKey operation middleware
-func (siw *ServerInterfaceWrapper) UpdateVaultByAPIKey(c *fiber.Ctx) error {
+// SetVaultKeyByNameAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) SetVaultKeyByNameAPIKey(c *fiber.Ctx) error {
 
 	var err error
 
-	// ------------- Path parameter "uniqueId" -------------
-	var uniqueId string
+	// ------------- Path parameter "name" -------------
+	var name string
 
-	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	err = runtime.BindStyledParameterWithOptions("simple", "name", c.Params("name"), &name, runtime.BindStyledParameterOptions{Explode: false, Required: true})
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter name: %w", err).Error())
 	}
 
-	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
-
-	return siw.Handler.UpdateVaultByAPIKey(c, uniqueId)
-}
+	// ------------- Path parameter "key" -------------
+	var key string
 
-// GetVaultsByAPIKey operation middleware
-func (siw *ServerInterfaceWrapper) GetVaultsByAPIKey(c *fiber.Ctx) error {
+	err = runtime.BindStyledParameterWithOptions("simple", "key", c.Params("key"), &key, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter key: %w", err).Error())
+	}
 
 	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
 
-	return siw.Handler.GetVaultsByAPIKey(c)
-}
-
-// GetConfig operation middleware
-func (siw *ServerInterfaceWrapper) GetConfig(c *fiber.Ctx) error {
-
-	return siw.Handler.GetConfig(c)
-}
-
-// Health operation middleware
-func (siw *ServerInterfaceWrapper) Health(c *fiber.Ctx) error {
-
-	return siw.Handler.Health(c)
+	return siw.Handler.SetVaultKeyByNameAPIKey(c, name, key)
 }
 
-// GetStatus operation middleware
-func (siw *ServerInterfaceWrapper) GetStatus(c *fiber.Ctx) error {
-
-	return siw.Handler.GetStatus(c)
-}
+// GetVaultByAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultByAPIKey(c *fiber.Ctx) error {
 
-// GetCurrentUser operation middleware
-func (siw *ServerInterfaceWrapper) GetCurrentUser(c *fiber.Ctx) error {
+	var err error
 
-	return siw.Handler.GetCurrentUser(c)
-}
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-// GetVaults operation middleware
-func (siw *ServerInterfaceWrapper) GetVaults(c *fiber.Ctx) error {
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-	var err error
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params GetVaultsParams
+	var params GetVaultByAPIKeyParams
 
 	var query url.Values
 	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
@@ -955,37 +2342,36 @@ func (siw *ServerInterfaceWrapper) GetVaults(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "pageSize" -------------
+	// ------------- Optional query parameter "wrap_ttl" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "pageSize", query, &params.PageSize)
+	err = runtime.BindQueryParameter("form", true, false, "wrap_ttl", query, &params.WrapTtl)
 	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pageSize: %w", err).Error())
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter wrap_ttl: %w", err).Error())
 	}
 
-	// ------------- Optional query parameter "pageIndex" -------------
+	return siw.Handler.GetVaultByAPIKey(c, uniqueId, params)
+}
 
-	err = runtime.BindQueryParameter("form", true, false, "pageIndex", query, &params.PageIndex)
-	if err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pageIndex: %w", err).Error())
-	}
+// UpdateVaultByAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) UpdateVaultByAPIKey(c *fiber.Ctx) error {
 
-	return siw.Handler.GetVaults(c, params)
-}
+	var err error
 
-// CreateVault operation middleware
-func (siw *ServerInterfaceWrapper) CreateVault(c *fiber.Ctx) error {
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-	return siw.Handler.CreateVault(c)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-// GetVaultFilterOptions operation middleware
-func (siw *ServerInterfaceWrapper) GetVaultFilterOptions(c *fiber.Ctx) error {
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
 
-	return siw.Handler.GetVaultFilterOptions(c)
+	return siw.Handler.UpdateVaultByAPIKey(c, uniqueId)
 }
 
-// DeleteVault operation middleware
-func (siw *ServerInterfaceWrapper) DeleteVault(c *fiber.Ctx) error {
+// GetVaultKeyByAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultKeyByAPIKey(c *fiber.Ctx) error {
 
 	var err error
 
@@ -997,11 +2383,21 @@ func (siw *ServerInterfaceWrapper) DeleteVault(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
 	}
 
-	return siw.Handler.DeleteVault(c, uniqueId)
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", c.Params("key"), &key, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter key: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.GetVaultKeyByAPIKey(c, uniqueId, key)
 }
 
-// GetVault operation middleware
-func (siw *ServerInterfaceWrapper) GetVault(c *fiber.Ctx) error {
+// SetVaultKeyByAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) SetVaultKeyByAPIKey(c *fiber.Ctx) error {
 
 	var err error
 
@@ -1013,11 +2409,21 @@ func (siw *ServerInterfaceWrapper) GetVault(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
 	}
 
-	return siw.Handler.GetVault(c, uniqueId)
+	// ------------- Path parameter "key" -------------
+	var key string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "key", c.Params("key"), &key, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter key: %w", err).Error())
+	}
+
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
+
+	return siw.Handler.SetVaultKeyByAPIKey(c, uniqueId, key)
 }
 
-// UpdateVault operation middleware
-func (siw *ServerInterfaceWrapper) UpdateVault(c *fiber.Ctx) error {
+// GetVaultVersionsByAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultVersionsByAPIKey(c *fiber.Ctx) error {
 
 	var err error
 
@@ -1029,812 +2435,4685 @@ func (siw *ServerInterfaceWrapper) UpdateVault(c *fiber.Ctx) error {
 		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
 	}
 
-	return siw.Handler.UpdateVault(c, uniqueId)
-}
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
 
-// FiberServerOptions provides options for the Fiber server.
-type FiberServerOptions struct {
-	BaseURL     string
-	Middlewares []MiddlewareFunc
+	return siw.Handler.GetVaultVersionsByAPIKey(c, uniqueId)
 }
 
-// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
-func RegisterHandlers(router fiber.Router, si ServerInterface) {
-	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
-}
+// GetVaultsByAPIKey operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultsByAPIKey(c *fiber.Ctx) error {
 
-// RegisterHandlersWithOptions creates http.Handler with additional options
-func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
-	wrapper := ServerInterfaceWrapper{
-		Handler: si,
-	}
+	c.Context().SetUserValue(ApiKeyAuthScopes, []string{})
 
-	for _, m := range options.Middlewares {
-		router.Use(fiber.Handler(m))
-	}
+	return siw.Handler.GetVaultsByAPIKey(c)
+}
 
-	router.Get(options.BaseURL+"/api/api-keys", wrapper.GetAPIKeys)
+// GetConfig operation middleware
+func (siw *ServerInterfaceWrapper) GetConfig(c *fiber.Ctx) error {
 
-	router.Post(options.BaseURL+"/api/api-keys", wrapper.CreateAPIKey)
+	return siw.Handler.GetConfig(c)
+}
 
-	router.Delete(options.BaseURL+"/api/api-keys/:id", wrapper.DeleteAPIKey)
+// Health operation middleware
+func (siw *ServerInterfaceWrapper) Health(c *fiber.Ctx) error {
 
-	router.Patch(options.BaseURL+"/api/api-keys/:id", wrapper.UpdateAPIKey)
+	return siw.Handler.Health(c)
+}
 
-	router.Get(options.BaseURL+"/api/audit-logs", wrapper.GetAuditLogs)
+// GetOrganizations operation middleware
+func (siw *ServerInterfaceWrapper) GetOrganizations(c *fiber.Ctx) error {
 
-	router.Get(options.BaseURL+"/api/audit-logs/metrics", wrapper.GetAuditMetrics)
+	return siw.Handler.GetOrganizations(c)
+}
 
-	router.Post(options.BaseURL+"/api/auth/login", wrapper.Login)
+// CreateOrganization operation middleware
+func (siw *ServerInterfaceWrapper) CreateOrganization(c *fiber.Ctx) error {
 
-	router.Get(options.BaseURL+"/api/auth/logout", wrapper.Logout)
+	return siw.Handler.CreateOrganization(c)
+}
 
-	router.Post(options.BaseURL+"/api/auth/magic-link/request", wrapper.RequestMagicLink)
+// DeleteOrganization operation middleware
+func (siw *ServerInterfaceWrapper) DeleteOrganization(c *fiber.Ctx) error {
 
-	router.Get(options.BaseURL+"/api/auth/magic-link/token", wrapper.ConsumeMagicLink)
+	var err error
 
-	router.Post(options.BaseURL+"/api/auth/password/change", wrapper.ChangePassword)
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	router.Post(options.BaseURL+"/api/auth/password/reset/confirm", wrapper.ConfirmPasswordReset)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-	router.Post(options.BaseURL+"/api/auth/password/reset/request", wrapper.RequestPasswordReset)
+	return siw.Handler.DeleteOrganization(c, id)
+}
 
-	router.Post(options.BaseURL+"/api/auth/signup", wrapper.Signup)
+// GetOrganization operation middleware
+func (siw *ServerInterfaceWrapper) GetOrganization(c *fiber.Ctx) error {
 
-	router.Get(options.BaseURL+"/api/cli/vault/name/:name", wrapper.GetVaultByNameAPIKey)
+	var err error
 
-	router.Put(options.BaseURL+"/api/cli/vault/name/:name", wrapper.UpdateVaultByNameAPIKey)
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	router.Get(options.BaseURL+"/api/cli/vault/:uniqueId", wrapper.GetVaultByAPIKey)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-	router.Put(options.BaseURL+"/api/cli/vault/:uniqueId", wrapper.UpdateVaultByAPIKey)
+	return siw.Handler.GetOrganization(c, id)
+}
 
-	router.Get(options.BaseURL+"/api/cli/vaults", wrapper.GetVaultsByAPIKey)
+// UpdateOrganization operation middleware
+func (siw *ServerInterfaceWrapper) UpdateOrganization(c *fiber.Ctx) error {
 
-	router.Get(options.BaseURL+"/api/config", wrapper.GetConfig)
+	var err error
 
-	router.Get(options.BaseURL+"/api/health", wrapper.Health)
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	router.Get(options.BaseURL+"/api/status", wrapper.GetStatus)
-
-	router.Get(options.BaseURL+"/api/user", wrapper.GetCurrentUser)
-
-	router.Get(options.BaseURL+"/api/vaults", wrapper.GetVaults)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-	router.Post(options.BaseURL+"/api/vaults", wrapper.CreateVault)
+	return siw.Handler.UpdateOrganization(c, id)
+}
 
-	router.Get(options.BaseURL+"/api/vaults/filter-options", wrapper.GetVaultFilterOptions)
+// GetOrganizationMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetOrganizationMembers(c *fiber.Ctx) error {
 
-	router.Delete(options.BaseURL+"/api/vaults/:uniqueId", wrapper.DeleteVault)
+	var err error
 
-	router.Get(options.BaseURL+"/api/vaults/:uniqueId", wrapper.GetVault)
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	router.Put(options.BaseURL+"/api/vaults/:uniqueId", wrapper.UpdateVault)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
+	return siw.Handler.GetOrganizationMembers(c, id)
 }
 
-type GetAPIKeysRequestObject struct {
-	Params GetAPIKeysParams
-}
+// AddOrganizationMember operation middleware
+func (siw *ServerInterfaceWrapper) AddOrganizationMember(c *fiber.Ctx) error {
 
-type GetAPIKeysResponseObject interface {
-	VisitGetAPIKeysResponse(ctx *fiber.Ctx) error
-}
+	var err error
 
-type GetAPIKeys200JSONResponse APIKeysResponse
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-func (response GetAPIKeys200JSONResponse) VisitGetAPIKeysResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
+	return siw.Handler.AddOrganizationMember(c, id)
 }
 
-type CreateAPIKeyRequestObject struct {
-	Body *CreateAPIKeyJSONRequestBody
-}
+// RemoveOrganizationMember operation middleware
+func (siw *ServerInterfaceWrapper) RemoveOrganizationMember(c *fiber.Ctx) error {
 
-type CreateAPIKeyResponseObject interface {
-	VisitCreateAPIKeyResponse(ctx *fiber.Ctx) error
-}
+	var err error
 
-type CreateAPIKey201JSONResponse CreateAPIKeyResponse
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-func (response CreateAPIKey201JSONResponse) VisitCreateAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(201)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
-}
+	// ------------- Path parameter "userId" -------------
+	var userId int64
 
-type DeleteAPIKeyRequestObject struct {
-	Id int64 `json:"id"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Params("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+	}
 
-type DeleteAPIKeyResponseObject interface {
-	VisitDeleteAPIKeyResponse(ctx *fiber.Ctx) error
+	return siw.Handler.RemoveOrganizationMember(c, id, userId)
 }
 
-type DeleteAPIKey204Response struct {
-}
+// UpdateOrganizationMember operation middleware
+func (siw *ServerInterfaceWrapper) UpdateOrganizationMember(c *fiber.Ctx) error {
 
-func (response DeleteAPIKey204Response) VisitDeleteAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Status(204)
-	return nil
-}
+	var err error
 
-type UpdateAPIKeyRequestObject struct {
-	Id   int64 `json:"id"`
-	Body *UpdateAPIKeyJSONRequestBody
-}
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-type UpdateAPIKeyResponseObject interface {
-	VisitUpdateAPIKeyResponse(ctx *fiber.Ctx) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-type UpdateAPIKey200JSONResponse VaultAPIKey
+	// ------------- Path parameter "userId" -------------
+	var userId int64
 
-func (response UpdateAPIKey200JSONResponse) VisitUpdateAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "userId", c.Params("userId"), &userId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter userId: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
+	return siw.Handler.UpdateOrganizationMember(c, id, userId)
 }
 
-type GetAuditLogsRequestObject struct {
-	Params GetAuditLogsParams
-}
+// RevokeSessions operation middleware
+func (siw *ServerInterfaceWrapper) RevokeSessions(c *fiber.Ctx) error {
 
-type GetAuditLogsResponseObject interface {
-	VisitGetAuditLogsResponse(ctx *fiber.Ctx) error
-}
+	var err error
 
-type GetAuditLogs200JSONResponse AuditLogsResponse
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RevokeSessionsParams
 
-func (response GetAuditLogs200JSONResponse) VisitGetAuditLogsResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
-}
+	// ------------- Optional query parameter "keepCurrent" -------------
 
-type GetAuditMetricsRequestObject struct {
+	err = runtime.BindQueryParameter("form", true, false, "keepCurrent", query, &params.KeepCurrent)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter keepCurrent: %w", err).Error())
+	}
+
+	return siw.Handler.RevokeSessions(c, params)
 }
 
-type GetAuditMetricsResponseObject interface {
-	VisitGetAuditMetricsResponse(ctx *fiber.Ctx) error
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(c *fiber.Ctx) error {
+
+	return siw.Handler.GetSessions(c)
 }
 
-type GetAuditMetrics200JSONResponse AuditMetricsResponse
+// RevokeSession operation middleware
+func (siw *ServerInterfaceWrapper) RevokeSession(c *fiber.Ctx) error {
 
-func (response GetAuditMetrics200JSONResponse) VisitGetAuditMetricsResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	var err error
 
-	return ctx.JSON(&response)
-}
+	// ------------- Path parameter "id" -------------
+	var id string
 
-type LoginRequestObject struct {
-	Body *LoginJSONRequestBody
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.RevokeSession(c, id)
 }
 
-type LoginResponseObject interface {
-	VisitLoginResponse(ctx *fiber.Ctx) error
+// CreateShare operation middleware
+func (siw *ServerInterfaceWrapper) CreateShare(c *fiber.Ctx) error {
+
+	return siw.Handler.CreateShare(c)
 }
 
-type Login200JSONResponse LoginResponse
+// GetShare operation middleware
+func (siw *ServerInterfaceWrapper) GetShare(c *fiber.Ctx) error {
 
-func (response Login200JSONResponse) VisitLoginResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	var err error
 
-	return ctx.JSON(&response)
-}
+	// ------------- Path parameter "id" -------------
+	var id string
 
-type LogoutRequestObject struct {
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-type LogoutResponseObject interface {
-	VisitLogoutResponse(ctx *fiber.Ctx) error
+	return siw.Handler.GetShare(c, id)
 }
 
-type Logout200Response struct {
-}
+// GetStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetStatus(c *fiber.Ctx) error {
 
-func (response Logout200Response) VisitLogoutResponse(ctx *fiber.Ctx) error {
-	ctx.Status(200)
-	return nil
+	return siw.Handler.GetStatus(c)
 }
 
-type RequestMagicLinkRequestObject struct {
-	Body *RequestMagicLinkJSONRequestBody
-}
+// GetCurrentUser operation middleware
+func (siw *ServerInterfaceWrapper) GetCurrentUser(c *fiber.Ctx) error {
 
-type RequestMagicLinkResponseObject interface {
-	VisitRequestMagicLinkResponse(ctx *fiber.Ctx) error
+	return siw.Handler.GetCurrentUser(c)
 }
 
-type RequestMagicLink200JSONResponse EmailTokenResponse
+// GetVaults operation middleware
+func (siw *ServerInterfaceWrapper) GetVaults(c *fiber.Ctx) error {
 
-func (response RequestMagicLink200JSONResponse) VisitRequestMagicLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	var err error
 
-	return ctx.JSON(&response)
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetVaultsParams
 
-type RequestMagicLink429ResponseHeaders struct {
-	RetryAfter string
-}
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
 
-type RequestMagicLink429JSONResponse struct {
-	Body    EmailTokenResponse
-	Headers RequestMagicLink429ResponseHeaders
-}
+	// ------------- Optional query parameter "pageSize" -------------
 
-func (response RequestMagicLink429JSONResponse) VisitRequestMagicLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(429)
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", query, &params.PageSize)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pageSize: %w", err).Error())
+	}
 
-	return ctx.JSON(&response.Body)
-}
+	// ------------- Optional query parameter "pageIndex" -------------
 
-type RequestMagicLink500JSONResponse EmailTokenResponse
+	err = runtime.BindQueryParameter("form", true, false, "pageIndex", query, &params.PageIndex)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pageIndex: %w", err).Error())
+	}
 
-func (response RequestMagicLink500JSONResponse) VisitRequestMagicLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	// ------------- Optional query parameter "expiring_within" -------------
 
-	return ctx.JSON(&response)
-}
+	err = runtime.BindQueryParameter("form", true, false, "expiring_within", query, &params.ExpiringWithin)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter expiring_within: %w", err).Error())
+	}
 
-type ConsumeMagicLinkRequestObject struct {
-	Params ConsumeMagicLinkParams
+	return siw.Handler.GetVaults(c, params)
 }
 
-type ConsumeMagicLinkResponseObject interface {
-	VisitConsumeMagicLinkResponse(ctx *fiber.Ctx) error
-}
+// CreateVault operation middleware
+func (siw *ServerInterfaceWrapper) CreateVault(c *fiber.Ctx) error {
 
-type ConsumeMagicLink302Response struct {
+	return siw.Handler.CreateVault(c)
 }
 
-func (response ConsumeMagicLink302Response) VisitConsumeMagicLinkResponse(ctx *fiber.Ctx) error {
-	ctx.Status(302)
-	return nil
-}
+// GetVaultFilterOptions operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultFilterOptions(c *fiber.Ctx) error {
 
-type ChangePasswordRequestObject struct {
-	Body *ChangePasswordJSONRequestBody
+	return siw.Handler.GetVaultFilterOptions(c)
 }
 
-type ChangePasswordResponseObject interface {
-	VisitChangePasswordResponse(ctx *fiber.Ctx) error
-}
+// DeleteVault operation middleware
+func (siw *ServerInterfaceWrapper) DeleteVault(c *fiber.Ctx) error {
 
-type ChangePassword200Response struct {
-}
+	var err error
 
-func (response ChangePassword200Response) VisitChangePasswordResponse(ctx *fiber.Ctx) error {
-	ctx.Status(200)
-	return nil
-}
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-type ChangePassword400Response struct {
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteVault(c, uniqueId)
 }
 
-func (response ChangePassword400Response) VisitChangePasswordResponse(ctx *fiber.Ctx) error {
-	ctx.Status(400)
-	return nil
-}
+// GetVault operation middleware
+func (siw *ServerInterfaceWrapper) GetVault(c *fiber.Ctx) error {
 
-type ChangePassword401Response struct {
-}
+	var err error
 
-func (response ChangePassword401Response) VisitChangePasswordResponse(ctx *fiber.Ctx) error {
-	ctx.Status(401)
-	return nil
-}
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-type ConfirmPasswordResetRequestObject struct {
-	Body *ConfirmPasswordResetJSONRequestBody
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-type ConfirmPasswordResetResponseObject interface {
-	VisitConfirmPasswordResetResponse(ctx *fiber.Ctx) error
+	return siw.Handler.GetVault(c, uniqueId)
 }
 
-type ConfirmPasswordReset200Response struct {
-}
+// UpdateVault operation middleware
+func (siw *ServerInterfaceWrapper) UpdateVault(c *fiber.Ctx) error {
 
-func (response ConfirmPasswordReset200Response) VisitConfirmPasswordResetResponse(ctx *fiber.Ctx) error {
-	ctx.Status(200)
-	return nil
-}
+	var err error
 
-type RequestPasswordResetRequestObject struct {
-	Body *RequestPasswordResetJSONRequestBody
-}
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-type RequestPasswordResetResponseObject interface {
-	VisitRequestPasswordResetResponse(ctx *fiber.Ctx) error
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
+
+	return siw.Handler.UpdateVault(c, uniqueId)
 }
 
-type RequestPasswordReset200JSONResponse EmailTokenResponse
+// DeleteVaultRotationPolicy operation middleware
+func (siw *ServerInterfaceWrapper) DeleteVaultRotationPolicy(c *fiber.Ctx) error {
 
-func (response RequestPasswordReset200JSONResponse) VisitRequestPasswordResetResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	var err error
 
-	return ctx.JSON(&response)
-}
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-type RequestPasswordReset429ResponseHeaders struct {
-	RetryAfter string
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-type RequestPasswordReset429JSONResponse struct {
-	Body    EmailTokenResponse
-	Headers RequestPasswordReset429ResponseHeaders
+	return siw.Handler.DeleteVaultRotationPolicy(c, uniqueId)
 }
 
-func (response RequestPasswordReset429JSONResponse) VisitRequestPasswordResetResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(429)
+// GetVaultRotationPolicy operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultRotationPolicy(c *fiber.Ctx) error {
 
-	return ctx.JSON(&response.Body)
-}
+	var err error
 
-type RequestPasswordReset500JSONResponse EmailTokenResponse
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-func (response RequestPasswordReset500JSONResponse) VisitRequestPasswordResetResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(500)
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
+	return siw.Handler.GetVaultRotationPolicy(c, uniqueId)
 }
 
-type SignupRequestObject struct {
-	Body *SignupJSONRequestBody
-}
+// SetVaultRotationPolicy operation middleware
+func (siw *ServerInterfaceWrapper) SetVaultRotationPolicy(c *fiber.Ctx) error {
 
-type SignupResponseObject interface {
-	VisitSignupResponse(ctx *fiber.Ctx) error
-}
+	var err error
 
-type Signup200JSONResponse SignupResponse
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-func (response Signup200JSONResponse) VisitSignupResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
+	return siw.Handler.SetVaultRotationPolicy(c, uniqueId)
 }
 
-type GetVaultByNameAPIKeyRequestObject struct {
-	Name string `json:"name"`
-}
+// RotateVault operation middleware
+func (siw *ServerInterfaceWrapper) RotateVault(c *fiber.Ctx) error {
 
-type GetVaultByNameAPIKeyResponseObject interface {
-	VisitGetVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error
-}
+	var err error
 
-type GetVaultByNameAPIKey200JSONResponse Vault
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-func (response GetVaultByNameAPIKey200JSONResponse) VisitGetVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
+	return siw.Handler.RotateVault(c, uniqueId)
 }
 
-type UpdateVaultByNameAPIKeyRequestObject struct {
-	Name string `json:"name"`
-	Body *UpdateVaultByNameAPIKeyJSONRequestBody
-}
+// GetVaultVersions operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultVersions(c *fiber.Ctx) error {
 
-type UpdateVaultByNameAPIKeyResponseObject interface {
-	VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error
-}
+	var err error
 
-type UpdateVaultByNameAPIKey200JSONResponse Vault
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-func (response UpdateVaultByNameAPIKey200JSONResponse) VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
+	return siw.Handler.GetVaultVersions(c, uniqueId)
 }
 
-type UpdateVaultByNameAPIKey400Response struct {
-}
+// GetVaultVersion operation middleware
+func (siw *ServerInterfaceWrapper) GetVaultVersion(c *fiber.Ctx) error {
 
-func (response UpdateVaultByNameAPIKey400Response) VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Status(400)
-	return nil
-}
+	var err error
 
-type UpdateVaultByNameAPIKey403Response struct {
-}
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-func (response UpdateVaultByNameAPIKey403Response) VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Status(403)
-	return nil
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-type UpdateVaultByNameAPIKey404Response struct {
-}
+	// ------------- Path parameter "version" -------------
+	var version int
 
-func (response UpdateVaultByNameAPIKey404Response) VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Status(404)
-	return nil
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "version", c.Params("version"), &version, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter version: %w", err).Error())
+	}
 
-type GetVaultByAPIKeyRequestObject struct {
-	UniqueId string `json:"uniqueId"`
+	return siw.Handler.GetVaultVersion(c, uniqueId, version)
 }
 
-type GetVaultByAPIKeyResponseObject interface {
-	VisitGetVaultByAPIKeyResponse(ctx *fiber.Ctx) error
-}
+// RestoreVaultVersion operation middleware
+func (siw *ServerInterfaceWrapper) RestoreVaultVersion(c *fiber.Ctx) error {
 
-type GetVaultByAPIKey200JSONResponse Vault
+	var err error
 
-func (response GetVaultByAPIKey200JSONResponse) VisitGetVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	// ------------- Path parameter "uniqueId" -------------
+	var uniqueId string
 
-	return ctx.JSON(&response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "uniqueId", c.Params("uniqueId"), &uniqueId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter uniqueId: %w", err).Error())
+	}
 
-type UpdateVaultByAPIKeyRequestObject struct {
-	UniqueId string `json:"uniqueId"`
-	Body     *UpdateVaultByAPIKeyJSONRequestBody
-}
+	// ------------- Path parameter "version" -------------
+	var version int
 
-type UpdateVaultByAPIKeyResponseObject interface {
-	VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "version", c.Params("version"), &version, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter version: %w", err).Error())
+	}
 
-type UpdateVaultByAPIKey200JSONResponse Vault
+	return siw.Handler.RestoreVaultVersion(c, uniqueId, version)
+}
 
-func (response UpdateVaultByAPIKey200JSONResponse) VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+// GetWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhooks(c *fiber.Ctx) error {
 
-	return ctx.JSON(&response)
+	return siw.Handler.GetWebhooks(c)
 }
 
-type UpdateVaultByAPIKey400Response struct {
-}
+// CreateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) CreateWebhook(c *fiber.Ctx) error {
 
-func (response UpdateVaultByAPIKey400Response) VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Status(400)
-	return nil
+	return siw.Handler.CreateWebhook(c)
 }
 
-type UpdateVaultByAPIKey403Response struct {
-}
+// DeleteWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWebhook(c *fiber.Ctx) error {
 
-func (response UpdateVaultByAPIKey403Response) VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Status(403)
-	return nil
-}
+	var err error
 
-type UpdateVaultByAPIKey404Response struct {
-}
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-func (response UpdateVaultByAPIKey404Response) VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Status(404)
-	return nil
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-type GetVaultsByAPIKeyRequestObject struct {
+	return siw.Handler.DeleteWebhook(c, id)
 }
 
-type GetVaultsByAPIKeyResponseObject interface {
-	VisitGetVaultsByAPIKeyResponse(ctx *fiber.Ctx) error
-}
+// GetWebhook operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhook(c *fiber.Ctx) error {
 
-type GetVaultsByAPIKey200JSONResponse []VaultLite
+	var err error
 
-func (response GetVaultsByAPIKey200JSONResponse) VisitGetVaultsByAPIKeyResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	return ctx.JSON(&response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-type GetConfigRequestObject struct {
+	return siw.Handler.GetWebhook(c, id)
 }
 
-type GetConfigResponseObject interface {
-	VisitGetConfigResponse(ctx *fiber.Ctx) error
-}
+// UpdateWebhook operation middleware
+func (siw *ServerInterfaceWrapper) UpdateWebhook(c *fiber.Ctx) error {
 
-type GetConfig200JSONResponse ConfigResponse
+	var err error
 
-func (response GetConfig200JSONResponse) VisitGetConfigResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	return ctx.JSON(&response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-type HealthRequestObject struct {
+	return siw.Handler.UpdateWebhook(c, id)
 }
 
-type HealthResponseObject interface {
-	VisitHealthResponse(ctx *fiber.Ctx) error
-}
+// GetWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) GetWebhookDeliveries(c *fiber.Ctx) error {
 
-type Health200JSONResponse HealthCheckResponse
+	var err error
 
-func (response Health200JSONResponse) VisitHealthResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	// ------------- Path parameter "id" -------------
+	var id int64
 
-	return ctx.JSON(&response)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
 
-type GetStatusRequestObject struct {
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhookDeliveriesParams
 
-type GetStatusResponseObject interface {
-	VisitGetStatusResponse(ctx *fiber.Ctx) error
-}
+	var query url.Values
+	query, err = url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for query string: %w", err).Error())
+	}
 
-type GetStatus200JSONResponse StatusResponse
+	// ------------- Optional query parameter "pageSize" -------------
 
-func (response GetStatus200JSONResponse) VisitGetStatusResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	err = runtime.BindQueryParameter("form", true, false, "pageSize", query, &params.PageSize)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pageSize: %w", err).Error())
+	}
 
-	return ctx.JSON(&response)
-}
+	// ------------- Optional query parameter "pageIndex" -------------
 
-type GetCurrentUserRequestObject struct {
-}
+	err = runtime.BindQueryParameter("form", true, false, "pageIndex", query, &params.PageIndex)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter pageIndex: %w", err).Error())
+	}
 
-type GetCurrentUserResponseObject interface {
-	VisitGetCurrentUserResponse(ctx *fiber.Ctx) error
+	return siw.Handler.GetWebhookDeliveries(c, id, params)
 }
 
-type GetCurrentUser200JSONResponse GetUserResponse
+// TestWebhook operation middleware
+func (siw *ServerInterfaceWrapper) TestWebhook(c *fiber.Ctx) error {
 
-func (response GetCurrentUser200JSONResponse) VisitGetCurrentUserResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	var err error
 
-	return ctx.JSON(&response)
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.TestWebhook(c, id)
 }
 
-type GetVaultsRequestObject struct {
-	Params GetVaultsParams
+// GetWorkloadIdentities operation middleware
+func (siw *ServerInterfaceWrapper) GetWorkloadIdentities(c *fiber.Ctx) error {
+
+	return siw.Handler.GetWorkloadIdentities(c)
 }
 
-type GetVaultsResponseObject interface {
-	VisitGetVaultsResponse(ctx *fiber.Ctx) error
+// CreateWorkloadIdentity operation middleware
+func (siw *ServerInterfaceWrapper) CreateWorkloadIdentity(c *fiber.Ctx) error {
+
+	return siw.Handler.CreateWorkloadIdentity(c)
 }
 
-type GetVaults200JSONResponse VaultsResponse
+// DeleteWorkloadIdentity operation middleware
+func (siw *ServerInterfaceWrapper) DeleteWorkloadIdentity(c *fiber.Ctx) error {
 
-func (response GetVaults200JSONResponse) VisitGetVaultsResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	var err error
 
-	return ctx.JSON(&response)
+	// ------------- Path parameter "id" -------------
+	var id int64
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", c.Params("id"), &id, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Errorf("Invalid format for parameter id: %w", err).Error())
+	}
+
+	return siw.Handler.DeleteWorkloadIdentity(c, id)
 }
 
-type CreateVaultRequestObject struct {
-	Body *CreateVaultJSONRequestBody
+// FiberServerOptions provides options for the Fiber server.
+type FiberServerOptions struct {
+	BaseURL     string
+	Middlewares []MiddlewareFunc
 }
 
-type CreateVaultResponseObject interface {
-	VisitCreateVaultResponse(ctx *fiber.Ctx) error
+// RegisterHandlers creates http.Handler with routing matching OpenAPI spec.
+func RegisterHandlers(router fiber.Router, si ServerInterface) {
+	RegisterHandlersWithOptions(router, si, FiberServerOptions{})
 }
 
-type CreateVault201JSONResponse Vault
+// RegisterHandlersWithOptions creates http.Handler with additional options
+func RegisterHandlersWithOptions(router fiber.Router, si ServerInterface, options FiberServerOptions) {
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
 
-func (response CreateVault201JSONResponse) VisitCreateVaultResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(201)
+	for _, m := range options.Middlewares {
+		router.Use(fiber.Handler(m))
+	}
 
-	return ctx.JSON(&response)
-}
+	router.Get(options.BaseURL+"/api/api-keys", wrapper.GetAPIKeys)
 
-type GetVaultFilterOptionsRequestObject struct {
-}
+	router.Post(options.BaseURL+"/api/api-keys", wrapper.CreateAPIKey)
 
-type GetVaultFilterOptionsResponseObject interface {
-	VisitGetVaultFilterOptionsResponse(ctx *fiber.Ctx) error
-}
+	router.Delete(options.BaseURL+"/api/api-keys/:id", wrapper.DeleteAPIKey)
 
-type GetVaultFilterOptions200JSONResponse VaultFilterOptionsResponse
+	router.Patch(options.BaseURL+"/api/api-keys/:id", wrapper.UpdateAPIKey)
 
-func (response GetVaultFilterOptions200JSONResponse) VisitGetVaultFilterOptionsResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	router.Get(options.BaseURL+"/api/audit-logs", wrapper.GetAuditLogs)
 
-	return ctx.JSON(&response)
-}
+	router.Get(options.BaseURL+"/api/audit-logs/export", wrapper.ExportAuditLogs)
 
-type DeleteVaultRequestObject struct {
-	UniqueId string `json:"uniqueId"`
-}
+	router.Get(options.BaseURL+"/api/audit-logs/metrics", wrapper.GetAuditMetrics)
 
-type DeleteVaultResponseObject interface {
-	VisitDeleteVaultResponse(ctx *fiber.Ctx) error
-}
+	router.Get(options.BaseURL+"/api/audit-logs/verify", wrapper.VerifyAuditLogs)
 
-type DeleteVault204Response struct {
-}
+	router.Get(options.BaseURL+"/api/auth/2fa", wrapper.GetTwoFactorStatus)
 
-func (response DeleteVault204Response) VisitDeleteVaultResponse(ctx *fiber.Ctx) error {
-	ctx.Status(204)
-	return nil
-}
+	router.Post(options.BaseURL+"/api/auth/2fa/recovery-codes", wrapper.RegenerateRecoveryCodes)
 
-type GetVaultRequestObject struct {
-	UniqueId string `json:"uniqueId"`
-}
+	router.Post(options.BaseURL+"/api/auth/2fa/totp/disable", wrapper.DisableTOTP)
 
-type GetVaultResponseObject interface {
-	VisitGetVaultResponse(ctx *fiber.Ctx) error
-}
+	router.Post(options.BaseURL+"/api/auth/2fa/totp/enable", wrapper.EnableTOTP)
 
-type GetVault200JSONResponse Vault
+	router.Post(options.BaseURL+"/api/auth/2fa/totp/setup", wrapper.SetupTOTP)
 
-func (response GetVault200JSONResponse) VisitGetVaultResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	router.Post(options.BaseURL+"/api/auth/login", wrapper.Login)
 
-	return ctx.JSON(&response)
-}
+	router.Post(options.BaseURL+"/api/auth/login/2fa", wrapper.LoginTwoFactor)
 
-type UpdateVaultRequestObject struct {
-	UniqueId string `json:"uniqueId"`
-	Body     *UpdateVaultJSONRequestBody
-}
+	router.Get(options.BaseURL+"/api/auth/logout", wrapper.Logout)
 
-type UpdateVaultResponseObject interface {
-	VisitUpdateVaultResponse(ctx *fiber.Ctx) error
-}
+	router.Post(options.BaseURL+"/api/auth/magic-link/request", wrapper.RequestMagicLink)
 
-type UpdateVault200JSONResponse Vault
+	router.Get(options.BaseURL+"/api/auth/magic-link/token", wrapper.ConsumeMagicLink)
 
-func (response UpdateVault200JSONResponse) VisitUpdateVaultResponse(ctx *fiber.Ctx) error {
-	ctx.Response().Header.Set("Content-Type", "application/json")
-	ctx.Status(200)
+	router.Post(options.BaseURL+"/api/auth/password/change", wrapper.ChangePassword)
 
-	return ctx.JSON(&response)
-}
+	router.Post(options.BaseURL+"/api/auth/password/reset/confirm", wrapper.ConfirmPasswordReset)
 
-// StrictServerInterface represents all server handlers.
-type StrictServerInterface interface {
+	router.Post(options.BaseURL+"/api/auth/password/reset/request", wrapper.RequestPasswordReset)
 
-	// (GET /api/api-keys)
-	GetAPIKeys(ctx context.Context, request GetAPIKeysRequestObject) (GetAPIKeysResponseObject, error)
+	router.Post(options.BaseURL+"/api/auth/refresh", wrapper.RefreshToken)
 
-	// (POST /api/api-keys)
-	CreateAPIKey(ctx context.Context, request CreateAPIKeyRequestObject) (CreateAPIKeyResponseObject, error)
+	router.Post(options.BaseURL+"/api/auth/signup", wrapper.Signup)
 
-	// (DELETE /api/api-keys/{id})
-	DeleteAPIKey(ctx context.Context, request DeleteAPIKeyRequestObject) (DeleteAPIKeyResponseObject, error)
+	router.Post(options.BaseURL+"/api/auth/token-exchange", wrapper.TokenExchange)
 
-	// (PATCH /api/api-keys/{id})
-	UpdateAPIKey(ctx context.Context, request UpdateAPIKeyRequestObject) (UpdateAPIKeyResponseObject, error)
+	router.Get(options.BaseURL+"/api/auth/webauthn/credentials", wrapper.GetWebAuthnCredentials)
 
-	// (GET /api/audit-logs)
-	GetAuditLogs(ctx context.Context, request GetAuditLogsRequestObject) (GetAuditLogsResponseObject, error)
+	router.Delete(options.BaseURL+"/api/auth/webauthn/credentials/:id", wrapper.DeleteWebAuthnCredential)
 
-	// (GET /api/audit-logs/metrics)
-	GetAuditMetrics(ctx context.Context, request GetAuditMetricsRequestObject) (GetAuditMetricsResponseObject, error)
+	router.Post(options.BaseURL+"/api/auth/webauthn/login/begin", wrapper.BeginWebAuthnLogin)
 
-	// (POST /api/auth/login)
-	Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error)
+	router.Post(options.BaseURL+"/api/auth/webauthn/login/finish", wrapper.FinishWebAuthnLogin)
 
-	// (GET /api/auth/logout)
-	Logout(ctx context.Context, request LogoutRequestObject) (LogoutResponseObject, error)
+	router.Post(options.BaseURL+"/api/auth/webauthn/register/begin", wrapper.BeginWebAuthnRegistration)
 
-	// (POST /api/auth/magic-link/request)
-	RequestMagicLink(ctx context.Context, request RequestMagicLinkRequestObject) (RequestMagicLinkResponseObject, error)
+	router.Post(options.BaseURL+"/api/auth/webauthn/register/finish", wrapper.FinishWebAuthnRegistration)
 
-	// (GET /api/auth/magic-link/token)
-	ConsumeMagicLink(ctx context.Context, request ConsumeMagicLinkRequestObject) (ConsumeMagicLinkResponseObject, error)
+	router.Get(options.BaseURL+"/api/cli/events", wrapper.StreamVaultEvents)
 
-	// (POST /api/auth/password/change)
-	ChangePassword(ctx context.Context, request ChangePasswordRequestObject) (ChangePasswordResponseObject, error)
+	router.Post(options.BaseURL+"/api/cli/unwrap", wrapper.Unwrap)
 
-	// (POST /api/auth/password/reset/confirm)
-	ConfirmPasswordReset(ctx context.Context, request ConfirmPasswordResetRequestObject) (ConfirmPasswordResetResponseObject, error)
+	router.Get(options.BaseURL+"/api/cli/vault/name/:name", wrapper.GetVaultByNameAPIKey)
 
-	// (POST /api/auth/password/reset/request)
-	RequestPasswordReset(ctx context.Context, request RequestPasswordResetRequestObject) (RequestPasswordResetResponseObject, error)
+	router.Put(options.BaseURL+"/api/cli/vault/name/:name", wrapper.UpdateVaultByNameAPIKey)
 
-	// (POST /api/auth/signup)
-	Signup(ctx context.Context, request SignupRequestObject) (SignupResponseObject, error)
+	router.Get(options.BaseURL+"/api/cli/vault/name/:name/keys/:key", wrapper.GetVaultKeyByNameAPIKey)
 
-	// (GET /api/cli/vault/name/{name})
-	GetVaultByNameAPIKey(ctx context.Context, request GetVaultByNameAPIKeyRequestObject) (GetVaultByNameAPIKeyResponseObject, error)
+	router.Put(options.BaseURL+"/api/cli/vault/name/:name/keys/:key", wrapper.SetVaultKeyByNameAPIKey)
+
+	router.Get(options.BaseURL+"/api/cli/vault/:uniqueId", wrapper.GetVaultByAPIKey)
+
+	router.Put(options.BaseURL+"/api/cli/vault/:uniqueId", wrapper.UpdateVaultByAPIKey)
+
+	router.Get(options.BaseURL+"/api/cli/vault/:uniqueId/keys/:key", wrapper.GetVaultKeyByAPIKey)
+
+	router.Put(options.BaseURL+"/api/cli/vault/:uniqueId/keys/:key", wrapper.SetVaultKeyByAPIKey)
+
+	router.Get(options.BaseURL+"/api/cli/vault/:uniqueId/versions", wrapper.GetVaultVersionsByAPIKey)
+
+	router.Get(options.BaseURL+"/api/cli/vaults", wrapper.GetVaultsByAPIKey)
+
+	router.Get(options.BaseURL+"/api/config", wrapper.GetConfig)
+
+	router.Get(options.BaseURL+"/api/health", wrapper.Health)
+
+	router.Get(options.BaseURL+"/api/organizations", wrapper.GetOrganizations)
+
+	router.Post(options.BaseURL+"/api/organizations", wrapper.CreateOrganization)
+
+	router.Delete(options.BaseURL+"/api/organizations/:id", wrapper.DeleteOrganization)
+
+	router.Get(options.BaseURL+"/api/organizations/:id", wrapper.GetOrganization)
+
+	router.Put(options.BaseURL+"/api/organizations/:id", wrapper.UpdateOrganization)
+
+	router.Get(options.BaseURL+"/api/organizations/:id/members", wrapper.GetOrganizationMembers)
+
+	router.Post(options.BaseURL+"/api/organizations/:id/members", wrapper.AddOrganizationMember)
+
+	router.Delete(options.BaseURL+"/api/organizations/:id/members/:userId", wrapper.RemoveOrganizationMember)
+
+	router.Put(options.BaseURL+"/api/organizations/:id/members/:userId", wrapper.UpdateOrganizationMember)
+
+	router.Delete(options.BaseURL+"/api/sessions", wrapper.RevokeSessions)
+
+	router.Get(options.BaseURL+"/api/sessions", wrapper.GetSessions)
+
+	router.Delete(options.BaseURL+"/api/sessions/:id", wrapper.RevokeSession)
+
+	router.Post(options.BaseURL+"/api/shares", wrapper.CreateShare)
+
+	router.Get(options.BaseURL+"/api/shares/:id", wrapper.GetShare)
+
+	router.Get(options.BaseURL+"/api/status", wrapper.GetStatus)
+
+	router.Get(options.BaseURL+"/api/user", wrapper.GetCurrentUser)
+
+	router.Get(options.BaseURL+"/api/vaults", wrapper.GetVaults)
+
+	router.Post(options.BaseURL+"/api/vaults", wrapper.CreateVault)
+
+	router.Get(options.BaseURL+"/api/vaults/filter-options", wrapper.GetVaultFilterOptions)
+
+	router.Delete(options.BaseURL+"/api/vaults/:uniqueId", wrapper.DeleteVault)
+
+	router.Get(options.BaseURL+"/api/vaults/:uniqueId", wrapper.GetVault)
+
+	router.Put(options.BaseURL+"/api/vaults/:uniqueId", wrapper.UpdateVault)
+
+	router.Delete(options.BaseURL+"/api/vaults/:uniqueId/rotation", wrapper.DeleteVaultRotationPolicy)
+
+	router.Get(options.BaseURL+"/api/vaults/:uniqueId/rotation", wrapper.GetVaultRotationPolicy)
+
+	router.Put(options.BaseURL+"/api/vaults/:uniqueId/rotation", wrapper.SetVaultRotationPolicy)
+
+	router.Post(options.BaseURL+"/api/vaults/:uniqueId/rotation/rotate", wrapper.RotateVault)
+
+	router.Get(options.BaseURL+"/api/vaults/:uniqueId/versions", wrapper.GetVaultVersions)
+
+	router.Get(options.BaseURL+"/api/vaults/:uniqueId/versions/:version", wrapper.GetVaultVersion)
+
+	router.Post(options.BaseURL+"/api/vaults/:uniqueId/versions/:version/restore", wrapper.RestoreVaultVersion)
+
+	router.Get(options.BaseURL+"/api/webhooks", wrapper.GetWebhooks)
+
+	router.Post(options.BaseURL+"/api/webhooks", wrapper.CreateWebhook)
+
+	router.Delete(options.BaseURL+"/api/webhooks/:id", wrapper.DeleteWebhook)
+
+	router.Get(options.BaseURL+"/api/webhooks/:id", wrapper.GetWebhook)
+
+	router.Put(options.BaseURL+"/api/webhooks/:id", wrapper.UpdateWebhook)
+
+	router.Get(options.BaseURL+"/api/webhooks/:id/deliveries", wrapper.GetWebhookDeliveries)
+
+	router.Post(options.BaseURL+"/api/webhooks/:id/test", wrapper.TestWebhook)
+
+	router.Get(options.BaseURL+"/api/workload-identities", wrapper.GetWorkloadIdentities)
+
+	router.Post(options.BaseURL+"/api/workload-identities", wrapper.CreateWorkloadIdentity)
+
+	router.Delete(options.BaseURL+"/api/workload-identities/:id", wrapper.DeleteWorkloadIdentity)
+
+}
+
+type GetAPIKeysRequestObject struct {
+	Params GetAPIKeysParams
+}
+
+type GetAPIKeysResponseObject interface {
+	VisitGetAPIKeysResponse(ctx *fiber.Ctx) error
+}
+
+type GetAPIKeys200JSONResponse APIKeysResponse
+
+func (response GetAPIKeys200JSONResponse) VisitGetAPIKeysResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateAPIKeyRequestObject struct {
+	Body *CreateAPIKeyJSONRequestBody
+}
+
+type CreateAPIKeyResponseObject interface {
+	VisitCreateAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type CreateAPIKey201JSONResponse CreateAPIKeyResponse
+
+func (response CreateAPIKey201JSONResponse) VisitCreateAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(201)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteAPIKeyRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteAPIKeyResponseObject interface {
+	VisitDeleteAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteAPIKey204Response struct {
+}
+
+func (response DeleteAPIKey204Response) VisitDeleteAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type UpdateAPIKeyRequestObject struct {
+	Id   int64 `json:"id"`
+	Body *UpdateAPIKeyJSONRequestBody
+}
+
+type UpdateAPIKeyResponseObject interface {
+	VisitUpdateAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateAPIKey200JSONResponse VaultAPIKey
+
+func (response UpdateAPIKey200JSONResponse) VisitUpdateAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetAuditLogsRequestObject struct {
+	Params GetAuditLogsParams
+}
+
+type GetAuditLogsResponseObject interface {
+	VisitGetAuditLogsResponse(ctx *fiber.Ctx) error
+}
+
+type GetAuditLogs200JSONResponse AuditLogsResponse
+
+func (response GetAuditLogs200JSONResponse) VisitGetAuditLogsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type ExportAuditLogsRequestObject struct {
+	Params ExportAuditLogsParams
+}
+
+type ExportAuditLogsResponseObject interface {
+	VisitExportAuditLogsResponse(ctx *fiber.Ctx) error
+}
+
+type ExportAuditLogs200ApplicationxNdjsonResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportAuditLogs200ApplicationxNdjsonResponse) VisitExportAuditLogsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/x-ndjson")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type ExportAuditLogs200TextcsvResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response ExportAuditLogs200TextcsvResponse) VisitExportAuditLogsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "text/csv")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type GetAuditMetricsRequestObject struct {
+}
+
+type GetAuditMetricsResponseObject interface {
+	VisitGetAuditMetricsResponse(ctx *fiber.Ctx) error
+}
+
+type GetAuditMetrics200JSONResponse AuditMetricsResponse
+
+func (response GetAuditMetrics200JSONResponse) VisitGetAuditMetricsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type VerifyAuditLogsRequestObject struct {
+}
+
+type VerifyAuditLogsResponseObject interface {
+	VisitVerifyAuditLogsResponse(ctx *fiber.Ctx) error
+}
+
+type VerifyAuditLogs200JSONResponse AuditChainVerification
+
+func (response VerifyAuditLogs200JSONResponse) VisitVerifyAuditLogsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetTwoFactorStatusRequestObject struct {
+}
+
+type GetTwoFactorStatusResponseObject interface {
+	VisitGetTwoFactorStatusResponse(ctx *fiber.Ctx) error
+}
+
+type GetTwoFactorStatus200JSONResponse TwoFactorStatusResponse
+
+func (response GetTwoFactorStatus200JSONResponse) VisitGetTwoFactorStatusResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RegenerateRecoveryCodesRequestObject struct {
+	Body *RegenerateRecoveryCodesJSONRequestBody
+}
+
+type RegenerateRecoveryCodesResponseObject interface {
+	VisitRegenerateRecoveryCodesResponse(ctx *fiber.Ctx) error
+}
+
+type RegenerateRecoveryCodes200JSONResponse RecoveryCodesResponse
+
+func (response RegenerateRecoveryCodes200JSONResponse) VisitRegenerateRecoveryCodesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RegenerateRecoveryCodes400Response struct {
+}
+
+func (response RegenerateRecoveryCodes400Response) VisitRegenerateRecoveryCodesResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type DisableTOTPRequestObject struct {
+	Body *DisableTOTPJSONRequestBody
+}
+
+type DisableTOTPResponseObject interface {
+	VisitDisableTOTPResponse(ctx *fiber.Ctx) error
+}
+
+type DisableTOTP200Response struct {
+}
+
+func (response DisableTOTP200Response) VisitDisableTOTPResponse(ctx *fiber.Ctx) error {
+	ctx.Status(200)
+	return nil
+}
+
+type DisableTOTP400Response struct {
+}
+
+func (response DisableTOTP400Response) VisitDisableTOTPResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type DisableTOTP401Response struct {
+}
+
+func (response DisableTOTP401Response) VisitDisableTOTPResponse(ctx *fiber.Ctx) error {
+	ctx.Status(401)
+	return nil
+}
+
+type EnableTOTPRequestObject struct {
+	Body *EnableTOTPJSONRequestBody
+}
+
+type EnableTOTPResponseObject interface {
+	VisitEnableTOTPResponse(ctx *fiber.Ctx) error
+}
+
+type EnableTOTP200JSONResponse RecoveryCodesResponse
+
+func (response EnableTOTP200JSONResponse) VisitEnableTOTPResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type EnableTOTP400Response struct {
+}
+
+func (response EnableTOTP400Response) VisitEnableTOTPResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type SetupTOTPRequestObject struct {
+}
+
+type SetupTOTPResponseObject interface {
+	VisitSetupTOTPResponse(ctx *fiber.Ctx) error
+}
+
+type SetupTOTP200JSONResponse TOTPSetupResponse
+
+func (response SetupTOTP200JSONResponse) VisitSetupTOTPResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type SetupTOTP400Response struct {
+}
+
+func (response SetupTOTP400Response) VisitSetupTOTPResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type LoginRequestObject struct {
+	Body *LoginJSONRequestBody
+}
+
+type LoginResponseObject interface {
+	VisitLoginResponse(ctx *fiber.Ctx) error
+}
+
+type Login200JSONResponse LoginResponse
+
+func (response Login200JSONResponse) VisitLoginResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type Login429Response struct {
+}
+
+func (response Login429Response) VisitLoginResponse(ctx *fiber.Ctx) error {
+	ctx.Status(429)
+	return nil
+}
+
+type Login503Response struct {
+}
+
+func (response Login503Response) VisitLoginResponse(ctx *fiber.Ctx) error {
+	ctx.Status(503)
+	return nil
+}
+
+type LoginTwoFactorRequestObject struct {
+	Body *LoginTwoFactorJSONRequestBody
+}
+
+type LoginTwoFactorResponseObject interface {
+	VisitLoginTwoFactorResponse(ctx *fiber.Ctx) error
+}
+
+type LoginTwoFactor200JSONResponse LoginResponse
+
+func (response LoginTwoFactor200JSONResponse) VisitLoginTwoFactorResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type LoginTwoFactor400Response struct {
+}
+
+func (response LoginTwoFactor400Response) VisitLoginTwoFactorResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type LoginTwoFactor401Response struct {
+}
+
+func (response LoginTwoFactor401Response) VisitLoginTwoFactorResponse(ctx *fiber.Ctx) error {
+	ctx.Status(401)
+	return nil
+}
+
+type LoginTwoFactor429Response struct {
+}
+
+func (response LoginTwoFactor429Response) VisitLoginTwoFactorResponse(ctx *fiber.Ctx) error {
+	ctx.Status(429)
+	return nil
+}
+
+type LogoutRequestObject struct {
+}
+
+type LogoutResponseObject interface {
+	VisitLogoutResponse(ctx *fiber.Ctx) error
+}
+
+type Logout200Response struct {
+}
+
+func (response Logout200Response) VisitLogoutResponse(ctx *fiber.Ctx) error {
+	ctx.Status(200)
+	return nil
+}
+
+type RequestMagicLinkRequestObject struct {
+	Body *RequestMagicLinkJSONRequestBody
+}
+
+type RequestMagicLinkResponseObject interface {
+	VisitRequestMagicLinkResponse(ctx *fiber.Ctx) error
+}
+
+type RequestMagicLink200JSONResponse EmailTokenResponse
+
+func (response RequestMagicLink200JSONResponse) VisitRequestMagicLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RequestMagicLink429ResponseHeaders struct {
+	RetryAfter string
+}
+
+type RequestMagicLink429JSONResponse struct {
+	Body    EmailTokenResponse
+	Headers RequestMagicLink429ResponseHeaders
+}
+
+func (response RequestMagicLink429JSONResponse) VisitRequestMagicLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type RequestMagicLink500JSONResponse EmailTokenResponse
+
+func (response RequestMagicLink500JSONResponse) VisitRequestMagicLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type ConsumeMagicLinkRequestObject struct {
+	Params ConsumeMagicLinkParams
+}
+
+type ConsumeMagicLinkResponseObject interface {
+	VisitConsumeMagicLinkResponse(ctx *fiber.Ctx) error
+}
+
+type ConsumeMagicLink302Response struct {
+}
+
+func (response ConsumeMagicLink302Response) VisitConsumeMagicLinkResponse(ctx *fiber.Ctx) error {
+	ctx.Status(302)
+	return nil
+}
+
+type ChangePasswordRequestObject struct {
+	Body *ChangePasswordJSONRequestBody
+}
+
+type ChangePasswordResponseObject interface {
+	VisitChangePasswordResponse(ctx *fiber.Ctx) error
+}
+
+type ChangePassword200Response struct {
+}
+
+func (response ChangePassword200Response) VisitChangePasswordResponse(ctx *fiber.Ctx) error {
+	ctx.Status(200)
+	return nil
+}
+
+type ChangePassword400Response struct {
+}
+
+func (response ChangePassword400Response) VisitChangePasswordResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type ChangePassword401Response struct {
+}
+
+func (response ChangePassword401Response) VisitChangePasswordResponse(ctx *fiber.Ctx) error {
+	ctx.Status(401)
+	return nil
+}
+
+type ConfirmPasswordResetRequestObject struct {
+	Body *ConfirmPasswordResetJSONRequestBody
+}
+
+type ConfirmPasswordResetResponseObject interface {
+	VisitConfirmPasswordResetResponse(ctx *fiber.Ctx) error
+}
+
+type ConfirmPasswordReset200Response struct {
+}
+
+func (response ConfirmPasswordReset200Response) VisitConfirmPasswordResetResponse(ctx *fiber.Ctx) error {
+	ctx.Status(200)
+	return nil
+}
+
+type RequestPasswordResetRequestObject struct {
+	Body *RequestPasswordResetJSONRequestBody
+}
+
+type RequestPasswordResetResponseObject interface {
+	VisitRequestPasswordResetResponse(ctx *fiber.Ctx) error
+}
+
+type RequestPasswordReset200JSONResponse EmailTokenResponse
+
+func (response RequestPasswordReset200JSONResponse) VisitRequestPasswordResetResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RequestPasswordReset429ResponseHeaders struct {
+	RetryAfter string
+}
+
+type RequestPasswordReset429JSONResponse struct {
+	Body    EmailTokenResponse
+	Headers RequestPasswordReset429ResponseHeaders
+}
+
+func (response RequestPasswordReset429JSONResponse) VisitRequestPasswordResetResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Retry-After", fmt.Sprint(response.Headers.RetryAfter))
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(429)
+
+	return ctx.JSON(&response.Body)
+}
+
+type RequestPasswordReset500JSONResponse EmailTokenResponse
+
+func (response RequestPasswordReset500JSONResponse) VisitRequestPasswordResetResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(500)
+
+	return ctx.JSON(&response)
+}
+
+type RefreshTokenRequestObject struct {
+	Body *RefreshTokenJSONRequestBody
+}
+
+type RefreshTokenResponseObject interface {
+	VisitRefreshTokenResponse(ctx *fiber.Ctx) error
+}
+
+type RefreshToken200JSONResponse TokenResponse
+
+func (response RefreshToken200JSONResponse) VisitRefreshTokenResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RefreshToken401Response struct {
+}
+
+func (response RefreshToken401Response) VisitRefreshTokenResponse(ctx *fiber.Ctx) error {
+	ctx.Status(401)
+	return nil
+}
+
+type SignupRequestObject struct {
+	Body *SignupJSONRequestBody
+}
+
+type SignupResponseObject interface {
+	VisitSignupResponse(ctx *fiber.Ctx) error
+}
+
+type Signup200JSONResponse SignupResponse
+
+func (response Signup200JSONResponse) VisitSignupResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type TokenExchangeRequestObject struct {
+	Body *TokenExchangeJSONRequestBody
+}
+
+type TokenExchangeResponseObject interface {
+	VisitTokenExchangeResponse(ctx *fiber.Ctx) error
+}
+
+type TokenExchange200JSONResponse TokenExchangeResponse
+
+func (response TokenExchange200JSONResponse) VisitTokenExchangeResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type TokenExchange400Response struct {
+}
+
+func (response TokenExchange400Response) VisitTokenExchangeResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type TokenExchange401Response struct {
+}
+
+func (response TokenExchange401Response) VisitTokenExchangeResponse(ctx *fiber.Ctx) error {
+	ctx.Status(401)
+	return nil
+}
+
+type TokenExchange403Response struct {
+}
+
+func (response TokenExchange403Response) VisitTokenExchangeResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type TokenExchange404Response struct {
+}
+
+func (response TokenExchange404Response) VisitTokenExchangeResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type TokenExchange429Response struct {
+}
+
+func (response TokenExchange429Response) VisitTokenExchangeResponse(ctx *fiber.Ctx) error {
+	ctx.Status(429)
+	return nil
+}
+
+type TokenExchange503Response struct {
+}
+
+func (response TokenExchange503Response) VisitTokenExchangeResponse(ctx *fiber.Ctx) error {
+	ctx.Status(503)
+	return nil
+}
+
+type GetWebAuthnCredentialsRequestObject struct {
+}
+
+type GetWebAuthnCredentialsResponseObject interface {
+	VisitGetWebAuthnCredentialsResponse(ctx *fiber.Ctx) error
+}
+
+type GetWebAuthnCredentials200JSONResponse WebAuthnCredentialsResponse
+
+func (response GetWebAuthnCredentials200JSONResponse) VisitGetWebAuthnCredentialsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteWebAuthnCredentialRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteWebAuthnCredentialResponseObject interface {
+	VisitDeleteWebAuthnCredentialResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteWebAuthnCredential204Response struct {
+}
+
+func (response DeleteWebAuthnCredential204Response) VisitDeleteWebAuthnCredentialResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type DeleteWebAuthnCredential404Response struct {
+}
+
+func (response DeleteWebAuthnCredential404Response) VisitDeleteWebAuthnCredentialResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type BeginWebAuthnLoginRequestObject struct {
+}
+
+type BeginWebAuthnLoginResponseObject interface {
+	VisitBeginWebAuthnLoginResponse(ctx *fiber.Ctx) error
+}
+
+type BeginWebAuthnLogin200JSONResponse WebAuthnBeginResponse
+
+func (response BeginWebAuthnLogin200JSONResponse) VisitBeginWebAuthnLoginResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type BeginWebAuthnLogin404Response struct {
+}
+
+func (response BeginWebAuthnLogin404Response) VisitBeginWebAuthnLoginResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type FinishWebAuthnLoginRequestObject struct {
+	Body *FinishWebAuthnLoginJSONRequestBody
+}
+
+type FinishWebAuthnLoginResponseObject interface {
+	VisitFinishWebAuthnLoginResponse(ctx *fiber.Ctx) error
+}
+
+type FinishWebAuthnLogin200JSONResponse LoginResponse
+
+func (response FinishWebAuthnLogin200JSONResponse) VisitFinishWebAuthnLoginResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type FinishWebAuthnLogin401Response struct {
+}
+
+func (response FinishWebAuthnLogin401Response) VisitFinishWebAuthnLoginResponse(ctx *fiber.Ctx) error {
+	ctx.Status(401)
+	return nil
+}
+
+type FinishWebAuthnLogin404Response struct {
+}
+
+func (response FinishWebAuthnLogin404Response) VisitFinishWebAuthnLoginResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type BeginWebAuthnRegistrationRequestObject struct {
+}
+
+type BeginWebAuthnRegistrationResponseObject interface {
+	VisitBeginWebAuthnRegistrationResponse(ctx *fiber.Ctx) error
+}
+
+type BeginWebAuthnRegistration200JSONResponse WebAuthnBeginResponse
+
+func (response BeginWebAuthnRegistration200JSONResponse) VisitBeginWebAuthnRegistrationResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type BeginWebAuthnRegistration404Response struct {
+}
+
+func (response BeginWebAuthnRegistration404Response) VisitBeginWebAuthnRegistrationResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type FinishWebAuthnRegistrationRequestObject struct {
+	Body *FinishWebAuthnRegistrationJSONRequestBody
+}
+
+type FinishWebAuthnRegistrationResponseObject interface {
+	VisitFinishWebAuthnRegistrationResponse(ctx *fiber.Ctx) error
+}
+
+type FinishWebAuthnRegistration201JSONResponse WebAuthnCredential
+
+func (response FinishWebAuthnRegistration201JSONResponse) VisitFinishWebAuthnRegistrationResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(201)
+
+	return ctx.JSON(&response)
+}
+
+type FinishWebAuthnRegistration400Response struct {
+}
+
+func (response FinishWebAuthnRegistration400Response) VisitFinishWebAuthnRegistrationResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type FinishWebAuthnRegistration404Response struct {
+}
+
+func (response FinishWebAuthnRegistration404Response) VisitFinishWebAuthnRegistrationResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type StreamVaultEventsRequestObject struct {
+	Params StreamVaultEventsParams
+}
+
+type StreamVaultEventsResponseObject interface {
+	VisitStreamVaultEventsResponse(ctx *fiber.Ctx) error
+}
+
+type StreamVaultEvents200TexteventStreamResponse struct {
+	Body          io.Reader
+	ContentLength int64
+}
+
+func (response StreamVaultEvents200TexteventStreamResponse) VisitStreamVaultEventsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "text/event-stream")
+	if response.ContentLength != 0 {
+		ctx.Response().Header.Set("Content-Length", fmt.Sprint(response.ContentLength))
+	}
+	ctx.Status(200)
+
+	if closer, ok := response.Body.(io.ReadCloser); ok {
+		defer closer.Close()
+	}
+	_, err := io.Copy(ctx.Response().BodyWriter(), response.Body)
+	return err
+}
+
+type UnwrapRequestObject struct {
+	Body *UnwrapJSONRequestBody
+}
+
+type UnwrapResponseObject interface {
+	VisitUnwrapResponse(ctx *fiber.Ctx) error
+}
+
+type Unwrap200JSONResponse Vault
+
+func (response Unwrap200JSONResponse) VisitUnwrapResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type Unwrap400Response struct {
+}
+
+func (response Unwrap400Response) VisitUnwrapResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type Unwrap429Response struct {
+}
+
+func (response Unwrap429Response) VisitUnwrapResponse(ctx *fiber.Ctx) error {
+	ctx.Status(429)
+	return nil
+}
+
+type GetVaultByNameAPIKeyRequestObject struct {
+	Name   string `json:"name"`
+	Params GetVaultByNameAPIKeyParams
+}
+
+type GetVaultByNameAPIKeyResponseObject interface {
+	VisitGetVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultByNameAPIKey200JSONResponse struct {
+	union json.RawMessage
+}
+
+func (response GetVaultByNameAPIKey200JSONResponse) VisitGetVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response.union)
+}
+
+type GetVaultByNameAPIKey400Response struct {
+}
+
+func (response GetVaultByNameAPIKey400Response) VisitGetVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type GetVaultByNameAPIKey403Response struct {
+}
+
+func (response GetVaultByNameAPIKey403Response) VisitGetVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type GetVaultByNameAPIKey404Response struct {
+}
+
+func (response GetVaultByNameAPIKey404Response) VisitGetVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetVaultByNameAPIKey502Response struct {
+}
+
+func (response GetVaultByNameAPIKey502Response) VisitGetVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(502)
+	return nil
+}
+
+type UpdateVaultByNameAPIKeyRequestObject struct {
+	Name string `json:"name"`
+	Body *UpdateVaultByNameAPIKeyJSONRequestBody
+}
+
+type UpdateVaultByNameAPIKeyResponseObject interface {
+	VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateVaultByNameAPIKey200JSONResponse Vault
+
+func (response UpdateVaultByNameAPIKey200JSONResponse) VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateVaultByNameAPIKey400Response struct {
+}
+
+func (response UpdateVaultByNameAPIKey400Response) VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type UpdateVaultByNameAPIKey403Response struct {
+}
+
+func (response UpdateVaultByNameAPIKey403Response) VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type UpdateVaultByNameAPIKey404Response struct {
+}
+
+func (response UpdateVaultByNameAPIKey404Response) VisitUpdateVaultByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetVaultKeyByNameAPIKeyRequestObject struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+type GetVaultKeyByNameAPIKeyResponseObject interface {
+	VisitGetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultKeyByNameAPIKey200JSONResponse VaultKeyValue
+
+func (response GetVaultKeyByNameAPIKey200JSONResponse) VisitGetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetVaultKeyByNameAPIKey400Response struct {
+}
+
+func (response GetVaultKeyByNameAPIKey400Response) VisitGetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type GetVaultKeyByNameAPIKey403Response struct {
+}
+
+func (response GetVaultKeyByNameAPIKey403Response) VisitGetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type GetVaultKeyByNameAPIKey404Response struct {
+}
+
+func (response GetVaultKeyByNameAPIKey404Response) VisitGetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type SetVaultKeyByNameAPIKeyRequestObject struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	Body *SetVaultKeyByNameAPIKeyJSONRequestBody
+}
+
+type SetVaultKeyByNameAPIKeyResponseObject interface {
+	VisitSetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type SetVaultKeyByNameAPIKey204Response struct {
+}
+
+func (response SetVaultKeyByNameAPIKey204Response) VisitSetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type SetVaultKeyByNameAPIKey400Response struct {
+}
+
+func (response SetVaultKeyByNameAPIKey400Response) VisitSetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type SetVaultKeyByNameAPIKey403Response struct {
+}
+
+func (response SetVaultKeyByNameAPIKey403Response) VisitSetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type SetVaultKeyByNameAPIKey404Response struct {
+}
+
+func (response SetVaultKeyByNameAPIKey404Response) VisitSetVaultKeyByNameAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetVaultByAPIKeyRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+	Params   GetVaultByAPIKeyParams
+}
+
+type GetVaultByAPIKeyResponseObject interface {
+	VisitGetVaultByAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultByAPIKey200JSONResponse struct {
+	union json.RawMessage
+}
+
+func (response GetVaultByAPIKey200JSONResponse) VisitGetVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response.union)
+}
+
+type GetVaultByAPIKey400Response struct {
+}
+
+func (response GetVaultByAPIKey400Response) VisitGetVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type GetVaultByAPIKey403Response struct {
+}
+
+func (response GetVaultByAPIKey403Response) VisitGetVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type GetVaultByAPIKey404Response struct {
+}
+
+func (response GetVaultByAPIKey404Response) VisitGetVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetVaultByAPIKey502Response struct {
+}
+
+func (response GetVaultByAPIKey502Response) VisitGetVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(502)
+	return nil
+}
+
+type UpdateVaultByAPIKeyRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+	Body     *UpdateVaultByAPIKeyJSONRequestBody
+}
+
+type UpdateVaultByAPIKeyResponseObject interface {
+	VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateVaultByAPIKey200JSONResponse Vault
+
+func (response UpdateVaultByAPIKey200JSONResponse) VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateVaultByAPIKey400Response struct {
+}
+
+func (response UpdateVaultByAPIKey400Response) VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type UpdateVaultByAPIKey403Response struct {
+}
+
+func (response UpdateVaultByAPIKey403Response) VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type UpdateVaultByAPIKey404Response struct {
+}
+
+func (response UpdateVaultByAPIKey404Response) VisitUpdateVaultByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetVaultKeyByAPIKeyRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+	Key      string `json:"key"`
+}
+
+type GetVaultKeyByAPIKeyResponseObject interface {
+	VisitGetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultKeyByAPIKey200JSONResponse VaultKeyValue
+
+func (response GetVaultKeyByAPIKey200JSONResponse) VisitGetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetVaultKeyByAPIKey400Response struct {
+}
+
+func (response GetVaultKeyByAPIKey400Response) VisitGetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type GetVaultKeyByAPIKey403Response struct {
+}
+
+func (response GetVaultKeyByAPIKey403Response) VisitGetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type GetVaultKeyByAPIKey404Response struct {
+}
+
+func (response GetVaultKeyByAPIKey404Response) VisitGetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type SetVaultKeyByAPIKeyRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+	Key      string `json:"key"`
+	Body     *SetVaultKeyByAPIKeyJSONRequestBody
+}
+
+type SetVaultKeyByAPIKeyResponseObject interface {
+	VisitSetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type SetVaultKeyByAPIKey204Response struct {
+}
+
+func (response SetVaultKeyByAPIKey204Response) VisitSetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type SetVaultKeyByAPIKey400Response struct {
+}
+
+func (response SetVaultKeyByAPIKey400Response) VisitSetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type SetVaultKeyByAPIKey403Response struct {
+}
+
+func (response SetVaultKeyByAPIKey403Response) VisitSetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type SetVaultKeyByAPIKey404Response struct {
+}
+
+func (response SetVaultKeyByAPIKey404Response) VisitSetVaultKeyByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetVaultVersionsByAPIKeyRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+}
+
+type GetVaultVersionsByAPIKeyResponseObject interface {
+	VisitGetVaultVersionsByAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultVersionsByAPIKey200JSONResponse VaultVersionsResponse
+
+func (response GetVaultVersionsByAPIKey200JSONResponse) VisitGetVaultVersionsByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetVaultVersionsByAPIKey403Response struct {
+}
+
+func (response GetVaultVersionsByAPIKey403Response) VisitGetVaultVersionsByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type GetVaultVersionsByAPIKey404Response struct {
+}
+
+func (response GetVaultVersionsByAPIKey404Response) VisitGetVaultVersionsByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetVaultsByAPIKeyRequestObject struct {
+}
+
+type GetVaultsByAPIKeyResponseObject interface {
+	VisitGetVaultsByAPIKeyResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultsByAPIKey200JSONResponse []VaultLite
+
+func (response GetVaultsByAPIKey200JSONResponse) VisitGetVaultsByAPIKeyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetConfigRequestObject struct {
+}
+
+type GetConfigResponseObject interface {
+	VisitGetConfigResponse(ctx *fiber.Ctx) error
+}
+
+type GetConfig200JSONResponse ConfigResponse
+
+func (response GetConfig200JSONResponse) VisitGetConfigResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type HealthRequestObject struct {
+}
+
+type HealthResponseObject interface {
+	VisitHealthResponse(ctx *fiber.Ctx) error
+}
+
+type Health200JSONResponse HealthCheckResponse
+
+func (response Health200JSONResponse) VisitHealthResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetOrganizationsRequestObject struct {
+}
+
+type GetOrganizationsResponseObject interface {
+	VisitGetOrganizationsResponse(ctx *fiber.Ctx) error
+}
+
+type GetOrganizations200JSONResponse OrganizationsResponse
+
+func (response GetOrganizations200JSONResponse) VisitGetOrganizationsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateOrganizationRequestObject struct {
+	Body *CreateOrganizationJSONRequestBody
+}
+
+type CreateOrganizationResponseObject interface {
+	VisitCreateOrganizationResponse(ctx *fiber.Ctx) error
+}
+
+type CreateOrganization201JSONResponse Organization
+
+func (response CreateOrganization201JSONResponse) VisitCreateOrganizationResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(201)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteOrganizationRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteOrganizationResponseObject interface {
+	VisitDeleteOrganizationResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteOrganization204Response struct {
+}
+
+func (response DeleteOrganization204Response) VisitDeleteOrganizationResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type DeleteOrganization403Response struct {
+}
+
+func (response DeleteOrganization403Response) VisitDeleteOrganizationResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type DeleteOrganization409Response struct {
+}
+
+func (response DeleteOrganization409Response) VisitDeleteOrganizationResponse(ctx *fiber.Ctx) error {
+	ctx.Status(409)
+	return nil
+}
+
+type GetOrganizationRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetOrganizationResponseObject interface {
+	VisitGetOrganizationResponse(ctx *fiber.Ctx) error
+}
+
+type GetOrganization200JSONResponse Organization
+
+func (response GetOrganization200JSONResponse) VisitGetOrganizationResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetOrganization404Response struct {
+}
+
+func (response GetOrganization404Response) VisitGetOrganizationResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type UpdateOrganizationRequestObject struct {
+	Id   int64 `json:"id"`
+	Body *UpdateOrganizationJSONRequestBody
+}
+
+type UpdateOrganizationResponseObject interface {
+	VisitUpdateOrganizationResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateOrganization200JSONResponse Organization
+
+func (response UpdateOrganization200JSONResponse) VisitUpdateOrganizationResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateOrganization403Response struct {
+}
+
+func (response UpdateOrganization403Response) VisitUpdateOrganizationResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type GetOrganizationMembersRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetOrganizationMembersResponseObject interface {
+	VisitGetOrganizationMembersResponse(ctx *fiber.Ctx) error
+}
+
+type GetOrganizationMembers200JSONResponse OrganizationMembersResponse
+
+func (response GetOrganizationMembers200JSONResponse) VisitGetOrganizationMembersResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type AddOrganizationMemberRequestObject struct {
+	Id   int64 `json:"id"`
+	Body *AddOrganizationMemberJSONRequestBody
+}
+
+type AddOrganizationMemberResponseObject interface {
+	VisitAddOrganizationMemberResponse(ctx *fiber.Ctx) error
+}
+
+type AddOrganizationMember201JSONResponse OrganizationMember
+
+func (response AddOrganizationMember201JSONResponse) VisitAddOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(201)
+
+	return ctx.JSON(&response)
+}
+
+type AddOrganizationMember403Response struct {
+}
+
+func (response AddOrganizationMember403Response) VisitAddOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type AddOrganizationMember409Response struct {
+}
+
+func (response AddOrganizationMember409Response) VisitAddOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Status(409)
+	return nil
+}
+
+type RemoveOrganizationMemberRequestObject struct {
+	Id     int64 `json:"id"`
+	UserId int64 `json:"userId"`
+}
+
+type RemoveOrganizationMemberResponseObject interface {
+	VisitRemoveOrganizationMemberResponse(ctx *fiber.Ctx) error
+}
+
+type RemoveOrganizationMember204Response struct {
+}
+
+func (response RemoveOrganizationMember204Response) VisitRemoveOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type RemoveOrganizationMember403Response struct {
+}
+
+func (response RemoveOrganizationMember403Response) VisitRemoveOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type RemoveOrganizationMember409Response struct {
+}
+
+func (response RemoveOrganizationMember409Response) VisitRemoveOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Status(409)
+	return nil
+}
+
+type UpdateOrganizationMemberRequestObject struct {
+	Id     int64 `json:"id"`
+	UserId int64 `json:"userId"`
+	Body   *UpdateOrganizationMemberJSONRequestBody
+}
+
+type UpdateOrganizationMemberResponseObject interface {
+	VisitUpdateOrganizationMemberResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateOrganizationMember200JSONResponse OrganizationMember
+
+func (response UpdateOrganizationMember200JSONResponse) VisitUpdateOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateOrganizationMember403Response struct {
+}
+
+func (response UpdateOrganizationMember403Response) VisitUpdateOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type UpdateOrganizationMember409Response struct {
+}
+
+func (response UpdateOrganizationMember409Response) VisitUpdateOrganizationMemberResponse(ctx *fiber.Ctx) error {
+	ctx.Status(409)
+	return nil
+}
+
+type RevokeSessionsRequestObject struct {
+	Params RevokeSessionsParams
+}
+
+type RevokeSessionsResponseObject interface {
+	VisitRevokeSessionsResponse(ctx *fiber.Ctx) error
+}
+
+type RevokeSessions200JSONResponse RevokeSessionsResponse
+
+func (response RevokeSessions200JSONResponse) VisitRevokeSessionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetSessionsRequestObject struct {
+}
+
+type GetSessionsResponseObject interface {
+	VisitGetSessionsResponse(ctx *fiber.Ctx) error
+}
+
+type GetSessions200JSONResponse SessionsResponse
+
+func (response GetSessions200JSONResponse) VisitGetSessionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RevokeSessionRequestObject struct {
+	Id string `json:"id"`
+}
+
+type RevokeSessionResponseObject interface {
+	VisitRevokeSessionResponse(ctx *fiber.Ctx) error
+}
+
+type RevokeSession204Response struct {
+}
+
+func (response RevokeSession204Response) VisitRevokeSessionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type RevokeSession404Response struct {
+}
+
+func (response RevokeSession404Response) VisitRevokeSessionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type CreateShareRequestObject struct {
+	Body *CreateShareJSONRequestBody
+}
+
+type CreateShareResponseObject interface {
+	VisitCreateShareResponse(ctx *fiber.Ctx) error
+}
+
+type CreateShare201JSONResponse CreateShareResponse
+
+func (response CreateShare201JSONResponse) VisitCreateShareResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(201)
+
+	return ctx.JSON(&response)
+}
+
+type CreateShare400Response struct {
+}
+
+func (response CreateShare400Response) VisitCreateShareResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type CreateShare404Response struct {
+}
+
+func (response CreateShare404Response) VisitCreateShareResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetShareRequestObject struct {
+	Id string `json:"id"`
+}
+
+type GetShareResponseObject interface {
+	VisitGetShareResponse(ctx *fiber.Ctx) error
+}
+
+type GetShare200JSONResponse SharedSecret
+
+func (response GetShare200JSONResponse) VisitGetShareResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetShare404Response struct {
+}
+
+func (response GetShare404Response) VisitGetShareResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetShare429Response struct {
+}
+
+func (response GetShare429Response) VisitGetShareResponse(ctx *fiber.Ctx) error {
+	ctx.Status(429)
+	return nil
+}
+
+type GetStatusRequestObject struct {
+}
+
+type GetStatusResponseObject interface {
+	VisitGetStatusResponse(ctx *fiber.Ctx) error
+}
+
+type GetStatus200JSONResponse StatusResponse
+
+func (response GetStatus200JSONResponse) VisitGetStatusResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetCurrentUserRequestObject struct {
+}
+
+type GetCurrentUserResponseObject interface {
+	VisitGetCurrentUserResponse(ctx *fiber.Ctx) error
+}
+
+type GetCurrentUser200JSONResponse GetUserResponse
+
+func (response GetCurrentUser200JSONResponse) VisitGetCurrentUserResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetVaultsRequestObject struct {
+	Params GetVaultsParams
+}
+
+type GetVaultsResponseObject interface {
+	VisitGetVaultsResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaults200JSONResponse VaultsResponse
+
+func (response GetVaults200JSONResponse) VisitGetVaultsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateVaultRequestObject struct {
+	Body *CreateVaultJSONRequestBody
+}
+
+type CreateVaultResponseObject interface {
+	VisitCreateVaultResponse(ctx *fiber.Ctx) error
+}
+
+type CreateVault201JSONResponse Vault
+
+func (response CreateVault201JSONResponse) VisitCreateVaultResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(201)
+
+	return ctx.JSON(&response)
+}
+
+type GetVaultFilterOptionsRequestObject struct {
+}
+
+type GetVaultFilterOptionsResponseObject interface {
+	VisitGetVaultFilterOptionsResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultFilterOptions200JSONResponse VaultFilterOptionsResponse
+
+func (response GetVaultFilterOptions200JSONResponse) VisitGetVaultFilterOptionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteVaultRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+}
+
+type DeleteVaultResponseObject interface {
+	VisitDeleteVaultResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteVault204Response struct {
+}
+
+func (response DeleteVault204Response) VisitDeleteVaultResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type GetVaultRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+}
+
+type GetVaultResponseObject interface {
+	VisitGetVaultResponse(ctx *fiber.Ctx) error
+}
+
+type GetVault200JSONResponse Vault
+
+func (response GetVault200JSONResponse) VisitGetVaultResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateVaultRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+	Body     *UpdateVaultJSONRequestBody
+}
+
+type UpdateVaultResponseObject interface {
+	VisitUpdateVaultResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateVault200JSONResponse Vault
+
+func (response UpdateVault200JSONResponse) VisitUpdateVaultResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type DeleteVaultRotationPolicyRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+}
+
+type DeleteVaultRotationPolicyResponseObject interface {
+	VisitDeleteVaultRotationPolicyResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteVaultRotationPolicy204Response struct {
+}
+
+func (response DeleteVaultRotationPolicy204Response) VisitDeleteVaultRotationPolicyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type GetVaultRotationPolicyRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+}
+
+type GetVaultRotationPolicyResponseObject interface {
+	VisitGetVaultRotationPolicyResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultRotationPolicy200JSONResponse RotationPolicy
+
+func (response GetVaultRotationPolicy200JSONResponse) VisitGetVaultRotationPolicyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetVaultRotationPolicy404Response struct {
+}
+
+func (response GetVaultRotationPolicy404Response) VisitGetVaultRotationPolicyResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type SetVaultRotationPolicyRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+	Body     *SetVaultRotationPolicyJSONRequestBody
+}
+
+type SetVaultRotationPolicyResponseObject interface {
+	VisitSetVaultRotationPolicyResponse(ctx *fiber.Ctx) error
+}
+
+type SetVaultRotationPolicy200JSONResponse RotationPolicy
+
+func (response SetVaultRotationPolicy200JSONResponse) VisitSetVaultRotationPolicyResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RotateVaultRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+}
+
+type RotateVaultResponseObject interface {
+	VisitRotateVaultResponse(ctx *fiber.Ctx) error
+}
+
+type RotateVault200JSONResponse Vault
+
+func (response RotateVault200JSONResponse) VisitRotateVaultResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RotateVault404Response struct {
+}
+
+func (response RotateVault404Response) VisitRotateVaultResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type RotateVault409Response struct {
+}
+
+func (response RotateVault409Response) VisitRotateVaultResponse(ctx *fiber.Ctx) error {
+	ctx.Status(409)
+	return nil
+}
+
+type RotateVault502Response struct {
+}
+
+func (response RotateVault502Response) VisitRotateVaultResponse(ctx *fiber.Ctx) error {
+	ctx.Status(502)
+	return nil
+}
+
+type GetVaultVersionsRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+}
+
+type GetVaultVersionsResponseObject interface {
+	VisitGetVaultVersionsResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultVersions200JSONResponse VaultVersionsResponse
+
+func (response GetVaultVersions200JSONResponse) VisitGetVaultVersionsResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetVaultVersions404Response struct {
+}
+
+func (response GetVaultVersions404Response) VisitGetVaultVersionsResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetVaultVersionRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+	Version  int    `json:"version"`
+}
+
+type GetVaultVersionResponseObject interface {
+	VisitGetVaultVersionResponse(ctx *fiber.Ctx) error
+}
+
+type GetVaultVersion200JSONResponse VaultVersion
+
+func (response GetVaultVersion200JSONResponse) VisitGetVaultVersionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetVaultVersion404Response struct {
+}
+
+func (response GetVaultVersion404Response) VisitGetVaultVersionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type RestoreVaultVersionRequestObject struct {
+	UniqueId string `json:"uniqueId"`
+	Version  int    `json:"version"`
+}
+
+type RestoreVaultVersionResponseObject interface {
+	VisitRestoreVaultVersionResponse(ctx *fiber.Ctx) error
+}
+
+type RestoreVaultVersion200JSONResponse Vault
+
+func (response RestoreVaultVersion200JSONResponse) VisitRestoreVaultVersionResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type RestoreVaultVersion404Response struct {
+}
+
+func (response RestoreVaultVersion404Response) VisitRestoreVaultVersionResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetWebhooksRequestObject struct {
+}
+
+type GetWebhooksResponseObject interface {
+	VisitGetWebhooksResponse(ctx *fiber.Ctx) error
+}
+
+type GetWebhooks200JSONResponse WebhooksResponse
+
+func (response GetWebhooks200JSONResponse) VisitGetWebhooksResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWebhookRequestObject struct {
+	Body *CreateWebhookJSONRequestBody
+}
+
+type CreateWebhookResponseObject interface {
+	VisitCreateWebhookResponse(ctx *fiber.Ctx) error
+}
+
+type CreateWebhook201JSONResponse CreateWebhookResponse
+
+func (response CreateWebhook201JSONResponse) VisitCreateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(201)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWebhook403Response struct {
+}
+
+func (response CreateWebhook403Response) VisitCreateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Status(403)
+	return nil
+}
+
+type DeleteWebhookRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteWebhookResponseObject interface {
+	VisitDeleteWebhookResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteWebhook204Response struct {
+}
+
+func (response DeleteWebhook204Response) VisitDeleteWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type DeleteWebhook404Response struct {
+}
+
+func (response DeleteWebhook404Response) VisitDeleteWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetWebhookRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type GetWebhookResponseObject interface {
+	VisitGetWebhookResponse(ctx *fiber.Ctx) error
+}
+
+type GetWebhook200JSONResponse Webhook
+
+func (response GetWebhook200JSONResponse) VisitGetWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhook404Response struct {
+}
+
+func (response GetWebhook404Response) VisitGetWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type UpdateWebhookRequestObject struct {
+	Id   int64 `json:"id"`
+	Body *UpdateWebhookJSONRequestBody
+}
+
+type UpdateWebhookResponseObject interface {
+	VisitUpdateWebhookResponse(ctx *fiber.Ctx) error
+}
+
+type UpdateWebhook200JSONResponse Webhook
+
+func (response UpdateWebhook200JSONResponse) VisitUpdateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type UpdateWebhook404Response struct {
+}
+
+func (response UpdateWebhook404Response) VisitUpdateWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetWebhookDeliveriesRequestObject struct {
+	Id     int64 `json:"id"`
+	Params GetWebhookDeliveriesParams
+}
+
+type GetWebhookDeliveriesResponseObject interface {
+	VisitGetWebhookDeliveriesResponse(ctx *fiber.Ctx) error
+}
+
+type GetWebhookDeliveries200JSONResponse WebhookDeliveriesResponse
+
+func (response GetWebhookDeliveries200JSONResponse) VisitGetWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type GetWebhookDeliveries404Response struct {
+}
+
+func (response GetWebhookDeliveries404Response) VisitGetWebhookDeliveriesResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type TestWebhookRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type TestWebhookResponseObject interface {
+	VisitTestWebhookResponse(ctx *fiber.Ctx) error
+}
+
+type TestWebhook200JSONResponse WebhookDelivery
+
+func (response TestWebhook200JSONResponse) VisitTestWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type TestWebhook404Response struct {
+}
+
+func (response TestWebhook404Response) VisitTestWebhookResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+type GetWorkloadIdentitiesRequestObject struct {
+}
+
+type GetWorkloadIdentitiesResponseObject interface {
+	VisitGetWorkloadIdentitiesResponse(ctx *fiber.Ctx) error
+}
+
+type GetWorkloadIdentities200JSONResponse WorkloadIdentitiesResponse
+
+func (response GetWorkloadIdentities200JSONResponse) VisitGetWorkloadIdentitiesResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(200)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWorkloadIdentityRequestObject struct {
+	Body *CreateWorkloadIdentityJSONRequestBody
+}
+
+type CreateWorkloadIdentityResponseObject interface {
+	VisitCreateWorkloadIdentityResponse(ctx *fiber.Ctx) error
+}
+
+type CreateWorkloadIdentity201JSONResponse WorkloadIdentity
+
+func (response CreateWorkloadIdentity201JSONResponse) VisitCreateWorkloadIdentityResponse(ctx *fiber.Ctx) error {
+	ctx.Response().Header.Set("Content-Type", "application/json")
+	ctx.Status(201)
+
+	return ctx.JSON(&response)
+}
+
+type CreateWorkloadIdentity400Response struct {
+}
+
+func (response CreateWorkloadIdentity400Response) VisitCreateWorkloadIdentityResponse(ctx *fiber.Ctx) error {
+	ctx.Status(400)
+	return nil
+}
+
+type DeleteWorkloadIdentityRequestObject struct {
+	Id int64 `json:"id"`
+}
+
+type DeleteWorkloadIdentityResponseObject interface {
+	VisitDeleteWorkloadIdentityResponse(ctx *fiber.Ctx) error
+}
+
+type DeleteWorkloadIdentity204Response struct {
+}
+
+func (response DeleteWorkloadIdentity204Response) VisitDeleteWorkloadIdentityResponse(ctx *fiber.Ctx) error {
+	ctx.Status(204)
+	return nil
+}
+
+type DeleteWorkloadIdentity404Response struct {
+}
+
+func (response DeleteWorkloadIdentity404Response) VisitDeleteWorkloadIdentityResponse(ctx *fiber.Ctx) error {
+	ctx.Status(404)
+	return nil
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+
+	// (GET /api/api-keys)
+	GetAPIKeys(ctx context.Context, request GetAPIKeysRequestObject) (GetAPIKeysResponseObject, error)
+
+	// (POST /api/api-keys)
+	CreateAPIKey(ctx context.Context, request CreateAPIKeyRequestObject) (CreateAPIKeyResponseObject, error)
+
+	// (DELETE /api/api-keys/{id})
+	DeleteAPIKey(ctx context.Context, request DeleteAPIKeyRequestObject) (DeleteAPIKeyResponseObject, error)
+
+	// (PATCH /api/api-keys/{id})
+	UpdateAPIKey(ctx context.Context, request UpdateAPIKeyRequestObject) (UpdateAPIKeyResponseObject, error)
+
+	// (GET /api/audit-logs)
+	GetAuditLogs(ctx context.Context, request GetAuditLogsRequestObject) (GetAuditLogsResponseObject, error)
+
+	// (GET /api/audit-logs/export)
+	ExportAuditLogs(ctx context.Context, request ExportAuditLogsRequestObject) (ExportAuditLogsResponseObject, error)
+
+	// (GET /api/audit-logs/metrics)
+	GetAuditMetrics(ctx context.Context, request GetAuditMetricsRequestObject) (GetAuditMetricsResponseObject, error)
+
+	// (GET /api/audit-logs/verify)
+	VerifyAuditLogs(ctx context.Context, request VerifyAuditLogsRequestObject) (VerifyAuditLogsResponseObject, error)
+
+	// (GET /api/auth/2fa)
+	GetTwoFactorStatus(ctx context.Context, request GetTwoFactorStatusRequestObject) (GetTwoFactorStatusResponseObject, error)
+
+	// (POST /api/auth/2fa/recovery-codes)
+	RegenerateRecoveryCodes(ctx context.Context, request RegenerateRecoveryCodesRequestObject) (RegenerateRecoveryCodesResponseObject, error)
+
+	// (POST /api/auth/2fa/totp/disable)
+	DisableTOTP(ctx context.Context, request DisableTOTPRequestObject) (DisableTOTPResponseObject, error)
+
+	// (POST /api/auth/2fa/totp/enable)
+	EnableTOTP(ctx context.Context, request EnableTOTPRequestObject) (EnableTOTPResponseObject, error)
+
+	// (POST /api/auth/2fa/totp/setup)
+	SetupTOTP(ctx context.Context, request SetupTOTPRequestObject) (SetupTOTPResponseObject, error)
+
+	// (POST /api/auth/login)
+	Login(ctx context.Context, request LoginRequestObject) (LoginResponseObject, error)
+
+	// (POST /api/auth/login/2fa)
+	LoginTwoFactor(ctx context.Context, request LoginTwoFactorRequestObject) (LoginTwoFactorResponseObject, error)
+
+	// (GET /api/auth/logout)
+	Logout(ctx context.Context, request LogoutRequestObject) (LogoutResponseObject, error)
+
+	// (POST /api/auth/magic-link/request)
+	RequestMagicLink(ctx context.Context, request RequestMagicLinkRequestObject) (RequestMagicLinkResponseObject, error)
+
+	// (GET /api/auth/magic-link/token)
+	ConsumeMagicLink(ctx context.Context, request ConsumeMagicLinkRequestObject) (ConsumeMagicLinkResponseObject, error)
+
+	// (POST /api/auth/password/change)
+	ChangePassword(ctx context.Context, request ChangePasswordRequestObject) (ChangePasswordResponseObject, error)
+
+	// (POST /api/auth/password/reset/confirm)
+	ConfirmPasswordReset(ctx context.Context, request ConfirmPasswordResetRequestObject) (ConfirmPasswordResetResponseObject, error)
+
+	// (POST /api/auth/password/reset/request)
+	RequestPasswordReset(ctx context.Context, request RequestPasswordResetRequestObject) (RequestPasswordResetResponseObject, error)
+
+	// (POST /api/auth/refresh)
+	RefreshToken(ctx context.Context, request RefreshTokenRequestObject) (RefreshTokenResponseObject, error)
+
+	// (POST /api/auth/signup)
+	Signup(ctx context.Context, request SignupRequestObject) (SignupResponseObject, error)
+
+	// (POST /api/auth/token-exchange)
+	TokenExchange(ctx context.Context, request TokenExchangeRequestObject) (TokenExchangeResponseObject, error)
+
+	// (GET /api/auth/webauthn/credentials)
+	GetWebAuthnCredentials(ctx context.Context, request GetWebAuthnCredentialsRequestObject) (GetWebAuthnCredentialsResponseObject, error)
+
+	// (DELETE /api/auth/webauthn/credentials/{id})
+	DeleteWebAuthnCredential(ctx context.Context, request DeleteWebAuthnCredentialRequestObject) (DeleteWebAuthnCredentialResponseObject, error)
+
+	// (POST /api/auth/webauthn/login/begin)
+	BeginWebAuthnLogin(ctx context.Context, request BeginWebAuthnLoginRequestObject) (BeginWebAuthnLoginResponseObject, error)
+
+	// (POST /api/auth/webauthn/login/finish)
+	FinishWebAuthnLogin(ctx context.Context, request FinishWebAuthnLoginRequestObject) (FinishWebAuthnLoginResponseObject, error)
+
+	// (POST /api/auth/webauthn/register/begin)
+	BeginWebAuthnRegistration(ctx context.Context, request BeginWebAuthnRegistrationRequestObject) (BeginWebAuthnRegistrationResponseObject, error)
+
+	// (POST /api/auth/webauthn/register/finish)
+	FinishWebAuthnRegistration(ctx context.Context, request FinishWebAuthnRegistrationRequestObject) (FinishWebAuthnRegistrationResponseObject, error)
+
+	// (GET /api/cli/events)
+	StreamVaultEvents(ctx context.Context, request StreamVaultEventsRequestObject) (StreamVaultEventsResponseObject, error)
+
+	// (POST /api/cli/unwrap)
+	Unwrap(ctx context.Context, request UnwrapRequestObject) (UnwrapResponseObject, error)
+
+	// (GET /api/cli/vault/name/{name})
+	GetVaultByNameAPIKey(ctx context.Context, request GetVaultByNameAPIKeyRequestObject) (GetVaultByNameAPIKeyResponseObject, error)
+
+	// (PUT /api/cli/vault/name/{name})
+	UpdateVaultByNameAPIKey(ctx context.Context, request UpdateVaultByNameAPIKeyRequestObject) (UpdateVaultByNameAPIKeyResponseObject, error)
+
+	// (GET /api/cli/vault/name/{name}/keys/{key})
+	GetVaultKeyByNameAPIKey(ctx context.Context, request GetVaultKeyByNameAPIKeyRequestObject) (GetVaultKeyByNameAPIKeyResponseObject, error)
+
+	// (PUT /api/cli/vault/name/{name}/keys/{key})
+	SetVaultKeyByNameAPIKey(ctx context.Context, request SetVaultKeyByNameAPIKeyRequestObject) (SetVaultKeyByNameAPIKeyResponseObject, error)
+
+	// (GET /api/cli/vault/{uniqueId})
+	GetVaultByAPIKey(ctx context.Context, request GetVaultByAPIKeyRequestObject) (GetVaultByAPIKeyResponseObject, error)
+
+	// (PUT /api/cli/vault/{uniqueId})
+	UpdateVaultByAPIKey(ctx context.Context, request UpdateVaultByAPIKeyRequestObject) (UpdateVaultByAPIKeyResponseObject, error)
+
+	// (GET /api/cli/vault/{uniqueId}/keys/{key})
+	GetVaultKeyByAPIKey(ctx context.Context, request GetVaultKeyByAPIKeyRequestObject) (GetVaultKeyByAPIKeyResponseObject, error)
+
+	// (PUT /api/cli/vault/{uniqueId}/keys/{key})
+	SetVaultKeyByAPIKey(ctx context.Context, request SetVaultKeyByAPIKeyRequestObject) (SetVaultKeyByAPIKeyResponseObject, error)
+
+	// (GET /api/cli/vault/{uniqueId}/versions)
+	GetVaultVersionsByAPIKey(ctx context.Context, request GetVaultVersionsByAPIKeyRequestObject) (GetVaultVersionsByAPIKeyResponseObject, error)
+
+	// (GET /api/cli/vaults)
+	GetVaultsByAPIKey(ctx context.Context, request GetVaultsByAPIKeyRequestObject) (GetVaultsByAPIKeyResponseObject, error)
+	// Get public configuration
+	// (GET /api/config)
+	GetConfig(ctx context.Context, request GetConfigRequestObject) (GetConfigResponseObject, error)
+
+	// (GET /api/health)
+	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
+
+	// (GET /api/organizations)
+	GetOrganizations(ctx context.Context, request GetOrganizationsRequestObject) (GetOrganizationsResponseObject, error)
+
+	// (POST /api/organizations)
+	CreateOrganization(ctx context.Context, request CreateOrganizationRequestObject) (CreateOrganizationResponseObject, error)
+
+	// (DELETE /api/organizations/{id})
+	DeleteOrganization(ctx context.Context, request DeleteOrganizationRequestObject) (DeleteOrganizationResponseObject, error)
+
+	// (GET /api/organizations/{id})
+	GetOrganization(ctx context.Context, request GetOrganizationRequestObject) (GetOrganizationResponseObject, error)
+
+	// (PUT /api/organizations/{id})
+	UpdateOrganization(ctx context.Context, request UpdateOrganizationRequestObject) (UpdateOrganizationResponseObject, error)
+
+	// (GET /api/organizations/{id}/members)
+	GetOrganizationMembers(ctx context.Context, request GetOrganizationMembersRequestObject) (GetOrganizationMembersResponseObject, error)
+
+	// (POST /api/organizations/{id}/members)
+	AddOrganizationMember(ctx context.Context, request AddOrganizationMemberRequestObject) (AddOrganizationMemberResponseObject, error)
+
+	// (DELETE /api/organizations/{id}/members/{userId})
+	RemoveOrganizationMember(ctx context.Context, request RemoveOrganizationMemberRequestObject) (RemoveOrganizationMemberResponseObject, error)
+
+	// (PUT /api/organizations/{id}/members/{userId})
+	UpdateOrganizationMember(ctx context.Context, request UpdateOrganizationMemberRequestObject) (UpdateOrganizationMemberResponseObject, error)
+
+	// (DELETE /api/sessions)
+	RevokeSessions(ctx context.Context, request RevokeSessionsRequestObject) (RevokeSessionsResponseObject, error)
+
+	// (GET /api/sessions)
+	GetSessions(ctx context.Context, request GetSessionsRequestObject) (GetSessionsResponseObject, error)
+
+	// (DELETE /api/sessions/{id})
+	RevokeSession(ctx context.Context, request RevokeSessionRequestObject) (RevokeSessionResponseObject, error)
+
+	// (POST /api/shares)
+	CreateShare(ctx context.Context, request CreateShareRequestObject) (CreateShareResponseObject, error)
+
+	// (GET /api/shares/{id})
+	GetShare(ctx context.Context, request GetShareRequestObject) (GetShareResponseObject, error)
+	// Get system status
+	// (GET /api/status)
+	GetStatus(ctx context.Context, request GetStatusRequestObject) (GetStatusResponseObject, error)
+
+	// (GET /api/user)
+	GetCurrentUser(ctx context.Context, request GetCurrentUserRequestObject) (GetCurrentUserResponseObject, error)
+
+	// (GET /api/vaults)
+	GetVaults(ctx context.Context, request GetVaultsRequestObject) (GetVaultsResponseObject, error)
+
+	// (POST /api/vaults)
+	CreateVault(ctx context.Context, request CreateVaultRequestObject) (CreateVaultResponseObject, error)
+
+	// (GET /api/vaults/filter-options)
+	GetVaultFilterOptions(ctx context.Context, request GetVaultFilterOptionsRequestObject) (GetVaultFilterOptionsResponseObject, error)
+
+	// (DELETE /api/vaults/{uniqueId})
+	DeleteVault(ctx context.Context, request DeleteVaultRequestObject) (DeleteVaultResponseObject, error)
+
+	// (GET /api/vaults/{uniqueId})
+	GetVault(ctx context.Context, request GetVaultRequestObject) (GetVaultResponseObject, error)
+
+	// (PUT /api/vaults/{uniqueId})
+	UpdateVault(ctx context.Context, request UpdateVaultRequestObject) (UpdateVaultResponseObject, error)
+
+	// (DELETE /api/vaults/{uniqueId}/rotation)
+	DeleteVaultRotationPolicy(ctx context.Context, request DeleteVaultRotationPolicyRequestObject) (DeleteVaultRotationPolicyResponseObject, error)
+
+	// (GET /api/vaults/{uniqueId}/rotation)
+	GetVaultRotationPolicy(ctx context.Context, request GetVaultRotationPolicyRequestObject) (GetVaultRotationPolicyResponseObject, error)
+
+	// (PUT /api/vaults/{uniqueId}/rotation)
+	SetVaultRotationPolicy(ctx context.Context, request SetVaultRotationPolicyRequestObject) (SetVaultRotationPolicyResponseObject, error)
+
+	// (POST /api/vaults/{uniqueId}/rotation/rotate)
+	RotateVault(ctx context.Context, request RotateVaultRequestObject) (RotateVaultResponseObject, error)
+
+	// (GET /api/vaults/{uniqueId}/versions)
+	GetVaultVersions(ctx context.Context, request GetVaultVersionsRequestObject) (GetVaultVersionsResponseObject, error)
+
+	// (GET /api/vaults/{uniqueId}/versions/{version})
+	GetVaultVersion(ctx context.Context, request GetVaultVersionRequestObject) (GetVaultVersionResponseObject, error)
+
+	// (POST /api/vaults/{uniqueId}/versions/{version}/restore)
+	RestoreVaultVersion(ctx context.Context, request RestoreVaultVersionRequestObject) (RestoreVaultVersionResponseObject, error)
+
+	// (GET /api/webhooks)
+	GetWebhooks(ctx context.Context, request GetWebhooksRequestObject) (GetWebhooksResponseObject, error)
+
+	// (POST /api/webhooks)
+	CreateWebhook(ctx context.Context, request CreateWebhookRequestObject) (CreateWebhookResponseObject, error)
+
+	// (DELETE /api/webhooks/{id})
+	DeleteWebhook(ctx context.Context, request DeleteWebhookRequestObject) (DeleteWebhookResponseObject, error)
+
+	// (GET /api/webhooks/{id})
+	GetWebhook(ctx context.Context, request GetWebhookRequestObject) (GetWebhookResponseObject, error)
+
+	// (PUT /api/webhooks/{id})
+	UpdateWebhook(ctx context.Context, request UpdateWebhookRequestObject) (UpdateWebhookResponseObject, error)
+
+	// (GET /api/webhooks/{id}/deliveries)
+	GetWebhookDeliveries(ctx context.Context, request GetWebhookDeliveriesRequestObject) (GetWebhookDeliveriesResponseObject, error)
+
+	// (POST /api/webhooks/{id}/test)
+	TestWebhook(ctx context.Context, request TestWebhookRequestObject) (TestWebhookResponseObject, error)
+
+	// (GET /api/workload-identities)
+	GetWorkloadIdentities(ctx context.Context, request GetWorkloadIdentitiesRequestObject) (GetWorkloadIdentitiesResponseObject, error)
+
+	// (POST /api/workload-identities)
+	CreateWorkloadIdentity(ctx context.Context, request CreateWorkloadIdentityRequestObject) (CreateWorkloadIdentityResponseObject, error)
+
+	// (DELETE /api/workload-identities/{id})
+	DeleteWorkloadIdentity(ctx context.Context, request DeleteWorkloadIdentityRequestObject) (DeleteWorkloadIdentityResponseObject, error)
+}
+
+type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+
+type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// GetAPIKeys operation middleware
+func (sh *strictHandler) GetAPIKeys(ctx *fiber.Ctx, params GetAPIKeysParams) error {
+	var request GetAPIKeysRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAPIKeys(ctx.UserContext(), request.(GetAPIKeysRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAPIKeys")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetAPIKeysResponseObject); ok {
+		if err := validResponse.VisitGetAPIKeysResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateAPIKey operation middleware
+func (sh *strictHandler) CreateAPIKey(ctx *fiber.Ctx) error {
+	var request CreateAPIKeyRequestObject
+
+	var body CreateAPIKeyJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateAPIKey(ctx.UserContext(), request.(CreateAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(CreateAPIKeyResponseObject); ok {
+		if err := validResponse.VisitCreateAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteAPIKey operation middleware
+func (sh *strictHandler) DeleteAPIKey(ctx *fiber.Ctx, id int64) error {
+	var request DeleteAPIKeyRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteAPIKey(ctx.UserContext(), request.(DeleteAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(DeleteAPIKeyResponseObject); ok {
+		if err := validResponse.VisitDeleteAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateAPIKey operation middleware
+func (sh *strictHandler) UpdateAPIKey(ctx *fiber.Ctx, id int64) error {
+	var request UpdateAPIKeyRequestObject
+
+	request.Id = id
+
+	var body UpdateAPIKeyJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateAPIKey(ctx.UserContext(), request.(UpdateAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UpdateAPIKeyResponseObject); ok {
+		if err := validResponse.VisitUpdateAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetAuditLogs operation middleware
+func (sh *strictHandler) GetAuditLogs(ctx *fiber.Ctx, params GetAuditLogsParams) error {
+	var request GetAuditLogsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAuditLogs(ctx.UserContext(), request.(GetAuditLogsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAuditLogs")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetAuditLogsResponseObject); ok {
+		if err := validResponse.VisitGetAuditLogsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ExportAuditLogs operation middleware
+func (sh *strictHandler) ExportAuditLogs(ctx *fiber.Ctx, params ExportAuditLogsParams) error {
+	var request ExportAuditLogsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ExportAuditLogs(ctx.UserContext(), request.(ExportAuditLogsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ExportAuditLogs")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ExportAuditLogsResponseObject); ok {
+		if err := validResponse.VisitExportAuditLogsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetAuditMetrics operation middleware
+func (sh *strictHandler) GetAuditMetrics(ctx *fiber.Ctx) error {
+	var request GetAuditMetricsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetAuditMetrics(ctx.UserContext(), request.(GetAuditMetricsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetAuditMetrics")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetAuditMetricsResponseObject); ok {
+		if err := validResponse.VisitGetAuditMetricsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// VerifyAuditLogs operation middleware
+func (sh *strictHandler) VerifyAuditLogs(ctx *fiber.Ctx) error {
+	var request VerifyAuditLogsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.VerifyAuditLogs(ctx.UserContext(), request.(VerifyAuditLogsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "VerifyAuditLogs")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(VerifyAuditLogsResponseObject); ok {
+		if err := validResponse.VisitVerifyAuditLogsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetTwoFactorStatus operation middleware
+func (sh *strictHandler) GetTwoFactorStatus(ctx *fiber.Ctx) error {
+	var request GetTwoFactorStatusRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetTwoFactorStatus(ctx.UserContext(), request.(GetTwoFactorStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetTwoFactorStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetTwoFactorStatusResponseObject); ok {
+		if err := validResponse.VisitGetTwoFactorStatusResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RegenerateRecoveryCodes operation middleware
+func (sh *strictHandler) RegenerateRecoveryCodes(ctx *fiber.Ctx) error {
+	var request RegenerateRecoveryCodesRequestObject
+
+	var body RegenerateRecoveryCodesJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.RegenerateRecoveryCodes(ctx.UserContext(), request.(RegenerateRecoveryCodesRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RegenerateRecoveryCodes")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(RegenerateRecoveryCodesResponseObject); ok {
+		if err := validResponse.VisitRegenerateRecoveryCodesResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DisableTOTP operation middleware
+func (sh *strictHandler) DisableTOTP(ctx *fiber.Ctx) error {
+	var request DisableTOTPRequestObject
+
+	var body DisableTOTPJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DisableTOTP(ctx.UserContext(), request.(DisableTOTPRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DisableTOTP")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(DisableTOTPResponseObject); ok {
+		if err := validResponse.VisitDisableTOTPResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// EnableTOTP operation middleware
+func (sh *strictHandler) EnableTOTP(ctx *fiber.Ctx) error {
+	var request EnableTOTPRequestObject
+
+	var body EnableTOTPJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.EnableTOTP(ctx.UserContext(), request.(EnableTOTPRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "EnableTOTP")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(EnableTOTPResponseObject); ok {
+		if err := validResponse.VisitEnableTOTPResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SetupTOTP operation middleware
+func (sh *strictHandler) SetupTOTP(ctx *fiber.Ctx) error {
+	var request SetupTOTPRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.SetupTOTP(ctx.UserContext(), request.(SetupTOTPRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetupTOTP")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(SetupTOTPResponseObject); ok {
+		if err := validResponse.VisitSetupTOTPResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Login operation middleware
+func (sh *strictHandler) Login(ctx *fiber.Ctx) error {
+	var request LoginRequestObject
+
+	var body LoginJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.Login(ctx.UserContext(), request.(LoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Login")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(LoginResponseObject); ok {
+		if err := validResponse.VisitLoginResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// LoginTwoFactor operation middleware
+func (sh *strictHandler) LoginTwoFactor(ctx *fiber.Ctx) error {
+	var request LoginTwoFactorRequestObject
+
+	var body LoginTwoFactorJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.LoginTwoFactor(ctx.UserContext(), request.(LoginTwoFactorRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "LoginTwoFactor")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(LoginTwoFactorResponseObject); ok {
+		if err := validResponse.VisitLoginTwoFactorResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Logout operation middleware
+func (sh *strictHandler) Logout(ctx *fiber.Ctx) error {
+	var request LogoutRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.Logout(ctx.UserContext(), request.(LogoutRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Logout")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(LogoutResponseObject); ok {
+		if err := validResponse.VisitLogoutResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RequestMagicLink operation middleware
+func (sh *strictHandler) RequestMagicLink(ctx *fiber.Ctx) error {
+	var request RequestMagicLinkRequestObject
+
+	var body RequestMagicLinkJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestMagicLink(ctx.UserContext(), request.(RequestMagicLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestMagicLink")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(RequestMagicLinkResponseObject); ok {
+		if err := validResponse.VisitRequestMagicLinkResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ConsumeMagicLink operation middleware
+func (sh *strictHandler) ConsumeMagicLink(ctx *fiber.Ctx, params ConsumeMagicLinkParams) error {
+	var request ConsumeMagicLinkRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ConsumeMagicLink(ctx.UserContext(), request.(ConsumeMagicLinkRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ConsumeMagicLink")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ConsumeMagicLinkResponseObject); ok {
+		if err := validResponse.VisitConsumeMagicLinkResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ChangePassword operation middleware
+func (sh *strictHandler) ChangePassword(ctx *fiber.Ctx) error {
+	var request ChangePasswordRequestObject
+
+	var body ChangePasswordJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ChangePassword(ctx.UserContext(), request.(ChangePasswordRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ChangePassword")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ChangePasswordResponseObject); ok {
+		if err := validResponse.VisitChangePasswordResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// ConfirmPasswordReset operation middleware
+func (sh *strictHandler) ConfirmPasswordReset(ctx *fiber.Ctx) error {
+	var request ConfirmPasswordResetRequestObject
+
+	var body ConfirmPasswordResetJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.ConfirmPasswordReset(ctx.UserContext(), request.(ConfirmPasswordResetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "ConfirmPasswordReset")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(ConfirmPasswordResetResponseObject); ok {
+		if err := validResponse.VisitConfirmPasswordResetResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RequestPasswordReset operation middleware
+func (sh *strictHandler) RequestPasswordReset(ctx *fiber.Ctx) error {
+	var request RequestPasswordResetRequestObject
+
+	var body RequestPasswordResetJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.RequestPasswordReset(ctx.UserContext(), request.(RequestPasswordResetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RequestPasswordReset")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(RequestPasswordResetResponseObject); ok {
+		if err := validResponse.VisitRequestPasswordResetResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// RefreshToken operation middleware
+func (sh *strictHandler) RefreshToken(ctx *fiber.Ctx) error {
+	var request RefreshTokenRequestObject
+
+	var body RefreshTokenJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.RefreshToken(ctx.UserContext(), request.(RefreshTokenRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RefreshToken")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(RefreshTokenResponseObject); ok {
+		if err := validResponse.VisitRefreshTokenResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Signup operation middleware
+func (sh *strictHandler) Signup(ctx *fiber.Ctx) error {
+	var request SignupRequestObject
+
+	var body SignupJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.Signup(ctx.UserContext(), request.(SignupRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Signup")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(SignupResponseObject); ok {
+		if err := validResponse.VisitSignupResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// TokenExchange operation middleware
+func (sh *strictHandler) TokenExchange(ctx *fiber.Ctx) error {
+	var request TokenExchangeRequestObject
+
+	var body TokenExchangeJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.TokenExchange(ctx.UserContext(), request.(TokenExchangeRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "TokenExchange")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(TokenExchangeResponseObject); ok {
+		if err := validResponse.VisitTokenExchangeResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetWebAuthnCredentials operation middleware
+func (sh *strictHandler) GetWebAuthnCredentials(ctx *fiber.Ctx) error {
+	var request GetWebAuthnCredentialsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetWebAuthnCredentials(ctx.UserContext(), request.(GetWebAuthnCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetWebAuthnCredentials")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetWebAuthnCredentialsResponseObject); ok {
+		if err := validResponse.VisitGetWebAuthnCredentialsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteWebAuthnCredential operation middleware
+func (sh *strictHandler) DeleteWebAuthnCredential(ctx *fiber.Ctx, id int64) error {
+	var request DeleteWebAuthnCredentialRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteWebAuthnCredential(ctx.UserContext(), request.(DeleteWebAuthnCredentialRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteWebAuthnCredential")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(DeleteWebAuthnCredentialResponseObject); ok {
+		if err := validResponse.VisitDeleteWebAuthnCredentialResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// BeginWebAuthnLogin operation middleware
+func (sh *strictHandler) BeginWebAuthnLogin(ctx *fiber.Ctx) error {
+	var request BeginWebAuthnLoginRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.BeginWebAuthnLogin(ctx.UserContext(), request.(BeginWebAuthnLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BeginWebAuthnLogin")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(BeginWebAuthnLoginResponseObject); ok {
+		if err := validResponse.VisitBeginWebAuthnLoginResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// FinishWebAuthnLogin operation middleware
+func (sh *strictHandler) FinishWebAuthnLogin(ctx *fiber.Ctx) error {
+	var request FinishWebAuthnLoginRequestObject
+
+	var body FinishWebAuthnLoginJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.FinishWebAuthnLogin(ctx.UserContext(), request.(FinishWebAuthnLoginRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FinishWebAuthnLogin")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(FinishWebAuthnLoginResponseObject); ok {
+		if err := validResponse.VisitFinishWebAuthnLoginResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// BeginWebAuthnRegistration operation middleware
+func (sh *strictHandler) BeginWebAuthnRegistration(ctx *fiber.Ctx) error {
+	var request BeginWebAuthnRegistrationRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.BeginWebAuthnRegistration(ctx.UserContext(), request.(BeginWebAuthnRegistrationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "BeginWebAuthnRegistration")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(BeginWebAuthnRegistrationResponseObject); ok {
+		if err := validResponse.VisitBeginWebAuthnRegistrationResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// FinishWebAuthnRegistration operation middleware
+func (sh *strictHandler) FinishWebAuthnRegistration(ctx *fiber.Ctx) error {
+	var request FinishWebAuthnRegistrationRequestObject
+
+	var body FinishWebAuthnRegistrationJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.FinishWebAuthnRegistration(ctx.UserContext(), request.(FinishWebAuthnRegistrationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "FinishWebAuthnRegistration")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(FinishWebAuthnRegistrationResponseObject); ok {
+		if err := validResponse.VisitFinishWebAuthnRegistrationResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// StreamVaultEvents operation middleware
+func (sh *strictHandler) StreamVaultEvents(ctx *fiber.Ctx, params StreamVaultEventsParams) error {
+	var request StreamVaultEventsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.StreamVaultEvents(ctx.UserContext(), request.(StreamVaultEventsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "StreamVaultEvents")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(StreamVaultEventsResponseObject); ok {
+		if err := validResponse.VisitStreamVaultEventsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Unwrap operation middleware
+func (sh *strictHandler) Unwrap(ctx *fiber.Ctx) error {
+	var request UnwrapRequestObject
+
+	var body UnwrapJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.Unwrap(ctx.UserContext(), request.(UnwrapRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Unwrap")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UnwrapResponseObject); ok {
+		if err := validResponse.VisitUnwrapResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetVaultByNameAPIKey operation middleware
+func (sh *strictHandler) GetVaultByNameAPIKey(ctx *fiber.Ctx, name string, params GetVaultByNameAPIKeyParams) error {
+	var request GetVaultByNameAPIKeyRequestObject
+
+	request.Name = name
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVaultByNameAPIKey(ctx.UserContext(), request.(GetVaultByNameAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVaultByNameAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetVaultByNameAPIKeyResponseObject); ok {
+		if err := validResponse.VisitGetVaultByNameAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateVaultByNameAPIKey operation middleware
+func (sh *strictHandler) UpdateVaultByNameAPIKey(ctx *fiber.Ctx, name string) error {
+	var request UpdateVaultByNameAPIKeyRequestObject
+
+	request.Name = name
+
+	var body UpdateVaultByNameAPIKeyJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateVaultByNameAPIKey(ctx.UserContext(), request.(UpdateVaultByNameAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateVaultByNameAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UpdateVaultByNameAPIKeyResponseObject); ok {
+		if err := validResponse.VisitUpdateVaultByNameAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetVaultKeyByNameAPIKey operation middleware
+func (sh *strictHandler) GetVaultKeyByNameAPIKey(ctx *fiber.Ctx, name string, key string) error {
+	var request GetVaultKeyByNameAPIKeyRequestObject
+
+	request.Name = name
+	request.Key = key
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVaultKeyByNameAPIKey(ctx.UserContext(), request.(GetVaultKeyByNameAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVaultKeyByNameAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetVaultKeyByNameAPIKeyResponseObject); ok {
+		if err := validResponse.VisitGetVaultKeyByNameAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SetVaultKeyByNameAPIKey operation middleware
+func (sh *strictHandler) SetVaultKeyByNameAPIKey(ctx *fiber.Ctx, name string, key string) error {
+	var request SetVaultKeyByNameAPIKeyRequestObject
+
+	request.Name = name
+	request.Key = key
+
+	var body SetVaultKeyByNameAPIKeyJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.SetVaultKeyByNameAPIKey(ctx.UserContext(), request.(SetVaultKeyByNameAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetVaultKeyByNameAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(SetVaultKeyByNameAPIKeyResponseObject); ok {
+		if err := validResponse.VisitSetVaultKeyByNameAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetVaultByAPIKey operation middleware
+func (sh *strictHandler) GetVaultByAPIKey(ctx *fiber.Ctx, uniqueId string, params GetVaultByAPIKeyParams) error {
+	var request GetVaultByAPIKeyRequestObject
+
+	request.UniqueId = uniqueId
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVaultByAPIKey(ctx.UserContext(), request.(GetVaultByAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVaultByAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetVaultByAPIKeyResponseObject); ok {
+		if err := validResponse.VisitGetVaultByAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// UpdateVaultByAPIKey operation middleware
+func (sh *strictHandler) UpdateVaultByAPIKey(ctx *fiber.Ctx, uniqueId string) error {
+	var request UpdateVaultByAPIKeyRequestObject
+
+	request.UniqueId = uniqueId
+
+	var body UpdateVaultByAPIKeyJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateVaultByAPIKey(ctx.UserContext(), request.(UpdateVaultByAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateVaultByAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UpdateVaultByAPIKeyResponseObject); ok {
+		if err := validResponse.VisitUpdateVaultByAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetVaultKeyByAPIKey operation middleware
+func (sh *strictHandler) GetVaultKeyByAPIKey(ctx *fiber.Ctx, uniqueId string, key string) error {
+	var request GetVaultKeyByAPIKeyRequestObject
+
+	request.UniqueId = uniqueId
+	request.Key = key
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVaultKeyByAPIKey(ctx.UserContext(), request.(GetVaultKeyByAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVaultKeyByAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetVaultKeyByAPIKeyResponseObject); ok {
+		if err := validResponse.VisitGetVaultKeyByAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// SetVaultKeyByAPIKey operation middleware
+func (sh *strictHandler) SetVaultKeyByAPIKey(ctx *fiber.Ctx, uniqueId string, key string) error {
+	var request SetVaultKeyByAPIKeyRequestObject
+
+	request.UniqueId = uniqueId
+	request.Key = key
+
+	var body SetVaultKeyByAPIKeyJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.SetVaultKeyByAPIKey(ctx.UserContext(), request.(SetVaultKeyByAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "SetVaultKeyByAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(SetVaultKeyByAPIKeyResponseObject); ok {
+		if err := validResponse.VisitSetVaultKeyByAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetVaultVersionsByAPIKey operation middleware
+func (sh *strictHandler) GetVaultVersionsByAPIKey(ctx *fiber.Ctx, uniqueId string) error {
+	var request GetVaultVersionsByAPIKeyRequestObject
+
+	request.UniqueId = uniqueId
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVaultVersionsByAPIKey(ctx.UserContext(), request.(GetVaultVersionsByAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVaultVersionsByAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetVaultVersionsByAPIKeyResponseObject); ok {
+		if err := validResponse.VisitGetVaultVersionsByAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetVaultsByAPIKey operation middleware
+func (sh *strictHandler) GetVaultsByAPIKey(ctx *fiber.Ctx) error {
+	var request GetVaultsByAPIKeyRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVaultsByAPIKey(ctx.UserContext(), request.(GetVaultsByAPIKeyRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVaultsByAPIKey")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetVaultsByAPIKeyResponseObject); ok {
+		if err := validResponse.VisitGetVaultsByAPIKeyResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetConfig operation middleware
+func (sh *strictHandler) GetConfig(ctx *fiber.Ctx) error {
+	var request GetConfigRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetConfig(ctx.UserContext(), request.(GetConfigRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetConfig")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetConfigResponseObject); ok {
+		if err := validResponse.VisitGetConfigResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// Health operation middleware
+func (sh *strictHandler) Health(ctx *fiber.Ctx) error {
+	var request HealthRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.Health(ctx.UserContext(), request.(HealthRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "Health")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(HealthResponseObject); ok {
+		if err := validResponse.VisitHealthResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetOrganizations operation middleware
+func (sh *strictHandler) GetOrganizations(ctx *fiber.Ctx) error {
+	var request GetOrganizationsRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrganizations(ctx.UserContext(), request.(GetOrganizationsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrganizations")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetOrganizationsResponseObject); ok {
+		if err := validResponse.VisitGetOrganizationsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateOrganization operation middleware
+func (sh *strictHandler) CreateOrganization(ctx *fiber.Ctx) error {
+	var request CreateOrganizationRequestObject
+
+	var body CreateOrganizationJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.CreateOrganization(ctx.UserContext(), request.(CreateOrganizationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "CreateOrganization")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(CreateOrganizationResponseObject); ok {
+		if err := validResponse.VisitCreateOrganizationResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
 
-	// (PUT /api/cli/vault/name/{name})
-	UpdateVaultByNameAPIKey(ctx context.Context, request UpdateVaultByNameAPIKeyRequestObject) (UpdateVaultByNameAPIKeyResponseObject, error)
+// DeleteOrganization operation middleware
+func (sh *strictHandler) DeleteOrganization(ctx *fiber.Ctx, id int64) error {
+	var request DeleteOrganizationRequestObject
 
-	// (GET /api/cli/vault/{uniqueId})
-	GetVaultByAPIKey(ctx context.Context, request GetVaultByAPIKeyRequestObject) (GetVaultByAPIKeyResponseObject, error)
+	request.Id = id
 
-	// (PUT /api/cli/vault/{uniqueId})
-	UpdateVaultByAPIKey(ctx context.Context, request UpdateVaultByAPIKeyRequestObject) (UpdateVaultByAPIKeyResponseObject, error)
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteOrganization(ctx.UserContext(), request.(DeleteOrganizationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteOrganization")
+	}
 
-	// (GET /api/cli/vaults)
-	GetVaultsByAPIKey(ctx context.Context, request GetVaultsByAPIKeyRequestObject) (GetVaultsByAPIKeyResponseObject, error)
-	// Get public configuration
-	// (GET /api/config)
-	GetConfig(ctx context.Context, request GetConfigRequestObject) (GetConfigResponseObject, error)
+	response, err := handler(ctx, request)
 
-	// (GET /api/health)
-	Health(ctx context.Context, request HealthRequestObject) (HealthResponseObject, error)
-	// Get system status
-	// (GET /api/status)
-	GetStatus(ctx context.Context, request GetStatusRequestObject) (GetStatusResponseObject, error)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(DeleteOrganizationResponseObject); ok {
+		if err := validResponse.VisitDeleteOrganizationResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
 
-	// (GET /api/user)
-	GetCurrentUser(ctx context.Context, request GetCurrentUserRequestObject) (GetCurrentUserResponseObject, error)
+// GetOrganization operation middleware
+func (sh *strictHandler) GetOrganization(ctx *fiber.Ctx, id int64) error {
+	var request GetOrganizationRequestObject
 
-	// (GET /api/vaults)
-	GetVaults(ctx context.Context, request GetVaultsRequestObject) (GetVaultsResponseObject, error)
+	request.Id = id
 
-	// (POST /api/vaults)
-	CreateVault(ctx context.Context, request CreateVaultRequestObject) (CreateVaultResponseObject, error)
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetOrganization(ctx.UserContext(), request.(GetOrganizationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetOrganization")
+	}
 
-	// (GET /api/vaults/filter-options)
-	GetVaultFilterOptions(ctx context.Context, request GetVaultFilterOptionsRequestObject) (GetVaultFilterOptionsResponseObject, error)
+	response, err := handler(ctx, request)
 
-	// (DELETE /api/vaults/{uniqueId})
-	DeleteVault(ctx context.Context, request DeleteVaultRequestObject) (DeleteVaultResponseObject, error)
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetOrganizationResponseObject); ok {
+		if err := validResponse.VisitGetOrganizationResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
 
-	// (GET /api/vaults/{uniqueId})
-	GetVault(ctx context.Context, request GetVaultRequestObject) (GetVaultResponseObject, error)
+// UpdateOrganization operation middleware
+func (sh *strictHandler) UpdateOrganization(ctx *fiber.Ctx, id int64) error {
+	var request UpdateOrganizationRequestObject
 
-	// (PUT /api/vaults/{uniqueId})
-	UpdateVault(ctx context.Context, request UpdateVaultRequestObject) (UpdateVaultResponseObject, error)
-}
+	request.Id = id
 
-type StrictHandlerFunc func(ctx *fiber.Ctx, args interface{}) (interface{}, error)
+	var body UpdateOrganizationJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
 
-type StrictMiddlewareFunc func(f StrictHandlerFunc, operationID string) StrictHandlerFunc
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.UpdateOrganization(ctx.UserContext(), request.(UpdateOrganizationRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "UpdateOrganization")
+	}
 
-func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
-	return &strictHandler{ssi: ssi, middlewares: middlewares}
-}
+	response, err := handler(ctx, request)
 
-type strictHandler struct {
-	ssi         StrictServerInterface
-	middlewares []StrictMiddlewareFunc
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(UpdateOrganizationResponseObject); ok {
+		if err := validResponse.VisitUpdateOrganizationResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
 }
 
-// GetAPIKeys operation middleware
-func (sh *strictHandler) GetAPIKeys(ctx *fiber.Ctx, params GetAPIKeysParams) error {
-	var request GetAPIKeysRequestObject
+// GetOrganizationMembers operation middleware
+func (sh *strictHandler) GetOrganizationMembers(ctx *fiber.Ctx, id int64) error {
+	var request GetOrganizationMembersRequestObject
 
-	request.Params = params
+	request.Id = id
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetAPIKeys(ctx.UserContext(), request.(GetAPIKeysRequestObject))
+		return sh.ssi.GetOrganizationMembers(ctx.UserContext(), request.(GetOrganizationMembersRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetAPIKeys")
+		handler = middleware(handler, "GetOrganizationMembers")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetAPIKeysResponseObject); ok {
-		if err := validResponse.VisitGetAPIKeysResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetOrganizationMembersResponseObject); ok {
+		if err := validResponse.VisitGetOrganizationMembersResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -1843,29 +7122,31 @@ func (sh *strictHandler) GetAPIKeys(ctx *fiber.Ctx, params GetAPIKeysParams) err
 	return nil
 }
 
-// CreateAPIKey operation middleware
-func (sh *strictHandler) CreateAPIKey(ctx *fiber.Ctx) error {
-	var request CreateAPIKeyRequestObject
+// AddOrganizationMember operation middleware
+func (sh *strictHandler) AddOrganizationMember(ctx *fiber.Ctx, id int64) error {
+	var request AddOrganizationMemberRequestObject
 
-	var body CreateAPIKeyJSONRequestBody
+	request.Id = id
+
+	var body AddOrganizationMemberJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateAPIKey(ctx.UserContext(), request.(CreateAPIKeyRequestObject))
+		return sh.ssi.AddOrganizationMember(ctx.UserContext(), request.(AddOrganizationMemberRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateAPIKey")
+		handler = middleware(handler, "AddOrganizationMember")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(CreateAPIKeyResponseObject); ok {
-		if err := validResponse.VisitCreateAPIKeyResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(AddOrganizationMemberResponseObject); ok {
+		if err := validResponse.VisitAddOrganizationMemberResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -1874,25 +7155,26 @@ func (sh *strictHandler) CreateAPIKey(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// DeleteAPIKey operation middleware
-func (sh *strictHandler) DeleteAPIKey(ctx *fiber.Ctx, id int64) error {
-	var request DeleteAPIKeyRequestObject
+// RemoveOrganizationMember operation middleware
+func (sh *strictHandler) RemoveOrganizationMember(ctx *fiber.Ctx, id int64, userId int64) error {
+	var request RemoveOrganizationMemberRequestObject
 
 	request.Id = id
+	request.UserId = userId
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteAPIKey(ctx.UserContext(), request.(DeleteAPIKeyRequestObject))
+		return sh.ssi.RemoveOrganizationMember(ctx.UserContext(), request.(RemoveOrganizationMemberRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteAPIKey")
+		handler = middleware(handler, "RemoveOrganizationMember")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(DeleteAPIKeyResponseObject); ok {
-		if err := validResponse.VisitDeleteAPIKeyResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(RemoveOrganizationMemberResponseObject); ok {
+		if err := validResponse.VisitRemoveOrganizationMemberResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -1901,31 +7183,32 @@ func (sh *strictHandler) DeleteAPIKey(ctx *fiber.Ctx, id int64) error {
 	return nil
 }
 
-// UpdateAPIKey operation middleware
-func (sh *strictHandler) UpdateAPIKey(ctx *fiber.Ctx, id int64) error {
-	var request UpdateAPIKeyRequestObject
+// UpdateOrganizationMember operation middleware
+func (sh *strictHandler) UpdateOrganizationMember(ctx *fiber.Ctx, id int64, userId int64) error {
+	var request UpdateOrganizationMemberRequestObject
 
 	request.Id = id
+	request.UserId = userId
 
-	var body UpdateAPIKeyJSONRequestBody
+	var body UpdateOrganizationMemberJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateAPIKey(ctx.UserContext(), request.(UpdateAPIKeyRequestObject))
+		return sh.ssi.UpdateOrganizationMember(ctx.UserContext(), request.(UpdateOrganizationMemberRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateAPIKey")
+		handler = middleware(handler, "UpdateOrganizationMember")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(UpdateAPIKeyResponseObject); ok {
-		if err := validResponse.VisitUpdateAPIKeyResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(UpdateOrganizationMemberResponseObject); ok {
+		if err := validResponse.VisitUpdateOrganizationMemberResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -1934,25 +7217,25 @@ func (sh *strictHandler) UpdateAPIKey(ctx *fiber.Ctx, id int64) error {
 	return nil
 }
 
-// GetAuditLogs operation middleware
-func (sh *strictHandler) GetAuditLogs(ctx *fiber.Ctx, params GetAuditLogsParams) error {
-	var request GetAuditLogsRequestObject
+// RevokeSessions operation middleware
+func (sh *strictHandler) RevokeSessions(ctx *fiber.Ctx, params RevokeSessionsParams) error {
+	var request RevokeSessionsRequestObject
 
 	request.Params = params
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetAuditLogs(ctx.UserContext(), request.(GetAuditLogsRequestObject))
+		return sh.ssi.RevokeSessions(ctx.UserContext(), request.(RevokeSessionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetAuditLogs")
+		handler = middleware(handler, "RevokeSessions")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetAuditLogsResponseObject); ok {
-		if err := validResponse.VisitGetAuditLogsResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(RevokeSessionsResponseObject); ok {
+		if err := validResponse.VisitRevokeSessionsResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -1961,23 +7244,23 @@ func (sh *strictHandler) GetAuditLogs(ctx *fiber.Ctx, params GetAuditLogsParams)
 	return nil
 }
 
-// GetAuditMetrics operation middleware
-func (sh *strictHandler) GetAuditMetrics(ctx *fiber.Ctx) error {
-	var request GetAuditMetricsRequestObject
+// GetSessions operation middleware
+func (sh *strictHandler) GetSessions(ctx *fiber.Ctx) error {
+	var request GetSessionsRequestObject
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetAuditMetrics(ctx.UserContext(), request.(GetAuditMetricsRequestObject))
+		return sh.ssi.GetSessions(ctx.UserContext(), request.(GetSessionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetAuditMetrics")
+		handler = middleware(handler, "GetSessions")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetAuditMetricsResponseObject); ok {
-		if err := validResponse.VisitGetAuditMetricsResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetSessionsResponseObject); ok {
+		if err := validResponse.VisitGetSessionsResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -1986,29 +7269,56 @@ func (sh *strictHandler) GetAuditMetrics(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// Login operation middleware
-func (sh *strictHandler) Login(ctx *fiber.Ctx) error {
-	var request LoginRequestObject
+// RevokeSession operation middleware
+func (sh *strictHandler) RevokeSession(ctx *fiber.Ctx, id string) error {
+	var request RevokeSessionRequestObject
 
-	var body LoginJSONRequestBody
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.RevokeSession(ctx.UserContext(), request.(RevokeSessionRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "RevokeSession")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(RevokeSessionResponseObject); ok {
+		if err := validResponse.VisitRevokeSessionResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateShare operation middleware
+func (sh *strictHandler) CreateShare(ctx *fiber.Ctx) error {
+	var request CreateShareRequestObject
+
+	var body CreateShareJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.Login(ctx.UserContext(), request.(LoginRequestObject))
+		return sh.ssi.CreateShare(ctx.UserContext(), request.(CreateShareRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Login")
+		handler = middleware(handler, "CreateShare")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(LoginResponseObject); ok {
-		if err := validResponse.VisitLoginResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(CreateShareResponseObject); ok {
+		if err := validResponse.VisitCreateShareResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2017,23 +7327,25 @@ func (sh *strictHandler) Login(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// Logout operation middleware
-func (sh *strictHandler) Logout(ctx *fiber.Ctx) error {
-	var request LogoutRequestObject
+// GetShare operation middleware
+func (sh *strictHandler) GetShare(ctx *fiber.Ctx, id string) error {
+	var request GetShareRequestObject
+
+	request.Id = id
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.Logout(ctx.UserContext(), request.(LogoutRequestObject))
+		return sh.ssi.GetShare(ctx.UserContext(), request.(GetShareRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Logout")
+		handler = middleware(handler, "GetShare")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(LogoutResponseObject); ok {
-		if err := validResponse.VisitLogoutResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetShareResponseObject); ok {
+		if err := validResponse.VisitGetShareResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2042,29 +7354,106 @@ func (sh *strictHandler) Logout(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// RequestMagicLink operation middleware
-func (sh *strictHandler) RequestMagicLink(ctx *fiber.Ctx) error {
-	var request RequestMagicLinkRequestObject
+// GetStatus operation middleware
+func (sh *strictHandler) GetStatus(ctx *fiber.Ctx) error {
+	var request GetStatusRequestObject
 
-	var body RequestMagicLinkJSONRequestBody
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetStatus(ctx.UserContext(), request.(GetStatusRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetStatus")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetStatusResponseObject); ok {
+		if err := validResponse.VisitGetStatusResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetCurrentUser operation middleware
+func (sh *strictHandler) GetCurrentUser(ctx *fiber.Ctx) error {
+	var request GetCurrentUserRequestObject
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetCurrentUser(ctx.UserContext(), request.(GetCurrentUserRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetCurrentUser")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetCurrentUserResponseObject); ok {
+		if err := validResponse.VisitGetCurrentUserResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// GetVaults operation middleware
+func (sh *strictHandler) GetVaults(ctx *fiber.Ctx, params GetVaultsParams) error {
+	var request GetVaultsRequestObject
+
+	request.Params = params
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.GetVaults(ctx.UserContext(), request.(GetVaultsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "GetVaults")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(GetVaultsResponseObject); ok {
+		if err := validResponse.VisitGetVaultsResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// CreateVault operation middleware
+func (sh *strictHandler) CreateVault(ctx *fiber.Ctx) error {
+	var request CreateVaultRequestObject
+
+	var body CreateVaultJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.RequestMagicLink(ctx.UserContext(), request.(RequestMagicLinkRequestObject))
+		return sh.ssi.CreateVault(ctx.UserContext(), request.(CreateVaultRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RequestMagicLink")
+		handler = middleware(handler, "CreateVault")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(RequestMagicLinkResponseObject); ok {
-		if err := validResponse.VisitRequestMagicLinkResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(CreateVaultResponseObject); ok {
+		if err := validResponse.VisitCreateVaultResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2073,25 +7462,23 @@ func (sh *strictHandler) RequestMagicLink(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// ConsumeMagicLink operation middleware
-func (sh *strictHandler) ConsumeMagicLink(ctx *fiber.Ctx, params ConsumeMagicLinkParams) error {
-	var request ConsumeMagicLinkRequestObject
-
-	request.Params = params
+// GetVaultFilterOptions operation middleware
+func (sh *strictHandler) GetVaultFilterOptions(ctx *fiber.Ctx) error {
+	var request GetVaultFilterOptionsRequestObject
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.ConsumeMagicLink(ctx.UserContext(), request.(ConsumeMagicLinkRequestObject))
+		return sh.ssi.GetVaultFilterOptions(ctx.UserContext(), request.(GetVaultFilterOptionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ConsumeMagicLink")
+		handler = middleware(handler, "GetVaultFilterOptions")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(ConsumeMagicLinkResponseObject); ok {
-		if err := validResponse.VisitConsumeMagicLinkResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetVaultFilterOptionsResponseObject); ok {
+		if err := validResponse.VisitGetVaultFilterOptionsResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2100,29 +7487,25 @@ func (sh *strictHandler) ConsumeMagicLink(ctx *fiber.Ctx, params ConsumeMagicLin
 	return nil
 }
 
-// ChangePassword operation middleware
-func (sh *strictHandler) ChangePassword(ctx *fiber.Ctx) error {
-	var request ChangePasswordRequestObject
+// DeleteVault operation middleware
+func (sh *strictHandler) DeleteVault(ctx *fiber.Ctx, uniqueId string) error {
+	var request DeleteVaultRequestObject
 
-	var body ChangePasswordJSONRequestBody
-	if err := ctx.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	}
-	request.Body = &body
+	request.UniqueId = uniqueId
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.ChangePassword(ctx.UserContext(), request.(ChangePasswordRequestObject))
+		return sh.ssi.DeleteVault(ctx.UserContext(), request.(DeleteVaultRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ChangePassword")
+		handler = middleware(handler, "DeleteVault")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(ChangePasswordResponseObject); ok {
-		if err := validResponse.VisitChangePasswordResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(DeleteVaultResponseObject); ok {
+		if err := validResponse.VisitDeleteVaultResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2131,29 +7514,25 @@ func (sh *strictHandler) ChangePassword(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// ConfirmPasswordReset operation middleware
-func (sh *strictHandler) ConfirmPasswordReset(ctx *fiber.Ctx) error {
-	var request ConfirmPasswordResetRequestObject
+// GetVault operation middleware
+func (sh *strictHandler) GetVault(ctx *fiber.Ctx, uniqueId string) error {
+	var request GetVaultRequestObject
 
-	var body ConfirmPasswordResetJSONRequestBody
-	if err := ctx.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	}
-	request.Body = &body
+	request.UniqueId = uniqueId
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.ConfirmPasswordReset(ctx.UserContext(), request.(ConfirmPasswordResetRequestObject))
+		return sh.ssi.GetVault(ctx.UserContext(), request.(GetVaultRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "ConfirmPasswordReset")
+		handler = middleware(handler, "GetVault")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(ConfirmPasswordResetResponseObject); ok {
-		if err := validResponse.VisitConfirmPasswordResetResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetVaultResponseObject); ok {
+		if err := validResponse.VisitGetVaultResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2162,29 +7541,31 @@ func (sh *strictHandler) ConfirmPasswordReset(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// RequestPasswordReset operation middleware
-func (sh *strictHandler) RequestPasswordReset(ctx *fiber.Ctx) error {
-	var request RequestPasswordResetRequestObject
+// UpdateVault operation middleware
+func (sh *strictHandler) UpdateVault(ctx *fiber.Ctx, uniqueId string) error {
+	var request UpdateVaultRequestObject
 
-	var body RequestPasswordResetJSONRequestBody
+	request.UniqueId = uniqueId
+
+	var body UpdateVaultJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.RequestPasswordReset(ctx.UserContext(), request.(RequestPasswordResetRequestObject))
+		return sh.ssi.UpdateVault(ctx.UserContext(), request.(UpdateVaultRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "RequestPasswordReset")
+		handler = middleware(handler, "UpdateVault")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(RequestPasswordResetResponseObject); ok {
-		if err := validResponse.VisitRequestPasswordResetResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(UpdateVaultResponseObject); ok {
+		if err := validResponse.VisitUpdateVaultResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2193,29 +7574,25 @@ func (sh *strictHandler) RequestPasswordReset(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// Signup operation middleware
-func (sh *strictHandler) Signup(ctx *fiber.Ctx) error {
-	var request SignupRequestObject
+// DeleteVaultRotationPolicy operation middleware
+func (sh *strictHandler) DeleteVaultRotationPolicy(ctx *fiber.Ctx, uniqueId string) error {
+	var request DeleteVaultRotationPolicyRequestObject
 
-	var body SignupJSONRequestBody
-	if err := ctx.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	}
-	request.Body = &body
+	request.UniqueId = uniqueId
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.Signup(ctx.UserContext(), request.(SignupRequestObject))
+		return sh.ssi.DeleteVaultRotationPolicy(ctx.UserContext(), request.(DeleteVaultRotationPolicyRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Signup")
+		handler = middleware(handler, "DeleteVaultRotationPolicy")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(SignupResponseObject); ok {
-		if err := validResponse.VisitSignupResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(DeleteVaultRotationPolicyResponseObject); ok {
+		if err := validResponse.VisitDeleteVaultRotationPolicyResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2224,25 +7601,25 @@ func (sh *strictHandler) Signup(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// GetVaultByNameAPIKey operation middleware
-func (sh *strictHandler) GetVaultByNameAPIKey(ctx *fiber.Ctx, name string) error {
-	var request GetVaultByNameAPIKeyRequestObject
+// GetVaultRotationPolicy operation middleware
+func (sh *strictHandler) GetVaultRotationPolicy(ctx *fiber.Ctx, uniqueId string) error {
+	var request GetVaultRotationPolicyRequestObject
 
-	request.Name = name
+	request.UniqueId = uniqueId
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetVaultByNameAPIKey(ctx.UserContext(), request.(GetVaultByNameAPIKeyRequestObject))
+		return sh.ssi.GetVaultRotationPolicy(ctx.UserContext(), request.(GetVaultRotationPolicyRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetVaultByNameAPIKey")
+		handler = middleware(handler, "GetVaultRotationPolicy")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetVaultByNameAPIKeyResponseObject); ok {
-		if err := validResponse.VisitGetVaultByNameAPIKeyResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetVaultRotationPolicyResponseObject); ok {
+		if err := validResponse.VisitGetVaultRotationPolicyResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2251,31 +7628,31 @@ func (sh *strictHandler) GetVaultByNameAPIKey(ctx *fiber.Ctx, name string) error
 	return nil
 }
 
-// UpdateVaultByNameAPIKey operation middleware
-func (sh *strictHandler) UpdateVaultByNameAPIKey(ctx *fiber.Ctx, name string) error {
-	var request UpdateVaultByNameAPIKeyRequestObject
+// SetVaultRotationPolicy operation middleware
+func (sh *strictHandler) SetVaultRotationPolicy(ctx *fiber.Ctx, uniqueId string) error {
+	var request SetVaultRotationPolicyRequestObject
 
-	request.Name = name
+	request.UniqueId = uniqueId
 
-	var body UpdateVaultByNameAPIKeyJSONRequestBody
+	var body SetVaultRotationPolicyJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateVaultByNameAPIKey(ctx.UserContext(), request.(UpdateVaultByNameAPIKeyRequestObject))
+		return sh.ssi.SetVaultRotationPolicy(ctx.UserContext(), request.(SetVaultRotationPolicyRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateVaultByNameAPIKey")
+		handler = middleware(handler, "SetVaultRotationPolicy")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(UpdateVaultByNameAPIKeyResponseObject); ok {
-		if err := validResponse.VisitUpdateVaultByNameAPIKeyResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(SetVaultRotationPolicyResponseObject); ok {
+		if err := validResponse.VisitSetVaultRotationPolicyResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2284,25 +7661,25 @@ func (sh *strictHandler) UpdateVaultByNameAPIKey(ctx *fiber.Ctx, name string) er
 	return nil
 }
 
-// GetVaultByAPIKey operation middleware
-func (sh *strictHandler) GetVaultByAPIKey(ctx *fiber.Ctx, uniqueId string) error {
-	var request GetVaultByAPIKeyRequestObject
+// RotateVault operation middleware
+func (sh *strictHandler) RotateVault(ctx *fiber.Ctx, uniqueId string) error {
+	var request RotateVaultRequestObject
 
 	request.UniqueId = uniqueId
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetVaultByAPIKey(ctx.UserContext(), request.(GetVaultByAPIKeyRequestObject))
+		return sh.ssi.RotateVault(ctx.UserContext(), request.(RotateVaultRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetVaultByAPIKey")
+		handler = middleware(handler, "RotateVault")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetVaultByAPIKeyResponseObject); ok {
-		if err := validResponse.VisitGetVaultByAPIKeyResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(RotateVaultResponseObject); ok {
+		if err := validResponse.VisitRotateVaultResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2311,31 +7688,25 @@ func (sh *strictHandler) GetVaultByAPIKey(ctx *fiber.Ctx, uniqueId string) error
 	return nil
 }
 
-// UpdateVaultByAPIKey operation middleware
-func (sh *strictHandler) UpdateVaultByAPIKey(ctx *fiber.Ctx, uniqueId string) error {
-	var request UpdateVaultByAPIKeyRequestObject
+// GetVaultVersions operation middleware
+func (sh *strictHandler) GetVaultVersions(ctx *fiber.Ctx, uniqueId string) error {
+	var request GetVaultVersionsRequestObject
 
 	request.UniqueId = uniqueId
 
-	var body UpdateVaultByAPIKeyJSONRequestBody
-	if err := ctx.BodyParser(&body); err != nil {
-		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	}
-	request.Body = &body
-
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateVaultByAPIKey(ctx.UserContext(), request.(UpdateVaultByAPIKeyRequestObject))
+		return sh.ssi.GetVaultVersions(ctx.UserContext(), request.(GetVaultVersionsRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateVaultByAPIKey")
+		handler = middleware(handler, "GetVaultVersions")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(UpdateVaultByAPIKeyResponseObject); ok {
-		if err := validResponse.VisitUpdateVaultByAPIKeyResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetVaultVersionsResponseObject); ok {
+		if err := validResponse.VisitGetVaultVersionsResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2344,23 +7715,26 @@ func (sh *strictHandler) UpdateVaultByAPIKey(ctx *fiber.Ctx, uniqueId string) er
 	return nil
 }
 
-// GetVaultsByAPIKey operation middleware
-func (sh *strictHandler) GetVaultsByAPIKey(ctx *fiber.Ctx) error {
-	var request GetVaultsByAPIKeyRequestObject
+// GetVaultVersion operation middleware
+func (sh *strictHandler) GetVaultVersion(ctx *fiber.Ctx, uniqueId string, version int) error {
+	var request GetVaultVersionRequestObject
+
+	request.UniqueId = uniqueId
+	request.Version = version
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetVaultsByAPIKey(ctx.UserContext(), request.(GetVaultsByAPIKeyRequestObject))
+		return sh.ssi.GetVaultVersion(ctx.UserContext(), request.(GetVaultVersionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetVaultsByAPIKey")
+		handler = middleware(handler, "GetVaultVersion")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetVaultsByAPIKeyResponseObject); ok {
-		if err := validResponse.VisitGetVaultsByAPIKeyResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetVaultVersionResponseObject); ok {
+		if err := validResponse.VisitGetVaultVersionResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2369,23 +7743,26 @@ func (sh *strictHandler) GetVaultsByAPIKey(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// GetConfig operation middleware
-func (sh *strictHandler) GetConfig(ctx *fiber.Ctx) error {
-	var request GetConfigRequestObject
+// RestoreVaultVersion operation middleware
+func (sh *strictHandler) RestoreVaultVersion(ctx *fiber.Ctx, uniqueId string, version int) error {
+	var request RestoreVaultVersionRequestObject
+
+	request.UniqueId = uniqueId
+	request.Version = version
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetConfig(ctx.UserContext(), request.(GetConfigRequestObject))
+		return sh.ssi.RestoreVaultVersion(ctx.UserContext(), request.(RestoreVaultVersionRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetConfig")
+		handler = middleware(handler, "RestoreVaultVersion")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetConfigResponseObject); ok {
-		if err := validResponse.VisitGetConfigResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(RestoreVaultVersionResponseObject); ok {
+		if err := validResponse.VisitRestoreVaultVersionResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2394,23 +7771,23 @@ func (sh *strictHandler) GetConfig(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// Health operation middleware
-func (sh *strictHandler) Health(ctx *fiber.Ctx) error {
-	var request HealthRequestObject
+// GetWebhooks operation middleware
+func (sh *strictHandler) GetWebhooks(ctx *fiber.Ctx) error {
+	var request GetWebhooksRequestObject
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.Health(ctx.UserContext(), request.(HealthRequestObject))
+		return sh.ssi.GetWebhooks(ctx.UserContext(), request.(GetWebhooksRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "Health")
+		handler = middleware(handler, "GetWebhooks")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(HealthResponseObject); ok {
-		if err := validResponse.VisitHealthResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetWebhooksResponseObject); ok {
+		if err := validResponse.VisitGetWebhooksResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2419,23 +7796,29 @@ func (sh *strictHandler) Health(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// GetStatus operation middleware
-func (sh *strictHandler) GetStatus(ctx *fiber.Ctx) error {
-	var request GetStatusRequestObject
+// CreateWebhook operation middleware
+func (sh *strictHandler) CreateWebhook(ctx *fiber.Ctx) error {
+	var request CreateWebhookRequestObject
+
+	var body CreateWebhookJSONRequestBody
+	if err := ctx.BodyParser(&body); err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	}
+	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetStatus(ctx.UserContext(), request.(GetStatusRequestObject))
+		return sh.ssi.CreateWebhook(ctx.UserContext(), request.(CreateWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetStatus")
+		handler = middleware(handler, "CreateWebhook")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetStatusResponseObject); ok {
-		if err := validResponse.VisitGetStatusResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(CreateWebhookResponseObject); ok {
+		if err := validResponse.VisitCreateWebhookResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2444,23 +7827,25 @@ func (sh *strictHandler) GetStatus(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// GetCurrentUser operation middleware
-func (sh *strictHandler) GetCurrentUser(ctx *fiber.Ctx) error {
-	var request GetCurrentUserRequestObject
+// DeleteWebhook operation middleware
+func (sh *strictHandler) DeleteWebhook(ctx *fiber.Ctx, id int64) error {
+	var request DeleteWebhookRequestObject
+
+	request.Id = id
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetCurrentUser(ctx.UserContext(), request.(GetCurrentUserRequestObject))
+		return sh.ssi.DeleteWebhook(ctx.UserContext(), request.(DeleteWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetCurrentUser")
+		handler = middleware(handler, "DeleteWebhook")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetCurrentUserResponseObject); ok {
-		if err := validResponse.VisitGetCurrentUserResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(DeleteWebhookResponseObject); ok {
+		if err := validResponse.VisitDeleteWebhookResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2469,25 +7854,25 @@ func (sh *strictHandler) GetCurrentUser(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// GetVaults operation middleware
-func (sh *strictHandler) GetVaults(ctx *fiber.Ctx, params GetVaultsParams) error {
-	var request GetVaultsRequestObject
+// GetWebhook operation middleware
+func (sh *strictHandler) GetWebhook(ctx *fiber.Ctx, id int64) error {
+	var request GetWebhookRequestObject
 
-	request.Params = params
+	request.Id = id
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetVaults(ctx.UserContext(), request.(GetVaultsRequestObject))
+		return sh.ssi.GetWebhook(ctx.UserContext(), request.(GetWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetVaults")
+		handler = middleware(handler, "GetWebhook")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetVaultsResponseObject); ok {
-		if err := validResponse.VisitGetVaultsResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetWebhookResponseObject); ok {
+		if err := validResponse.VisitGetWebhookResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2496,29 +7881,31 @@ func (sh *strictHandler) GetVaults(ctx *fiber.Ctx, params GetVaultsParams) error
 	return nil
 }
 
-// CreateVault operation middleware
-func (sh *strictHandler) CreateVault(ctx *fiber.Ctx) error {
-	var request CreateVaultRequestObject
+// UpdateWebhook operation middleware
+func (sh *strictHandler) UpdateWebhook(ctx *fiber.Ctx, id int64) error {
+	var request UpdateWebhookRequestObject
 
-	var body CreateVaultJSONRequestBody
+	request.Id = id
+
+	var body UpdateWebhookJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.CreateVault(ctx.UserContext(), request.(CreateVaultRequestObject))
+		return sh.ssi.UpdateWebhook(ctx.UserContext(), request.(UpdateWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "CreateVault")
+		handler = middleware(handler, "UpdateWebhook")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(CreateVaultResponseObject); ok {
-		if err := validResponse.VisitCreateVaultResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(UpdateWebhookResponseObject); ok {
+		if err := validResponse.VisitUpdateWebhookResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2527,23 +7914,26 @@ func (sh *strictHandler) CreateVault(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// GetVaultFilterOptions operation middleware
-func (sh *strictHandler) GetVaultFilterOptions(ctx *fiber.Ctx) error {
-	var request GetVaultFilterOptionsRequestObject
+// GetWebhookDeliveries operation middleware
+func (sh *strictHandler) GetWebhookDeliveries(ctx *fiber.Ctx, id int64, params GetWebhookDeliveriesParams) error {
+	var request GetWebhookDeliveriesRequestObject
+
+	request.Id = id
+	request.Params = params
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetVaultFilterOptions(ctx.UserContext(), request.(GetVaultFilterOptionsRequestObject))
+		return sh.ssi.GetWebhookDeliveries(ctx.UserContext(), request.(GetWebhookDeliveriesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetVaultFilterOptions")
+		handler = middleware(handler, "GetWebhookDeliveries")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetVaultFilterOptionsResponseObject); ok {
-		if err := validResponse.VisitGetVaultFilterOptionsResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetWebhookDeliveriesResponseObject); ok {
+		if err := validResponse.VisitGetWebhookDeliveriesResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2552,25 +7942,25 @@ func (sh *strictHandler) GetVaultFilterOptions(ctx *fiber.Ctx) error {
 	return nil
 }
 
-// DeleteVault operation middleware
-func (sh *strictHandler) DeleteVault(ctx *fiber.Ctx, uniqueId string) error {
-	var request DeleteVaultRequestObject
+// TestWebhook operation middleware
+func (sh *strictHandler) TestWebhook(ctx *fiber.Ctx, id int64) error {
+	var request TestWebhookRequestObject
 
-	request.UniqueId = uniqueId
+	request.Id = id
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.DeleteVault(ctx.UserContext(), request.(DeleteVaultRequestObject))
+		return sh.ssi.TestWebhook(ctx.UserContext(), request.(TestWebhookRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "DeleteVault")
+		handler = middleware(handler, "TestWebhook")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(DeleteVaultResponseObject); ok {
-		if err := validResponse.VisitDeleteVaultResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(TestWebhookResponseObject); ok {
+		if err := validResponse.VisitTestWebhookResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2579,25 +7969,23 @@ func (sh *strictHandler) DeleteVault(ctx *fiber.Ctx, uniqueId string) error {
 	return nil
 }
 
-// GetVault operation middleware
-func (sh *strictHandler) GetVault(ctx *fiber.Ctx, uniqueId string) error {
-	var request GetVaultRequestObject
-
-	request.UniqueId = uniqueId
+// GetWorkloadIdentities operation middleware
+func (sh *strictHandler) GetWorkloadIdentities(ctx *fiber.Ctx) error {
+	var request GetWorkloadIdentitiesRequestObject
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.GetVault(ctx.UserContext(), request.(GetVaultRequestObject))
+		return sh.ssi.GetWorkloadIdentities(ctx.UserContext(), request.(GetWorkloadIdentitiesRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetVault")
+		handler = middleware(handler, "GetWorkloadIdentities")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(GetVaultResponseObject); ok {
-		if err := validResponse.VisitGetVaultResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(GetWorkloadIdentitiesResponseObject); ok {
+		if err := validResponse.VisitGetWorkloadIdentitiesResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
@@ -2606,31 +7994,56 @@ func (sh *strictHandler) GetVault(ctx *fiber.Ctx, uniqueId string) error {
 	return nil
 }
 
-// UpdateVault operation middleware
-func (sh *strictHandler) UpdateVault(ctx *fiber.Ctx, uniqueId string) error {
-	var request UpdateVaultRequestObject
-
-	request.UniqueId = uniqueId
+// CreateWorkloadIdentity operation middleware
+func (sh *strictHandler) CreateWorkloadIdentity(ctx *fiber.Ctx) error {
+	var request CreateWorkloadIdentityRequestObject
 
-	var body UpdateVaultJSONRequestBody
+	var body CreateWorkloadIdentityJSONRequestBody
 	if err := ctx.BodyParser(&body); err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
 	}
 	request.Body = &body
 
 	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
-		return sh.ssi.UpdateVault(ctx.UserContext(), request.(UpdateVaultRequestObject))
+		return sh.ssi.CreateWorkloadIdentity(ctx.UserContext(), request.(CreateWorkloadIdentityRequestObject))
 	}
 	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "UpdateVault")
+		handler = middleware(handler, "CreateWorkloadIdentity")
 	}
 
 	response, err := handler(ctx, request)
 
 	if err != nil {
 		return fiber.NewError(fiber.StatusBadRequest, err.Error())
-	} else if validResponse, ok := response.(UpdateVaultResponseObject); ok {
-		if err := validResponse.VisitUpdateVaultResponse(ctx); err != nil {
+	} else if validResponse, ok := response.(CreateWorkloadIdentityResponseObject); ok {
+		if err := validResponse.VisitCreateWorkloadIdentityResponse(ctx); err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// DeleteWorkloadIdentity operation middleware
+func (sh *strictHandler) DeleteWorkloadIdentity(ctx *fiber.Ctx, id int64) error {
+	var request DeleteWorkloadIdentityRequestObject
+
+	request.Id = id
+
+	handler := func(ctx *fiber.Ctx, request interface{}) (interface{}, error) {
+		return sh.ssi.DeleteWorkloadIdentity(ctx.UserContext(), request.(DeleteWorkloadIdentityRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "DeleteWorkloadIdentity")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return fiber.NewError(fiber.StatusBadRequest, err.Error())
+	} else if validResponse, ok := response.(DeleteWorkloadIdentityResponseObject); ok {
+		if err := validResponse.VisitDeleteWorkloadIdentityResponse(ctx); err != nil {
 			return fiber.NewError(fiber.StatusBadRequest, err.Error())
 		}
 	} else if response != nil {
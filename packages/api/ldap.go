@@ -0,0 +1,47 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/lwshen/vault-hub/internal/auth"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/gorm"
+)
+
+// authenticateLDAP verifies a password against the directory and returns the matching user,
+// creating it on the first login. Wrong passwords and unknown users return
+// auth.ErrLDAPInvalidCredentials; other errors mean the directory could not be reached.
+func authenticateLDAP(email, password string) (*model.User, error) {
+	identity, err := auth.LDAP().Authenticate(email, password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := model.User{Email: identity.Email}
+	err = user.GetByEmail()
+	if err == nil {
+		// Local accounts keep their own password and cannot be taken over through the directory
+		if user.Password != nil {
+			slog.Warn("LDAP login for a user with a local password", "userID", user.ID, "dn", identity.DN)
+			return nil, auth.ErrLDAPInvalidCredentials
+		}
+		return &user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Directory users are provisioned on their first login, like OIDC users
+	createParams := model.CreateUserParams{
+		Email:    identity.Email,
+		Password: nil,
+		Name:     identity.Name,
+	}
+	newUser, err := createParams.Create()
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("User created from LDAP", "email", newUser.Email, "dn", identity.DN)
+	return newUser, nil
+}
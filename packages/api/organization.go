@@ -0,0 +1,385 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/model"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+	"gorm.io/gorm"
+)
+
+// convertToApiOrganization converts a model.Organization to an api.Organization with the role of the current user
+func convertToApiOrganization(org *model.Organization, role model.OrganizationRole) Organization {
+	// #nosec G115
+	id := int64(org.ID)
+	return Organization{
+		Id:          id,
+		Name:        org.Name,
+		Description: &org.Description,
+		Role:        OrganizationRole(role),
+		CreatedAt:   &org.CreatedAt,
+		UpdatedAt:   &org.UpdatedAt,
+	}
+}
+
+// convertToApiOrganizationMember converts a model.Membership to an api.OrganizationMember
+func convertToApiOrganizationMember(membership *model.Membership) OrganizationMember {
+	// #nosec G115
+	userID := int64(membership.UserID)
+	return OrganizationMember{
+		UserId:    userID,
+		Email:     openapi_types.Email(membership.User.Email),
+		Name:      membership.User.Name,
+		Role:      OrganizationRole(membership.Role),
+		CreatedAt: &membership.CreatedAt,
+	}
+}
+
+// auditOrganizationOperation creates an audit log entry for organization operations
+func auditOrganizationOperation(c *fiber.Ctx, action model.ActionType, actor *model.Membership) {
+	ip, userAgent := getClientInfo(c)
+
+	err := model.LogOrganizationAction(actor.OrganizationID, action, actor.UserID, actor.Role, model.SourceWeb, ip, userAgent)
+	if err != nil {
+		// Log the audit error but don't fail the main operation
+		slog.Error("Failed to create audit log for organization operation",
+			"action", action,
+			"user_id", actor.UserID,
+			"organization_id", actor.OrganizationID,
+			"error", err)
+	}
+}
+
+// requireMembership returns the membership of the current user in an organization.
+// Non-members get a 404 so that organization IDs are not disclosed; members whose role
+// is below minRole get a 403.
+func requireMembership(c *fiber.Ctx, organizationID int64, userID uint, minRole model.OrganizationRole) (*model.Membership, error) {
+	if organizationID <= 0 {
+		return nil, handler.SendError(c, fiber.StatusNotFound, "organization not found")
+	}
+
+	// #nosec G115
+	membership, err := model.GetMembership(uint(organizationID), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, handler.SendError(c, fiber.StatusNotFound, "organization not found")
+		}
+		return nil, handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if !membership.Role.AtLeast(minRole) {
+		return nil, handler.SendError(c, fiber.StatusForbidden, "requires the "+string(minRole)+" role in this organization")
+	}
+	return membership, nil
+}
+
+// sendOrganizationError maps organization model errors to HTTP responses
+func sendOrganizationError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, model.ErrLastOwner), errors.Is(err, model.ErrOrganizationHasVaults), errors.Is(err, model.ErrAlreadyMember):
+		return handler.SendError(c, fiber.StatusConflict, err.Error())
+	default:
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+}
+
+// joinValidationErrors joins validation error messages into a single message
+func joinValidationErrors(errors map[string]string) string {
+	errorMsgs := make([]string, 0, len(errors))
+	for _, msg := range errors {
+		errorMsgs = append(errorMsgs, msg)
+	}
+	return strings.Join(errorMsgs, "; ")
+}
+
+// GetOrganizations handles GET /api/organizations
+func (Server) GetOrganizations(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	memberships, err := model.GetUserMemberships(user.ID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	organizations := make([]Organization, 0, len(memberships))
+	for i := range memberships {
+		organizations = append(organizations, convertToApiOrganization(&memberships[i].Organization, memberships[i].Role))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(OrganizationsResponse{Organizations: organizations})
+}
+
+// CreateOrganization handles POST /api/organizations
+func (Server) CreateOrganization(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var input CreateOrganizationRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	params := model.CreateOrganizationParams{
+		Name:        input.Name,
+		Description: getStringValue(input.Description),
+		UserID:      user.ID,
+	}
+	if errors := params.Validate(); len(errors) > 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, joinValidationErrors(errors))
+	}
+
+	org, err := params.Create()
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	auditOrganizationOperation(c, model.ActionCreateOrganization, &model.Membership{OrganizationID: org.ID, UserID: user.ID, Role: model.RoleOwner})
+
+	return c.Status(fiber.StatusCreated).JSON(convertToApiOrganization(org, model.RoleOwner))
+}
+
+// GetOrganization handles GET /api/organizations/{id}
+func (Server) GetOrganization(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	membership, err := requireMembership(c, id, user.ID, model.RoleViewer)
+	if err != nil || membership == nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiOrganization(&membership.Organization, membership.Role))
+}
+
+// UpdateOrganization handles PUT /api/organizations/{id}
+func (Server) UpdateOrganization(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	membership, err := requireMembership(c, id, user.ID, model.RoleAdmin)
+	if err != nil || membership == nil {
+		return err
+	}
+
+	var input UpdateOrganizationRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	params := model.UpdateOrganizationParams{
+		Name:        input.Name,
+		Description: input.Description,
+	}
+	if errors := params.Validate(); len(errors) > 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, joinValidationErrors(errors))
+	}
+
+	org := membership.Organization
+	if err := org.Update(&params); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	auditOrganizationOperation(c, model.ActionUpdateOrganization, membership)
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiOrganization(&org, membership.Role))
+}
+
+// DeleteOrganization handles DELETE /api/organizations/{id}
+func (Server) DeleteOrganization(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	membership, err := requireMembership(c, id, user.ID, model.RoleOwner)
+	if err != nil || membership == nil {
+		return err
+	}
+
+	if err := membership.Organization.Delete(); err != nil {
+		return sendOrganizationError(c, err)
+	}
+
+	auditOrganizationOperation(c, model.ActionDeleteOrganization, membership)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetOrganizationMembers handles GET /api/organizations/{id}/members
+func (Server) GetOrganizationMembers(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	membership, err := requireMembership(c, id, user.ID, model.RoleViewer)
+	if err != nil || membership == nil {
+		return err
+	}
+
+	memberships, err := model.GetOrganizationMembers(membership.OrganizationID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	members := make([]OrganizationMember, 0, len(memberships))
+	for i := range memberships {
+		members = append(members, convertToApiOrganizationMember(&memberships[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(OrganizationMembersResponse{Members: members})
+}
+
+// AddOrganizationMember handles POST /api/organizations/{id}/members
+func (Server) AddOrganizationMember(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	actor, err := requireMembership(c, id, user.ID, model.RoleAdmin)
+	if err != nil || actor == nil {
+		return err
+	}
+
+	var input AddOrganizationMemberRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	role := model.OrganizationRole(input.Role)
+	if !role.IsValid() {
+		return handler.SendError(c, fiber.StatusBadRequest, "invalid role")
+	}
+	if role == model.RoleOwner && actor.Role != model.RoleOwner {
+		return handler.SendError(c, fiber.StatusForbidden, "only owners can add owners")
+	}
+
+	member := model.User{Email: string(input.Email)}
+	if err := member.GetByEmail(); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return handler.SendError(c, fiber.StatusNotFound, "user not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	membership, err := model.AddMember(actor.OrganizationID, member.ID, role)
+	if err != nil {
+		return sendOrganizationError(c, err)
+	}
+
+	auditOrganizationOperation(c, model.ActionAddOrganizationMember, actor)
+
+	return c.Status(fiber.StatusCreated).JSON(convertToApiOrganizationMember(membership))
+}
+
+// findOrganizationMember finds the membership of another user in the actor's organization
+func findOrganizationMember(c *fiber.Ctx, actor *model.Membership, userID int64) (*model.Membership, error) {
+	if userID <= 0 {
+		return nil, handler.SendError(c, fiber.StatusNotFound, "member not found")
+	}
+
+	// #nosec G115
+	membership, err := model.GetMembership(actor.OrganizationID, uint(userID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, handler.SendError(c, fiber.StatusNotFound, "member not found")
+		}
+		return nil, handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return membership, nil
+}
+
+// UpdateOrganizationMember handles PUT /api/organizations/{id}/members/{userId}
+func (Server) UpdateOrganizationMember(c *fiber.Ctx, id int64, userID int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	actor, err := requireMembership(c, id, user.ID, model.RoleAdmin)
+	if err != nil || actor == nil {
+		return err
+	}
+
+	var input UpdateOrganizationMemberRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	role := model.OrganizationRole(input.Role)
+	if !role.IsValid() {
+		return handler.SendError(c, fiber.StatusBadRequest, "invalid role")
+	}
+
+	membership, err := findOrganizationMember(c, actor, userID)
+	if err != nil || membership == nil {
+		return err
+	}
+
+	// Only owners can grant or revoke the owner role
+	if (role == model.RoleOwner || membership.Role == model.RoleOwner) && actor.Role != model.RoleOwner {
+		return handler.SendError(c, fiber.StatusForbidden, "only owners can change the owner role")
+	}
+
+	if err := membership.UpdateRole(role); err != nil {
+		return sendOrganizationError(c, err)
+	}
+	if err := model.DB.Preload("User").First(membership, membership.ID).Error; err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	auditOrganizationOperation(c, model.ActionUpdateOrganizationMember, actor)
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiOrganizationMember(membership))
+}
+
+// RemoveOrganizationMember handles DELETE /api/organizations/{id}/members/{userId}
+func (Server) RemoveOrganizationMember(c *fiber.Ctx, id int64, userID int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	// Any member can leave; removing someone else requires the admin role
+	minRole := model.RoleAdmin
+	// #nosec G115
+	if userID == int64(user.ID) {
+		minRole = model.RoleViewer
+	}
+
+	actor, err := requireMembership(c, id, user.ID, minRole)
+	if err != nil || actor == nil {
+		return err
+	}
+
+	membership, err := findOrganizationMember(c, actor, userID)
+	if err != nil || membership == nil {
+		return err
+	}
+
+	if membership.Role == model.RoleOwner && actor.Role != model.RoleOwner {
+		return handler.SendError(c, fiber.StatusForbidden, "only owners can remove owners")
+	}
+
+	if err := membership.Remove(); err != nil {
+		return sendOrganizationError(c, err)
+	}
+
+	auditOrganizationOperation(c, model.ActionRemoveOrganizationMember, actor)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/ratelimit"
+	"github.com/lwshen/vault-hub/model"
+)
+
+// errTooManyLoginAttempts is the message of logins rejected by the account lockout
+const errTooManyLoginAttempts = "too many failed login attempts, try again later"
+
+// checkLoginAllowed rejects logins to an account that is locked or must wait after failed
+// attempts. It returns false after writing the error response. Unknown email addresses are
+// throttled the same way, so responses do not reveal which accounts exist.
+func checkLoginAllowed(c *fiber.Ctx, email string) (bool, error) {
+	wait, _, err := ratelimit.Default().Check(c.Context(), ratelimit.AccountKey(email), ratelimit.LoginFailures)
+	if err != nil {
+		return false, handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if wait > 0 {
+		return false, handler.SendTooManyRequests(c, wait, errTooManyLoginAttempts)
+	}
+	return true, nil
+}
+
+// recordLoginFailure counts a failed login against the account. For existing users the
+// failure is audited as action, and so is the lockout it may cause.
+func recordLoginFailure(c *fiber.Ctx, email string, user *model.User, action model.ActionType, clientIP, userAgent string) {
+	locked, err := ratelimit.Default().Fail(c.Context(), ratelimit.AccountKey(email), ratelimit.LoginFailures)
+	if err != nil {
+		slog.Error("Failed to record failed login", "error", err)
+	}
+	if user == nil {
+		return
+	}
+
+	if err := model.LogUserAction(action, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for failed login", "error", err, "userID", user.ID)
+	}
+	if locked {
+		slog.Warn("Account locked after failed logins", "userID", user.ID, "clientIP", clientIP)
+		if err := model.LogUserAction(model.ActionAccountLocked, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+			slog.Error("Failed to create audit log for account lockout", "error", err, "userID", user.ID)
+		}
+	}
+}
+
+// resetLoginFailures forgets the failed logins of an account after a successful login
+func resetLoginFailures(c *fiber.Ctx, email string) {
+	if err := ratelimit.Default().Reset(c.Context(), ratelimit.AccountKey(email)); err != nil {
+		slog.Error("Failed to reset failed logins", "error", err)
+	}
+}
@@ -0,0 +1,223 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/rotation"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/gorm"
+)
+
+// convertToApiRotationPolicy converts a model.RotationPolicy of the vault to an api.RotationPolicy
+func convertToApiRotationPolicy(p *model.RotationPolicy, vault *model.Vault) RotationPolicy {
+	policy := RotationPolicy{
+		Strategy:      RotationPolicyStrategy(p.Strategy),
+		Interval:      int64(vault.RotationInterval / time.Second),
+		Enabled:       p.Enabled,
+		Attempts:      p.Attempts,
+		LastAttemptAt: p.LastAttemptAt,
+		LastRotatedAt: p.LastRotatedAt,
+		NextAttemptAt: p.NextAttemptAt,
+	}
+	switch p.Strategy {
+	case model.RotationStrategyRandom:
+		charset, length := p.GeneratedCharset(), p.GeneratedLength()
+		policy.Charset = &charset
+		policy.Length = &length
+	case model.RotationStrategyHTTP:
+		policy.Url = &p.URL
+	}
+	if p.LastStatus != "" {
+		status := RotationPolicyLastStatus(p.LastStatus)
+		policy.LastStatus = &status
+	}
+	if p.LastError != "" {
+		policy.LastError = &p.LastError
+	}
+	return policy
+}
+
+// findVaultRotationPolicy returns the rotation policy of a vault, writing a 404 response
+// if there is none
+func findVaultRotationPolicy(c *fiber.Ctx, vault *model.Vault) (*model.RotationPolicy, error) {
+	policy, err := model.GetRotationPolicy(vault.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, handler.SendError(c, fiber.StatusNotFound, "rotation policy not found")
+		}
+		return nil, handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return policy, nil
+}
+
+// findRotationVault returns a vault the user has the permission on, writing an error
+// response otherwise
+func findRotationVault(c *fiber.Ctx, uniqueID string, userID uint, permission model.VaultPermission) (*model.Vault, error) {
+	var vault model.Vault
+	if err := vault.GetByUniqueID(uniqueID, userID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, handler.SendError(c, fiber.StatusNotFound, "vault not found")
+		}
+		return nil, handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if ok, err := checkVaultPermission(c, &vault, userID, permission); !ok {
+		return nil, err
+	}
+	return &vault, nil
+}
+
+// GetVaultRotationPolicy handles GET /api/vaults/{uniqueId}/rotation
+func (Server) GetVaultRotationPolicy(c *fiber.Ctx, uniqueID string) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	vault, err := findRotationVault(c, uniqueID, user.ID, model.VaultPermissionRead)
+	if err != nil || vault == nil {
+		return err
+	}
+	policy, err := findVaultRotationPolicy(c, vault)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiRotationPolicy(policy, vault))
+}
+
+// SetVaultRotationPolicy handles PUT /api/vaults/{uniqueId}/rotation
+func (Server) SetVaultRotationPolicy(c *fiber.Ctx, uniqueID string) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	vault, err := findRotationVault(c, uniqueID, user.ID, model.VaultPermissionWrite)
+	if err != nil || vault == nil {
+		return err
+	}
+	if vault.IsDynamic() {
+		return handler.SendError(c, fiber.StatusBadRequest, "database vaults issue short-lived credentials and cannot be rotated")
+	}
+	if vault.Type == model.VaultTypeKV {
+		return handler.SendError(c, fiber.StatusBadRequest, "kv vaults hold several secrets and cannot be rotated as one value")
+	}
+
+	var input SetRotationPolicyRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	params := model.SetRotationPolicyParams{
+		UserID:   user.ID,
+		Interval: time.Duration(input.Interval) * time.Second,
+		Strategy: model.RotationStrategy(input.Strategy),
+		Charset:  getStringValue(input.Charset),
+		URL:      getStringValue(input.Url),
+		Enabled:  input.Enabled == nil || *input.Enabled,
+	}
+	if input.Length != nil {
+		params.Length = *input.Length
+		if params.Length == 0 {
+			return handler.SendError(c, fiber.StatusBadRequest, "length must be positive")
+		}
+	}
+	if errors := params.Validate(); len(errors) > 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, joinValidationErrors(errors))
+	}
+
+	policy, secret, err := vault.SetRotationPolicy(&params)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionSetRotationPolicy, user.ID, model.SourceWeb, nil, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for set rotation policy", "error", err, "vaultID", vault.ID)
+	}
+
+	response := convertToApiRotationPolicy(policy, vault)
+	if secret != "" {
+		response.Secret = &secret
+	}
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// DeleteVaultRotationPolicy handles DELETE /api/vaults/{uniqueId}/rotation
+func (Server) DeleteVaultRotationPolicy(c *fiber.Ctx, uniqueID string) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	vault, err := findRotationVault(c, uniqueID, user.ID, model.VaultPermissionWrite)
+	if err != nil || vault == nil {
+		return err
+	}
+	policy, err := findVaultRotationPolicy(c, vault)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	if err := policy.Delete(); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionDeleteRotationPolicy, user.ID, model.SourceWeb, nil, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for delete rotation policy", "error", err, "vaultID", vault.ID)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RotateVault handles POST /api/vaults/{uniqueId}/rotation/rotate
+func (Server) RotateVault(c *fiber.Ctx, uniqueID string) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	vault, err := findRotationVault(c, uniqueID, user.ID, model.VaultPermissionWrite)
+	if err != nil || vault == nil {
+		return err
+	}
+	policy, err := findVaultRotationPolicy(c, vault)
+	if err != nil || policy == nil {
+		return err
+	}
+
+	if err := policy.Claim(); err != nil {
+		if errors.Is(err, model.ErrRotationInProgress) {
+			return handler.SendError(c, fiber.StatusConflict, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	author := model.VersionAuthor{UserID: user.ID, Source: model.SourceWeb}
+	if err := rotation.Rotate(c.Context(), rotation.NewClient(), policy, author); err != nil {
+		slog.Error("Failed to record vault rotation", "error", err, "vaultID", vault.ID)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to record rotation")
+	}
+
+	ip, userAgent := getClientInfo(c)
+	if policy.LastStatus != model.RotationSucceeded {
+		if err := model.LogVaultAction(vault.ID, model.ActionRotateVaultFailed, user.ID, model.SourceWeb, nil, ip, userAgent); err != nil {
+			slog.Error("Failed to create audit log for rotate vault", "error", err, "vaultID", vault.ID)
+		}
+		return handler.SendError(c, fiber.StatusBadGateway, "rotation failed: "+policy.LastError)
+	}
+	if err := model.LogVaultAction(vault.ID, model.ActionRotateVault, user.ID, model.SourceWeb, nil, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for rotate vault", "error", err, "vaultID", vault.ID)
+	}
+
+	rotated := &policy.Vault
+	response := convertToApiVault(rotated)
+	apiPolicy := convertToApiRotationPolicy(policy, rotated)
+	response.RotationPolicy = &apiPolicy
+	return c.Status(fiber.StatusOK).JSON(response)
+}
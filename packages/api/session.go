@@ -0,0 +1,110 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/model"
+)
+
+// getSessionFromContext returns the session of the request's access token, if any
+func getSessionFromContext(c *fiber.Ctx) *model.Session {
+	session, ok := c.Locals("session").(*model.Session)
+	if !ok {
+		return nil
+	}
+	return session
+}
+
+// convertToApiSession converts a model.Session to an api.Session
+func convertToApiSession(session *model.Session, current *model.Session) Session {
+	apiSession := Session{
+		Id:         session.UniqueID,
+		Current:    current != nil && current.ID == session.ID,
+		CreatedAt:  session.CreatedAt,
+		LastUsedAt: session.LastUsedAt,
+		ExpiresAt:  session.ExpiresAt,
+	}
+	if session.IPAddress != "" {
+		apiSession.IpAddress = &session.IPAddress
+	}
+	if session.UserAgent != "" {
+		apiSession.UserAgent = &session.UserAgent
+	}
+	return apiSession
+}
+
+// GetSessions handles GET /api/sessions
+func (Server) GetSessions(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	sessions, err := model.GetUserSessions(user.ID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	current := getSessionFromContext(c)
+	apiSessions := make([]Session, 0, len(sessions))
+	for i := range sessions {
+		apiSessions = append(apiSessions, convertToApiSession(&sessions[i], current))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(SessionsResponse{Sessions: apiSessions})
+}
+
+// RevokeSession handles DELETE /api/sessions/{id}
+func (Server) RevokeSession(c *fiber.Ctx, id string) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	session, err := model.GetActiveSession(id, user.ID)
+	if err != nil {
+		if errors.Is(err, model.ErrSessionRevoked) {
+			return handler.SendError(c, fiber.StatusNotFound, "session not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := session.Revoke(); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+	if err := model.LogUserAction(model.ActionRevokeSession, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for session revocation", "error", err, "userID", user.ID)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RevokeSessions handles DELETE /api/sessions, logging the user out everywhere
+func (Server) RevokeSessions(c *fiber.Ctx, params RevokeSessionsParams) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var exceptID *uint
+	if current := getSessionFromContext(c); current != nil && params.KeepCurrent != nil && *params.KeepCurrent {
+		exceptID = &current.ID
+	}
+
+	revoked, err := model.RevokeUserSessions(user.ID, exceptID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+	if err := model.LogUserAction(model.ActionRevokeAllSessions, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for session revocation", "error", err, "userID", user.ID)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(RevokeSessionsResponse{Revoked: revoked})
+}
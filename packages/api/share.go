@@ -0,0 +1,109 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/gorm"
+)
+
+// logShareAction logs a share action, on the vault for shared vault values
+func logShareAction(c *fiber.Ctx, share *model.Share, action model.ActionType) {
+	ip, userAgent := getClientInfo(c)
+	var err error
+	if share.VaultID != nil {
+		err = model.LogVaultAction(*share.VaultID, action, share.UserID, model.SourceWeb, nil, ip, userAgent)
+	} else {
+		err = model.LogUserAction(action, share.UserID, model.SourceWeb, ip, userAgent)
+	}
+	if err != nil {
+		slog.Error("Failed to create audit log for share", "action", action, "share_id", share.ID, "error", err)
+	}
+}
+
+// CreateShare handles POST /api/shares
+func (Server) CreateShare(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var input CreateShareRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+	if (input.VaultUniqueId == nil) == (input.Value == nil) {
+		return handler.SendError(c, fiber.StatusBadRequest, "set either vaultUniqueId or value")
+	}
+
+	params := model.CreateShareParams{UserID: user.ID}
+	if input.VaultUniqueId != nil {
+		var vault model.Vault
+		if err := vault.GetByUniqueID(*input.VaultUniqueId, user.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return handler.SendError(c, fiber.StatusNotFound, "vault not found")
+			}
+			return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		if vault.IsDynamic() {
+			return handler.SendError(c, fiber.StatusBadRequest, "database vaults cannot be shared")
+		}
+		params.VaultID = &vault.ID
+		params.Value = vault.Value
+	} else {
+		params.Value = *input.Value
+	}
+	if input.MaxViews != nil {
+		params.MaxViews = *input.MaxViews
+		if params.MaxViews == 0 {
+			return handler.SendError(c, fiber.StatusBadRequest, fmt.Sprintf("max views must be between 1 and %d", model.MaxShareMaxViews))
+		}
+	}
+	if input.ExpiresIn != nil {
+		params.ExpiresIn = time.Duration(*input.ExpiresIn) * time.Second
+		if params.ExpiresIn == 0 {
+			return handler.SendError(c, fiber.StatusBadRequest, "expiry must be positive")
+		}
+	}
+	if errors := params.Validate(); len(errors) > 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, joinValidationErrors(errors))
+	}
+
+	share, key, err := params.Create()
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	logShareAction(c, share, model.ActionCreateShare)
+
+	// The key only travels in the fragment, which browsers never send to the server
+	return c.Status(fiber.StatusCreated).JSON(CreateShareResponse{
+		Id:        share.UniqueID,
+		Url:       fmt.Sprintf("%s/share/%s#%s", c.BaseURL(), share.UniqueID, key),
+		ExpiresAt: share.ExpiresAt,
+		MaxViews:  share.MaxViews,
+	})
+}
+
+// GetShare handles GET /api/shares/{id}
+func (Server) GetShare(c *fiber.Ctx, id string) error {
+	share, err := model.ViewShare(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return handler.SendError(c, fiber.StatusNotFound, "share not found or no longer available")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	logShareAction(c, share, model.ActionViewShare)
+
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	return c.Status(fiber.StatusOK).JSON(SharedSecret{
+		Ciphertext:     share.Ciphertext,
+		ExpiresAt:      share.ExpiresAt,
+		RemainingViews: share.RemainingViews(),
+	})
+}
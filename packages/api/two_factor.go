@@ -0,0 +1,245 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/auth"
+	"github.com/lwshen/vault-hub/model"
+)
+
+// verifySecondFactor checks a TOTP code, or a recovery code when no TOTP code is given, and
+// reports whether a recovery code was used
+func verifySecondFactor(user *model.User, code, recoveryCode *string) (bool, bool, error) {
+	if code != nil && *code != "" {
+		ok, err := user.VerifyTOTP(*code)
+		return ok, false, err
+	}
+	if recoveryCode != nil && *recoveryCode != "" {
+		ok, err := user.UseRecoveryCode(*recoveryCode)
+		return ok, ok, err
+	}
+	return false, false, nil
+}
+
+// logRecoveryCodeUse records that one of the user's recovery codes was consumed
+func logRecoveryCodeUse(userID uint, clientIP, userAgent string) {
+	if err := model.LogUserAction(model.ActionUseRecoveryCode, userID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for recovery code use", "error", err, "userID", userID)
+	}
+}
+
+// LoginTwoFactor completes a password login with a TOTP code or a recovery code
+func (Server) LoginTwoFactor(c *fiber.Ctx) error {
+	var input LoginTwoFactorRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+
+	challenge, err := model.GetLoginChallenge(input.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidLoginChallenge) {
+			return handler.SendError(c, fiber.StatusUnauthorized, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	user := &challenge.User
+	if !user.TwoFactorEnabled() {
+		// Two-factor authentication was disabled after the challenge was issued
+		return handler.SendError(c, fiber.StatusUnauthorized, model.ErrInvalidLoginChallenge.Error())
+	}
+	if ok, err := checkLoginAllowed(c, user.Email); !ok {
+		return err
+	}
+
+	ok, usedRecoveryCode, err := verifySecondFactor(user, input.Code, input.RecoveryCode)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if !ok {
+		if err := challenge.RecordFailure(); err != nil {
+			slog.Error("Failed to record login challenge failure", "error", err, "userID", user.ID)
+		}
+		recordLoginFailure(c, user.Email, user, model.ActionTwoFactorFailed, clientIP, userAgent)
+		return handler.SendError(c, fiber.StatusBadRequest, model.ErrInvalidTwoFactorCode.Error())
+	}
+
+	if err := challenge.Consume(); err != nil {
+		if errors.Is(err, model.ErrInvalidLoginChallenge) {
+			return handler.SendError(c, fiber.StatusUnauthorized, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if usedRecoveryCode {
+		logRecoveryCodeUse(user.ID, clientIP, userAgent)
+	}
+
+	return completeLogin(c, user, model.ActionLoginUser, clientIP, userAgent)
+}
+
+// GetTwoFactorStatus handles GET /api/auth/2fa
+func (Server) GetTwoFactorStatus(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	remaining, err := user.RemainingRecoveryCodes()
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(TwoFactorStatusResponse{
+		Enabled:                user.TwoFactorEnabled(),
+		EnabledAt:              user.TOTPEnabledAt,
+		RecoveryCodesRemaining: int(remaining),
+	})
+}
+
+// SetupTOTP handles POST /api/auth/2fa/totp/setup
+func (Server) SetupTOTP(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	// Only password logins ask for a second factor. OIDC and LDAP users have no local password:
+	// their second factor, if any, is enforced by the identity provider or the directory.
+	var fullUser model.User
+	if err := model.DB.First(&fullUser, user.ID).Error; err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, "user not found")
+	}
+	if fullUser.Password == nil {
+		return handler.SendError(c, fiber.StatusBadRequest, "two-factor authentication is only available for password logins")
+	}
+
+	secret, err := user.StartTOTPEnrollment()
+	if err != nil {
+		if errors.Is(err, model.ErrTwoFactorEnabled) {
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(TOTPSetupResponse{
+		Secret:          secret,
+		ProvisioningUri: auth.TOTPProvisioningURI(model.TOTPIssuer, user.Email, secret),
+	})
+}
+
+// EnableTOTP handles POST /api/auth/2fa/totp/enable
+func (Server) EnableTOTP(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var input EnableTOTPRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	codes, err := user.EnableTOTP(input.Code)
+	if err != nil {
+		if errors.Is(err, model.ErrTwoFactorEnabled) || errors.Is(err, model.ErrTOTPEnrollmentNotStarted) ||
+			errors.Is(err, model.ErrInvalidTwoFactorCode) {
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+	if err := model.LogUserAction(model.ActionEnableTwoFactor, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for enabling two-factor authentication", "error", err, "userID", user.ID)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTOTP handles POST /api/auth/2fa/totp/disable
+func (Server) DisableTOTP(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var input DisableTwoFactorRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	// Re-fetch user to get password hash (middleware clears it)
+	var fullUser model.User
+	if err := model.DB.First(&fullUser, user.ID).Error; err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, "user not found")
+	}
+	if !fullUser.ComparePassword(input.Password) {
+		return handler.SendError(c, fiber.StatusUnauthorized, "invalid password")
+	}
+	if !fullUser.TwoFactorEnabled() {
+		return handler.SendError(c, fiber.StatusBadRequest, model.ErrTwoFactorNotEnabled.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+	ok, usedRecoveryCode, err := verifySecondFactor(&fullUser, input.Code, input.RecoveryCode)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if !ok {
+		return handler.SendError(c, fiber.StatusBadRequest, model.ErrInvalidTwoFactorCode.Error())
+	}
+	if usedRecoveryCode {
+		logRecoveryCodeUse(fullUser.ID, clientIP, userAgent)
+	}
+
+	if err := fullUser.DisableTOTP(); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := model.LogUserAction(model.ActionDisableTwoFactor, fullUser.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for disabling two-factor authentication", "error", err, "userID", fullUser.ID)
+	}
+
+	return c.SendStatus(fiber.StatusOK)
+}
+
+// RegenerateRecoveryCodes handles POST /api/auth/2fa/recovery-codes
+func (Server) RegenerateRecoveryCodes(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var input RegenerateRecoveryCodesRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	if !user.TwoFactorEnabled() {
+		return handler.SendError(c, fiber.StatusBadRequest, model.ErrTwoFactorNotEnabled.Error())
+	}
+	ok, err := user.VerifyTOTP(input.Code)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if !ok {
+		return handler.SendError(c, fiber.StatusBadRequest, model.ErrInvalidTwoFactorCode.Error())
+	}
+
+	codes, err := user.RegenerateRecoveryCodes()
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+	if err := model.LogUserAction(model.ActionRegenerateRecoveryCodes, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for regenerating recovery codes", "error", err, "userID", user.ID)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(RecoveryCodesResponse{RecoveryCodes: codes})
+}
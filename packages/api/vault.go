@@ -1,8 +1,13 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
@@ -37,21 +42,60 @@ func getUserFromContext(c *fiber.Ctx) (*model.User, error) {
 	return user, nil
 }
 
+// checkVaultPermission verifies that the user's role on the vault allows the operation.
+// It returns false after writing the error response when the operation is not allowed.
+func checkVaultPermission(c *fiber.Ctx, vault *model.Vault, userID uint, permission model.VaultPermission) (bool, error) {
+	err := vault.CheckPermission(userID, permission)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, model.ErrVaultPermissionDenied) {
+		return false, handler.SendError(c, fiber.StatusForbidden, "your organization role does not allow "+string(permission)+" access to this vault")
+	}
+	return false, handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+}
+
 // convertToApiVault converts a model.Vault to an api.Vault
 func convertToApiVault(vault *model.Vault) Vault {
 	// #nosec G115
 	userID := int64(vault.UserID)
-	return Vault{
+	response := Vault{
 		UniqueId:    vault.UniqueID,
 		UserId:      &userID,
 		Name:        vault.Name,
-		Value:       vault.Value,
+		Value:       vault.DisplayValue(),
 		Description: &vault.Description,
 		Category:    &vault.Category,
+		Type:        vaultTypePtr(vault.Type),
 		Favourite:   &vault.Favourite,
 		CreatedAt:   &vault.CreatedAt,
 		UpdatedAt:   &vault.UpdatedAt,
+
+		OrganizationId:   organizationIDPtr(vault.OrganizationID),
+		ExpiresAt:        vault.ExpiresAt,
+		RotationInterval: rotationIntervalPtr(vault.RotationInterval),
+	}
+	if data, err := vault.Data(); err == nil {
+		response.Data = &data
 	}
+	return response
+}
+
+// convertToApiKeyVault converts a model.Vault to an api.Vault for API key clients. Their
+// value is returned as is, as it holds what the client sent or the credentials issued for a
+// database vault; the stored config of a database vault is never returned unredacted. The
+// vault type and data are left out: released CLIs reject fields they do not know.
+func convertToApiKeyVault(vault *model.Vault) Vault {
+	response := convertToApiVault(vault)
+	response.Value = vault.Value
+	if vault.IsDynamic() {
+		if _, err := model.ParseDatabaseConfig(vault.Value); err == nil {
+			response.Value = model.RedactDatabaseConfig(vault.Value)
+		}
+	}
+	response.Type = nil
+	response.Data = nil
+	return response
 }
 
 // convertToApiVaultLite converts a model.Vault to an api.VaultLite
@@ -61,9 +105,59 @@ func convertToApiVaultLite(vault *model.Vault) VaultLite {
 		Name:        vault.Name,
 		Description: &vault.Description,
 		Category:    &vault.Category,
+		Type:        vaultTypePtr(vault.Type),
 		Favourite:   &vault.Favourite,
 		UpdatedAt:   &vault.UpdatedAt,
+
+		OrganizationId:   organizationIDPtr(vault.OrganizationID),
+		ExpiresAt:        vault.ExpiresAt,
+		RotationInterval: rotationIntervalPtr(vault.RotationInterval),
+	}
+}
+
+// vaultTypePtr converts a vault type to its API representation, omitting the static type
+func vaultTypePtr(vaultType model.VaultType) *VaultType {
+	if vaultType == "" || vaultType == model.VaultTypeStatic {
+		return nil
+	}
+	t := VaultType(vaultType)
+	return &t
+}
+
+// organizationIDPtr converts an optional organization ID to its API representation
+func organizationIDPtr(id *uint) *int64 {
+	if id == nil {
+		return nil
+	}
+	// #nosec G115
+	organizationID := int64(*id)
+	return &organizationID
+}
+
+// rotationIntervalPtr converts a rotation interval to seconds, omitting an unset interval
+func rotationIntervalPtr(interval time.Duration) *int64 {
+	if interval == 0 {
+		return nil
+	}
+	seconds := int64(interval / time.Second)
+	return &seconds
+}
+
+// parsePeriod parses an expiring_within value, a number of days like 7d or a duration like 12h
+func parsePeriod(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid expiring_within %q, use a period like 7d or 12h", value)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid expiring_within %q, use a period like 7d or 12h", value)
 	}
+	return d, nil
 }
 
 // GetVaults handles GET /api/vaults with pagination
@@ -90,9 +184,16 @@ func (Server) GetVaults(c *fiber.Ctx, params GetVaultsParams) error {
 	if pageIndex < 1 {
 		return handler.SendError(c, fiber.StatusBadRequest, "pageIndex must be at least 1")
 	}
+	var expiringWithin time.Duration
+	if params.ExpiringWithin != nil {
+		expiringWithin, err = parsePeriod(*params.ExpiringWithin)
+		if err != nil {
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+	}
 
 	// Query paginated vaults for current user via model
-	vaults, totalCount, err := model.GetUserVaultsWithPagination(user.ID, pageSize, pageIndex)
+	vaults, totalCount, err := model.GetUserVaultsWithPagination(user.ID, pageSize, pageIndex, expiringWithin)
 	if err != nil {
 		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
 	}
@@ -135,7 +236,15 @@ func (Server) GetVault(c *fiber.Ctx, uniqueID string) error {
 		slog.Error("Failed to create audit log for read vault", "error", err, "vaultID", vault.ID)
 	}
 
-	return c.Status(fiber.StatusOK).JSON(convertToApiVault(&vault))
+	response := convertToApiVault(&vault)
+	if policy, err := model.GetRotationPolicy(vault.ID); err == nil {
+		apiPolicy := convertToApiRotationPolicy(policy, &vault)
+		response.RotationPolicy = &apiPolicy
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
 }
 
 // CreateVault handles POST /api/vaults
@@ -159,16 +268,46 @@ func (Server) CreateVault(c *fiber.Ctx) error {
 		UniqueID:    uniqueID.String(),
 		UserID:      user.ID,
 		Name:        input.Name,
-		Value:       input.Value,
+		Value:       getStringValue(input.Value),
 		Description: getStringValue(input.Description),
 		Category:    getStringValue(input.Category),
+		Source:      model.SourceWeb,
+		ExpiresAt:   input.ExpiresAt,
+	}
+	if input.RotationInterval != nil {
+		params.RotationInterval = time.Duration(*input.RotationInterval) * time.Second
+	}
+	if input.Type != nil {
+		params.Type = model.VaultType(*input.Type)
+	}
+	if input.Data != nil {
+		if input.Value != nil {
+			return handler.SendError(c, fiber.StatusBadRequest, "value cannot be combined with data")
+		}
+		if input.Type != nil && params.Type != model.VaultTypeKV {
+			return handler.SendError(c, fiber.StatusBadRequest, "data can only be set for kv vaults")
+		}
+		value, err := model.FormatKVValue(*input.Data)
+		if err != nil {
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+		params.Type = model.VaultTypeKV
+		params.Value = value
+	}
+	if input.OrganizationId != nil {
+		if *input.OrganizationId <= 0 {
+			return handler.SendError(c, fiber.StatusBadRequest, "invalid organizationId")
+		}
+		// #nosec G115
+		organizationID := uint(*input.OrganizationId)
+		params.OrganizationID = &organizationID
 	}
 
 	// Validate parameters
-	errors := params.Validate()
-	if len(errors) > 0 {
+	validationErrors := params.Validate()
+	if len(validationErrors) > 0 {
 		var errorMsgs []string
-		for _, msg := range errors {
+		for _, msg := range validationErrors {
 			errorMsgs = append(errorMsgs, msg)
 		}
 		return handler.SendError(c, fiber.StatusBadRequest, strings.Join(errorMsgs, "; "))
@@ -177,6 +316,9 @@ func (Server) CreateVault(c *fiber.Ctx) error {
 	// Create vault
 	vault, err := params.Create()
 	if err != nil {
+		if errors.Is(err, model.ErrVaultPermissionDenied) {
+			return handler.SendError(c, fiber.StatusForbidden, "creating vaults in this organization requires the member role")
+		}
 		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
 	}
 
@@ -205,6 +347,10 @@ func (Server) UpdateVault(c *fiber.Ctx, uniqueID string) error {
 		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
 	}
 
+	if ok, err := checkVaultPermission(c, &vault, user.ID, model.VaultPermissionWrite); !ok {
+		return err
+	}
+
 	var input UpdateVaultRequest
 	if err := c.BodyParser(&input); err != nil {
 		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
@@ -217,13 +363,27 @@ func (Server) UpdateVault(c *fiber.Ctx, uniqueID string) error {
 		Description: input.Description,
 		Category:    input.Category,
 		Favourite:   input.Favourite,
+		Author:      model.VersionAuthor{UserID: user.ID, Source: model.SourceWeb},
+		ExpiresAt:   input.ExpiresAt,
+		ClearExpiry: input.ClearExpiry != nil && *input.ClearExpiry,
+	}
+	if input.SetKeys != nil {
+		params.SetKeys = *input.SetKeys
+	}
+	if input.DeleteKeys != nil {
+		params.DeleteKeys = *input.DeleteKeys
+	}
+	if input.RotationInterval != nil {
+		interval := time.Duration(*input.RotationInterval) * time.Second
+		params.RotationInterval = &interval
 	}
 
 	// Validate parameters
-	errors := params.Validate()
-	if len(errors) > 0 {
+	validationErrors := params.Validate()
+	maps.Copy(validationErrors, vault.ValidateUpdate(&params))
+	if len(validationErrors) > 0 {
 		var errorMsgs []string
-		for _, msg := range errors {
+		for _, msg := range validationErrors {
 			errorMsgs = append(errorMsgs, msg)
 		}
 		return handler.SendError(c, fiber.StatusBadRequest, strings.Join(errorMsgs, "; "))
@@ -258,6 +418,10 @@ func (Server) DeleteVault(c *fiber.Ctx, uniqueID string) error {
 		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
 	}
 
+	if ok, err := checkVaultPermission(c, &vault, user.ID, model.VaultPermissionDelete); !ok {
+		return err
+	}
+
 	// Delete vault
 	err = vault.Delete()
 	if err != nil {
@@ -295,9 +459,10 @@ func (Server) GetVaultFilterOptions(c *fiber.Ctx) error {
 		return err
 	}
 
-	// Query all vaults for the user, selecting only needed fields
+	// Query all vaults the user can access, selecting only needed fields
 	var vaults []model.Vault
-	err = model.DB.Where("user_id = ? AND deleted_at IS NULL", user.ID).
+	err = model.DB.Scopes(model.AccessibleVaults(user.ID)).
+		Where("deleted_at IS NULL").
 		Select("unique_id", "name").
 		Order("name ASC").
 		Find(&vaults).Error
@@ -0,0 +1,191 @@
+package api
+
+import (
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/gorm"
+)
+
+// convertToApiVaultVersion converts a model.VaultVersion to an api.VaultVersion
+// The value is only included when includeValue is set
+func convertToApiVaultVersion(version *model.VaultVersion, includeValue bool) VaultVersion {
+	// #nosec G115
+	userID := int64(version.UserID)
+	apiVersion := VaultVersion{
+		Version:   int(version.Version),
+		UserId:    userID,
+		CreatedAt: version.CreatedAt,
+	}
+
+	if includeValue {
+		apiVersion.Value = &version.Value
+	}
+	if version.APIKeyID != nil {
+		// #nosec G115
+		apiKeyID := int64(*version.APIKeyID)
+		apiVersion.ApiKeyId = &apiKeyID
+	}
+	if version.Source != "" {
+		source := VaultVersionSource(version.Source)
+		apiVersion.Source = &source
+	}
+	if version.RestoredFrom != nil {
+		restoredFrom := int(*version.RestoredFrom)
+		apiVersion.RestoredFrom = &restoredFrom
+	}
+
+	return apiVersion
+}
+
+// buildVaultVersionsResponse lists the versions of a vault without their values
+func buildVaultVersionsResponse(vaultID uint) (*VaultVersionsResponse, error) {
+	versions, err := model.GetVaultVersions(vaultID)
+	if err != nil {
+		return nil, err
+	}
+
+	apiVersions := make([]VaultVersion, 0, len(versions))
+	for i := range versions {
+		apiVersions = append(apiVersions, convertToApiVaultVersion(&versions[i], false))
+	}
+
+	return &VaultVersionsResponse{Versions: apiVersions}, nil
+}
+
+// GetVaultVersions handles GET /api/vaults/{unique_id}/versions
+func (Server) GetVaultVersions(c *fiber.Ctx, uniqueID string) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var vault model.Vault
+	err = vault.GetByUniqueID(uniqueID, user.ID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handler.SendError(c, fiber.StatusNotFound, "vault not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	response, err := buildVaultVersionsResponse(vault.ID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
+
+// GetVaultVersion handles GET /api/vaults/{unique_id}/versions/{version}
+func (Server) GetVaultVersion(c *fiber.Ctx, uniqueID string, version int) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if version < 1 {
+		return handler.SendError(c, fiber.StatusBadRequest, "version must be at least 1")
+	}
+
+	var vault model.Vault
+	err = vault.GetByUniqueID(uniqueID, user.ID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handler.SendError(c, fiber.StatusNotFound, "vault not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	// #nosec G115
+	vaultVersion, err := model.GetVaultVersion(vault.ID, uint(version))
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handler.SendError(c, fiber.StatusNotFound, "version not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	// Reading an old value is still a read of the vault
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionReadVault, user.ID, model.SourceWeb, nil, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for read vault version", "error", err, "vaultID", vault.ID, "version", version)
+	}
+
+	if vault.IsDynamic() {
+		vaultVersion.Value = model.RedactDatabaseConfig(vaultVersion.Value)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiVaultVersion(vaultVersion, true))
+}
+
+// RestoreVaultVersion handles POST /api/vaults/{unique_id}/versions/{version}/restore
+func (Server) RestoreVaultVersion(c *fiber.Ctx, uniqueID string, version int) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if version < 1 {
+		return handler.SendError(c, fiber.StatusBadRequest, "version must be at least 1")
+	}
+
+	var vault model.Vault
+	err = vault.GetByUniqueID(uniqueID, user.ID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handler.SendError(c, fiber.StatusNotFound, "vault not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if ok, err := checkVaultPermission(c, &vault, user.ID, model.VaultPermissionWrite); !ok {
+		return err
+	}
+
+	author := model.VersionAuthor{UserID: user.ID, Source: model.SourceWeb}
+	// #nosec G115
+	err = vault.RestoreVersion(uint(version), author)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handler.SendError(c, fiber.StatusNotFound, "version not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	// Log restore action
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionRestoreVaultVersion, user.ID, model.SourceWeb, nil, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for restore vault version", "error", err, "vaultID", vault.ID, "version", version)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiVault(&vault))
+}
+
+// GetVaultVersionsByAPIKey - Get the version history of a vault by unique ID using API key
+func (s Server) GetVaultVersionsByAPIKey(c *fiber.Ctx, uniqueId string) error {
+	apiKey, err := getAPIKeyFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if ok, err := requireAPIKeyPermission(c, apiKey, model.PermissionVaultRead); !ok {
+		return err
+	}
+
+	// getVaultForAPIKey has already written the error response when vault is nil
+	vault, err := getVaultForAPIKey(c, uniqueId, apiKey)
+	if err != nil || vault == nil {
+		return err
+	}
+
+	response, err := buildVaultVersionsResponse(vault.ID)
+	if err != nil {
+		slog.Error("Failed to get vault versions", "error", err, "vaultID", vault.ID)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to retrieve vault versions")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(response)
+}
@@ -0,0 +1,321 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/auth"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/gorm"
+)
+
+// WebAuthnSessionTTL is how long a passkey ceremony can take between its begin and finish requests
+const WebAuthnSessionTTL = 5 * time.Minute
+
+// errWebAuthnDisabled is returned by all WebAuthn endpoints when no relying party is configured
+var errWebAuthnDisabled = errors.New("WebAuthn is not enabled")
+
+// convertToApiWebAuthnCredential converts a model.Credential to an api.WebAuthnCredential
+func convertToApiWebAuthnCredential(cred *model.Credential) WebAuthnCredential {
+	transports := make([]string, 0, len(cred.Transports))
+	transports = append(transports, cred.Transports...)
+
+	// #nosec G115
+	id := int64(cred.ID)
+	apiCredential := WebAuthnCredential{
+		Id:             id,
+		Name:           cred.Name,
+		Transports:     transports,
+		SignCount:      int64(cred.SignCount),
+		BackupEligible: cred.BackupEligible,
+		BackupState:    cred.BackupState,
+		CreatedAt:      cred.CreatedAt,
+		LastUsedAt:     cred.LastUsedAt,
+	}
+	if cred.AAGUID != "" {
+		apiCredential.Aaguid = &cred.AAGUID
+	}
+	return apiCredential
+}
+
+// toJSONObject converts ceremony options to the generic object of the API response
+func toJSONObject(v interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]interface{}
+	if err := json.Unmarshal(encoded, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// BeginWebAuthnRegistration returns the options to create a passkey for the current user
+func (Server) BeginWebAuthnRegistration(c *fiber.Ctx) error {
+	relyingParty := auth.WebAuthn()
+	if relyingParty == nil {
+		return handler.SendError(c, fiber.StatusNotFound, errWebAuthnDisabled.Error())
+	}
+
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	account, err := model.NewWebAuthnAccount(user)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	// Stop authenticators from creating a second passkey for the same account
+	exclusions := make([]protocol.CredentialDescriptor, 0, len(account.Credentials))
+	for _, credential := range account.WebAuthnCredentials() {
+		exclusions = append(exclusions, credential.Descriptor())
+	}
+
+	creation, session, err := relyingParty.BeginRegistration(account, webauthn.WithExclusions(exclusions))
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	sessionToken, err := model.CreateWebAuthnSession(&user.ID, model.WebAuthnCeremonyRegistration, session, WebAuthnSessionTTL)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	options, err := toJSONObject(creation)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(WebAuthnBeginResponse{
+		SessionToken: sessionToken,
+		Options:      options,
+	})
+}
+
+// FinishWebAuthnRegistration verifies the attestation of a new passkey and stores it
+func (Server) FinishWebAuthnRegistration(c *fiber.Ctx) error {
+	relyingParty := auth.WebAuthn()
+	if relyingParty == nil {
+		return handler.SendError(c, fiber.StatusNotFound, errWebAuthnDisabled.Error())
+	}
+
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var input WebAuthnRegistrationFinishRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+
+	session, err := model.ConsumeWebAuthnSession(input.SessionToken, model.WebAuthnCeremonyRegistration, &user.ID)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidWebAuthnSession) {
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	response, err := json.Marshal(input.Credential)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, "invalid credential: "+err.Error())
+	}
+
+	account, err := model.NewWebAuthnAccount(user)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	verified, err := relyingParty.CreateCredential(account, *session, parsed)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, "invalid credential: "+err.Error())
+	}
+
+	params := model.CreateCredentialParams{
+		UserID:     user.ID,
+		Credential: verified,
+	}
+	if input.Name != nil {
+		params.Name = *input.Name
+	}
+	if errs := params.Validate(); len(errs) > 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, joinValidationErrors(errs))
+	}
+
+	credential, err := params.Create()
+	if err != nil {
+		if errors.Is(err, model.ErrCredentialExists) {
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := model.LogUserAction(model.ActionRegisterCredential, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for passkey registration", "error", err, "userID", user.ID)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(convertToApiWebAuthnCredential(credential))
+}
+
+// BeginWebAuthnLogin returns the options to log in with a passkey. No email is needed: the
+// authenticator lets the user pick one of their passkeys for this site.
+func (Server) BeginWebAuthnLogin(c *fiber.Ctx) error {
+	relyingParty := auth.WebAuthn()
+	if relyingParty == nil {
+		return handler.SendError(c, fiber.StatusNotFound, errWebAuthnDisabled.Error())
+	}
+
+	assertion, session, err := relyingParty.BeginDiscoverableLogin()
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	sessionToken, err := model.CreateWebAuthnSession(nil, model.WebAuthnCeremonyLogin, session, WebAuthnSessionTTL)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	options, err := toJSONObject(assertion)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.Status(fiber.StatusOK).JSON(WebAuthnBeginResponse{
+		SessionToken: sessionToken,
+		Options:      options,
+	})
+}
+
+// FinishWebAuthnLogin verifies a passkey assertion and issues the same JWT as a password login.
+// A passkey already proves possession and, with user verification, presence, so it is not
+// followed by a TOTP challenge.
+func (Server) FinishWebAuthnLogin(c *fiber.Ctx) error {
+	relyingParty := auth.WebAuthn()
+	if relyingParty == nil {
+		return handler.SendError(c, fiber.StatusNotFound, errWebAuthnDisabled.Error())
+	}
+
+	var input WebAuthnLoginFinishRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+
+	session, err := model.ConsumeWebAuthnSession(input.SessionToken, model.WebAuthnCeremonyLogin, nil)
+	if err != nil {
+		if errors.Is(err, model.ErrInvalidWebAuthnSession) {
+			return handler.SendError(c, fiber.StatusUnauthorized, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	response, err := json.Marshal(input.Credential)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusUnauthorized, "Invalid passkey")
+	}
+
+	var account *model.WebAuthnAccount
+	findAccount := func(rawID, userHandle []byte) (webauthn.User, error) {
+		found, err := model.GetWebAuthnAccountByHandle(userHandle)
+		if err != nil {
+			return nil, err
+		}
+		account = found
+		return found, nil
+	}
+	verified, err := relyingParty.ValidateDiscoverableLogin(findAccount, *session, parsed)
+	if err != nil || account == nil {
+		return handler.SendError(c, fiber.StatusUnauthorized, "Invalid passkey")
+	}
+
+	credential, err := model.GetCredentialByCredentialID(account.User.ID, verified.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return handler.SendError(c, fiber.StatusUnauthorized, "Invalid passkey")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if err := credential.RecordLogin(verified); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	// A sign counter that went backwards means the authenticator may have been cloned
+	if verified.Authenticator.CloneWarning {
+		slog.Warn("Rejected passkey login with a cloned authenticator", "userID", account.User.ID, "credentialID", credential.ID)
+		return handler.SendError(c, fiber.StatusUnauthorized, "Invalid passkey")
+	}
+
+	return completeLogin(c, account.User, model.ActionWebAuthnLogin, clientIP, userAgent)
+}
+
+// GetWebAuthnCredentials handles GET /api/auth/webauthn/credentials
+func (Server) GetWebAuthnCredentials(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	credentials, err := model.GetUserCredentials(user.ID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	apiCredentials := make([]WebAuthnCredential, 0, len(credentials))
+	for i := range credentials {
+		apiCredentials = append(apiCredentials, convertToApiWebAuthnCredential(&credentials[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(WebAuthnCredentialsResponse{Credentials: apiCredentials})
+}
+
+// DeleteWebAuthnCredential handles DELETE /api/auth/webauthn/credentials/{id}
+func (Server) DeleteWebAuthnCredential(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	if id <= 0 {
+		return handler.SendError(c, fiber.StatusNotFound, "passkey not found")
+	}
+
+	// #nosec G115
+	credential, err := model.GetCredential(uint(id), user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return handler.SendError(c, fiber.StatusNotFound, "passkey not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := credential.Delete(); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	clientIP, userAgent := getClientInfo(c)
+	if err := model.LogUserAction(model.ActionDeleteCredential, user.ID, model.SourceWeb, clientIP, userAgent); err != nil {
+		slog.Error("Failed to create audit log for passkey deletion", "error", err, "userID", user.ID)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
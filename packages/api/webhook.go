@@ -0,0 +1,308 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/webhook"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/gorm"
+)
+
+// convertToApiWebhook converts a model.Webhook to an api.Webhook
+func convertToApiWebhook(w *model.Webhook) Webhook {
+	events := make([]WebhookEventType, 0, len(w.Events))
+	for _, event := range w.Events {
+		events = append(events, WebhookEventType(event))
+	}
+
+	// #nosec G115
+	id := int64(w.ID)
+	apiWebhook := Webhook{
+		Id:        id,
+		Name:      w.Name,
+		Url:       w.URL,
+		Events:    events,
+		Enabled:   w.Enabled,
+		CreatedAt: w.CreatedAt,
+		UpdatedAt: &w.UpdatedAt,
+	}
+	if w.Vault != nil {
+		vault := convertToApiVaultLite(w.Vault)
+		apiWebhook.Vault = &vault
+	}
+	return apiWebhook
+}
+
+// convertToApiWebhookDelivery converts a model.WebhookDelivery to an api.WebhookDelivery
+func convertToApiWebhookDelivery(d *model.WebhookDelivery) WebhookDelivery {
+	delivery := WebhookDelivery{
+		Id:            d.UniqueID,
+		Event:         string(d.Event),
+		Status:        WebhookDeliveryStatus(d.Status),
+		Attempts:      d.Attempts,
+		NextAttemptAt: d.NextAttemptAt,
+		LastAttemptAt: d.LastAttemptAt,
+		DeliveredAt:   d.DeliveredAt,
+		Payload:       &d.Payload,
+		CreatedAt:     d.CreatedAt,
+	}
+	if d.ResponseStatus != 0 {
+		delivery.ResponseStatus = &d.ResponseStatus
+	}
+	if d.ResponseBody != "" {
+		delivery.ResponseBody = &d.ResponseBody
+	}
+	if d.Error != "" {
+		delivery.Error = &d.Error
+	}
+	return delivery
+}
+
+// convertWebhookEvents converts API event types to model events
+func convertWebhookEvents(events []WebhookEventType) []model.WebhookEvent {
+	result := make([]model.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		result = append(result, model.WebhookEvent(event))
+	}
+	return result
+}
+
+// findWebhook returns a webhook of the user, writing a 404 response if there is none
+func findWebhook(c *fiber.Ctx, id int64, userID uint) (*model.Webhook, error) {
+	if id <= 0 {
+		return nil, handler.SendError(c, fiber.StatusNotFound, "webhook not found")
+	}
+
+	// #nosec G115
+	w, err := model.GetWebhook(uint(id), userID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, handler.SendError(c, fiber.StatusNotFound, "webhook not found")
+		}
+		return nil, handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return w, nil
+}
+
+// GetWebhooks handles GET /api/webhooks
+func (Server) GetWebhooks(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := model.GetUserWebhooks(user.ID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	apiWebhooks := make([]Webhook, 0, len(webhooks))
+	for i := range webhooks {
+		apiWebhooks = append(apiWebhooks, convertToApiWebhook(&webhooks[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(WebhooksResponse{Webhooks: apiWebhooks})
+}
+
+// CreateWebhook handles POST /api/webhooks
+func (Server) CreateWebhook(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var input CreateWebhookRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	params := model.CreateWebhookParams{
+		UserID:  user.ID,
+		Name:    input.Name,
+		URL:     input.Url,
+		Enabled: input.Enabled == nil || *input.Enabled,
+	}
+	if input.Events != nil && len(*input.Events) > 0 {
+		params.Events = convertWebhookEvents(*input.Events)
+	}
+
+	var vault *model.Vault
+	if input.VaultUniqueId != nil {
+		vault = &model.Vault{}
+		if err := vault.GetByUniqueID(*input.VaultUniqueId, user.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return handler.SendError(c, fiber.StatusNotFound, "vault not found")
+			}
+			return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		// A vault webhook sees the activity of every member, so it needs the role that may
+		// delete the vault
+		if ok, err := checkVaultPermission(c, vault, user.ID, model.VaultPermissionDelete); !ok {
+			return err
+		}
+		params.VaultID = &vault.ID
+	}
+
+	if errors := params.Validate(); len(errors) > 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, joinValidationErrors(errors))
+	}
+
+	w, secret, err := params.Create()
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	w.Vault = vault
+
+	return c.Status(fiber.StatusCreated).JSON(CreateWebhookResponse{
+		Webhook: convertToApiWebhook(w),
+		Secret:  secret,
+	})
+}
+
+// GetWebhook handles GET /api/webhooks/{id}
+func (Server) GetWebhook(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	w, err := findWebhook(c, id, user.ID)
+	if err != nil || w == nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiWebhook(w))
+}
+
+// UpdateWebhook handles PUT /api/webhooks/{id}
+func (Server) UpdateWebhook(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	w, err := findWebhook(c, id, user.ID)
+	if err != nil || w == nil {
+		return err
+	}
+
+	var input UpdateWebhookRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	params := model.UpdateWebhookParams{
+		Name:    input.Name,
+		URL:     input.Url,
+		Enabled: input.Enabled,
+	}
+	if input.Events != nil {
+		events := convertWebhookEvents(*input.Events)
+		params.Events = &events
+	}
+	if errors := params.Validate(); len(errors) > 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, joinValidationErrors(errors))
+	}
+
+	if err := w.Update(&params); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	// Reload to return the stored state
+	w, err = findWebhook(c, id, user.ID)
+	if err != nil || w == nil {
+		return err
+	}
+	return c.Status(fiber.StatusOK).JSON(convertToApiWebhook(w))
+}
+
+// DeleteWebhook handles DELETE /api/webhooks/{id}
+func (Server) DeleteWebhook(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	w, err := findWebhook(c, id, user.ID)
+	if err != nil || w == nil {
+		return err
+	}
+
+	if err := w.Delete(); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetWebhookDeliveries handles GET /api/webhooks/{id}/deliveries
+func (Server) GetWebhookDeliveries(c *fiber.Ctx, id int64, params GetWebhookDeliveriesParams) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	pageSize, pageIndex := 20, 1
+	if params.PageSize != nil {
+		pageSize = *params.PageSize
+	}
+	if params.PageIndex != nil {
+		pageIndex = *params.PageIndex
+	}
+	if pageSize < 1 || pageSize > 1000 {
+		return handler.SendError(c, fiber.StatusBadRequest, "pageSize must be between 1 and 1000")
+	}
+	if pageIndex < 1 {
+		return handler.SendError(c, fiber.StatusBadRequest, "pageIndex must be at least 1")
+	}
+
+	w, err := findWebhook(c, id, user.ID)
+	if err != nil || w == nil {
+		return err
+	}
+
+	deliveries, totalCount, err := model.GetWebhookDeliveriesWithPagination(w.ID, pageSize, pageIndex)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	apiDeliveries := make([]WebhookDelivery, 0, len(deliveries))
+	for i := range deliveries {
+		apiDeliveries = append(apiDeliveries, convertToApiWebhookDelivery(&deliveries[i]))
+	}
+
+	return c.Status(fiber.StatusOK).JSON(WebhookDeliveriesResponse{
+		Deliveries: apiDeliveries,
+		TotalCount: int(totalCount),
+		PageSize:   pageSize,
+		PageIndex:  pageIndex,
+	})
+}
+
+// TestWebhook handles POST /api/webhooks/{id}/test
+func (Server) TestWebhook(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	w, err := findWebhook(c, id, user.ID)
+	if err != nil || w == nil {
+		return err
+	}
+
+	delivery, err := w.CreateTestDelivery(user.ID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := webhook.Deliver(c.Context(), webhook.NewClient(), delivery); err != nil {
+		slog.Error("Failed to record webhook test delivery", "error", err, "webhookID", w.ID)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to record delivery")
+	}
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiWebhookDelivery(delivery))
+}
@@ -0,0 +1,231 @@
+package api
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/internal/auth"
+	"github.com/lwshen/vault-hub/internal/config"
+	"github.com/lwshen/vault-hub/model"
+	"gorm.io/gorm"
+)
+
+// convertToApiWorkloadIdentity converts a model.WorkloadIdentity to an api.WorkloadIdentity
+func convertToApiWorkloadIdentity(w *model.WorkloadIdentity) (WorkloadIdentity, error) {
+	// The exchanged API keys see the same vaults as a regular key with this scope
+	scope := model.APIKey{UserID: w.UserID, VaultIDs: w.VaultIDs, Permissions: w.Permissions}
+	vaults, err := scope.GetAccessibleVaults()
+	if err != nil {
+		return WorkloadIdentity{}, err
+	}
+	apiVaults := make([]VaultLite, 0, len(vaults))
+	for i := range vaults {
+		apiVaults = append(apiVaults, convertToApiVaultLite(&vaults[i]))
+	}
+
+	permissions := make([]APIKeyPermission, 0, len(scope.EffectivePermissions()))
+	for _, permission := range scope.EffectivePermissions() {
+		permissions = append(permissions, APIKeyPermission(permission))
+	}
+
+	// #nosec G115
+	id := int64(w.ID)
+	apiIdentity := WorkloadIdentity{
+		Id:          id,
+		Name:        w.Name,
+		Issuer:      w.Issuer,
+		Audience:    w.Audience,
+		Subject:     w.Subject,
+		Vaults:      &apiVaults,
+		Permissions: permissions,
+		TokenTtl:    int(w.TokenTTL / time.Second),
+		CreatedAt:   w.CreatedAt,
+	}
+	if len(w.Claims) > 0 {
+		claims := map[string]string(w.Claims)
+		apiIdentity.Claims = &claims
+	}
+	return apiIdentity, nil
+}
+
+// auditWorkloadIdentity creates an audit log entry for workload identity operations
+func auditWorkloadIdentity(c *fiber.Ctx, action model.ActionType, userID uint) {
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogUserAction(action, userID, model.SourceWeb, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for workload identity operation",
+			"action", action, "user_id", userID, "error", err)
+	}
+}
+
+// GetWorkloadIdentities handles GET /api/workload-identities
+func (Server) GetWorkloadIdentities(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	identities, err := model.GetUserWorkloadIdentities(user.ID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	apiIdentities := make([]WorkloadIdentity, 0, len(identities))
+	for i := range identities {
+		apiIdentity, err := convertToApiWorkloadIdentity(&identities[i])
+		if err != nil {
+			return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+		}
+		apiIdentities = append(apiIdentities, apiIdentity)
+	}
+
+	issuers := config.WorkloadIssuers
+	if issuers == nil {
+		issuers = []string{}
+	}
+	return c.Status(fiber.StatusOK).JSON(WorkloadIdentitiesResponse{
+		WorkloadIdentities: apiIdentities,
+		Issuers:            issuers,
+	})
+}
+
+// CreateWorkloadIdentity handles POST /api/workload-identities
+func (Server) CreateWorkloadIdentity(c *fiber.Ctx) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+
+	var input CreateWorkloadIdentityRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	vaultIDs, err := convertVaultUniqueIDs(input.VaultUniqueIds, user.ID)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	params := model.CreateWorkloadIdentityParams{
+		UserID:   user.ID,
+		Name:     input.Name,
+		Issuer:   input.Issuer,
+		Audience: input.Audience,
+		Subject:  input.Subject,
+		VaultIDs: vaultIDs,
+	}
+	if input.Claims != nil {
+		params.Claims = *input.Claims
+	}
+	if input.Permissions != nil {
+		params.Permissions = convertAPIKeyPermissions(*input.Permissions)
+	}
+	if input.TokenTtl != nil {
+		params.TokenTTL = time.Duration(*input.TokenTtl) * time.Second
+	}
+	if errors := params.Validate(config.WorkloadIssuers); len(errors) > 0 {
+		return handler.SendError(c, fiber.StatusBadRequest, joinValidationErrors(errors))
+	}
+
+	identity, err := params.Create()
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	auditWorkloadIdentity(c, model.ActionCreateWorkloadIdentity, user.ID)
+
+	apiIdentity, err := convertToApiWorkloadIdentity(identity)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	return c.Status(fiber.StatusCreated).JSON(apiIdentity)
+}
+
+// DeleteWorkloadIdentity handles DELETE /api/workload-identities/{id}
+func (Server) DeleteWorkloadIdentity(c *fiber.Ctx, id int64) error {
+	user, err := getUserFromContext(c)
+	if err != nil {
+		return err
+	}
+	if id <= 0 {
+		return handler.SendError(c, fiber.StatusNotFound, "workload identity not found")
+	}
+
+	// #nosec G115
+	identity, err := model.GetWorkloadIdentity(uint(id), user.ID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return handler.SendError(c, fiber.StatusNotFound, "workload identity not found")
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	if err := identity.Delete(); err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	auditWorkloadIdentity(c, model.ActionDeleteWorkloadIdentity, user.ID)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// TokenExchange handles POST /api/auth/token-exchange
+func (Server) TokenExchange(c *fiber.Ctx) error {
+	if !config.WorkloadIdentityEnabled {
+		return handler.SendError(c, fiber.StatusNotFound, "workload identity is not enabled")
+	}
+
+	var input TokenExchangeRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	token, err := auth.VerifyWorkloadToken(c.Context(), input.Token)
+	if errors.Is(err, auth.ErrWorkloadTokenInvalid) {
+		return handler.SendError(c, fiber.StatusUnauthorized, err.Error())
+	} else if err != nil {
+		slog.Error("Failed to verify workload token", "error", err)
+		return handler.SendError(c, fiber.StatusServiceUnavailable, "issuer unavailable")
+	}
+
+	matches, err := model.FindWorkloadIdentities(token)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if input.IdentityId != nil {
+		var selected []model.WorkloadIdentity
+		for _, identity := range matches {
+			// #nosec G115
+			if int64(identity.ID) == *input.IdentityId {
+				selected = append(selected, identity)
+			}
+		}
+		matches = selected
+	}
+	switch {
+	case len(matches) == 0:
+		slog.Info("Workload token matches no workload identity", "issuer", token.Issuer, "subject", token.Subject)
+		return handler.SendError(c, fiber.StatusForbidden, "no workload identity matches the token")
+	case len(matches) > 1:
+		return handler.SendError(c, fiber.StatusBadRequest, "the token matches several workload identities, select one with identityId")
+	}
+	identity := matches[0]
+
+	apiKey, plainKey, err := identity.IssueAPIKey()
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogAPIKeyAction(apiKey.ID, model.ActionTokenExchange, identity.UserID, model.SourceCLI, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for token exchange", "api_key_id", apiKey.ID, "error", err)
+	}
+
+	// #nosec G115
+	identityID := int64(identity.ID)
+	return c.Status(fiber.StatusOK).JSON(TokenExchangeResponse{
+		ApiKey:     plainKey,
+		ExpiresAt:  *apiKey.ExpiresAt,
+		IdentityId: identityID,
+	})
+}
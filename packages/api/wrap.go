@@ -0,0 +1,87 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/lwshen/vault-hub/handler"
+	"github.com/lwshen/vault-hub/model"
+)
+
+// parseWrapTTL parses a wrap_ttl value, either a duration like 5m or a number of seconds
+func parseWrapTTL(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		seconds, convErr := strconv.Atoi(value)
+		if convErr != nil {
+			return 0, fmt.Errorf("invalid wrap_ttl %q, use a duration like 5m or a number of seconds", value)
+		}
+		ttl = time.Duration(seconds) * time.Second
+	}
+	if ttl < model.MinWrapTTL || ttl > model.MaxWrapTTL {
+		return 0, fmt.Errorf("wrap_ttl must be between %s and %s", model.MinWrapTTL, model.MaxWrapTTL)
+	}
+	return ttl, nil
+}
+
+// wrapVault wraps the value of a vault read with an API key and responds with the token
+func wrapVault(c *fiber.Ctx, vault *model.Vault, apiKey *model.APIKey, ttl time.Duration) error {
+	token, wrapped, err := model.CreateWrappingToken(vault, apiKey.UserID, &apiKey.ID, ttl)
+	if err != nil {
+		slog.Error("Failed to wrap vault value", "error", err, "vaultID", vault.ID)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to wrap vault value")
+	}
+
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionWrapVault, apiKey.UserID, model.SourceCLI, &apiKey.ID, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for wrap vault", "error", err, "vaultID", vault.ID)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(WrapInfo{
+		WrapToken: token,
+		ExpiresAt: wrapped.ExpiresAt,
+	})
+}
+
+// Unwrap handles POST /api/cli/unwrap
+func (Server) Unwrap(c *fiber.Ctx) error {
+	var input UnwrapRequest
+	if err := c.BodyParser(&input); err != nil {
+		return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+	}
+
+	wrapped, value, err := model.UnwrapToken(input.Token)
+	if err != nil {
+		switch {
+		case errors.Is(err, model.ErrWrappingTokenUsed):
+			// Whoever redeemed it first has seen the value
+			slog.Warn("Wrapping token redeemed again", "ip", c.IP())
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		case errors.Is(err, model.ErrWrappingTokenInvalid):
+			return handler.SendError(c, fiber.StatusBadRequest, err.Error())
+		}
+		slog.Error("Failed to unwrap token", "error", err)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to unwrap token")
+	}
+
+	// The vault may have been deleted since; the wrapped value is still handed out
+	var vault model.Vault
+	if err := model.DB.Unscoped().First(&vault, wrapped.VaultID).Error; err != nil {
+		slog.Error("Failed to get wrapped vault", "error", err, "vaultID", wrapped.VaultID)
+		return handler.SendError(c, fiber.StatusInternalServerError, "failed to unwrap token")
+	}
+	vault.Value = value
+
+	ip, userAgent := getClientInfo(c)
+	if err := model.LogVaultAction(vault.ID, model.ActionUnwrapVault, wrapped.UserID, model.SourceCLI, wrapped.APIKeyID, ip, userAgent); err != nil {
+		slog.Error("Failed to create audit log for unwrap vault", "error", err, "vaultID", vault.ID)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(convertToApiKeyVault(&vault))
+}
@@ -1,13 +1,16 @@
 package route
 
 import (
+	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/lwshen/vault-hub/handler"
 	"github.com/lwshen/vault-hub/internal/config"
+	"github.com/lwshen/vault-hub/internal/ratelimit"
 	"github.com/lwshen/vault-hub/model"
 )
 
@@ -16,6 +19,15 @@ func jwtMiddleware(c *fiber.Ctx) error {
 
 	// Public routes that don't need authentication
 	if isPublicRoute(path) {
+		if isRateLimitedRoute(path) {
+			wait, err := ratelimit.Default().Allow(c.Context(), ratelimit.IPKey("auth", c.IP()), ratelimit.AuthRequests)
+			if err != nil {
+				return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+			}
+			if wait > 0 {
+				return handler.SendTooManyRequests(c, wait, "too many requests, try again later")
+			}
+		}
 		return c.Next()
 	}
 
@@ -40,6 +52,9 @@ func isPublicRoute(path string) bool {
 		"/api/version",
 		"/api/config",
 		"/api/auth/login",
+		"/api/auth/login/2fa",
+		"/api/auth/refresh",
+		"/api/auth/webauthn/login/",
 		"/api/auth/signup",
 		"/api/auth/login/oidc",
 		"/api/auth/callback/oidc",
@@ -47,6 +62,9 @@ func isPublicRoute(path string) bool {
 		"/api/auth/password/reset/confirm",
 		"/api/auth/magic-link/request",
 		"/api/auth/magic-link/token",
+		"/api/auth/token-exchange",
+		"/api/shares/",
+		"/api/cli/unwrap",
 	}
 
 	for _, route := range publicRoutes {
@@ -57,6 +75,29 @@ func isPublicRoute(path string) bool {
 	return false
 }
 
+// isRateLimitedRoute checks if a public route takes credentials and is limited per client IP
+func isRateLimitedRoute(path string) bool {
+	rateLimitedRoutes := []string{
+		"/api/auth/login",
+		"/api/auth/refresh",
+		"/api/auth/webauthn/login/",
+		"/api/auth/signup",
+		"/api/auth/password/reset/",
+		"/api/auth/magic-link/",
+		"/api/auth/token-exchange",
+		"/api/shares/",
+		"/api/cli/unwrap",
+	}
+
+	for _, route := range rateLimitedRoutes {
+		if strings.HasPrefix(path, route) {
+			// The OIDC login only redirects to the provider
+			return !strings.HasPrefix(path, "/api/auth/login/oidc")
+		}
+	}
+	return false
+}
+
 // jwtOnlyMiddleware ensures non-API-key routes only accept JWT authentication
 func jwtOnlyMiddleware(c *fiber.Ctx) error {
 	authHeader := c.Get("Authorization")
@@ -102,8 +143,21 @@ func apiKeyOnlyMiddleware(c *fiber.Ctx) error {
 }
 
 func handleAPIKeyAuth(c *fiber.Ctx, apiKey string) error {
+	// Clients that keep presenting invalid API keys are locked out
+	limitKey := ratelimit.IPKey("apikey", c.IP())
+	wait, _, err := ratelimit.Default().Check(c.Context(), limitKey, ratelimit.APIKeyFailures)
+	if err != nil {
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+	if wait > 0 {
+		return handler.SendTooManyRequests(c, wait, "too many invalid API keys, try again later")
+	}
+
 	key, err := model.ValidateAPIKey(apiKey)
 	if err != nil {
+		if _, err := ratelimit.Default().Fail(c.Context(), limitKey, ratelimit.APIKeyFailures); err != nil {
+			slog.Error("Failed to record invalid API key", "error", err)
+		}
 		return handler.SendError(c, fiber.StatusUnauthorized, "invalid API key")
 	}
 
@@ -135,6 +189,19 @@ func handleJWTAuth(c *fiber.Ctx, tokenString string) error {
 		return handler.SendError(c, fiber.StatusUnauthorized, "invalid user ID in token")
 	}
 
+	// Access tokens are bound to a session, which ends on logout or revocation
+	sessionID, ok := claims["sid"].(string)
+	if !ok || sessionID == "" {
+		return handler.SendError(c, fiber.StatusUnauthorized, "invalid session in token")
+	}
+	session, err := model.GetActiveSession(sessionID, uint(userID))
+	if err != nil {
+		if errors.Is(err, model.ErrSessionRevoked) {
+			return handler.SendError(c, fiber.StatusUnauthorized, err.Error())
+		}
+		return handler.SendError(c, fiber.StatusInternalServerError, err.Error())
+	}
+
 	var user model.User
 	if err := model.DB.First(&user, uint(userID)).Error; err != nil {
 		return handler.SendError(c, fiber.StatusUnauthorized, "user not found")
@@ -142,6 +209,7 @@ func handleJWTAuth(c *fiber.Ctx, tokenString string) error {
 
 	user.Password = nil
 	c.Locals("user", &user)
+	c.Locals("session", session)
 
 	return c.Next()
 }
@@ -23,7 +23,9 @@ func SetupRoutes(app *fiber.App) {
 	// Auth
 	auth := api.Group("/auth")
 	auth.Get("/login/oidc", handler.LoginOidc)
+	auth.Get("/login/oidc/:provider", handler.LoginOidc)
 	auth.Get("/callback/oidc", handler.LoginOidcCallback)
+	auth.Get("/callback/oidc/:provider", handler.LoginOidcCallback)
 	// Magic link consume endpoint exposed via API namespace
 	auth.Get("/magic-link/token", func(c *fiber.Ctx) error {
 		token := c.Query("token")